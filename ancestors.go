@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// maxAncestors bounds the length of the chain returned by Ancestors.
+const maxAncestors = 1024
+
+// Ancestors returns the info of p followed by the info of its parent, its
+// grandparent, and so on up to the root of the process tree (PID 1 on Unix,
+// System on Windows). A parent is only included if it was started before its
+// child so that a PID that was reused by an unrelated process after the
+// parent exited ends the chain instead of reporting the wrong process. The
+// chain also ends without an error when a parent has already exited. Other
+// errors are returned together with the part of the chain that was read.
+func Ancestors(p types.Process) ([]types.ProcessInfo, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []types.ProcessInfo{info}
+	seen := map[int]bool{info.PID: true}
+	for len(chain) < maxAncestors {
+		child := chain[len(chain)-1]
+		if child.PPID <= 0 || seen[child.PPID] {
+			break
+		}
+
+		parent, err := p.Parent()
+		if err != nil {
+			if processGone(err) {
+				break
+			}
+			return chain, fmt.Errorf("failed to get parent of process %d: %w", child.PID, err)
+		}
+		info, err := parent.Info()
+		if err != nil {
+			if processGone(err) {
+				break
+			}
+			return chain, fmt.Errorf("failed to get info of process %d: %w", child.PPID, err)
+		}
+		if info.PID != child.PPID || startedAfter(info, child) {
+			break
+		}
+
+		chain = append(chain, info)
+		seen[info.PID] = true
+		p = parent
+	}
+	return chain, nil
+}
+
+// startedAfter reports whether a was started after b. Unknown start times
+// are not compared.
+func startedAfter(a, b types.ProcessInfo) bool {
+	if a.StartTime.IsZero() || b.StartTime.IsZero() {
+		return false
+	}
+	return a.StartTime.After(b.StartTime)
+}
+
+// processGone reports whether err means that the process does not exist.
+func processGone(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
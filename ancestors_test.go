@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/providers/fake"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestAncestors(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider := &fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1, Name: "init", StartTime: boot}},
+			{ProcessInfo: types.ProcessInfo{PID: 10, PPID: 1, Name: "sshd", StartTime: boot.Add(time.Minute)}},
+			{ProcessInfo: types.ProcessInfo{PID: 42, PPID: 10, Name: "bash", StartTime: boot.Add(time.Hour)}},
+			// The parent of 50 exited and its PID was reused by 60.
+			{ProcessInfo: types.ProcessInfo{PID: 50, PPID: 60, Name: "orphan", StartTime: boot.Add(time.Hour)}},
+			{ProcessInfo: types.ProcessInfo{PID: 60, PPID: 1, Name: "reused", StartTime: boot.Add(2 * time.Hour)}},
+			// The parent of 70 exited.
+			{ProcessInfo: types.ProcessInfo{PID: 70, PPID: 80, Name: "exited"}},
+			{
+				ProcessInfo: types.ProcessInfo{PID: 90, PPID: 1, Name: "denied"},
+				Errors:      map[string]error{"Parent": errors.New("access denied")},
+			},
+		},
+	}
+
+	names := func(pid int) ([]string, error) {
+		p, err := provider.Process(pid)
+		require.NoError(t, err)
+		chain, err := Ancestors(p)
+		var names []string
+		for _, info := range chain {
+			names = append(names, info.Name)
+		}
+		return names, err
+	}
+
+	chain, err := names(42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bash", "sshd", "init"}, chain)
+
+	chain, err = names(50)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orphan"}, chain)
+
+	chain, err = names(70)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exited"}, chain)
+
+	chain, err = names(90)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"denied"}, chain)
+}
+
+func TestAncestorsSelf(t *testing.T) {
+	self, err := Self()
+	if errors.Is(err, types.ErrNotImplemented) {
+		t.Skip("process provider not implemented on", runtime.GOOS)
+	}
+	require.NoError(t, err)
+
+	// Ancestors owned by other users may not be readable.
+	chain, err := Ancestors(self)
+	if !errors.Is(err, os.ErrPermission) {
+		require.NoError(t, err)
+	}
+	require.NotEmpty(t, chain)
+	assert.Equal(t, self.PID(), chain[0].PID)
+	for i := 1; i < len(chain); i++ {
+		assert.Equal(t, chain[i-1].PPID, chain[i].PID)
+	}
+}
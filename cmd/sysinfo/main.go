@@ -0,0 +1,313 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Command sysinfo prints the host, memory, CPU, and process information
+// collected by go-sysinfo as JSON or as a human readable table. It is useful
+// for checking the values reported by a provider when debugging.
+//
+//	sysinfo [-format table|json] [-pid PID] [-processes]
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// report is the information printed by the command.
+type report struct {
+	Host        *types.HostInfo        `json:"host,omitempty"`
+	Memory      *types.HostMemoryInfo  `json:"memory,omitempty"`
+	CPU         *types.CPUTimes        `json:"cpu,omitempty"`
+	LoadAverage *types.LoadAverageInfo `json:"load_average,omitempty"`
+	Process     *processReport         `json:"process,omitempty"`
+	Processes   []processReport        `json:"processes,omitempty"`
+	Errors      []string               `json:"errors,omitempty"`
+}
+
+type processReport struct {
+	Info   types.ProcessInfo `json:"info"`
+	Memory *types.MemoryInfo `json:"memory,omitempty"`
+	CPU    *types.CPUTimes   `json:"cpu,omitempty"`
+	User   *types.UserInfo   `json:"user,omitempty"`
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sysinfo", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "table", "output format (table or json)")
+	pid := flags.Int("pid", 0, "PID of the process to report (defaults to this process)")
+	all := flags.Bool("processes", false, "report all processes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return 2
+	}
+
+	r := collect(*pid, *all)
+
+	var err error
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(r)
+	} else {
+		err = writeTable(stdout, r)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if len(r.Errors) > 0 {
+		if *format == "table" {
+			for _, e := range r.Errors {
+				fmt.Fprintln(stderr, "error:", e)
+			}
+		}
+		return 1
+	}
+	return 0
+}
+
+func collect(pid int, all bool) *report {
+	r := &report{}
+	addErr := func(what string, err error) {
+		// Data that is not supported by the platform is omitted.
+		if errors.Is(err, types.ErrNotImplemented) {
+			return
+		}
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", what, err))
+	}
+
+	host, err := sysinfo.Host()
+	if err != nil {
+		addErr("host", err)
+	}
+	if host != nil {
+		info := host.Info()
+		r.Host = &info
+
+		if r.Memory, err = host.Memory(); err != nil {
+			addErr("memory", err)
+		}
+		if cpu, err := host.CPUTime(); err != nil {
+			addErr("cpu", err)
+		} else {
+			r.CPU = &cpu
+		}
+		if l, ok := host.(types.LoadAverage); ok {
+			if r.LoadAverage, err = l.LoadAverage(); err != nil {
+				addErr("load average", err)
+			}
+		}
+	}
+
+	var proc types.Process
+	if pid != 0 {
+		proc, err = sysinfo.Process(pid)
+	} else {
+		proc, err = sysinfo.Self()
+	}
+	if err != nil {
+		addErr("process", err)
+	} else {
+		p := collectProcess(proc, addErr)
+		r.Process = &p
+	}
+
+	if all {
+		procs, err := sysinfo.Processes()
+		if err != nil {
+			addErr("processes", err)
+		}
+		for _, proc := range procs {
+			// Processes can exit or be inaccessible, which is not reported.
+			r.Processes = append(r.Processes, collectProcess(proc, func(string, error) {}))
+		}
+		sort.Slice(r.Processes, func(i, j int) bool {
+			return r.Processes[i].Info.PID < r.Processes[j].Info.PID
+		})
+	}
+	return r
+}
+
+func collectProcess(proc types.Process, addErr func(string, error)) processReport {
+	what := fmt.Sprintf("process %d", proc.PID())
+
+	info, err := proc.Info()
+	if err != nil {
+		addErr(what+" info", err)
+		info.PID = proc.PID()
+	}
+	p := processReport{Info: info}
+
+	if mem, err := proc.Memory(); err != nil {
+		addErr(what+" memory", err)
+	} else {
+		p.Memory = &mem
+	}
+	if cpu, err := proc.CPUTime(); err != nil {
+		addErr(what+" cpu", err)
+	} else {
+		p.CPU = &cpu
+	}
+	if user, err := proc.User(); err != nil {
+		addErr(what+" user", err)
+	} else {
+		// The names are informational, so lookup errors are ignored.
+		if resolved, err := sysinfo.ResolveUser(user); err == nil {
+			user = resolved
+		}
+		p.User = &user
+	}
+	return p
+}
+
+func writeTable(w io.Writer, r *report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	section := func(name string) { fmt.Fprintf(tw, "%s\n", strings.ToUpper(name)) }
+	row := func(key string, value interface{}) { fmt.Fprintf(tw, "  %s\t%v\n", key, value) }
+
+	if h := r.Host; h != nil {
+		section("host")
+		row("hostname", h.Hostname)
+		if h.FQDN != "" {
+			row("fqdn", h.FQDN)
+		}
+		row("architecture", h.Architecture)
+		if h.OS != nil {
+			row("os", strings.TrimSpace(h.OS.Name+" "+h.OS.Version))
+			row("os family", h.OS.Family)
+		}
+		row("kernel", h.KernelVersion)
+		row("boot time", h.BootTime.Format(time.RFC3339))
+		row("uptime", h.Uptime().Round(time.Second))
+		row("timezone", h.Timezone)
+		if h.UniqueID != "" {
+			row("id", h.UniqueID)
+		}
+		if len(h.IPs) > 0 {
+			row("ips", strings.Join(h.IPs, ", "))
+		}
+	}
+
+	if m := r.Memory; m != nil {
+		section("memory")
+		row("total", formatBytes(m.Total))
+		row("used", formatBytes(m.Used))
+		row("available", formatBytes(m.Available))
+		row("free", formatBytes(m.Free))
+		row("used percent", fmt.Sprintf("%.1f%%", m.UsedPercent()))
+		row("swap total", formatBytes(m.VirtualTotal))
+		row("swap used", formatBytes(m.VirtualUsed))
+	}
+
+	if c := r.CPU; c != nil {
+		section("cpu")
+		writeCPUTimes(row, *c)
+	}
+
+	if l := r.LoadAverage; l != nil {
+		section("load average")
+		row("1m, 5m, 15m", fmt.Sprintf("%.2f, %.2f, %.2f", l.One, l.Five, l.Fifteen))
+	}
+
+	if p := r.Process; p != nil {
+		section("process")
+		row("pid", p.Info.PID)
+		row("ppid", p.Info.PPID)
+		row("name", p.Info.Name)
+		row("exe", p.Info.Exe)
+		row("args", strings.Join(p.Info.Args, " "))
+		row("cwd", p.Info.CWD)
+		if !p.Info.StartTime.IsZero() {
+			row("start time", p.Info.StartTime.Format(time.RFC3339))
+		}
+		if p.User != nil {
+			row("uid", p.User.UID)
+			if p.User.Username != "" {
+				row("user", p.User.Username)
+			}
+		}
+		if p.Memory != nil {
+			row("resident", formatBytes(p.Memory.Resident))
+			row("virtual", formatBytes(p.Memory.Virtual))
+		}
+		if p.CPU != nil {
+			writeCPUTimes(row, *p.CPU)
+		}
+	}
+
+	if len(r.Processes) > 0 {
+		section("processes")
+		fmt.Fprintln(tw, "  PID\tPPID\tNAME\tRESIDENT\tCPU")
+		for _, p := range r.Processes {
+			var resident, cpu string
+			if p.Memory != nil {
+				resident = formatBytes(p.Memory.Resident)
+			}
+			if p.CPU != nil {
+				cpu = p.CPU.Total().Round(time.Millisecond).String()
+			}
+			fmt.Fprintf(tw, "  %d\t%d\t%s\t%s\t%s\n", p.Info.PID, p.Info.PPID, p.Info.Name, resident, cpu)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeCPUTimes(row func(string, interface{}), c types.CPUTimes) {
+	for _, t := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"user", c.User},
+		{"system", c.System},
+		{"idle", c.Idle},
+		{"iowait", c.IOWait},
+		{"irq", c.IRQ},
+		{"nice", c.Nice},
+		{"softirq", c.SoftIRQ},
+		{"steal", c.Steal},
+	} {
+		if t.d > 0 || t.name == "user" || t.name == "system" {
+			row(t.name+" time", t.d.Round(time.Millisecond))
+		}
+	}
+}
+
+// formatBytes formats a size in bytes using binary units.
+func formatBytes(n uint64) string {
+	return types.FormatBytes(n, types.IEC)
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/providers/fake"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func useFakeProvider(t *testing.T) {
+	restore := sysinfo.UseProvider(&fake.Provider{
+		HostFixture: &fake.Host{
+			HostInfo: types.HostInfo{
+				Hostname:     "web-1",
+				Architecture: "x86_64",
+				BootTime:     time.Now().Add(-time.Hour),
+				OS:           &types.OSInfo{Name: "Ubuntu", Version: "22.04", Family: "debian"},
+			},
+			CPUTimes:       types.CPUTimes{User: time.Minute, System: time.Second},
+			HostMemoryInfo: &types.HostMemoryInfo{Total: 8 << 30, Used: 6 << 30, Available: 4 << 30, Free: 2 << 30},
+		},
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1, Name: "init"}},
+			{
+				ProcessInfo: types.ProcessInfo{PID: 42, PPID: 1, Name: "app", Args: []string{"app", "-v"}},
+				MemoryInfo:  types.MemoryInfo{Resident: 1536},
+			},
+		},
+		SelfPID: 42,
+	})
+	t.Cleanup(restore)
+}
+
+func TestRunTable(t *testing.T) {
+	useFakeProvider(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-processes"}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+
+	out := stdout.String()
+	assert.Contains(t, out, "HOST\n")
+	assert.Regexp(t, `hostname +web-1\n`, out)
+	assert.Regexp(t, `os +Ubuntu 22.04\n`, out)
+	assert.Regexp(t, `total +8.0 GiB\n`, out)
+	assert.Regexp(t, `used percent +50.0%\n`, out)
+	assert.Regexp(t, `user time +1m0s\n`, out)
+	assert.Regexp(t, `args +app -v\n`, out)
+	assert.Regexp(t, `resident +1.5 KiB\n`, out)
+	assert.Regexp(t, `42 +1 +app +1.5 KiB`, out)
+	assert.NotContains(t, out, "LOAD AVERAGE")
+}
+
+func TestRunJSON(t *testing.T) {
+	useFakeProvider(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format", "json", "-pid", "1"}, &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+
+	var r report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &r))
+	require.NotNil(t, r.Host)
+	assert.Equal(t, "web-1", r.Host.Hostname)
+	require.NotNil(t, r.Memory)
+	assert.EqualValues(t, 8<<30, r.Memory.Total)
+	require.NotNil(t, r.Process)
+	assert.Equal(t, "init", r.Process.Info.Name)
+	assert.Empty(t, r.Processes)
+}
+
+func TestRunErrors(t *testing.T) {
+	useFakeProvider(t)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 1, run([]string{"-pid", "7"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "error: process: process 7 not found")
+
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"-format", "xml"}, &stdout, &stderr))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.0 KiB", formatBytes(1024))
+	assert.Equal(t, "1.5 MiB", formatBytes(3<<19))
+	assert.Equal(t, "16.0 EiB", formatBytes(1<<64-1))
+}
@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package prometheus exposes host metrics collected by go-sysinfo as a
+// prometheus.Collector so that small exporters can publish CPU, memory, load,
+// disk, and process count metrics without running node_exporter.
+//
+//	c, err := prometheus.NewCollector()
+//	if err != nil {
+//		return err
+//	}
+//	registry.MustRegister(c)
+//
+// go-sysinfo does not list filesystems, so the disk metrics are exported for
+// the mount points given with WithDisks, the root filesystem by default.
+package prometheus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// DefaultNamespace is the prefix of the metric names.
+const DefaultNamespace = "sysinfo"
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// Provider returns the host and processes that are collected. It is
+// implemented by the providers that can be passed to sysinfo.UseProvider,
+// like the client of a remote host.
+type Provider interface {
+	Host() (types.Host, error)
+	Processes() ([]types.Process, error)
+}
+
+// WithHost sets the host that is collected. By default the host returned by
+// sysinfo.Host is collected. The process count is only exported for a host
+// set with WithHost if WithProvider is also given, because the processes of
+// the host are not known.
+func WithHost(h types.Host) Option {
+	return func(c *Collector) { c.host = h }
+}
+
+// WithProvider sets the provider of the host and processes that are
+// collected. By default the functions of the sysinfo package are used. A
+// host set with WithHost takes precedence over the host of the provider.
+func WithProvider(p Provider) Option {
+	return func(c *Collector) { c.provider = p }
+}
+
+// WithDisks sets the mount points of the filesystems whose size and usage are
+// exported. It defaults to the root filesystem ("/", or the system drive on
+// Windows). No paths disable the disk metrics.
+func WithDisks(paths ...string) Option {
+	return func(c *Collector) { c.disks = paths }
+}
+
+// WithNamespace sets the prefix of the metric names. It defaults to
+// DefaultNamespace.
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) { c.namespace = namespace }
+}
+
+// WithoutProcesses disables the process count metric, which requires
+// listing all processes on every scrape.
+func WithoutProcesses() Option {
+	return func(c *Collector) { c.withoutProcesses = true }
+}
+
+// Collector is a prometheus.Collector for the metrics of a host. Metrics
+// that are not supported by the platform (e.g. the load average on Windows)
+// are omitted. Errors reading a metric are reported to the registry as
+// invalid metrics.
+type Collector struct {
+	host             types.Host
+	provider         Provider
+	namespace        string
+	disks            []string
+	withoutProcesses bool
+
+	processes func() ([]types.Process, error)
+	diskUsage func(path string) (diskUsage, error)
+
+	cpu             *prom.Desc
+	memoryTotal     *prom.Desc
+	memoryUsed      *prom.Desc
+	memoryAvailable *prom.Desc
+	memoryFree      *prom.Desc
+	swapTotal       *prom.Desc
+	swapUsed        *prom.Desc
+	swapFree        *prom.Desc
+	load1           *prom.Desc
+	load5           *prom.Desc
+	load15          *prom.Desc
+	diskTotal       *prom.Desc
+	diskUsed        *prom.Desc
+	diskAvailable   *prom.Desc
+	processCount    *prom.Desc
+}
+
+var _ prom.Collector = (*Collector)(nil)
+
+// NewCollector returns a Collector for the host.
+func NewCollector(opts ...Option) (*Collector, error) {
+	c := &Collector{
+		namespace: DefaultNamespace,
+		disks:     []string{rootPath()},
+		diskUsage: getDiskUsage,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.provider == nil && c.host == nil {
+		c.provider = localProvider{}
+	}
+	if c.host == nil {
+		h, err := c.provider.Host()
+		if h == nil {
+			return nil, err
+		}
+		c.host = h
+	}
+	if c.provider != nil && !c.withoutProcesses {
+		c.processes = c.provider.Processes
+	}
+
+	desc := func(name, help string, labels ...string) *prom.Desc {
+		return prom.NewDesc(prom.BuildFQName(c.namespace, "", name), help, labels, nil)
+	}
+	c.cpu = desc("cpu_seconds_total", "Seconds the CPUs spent in each mode.", "mode")
+	c.memoryTotal = desc("memory_total_bytes", "Total physical memory.")
+	c.memoryUsed = desc("memory_used_bytes", "Physical memory in use (total - free).")
+	c.memoryAvailable = desc("memory_available_bytes", "Physical memory available without swapping.")
+	c.memoryFree = desc("memory_free_bytes", "Physical memory not used by the system.")
+	c.swapTotal = desc("swap_total_bytes", "Total swap (virtual memory).")
+	c.swapUsed = desc("swap_used_bytes", "Swap in use.")
+	c.swapFree = desc("swap_free_bytes", "Swap not in use.")
+	c.load1 = desc("load1", "1 minute load average.")
+	c.load5 = desc("load5", "5 minute load average.")
+	c.load15 = desc("load15", "15 minute load average.")
+	c.diskTotal = desc("disk_total_bytes", "Size of the filesystem.", "path")
+	c.diskUsed = desc("disk_used_bytes", "Space in use on the filesystem (total - free).", "path")
+	c.diskAvailable = desc("disk_available_bytes", "Space available to unprivileged users on the filesystem.", "path")
+	c.processCount = desc("processes", "Number of processes.")
+	return c, nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, d := range []*prom.Desc{
+		c.cpu,
+		c.memoryTotal, c.memoryUsed, c.memoryAvailable, c.memoryFree,
+		c.swapTotal, c.swapUsed, c.swapFree,
+		c.load1, c.load5, c.load15,
+		c.diskTotal, c.diskUsed, c.diskAvailable,
+		c.processCount,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	c.collectCPU(ch)
+	c.collectMemory(ch)
+	c.collectLoad(ch)
+	c.collectDisks(ch)
+	c.collectProcesses(ch)
+}
+
+func (c *Collector) collectCPU(ch chan<- prom.Metric) {
+	cpu, err := c.host.CPUTime()
+	if err != nil {
+		ch <- prom.NewInvalidMetric(c.cpu, err)
+		return
+	}
+
+	for _, m := range []struct {
+		mode string
+		d    time.Duration
+	}{
+		{"user", cpu.User},
+		{"system", cpu.System},
+		{"idle", cpu.Idle},
+		{"iowait", cpu.IOWait},
+		{"irq", cpu.IRQ},
+		{"nice", cpu.Nice},
+		{"softirq", cpu.SoftIRQ},
+		{"steal", cpu.Steal},
+	} {
+		ch <- prom.MustNewConstMetric(c.cpu, prom.CounterValue, m.d.Seconds(), m.mode)
+	}
+}
+
+func (c *Collector) collectMemory(ch chan<- prom.Metric) {
+	mem, err := c.host.Memory()
+	if err != nil {
+		ch <- prom.NewInvalidMetric(c.memoryTotal, err)
+		return
+	}
+
+	gauge := func(d *prom.Desc, v uint64) {
+		ch <- prom.MustNewConstMetric(d, prom.GaugeValue, float64(v))
+	}
+	gauge(c.memoryTotal, mem.Total)
+	gauge(c.memoryUsed, mem.Used)
+	gauge(c.memoryAvailable, mem.Available)
+	gauge(c.memoryFree, mem.Free)
+	gauge(c.swapTotal, mem.VirtualTotal)
+	gauge(c.swapUsed, mem.VirtualUsed)
+	gauge(c.swapFree, mem.VirtualFree)
+}
+
+func (c *Collector) collectLoad(ch chan<- prom.Metric) {
+	l, ok := c.host.(types.LoadAverage)
+	if !ok {
+		return
+	}
+
+	load, err := l.LoadAverage()
+	if err != nil {
+		ch <- prom.NewInvalidMetric(c.load1, err)
+		return
+	}
+	ch <- prom.MustNewConstMetric(c.load1, prom.GaugeValue, load.One)
+	ch <- prom.MustNewConstMetric(c.load5, prom.GaugeValue, load.Five)
+	ch <- prom.MustNewConstMetric(c.load15, prom.GaugeValue, load.Fifteen)
+}
+
+func (c *Collector) collectDisks(ch chan<- prom.Metric) {
+	for _, path := range c.disks {
+		usage, err := c.diskUsage(path)
+		if errors.Is(err, types.ErrNotImplemented) {
+			return
+		}
+		if err != nil {
+			ch <- prom.NewInvalidMetric(c.diskTotal, fmt.Errorf("%v: %w", path, err))
+			continue
+		}
+
+		ch <- prom.MustNewConstMetric(c.diskTotal, prom.GaugeValue, float64(usage.total), path)
+		ch <- prom.MustNewConstMetric(c.diskUsed, prom.GaugeValue, float64(usage.total-usage.free), path)
+		ch <- prom.MustNewConstMetric(c.diskAvailable, prom.GaugeValue, float64(usage.available), path)
+	}
+}
+
+func (c *Collector) collectProcesses(ch chan<- prom.Metric) {
+	if c.processes == nil {
+		return
+	}
+
+	procs, err := c.processes()
+	if errors.Is(err, types.ErrNotImplemented) {
+		return
+	}
+	if err != nil {
+		ch <- prom.NewInvalidMetric(c.processCount, err)
+		return
+	}
+	ch <- prom.MustNewConstMetric(c.processCount, prom.GaugeValue, float64(len(procs)))
+}
+
+// localProvider collects the host that the Collector runs on.
+type localProvider struct{}
+
+func (localProvider) Host() (types.Host, error) {
+	return sysinfo.Host(sysinfo.WithoutFQDN())
+}
+
+func (localProvider) Processes() ([]types.Process, error) {
+	return sysinfo.Processes()
+}
+
+// diskUsage contains the size and free space of a filesystem in bytes.
+type diskUsage struct {
+	total     uint64
+	free      uint64 // Free space, including the space reserved for root.
+	available uint64 // Free space available to unprivileged users.
+}
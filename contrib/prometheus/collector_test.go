@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+type fakeHost struct{}
+
+func (fakeHost) Info() types.HostInfo { return types.HostInfo{} }
+
+func (fakeHost) CPUTime() (types.CPUTimes, error) {
+	return types.CPUTimes{User: 90 * time.Second, System: 30 * time.Second, Idle: 600 * time.Second}, nil
+}
+
+func (fakeHost) Memory() (*types.HostMemoryInfo, error) {
+	return &types.HostMemoryInfo{
+		Total:        4096,
+		Used:         3072,
+		Available:    2048,
+		Free:         1024,
+		VirtualTotal: 512,
+		VirtualUsed:  128,
+		VirtualFree:  384,
+	}, nil
+}
+
+type fakeLoadHost struct{ fakeHost }
+
+func (fakeLoadHost) LoadAverage() (*types.LoadAverageInfo, error) {
+	return &types.LoadAverageInfo{One: 1.5, Five: 0.75, Fifteen: 0.25}, nil
+}
+
+type fakeProvider struct {
+	host  types.Host
+	procs []types.Process
+	err   error
+}
+
+func (p *fakeProvider) Host() (types.Host, error) { return p.host, nil }
+
+func (p *fakeProvider) Processes() ([]types.Process, error) { return p.procs, p.err }
+
+func fakeDiskUsage(path string) (diskUsage, error) {
+	if path == "/missing" {
+		return diskUsage{}, errors.New("no such file or directory")
+	}
+	return diskUsage{total: 1000, free: 400, available: 300}, nil
+}
+
+func newTestCollector(t *testing.T, h types.Host, opts ...Option) *Collector {
+	p := &fakeProvider{host: h, procs: make([]types.Process, 3)}
+	c, err := NewCollector(append([]Option{WithProvider(p), WithDisks("/")}, opts...)...)
+	require.NoError(t, err)
+	c.diskUsage = fakeDiskUsage
+	return c
+}
+
+func TestCollector(t *testing.T) {
+	c := newTestCollector(t, fakeLoadHost{})
+
+	expected := `
+# HELP sysinfo_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE sysinfo_cpu_seconds_total counter
+sysinfo_cpu_seconds_total{mode="idle"} 600
+sysinfo_cpu_seconds_total{mode="iowait"} 0
+sysinfo_cpu_seconds_total{mode="irq"} 0
+sysinfo_cpu_seconds_total{mode="nice"} 0
+sysinfo_cpu_seconds_total{mode="softirq"} 0
+sysinfo_cpu_seconds_total{mode="steal"} 0
+sysinfo_cpu_seconds_total{mode="system"} 30
+sysinfo_cpu_seconds_total{mode="user"} 90
+# HELP sysinfo_disk_available_bytes Space available to unprivileged users on the filesystem.
+# TYPE sysinfo_disk_available_bytes gauge
+sysinfo_disk_available_bytes{path="/"} 300
+# HELP sysinfo_disk_total_bytes Size of the filesystem.
+# TYPE sysinfo_disk_total_bytes gauge
+sysinfo_disk_total_bytes{path="/"} 1000
+# HELP sysinfo_disk_used_bytes Space in use on the filesystem (total - free).
+# TYPE sysinfo_disk_used_bytes gauge
+sysinfo_disk_used_bytes{path="/"} 600
+# HELP sysinfo_load1 1 minute load average.
+# TYPE sysinfo_load1 gauge
+sysinfo_load1 1.5
+# HELP sysinfo_load15 15 minute load average.
+# TYPE sysinfo_load15 gauge
+sysinfo_load15 0.25
+# HELP sysinfo_load5 5 minute load average.
+# TYPE sysinfo_load5 gauge
+sysinfo_load5 0.75
+# HELP sysinfo_memory_available_bytes Physical memory available without swapping.
+# TYPE sysinfo_memory_available_bytes gauge
+sysinfo_memory_available_bytes 2048
+# HELP sysinfo_memory_free_bytes Physical memory not used by the system.
+# TYPE sysinfo_memory_free_bytes gauge
+sysinfo_memory_free_bytes 1024
+# HELP sysinfo_memory_total_bytes Total physical memory.
+# TYPE sysinfo_memory_total_bytes gauge
+sysinfo_memory_total_bytes 4096
+# HELP sysinfo_memory_used_bytes Physical memory in use (total - free).
+# TYPE sysinfo_memory_used_bytes gauge
+sysinfo_memory_used_bytes 3072
+# HELP sysinfo_processes Number of processes.
+# TYPE sysinfo_processes gauge
+sysinfo_processes 3
+# HELP sysinfo_swap_free_bytes Swap not in use.
+# TYPE sysinfo_swap_free_bytes gauge
+sysinfo_swap_free_bytes 384
+# HELP sysinfo_swap_total_bytes Total swap (virtual memory).
+# TYPE sysinfo_swap_total_bytes gauge
+sysinfo_swap_total_bytes 512
+# HELP sysinfo_swap_used_bytes Swap in use.
+# TYPE sysinfo_swap_used_bytes gauge
+sysinfo_swap_used_bytes 128
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+}
+
+func TestCollectorOptions(t *testing.T) {
+	c := newTestCollector(t, fakeHost{}, WithNamespace("host"), WithoutProcesses(), WithDisks())
+
+	// 8 CPU modes and 7 memory gauges. The host does not report the load
+	// average.
+	assert.Equal(t, 15, testutil.CollectAndCount(c))
+	assert.Equal(t, 8, testutil.CollectAndCount(c, "host_cpu_seconds_total"))
+	assert.Equal(t, 0, testutil.CollectAndCount(c, "host_processes"))
+}
+
+func TestCollectorProcessesError(t *testing.T) {
+	p := &fakeProvider{host: fakeHost{}, err: errors.New("access denied")}
+	c, err := NewCollector(WithProvider(p), WithDisks())
+	require.NoError(t, err)
+
+	reg := prom.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	_, err = reg.Gather()
+	assert.ErrorContains(t, err, "access denied")
+
+	p.err = types.ErrNotImplemented
+	_, err = reg.Gather()
+	assert.NoError(t, err)
+}
+
+func TestCollectorWithHost(t *testing.T) {
+	// The processes of a host given without a provider are not known.
+	c, err := NewCollector(WithHost(fakeHost{}), WithDisks())
+	require.NoError(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(c, "sysinfo_processes"))
+
+	// The host takes precedence over the host of the provider.
+	c, err = NewCollector(WithHost(fakeLoadHost{}), WithProvider(&fakeProvider{host: fakeHost{}, procs: make([]types.Process, 2)}), WithDisks())
+	require.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "sysinfo_load1"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "sysinfo_processes"))
+}
+
+func TestCollectorDisks(t *testing.T) {
+	c := newTestCollector(t, fakeHost{}, WithDisks("/", "/missing"))
+
+	reg := prom.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	_, err := reg.Gather()
+	assert.ErrorContains(t, err, "/missing: no such file or directory")
+
+	c.disks = []string{"/"}
+	assert.Equal(t, 1, testutil.CollectAndCount(c, "sysinfo_disk_used_bytes"))
+}
+
+func TestGetDiskUsage(t *testing.T) {
+	usage, err := getDiskUsage(rootPath())
+	if errors.Is(err, types.ErrNotImplemented) {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+	assert.NotZero(t, usage.total)
+	assert.LessOrEqual(t, usage.free, usage.total)
+	assert.LessOrEqual(t, usage.available, usage.free)
+}
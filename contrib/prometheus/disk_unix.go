@@ -0,0 +1,42 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build darwin || freebsd || linux
+// +build darwin freebsd linux
+
+package prometheus
+
+import "golang.org/x/sys/unix"
+
+func rootPath() string {
+	return "/"
+}
+
+// getDiskUsage returns the usage of the filesystem mounted at path.
+func getDiskUsage(path string) (diskUsage, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return diskUsage{}, err
+	}
+
+	bsize := uint64(st.Bsize)
+	return diskUsage{
+		total:     uint64(st.Blocks) * bsize,
+		free:      uint64(st.Bfree) * bsize,
+		available: uint64(st.Bavail) * bsize,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// rootPath returns the root of the system drive.
+func rootPath() string {
+	if drive := os.Getenv("SystemDrive"); drive != "" {
+		return drive + `\`
+	}
+	return `C:\`
+}
+
+// getDiskUsage returns the usage of the volume that contains path.
+func getDiskUsage(path string) (diskUsage, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return diskUsage{}, err
+	}
+
+	var u diskUsage
+	if err := windows.GetDiskFreeSpaceEx(p, &u.available, &u.total, &u.free); err != nil {
+		return diskUsage{}, err
+	}
+	return u, nil
+}
@@ -0,0 +1,30 @@
+module github.com/elastic/go-sysinfo/contrib/prometheus
+
+go 1.18
+
+require (
+	github.com/elastic/go-sysinfo v0.0.0-00010101000000-000000000000
+	github.com/prometheus/client_golang v1.14.0
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/sys v0.1.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/elastic/go-windows v1.0.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/joeshaw/multierror v0.0.0-20140124173710-69b34d4ec901 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.3.0 // indirect
+	github.com/prometheus/common v0.37.0 // indirect
+	github.com/prometheus/procfs v0.8.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	howett.net/plist v1.0.0 // indirect
+)
+
+replace github.com/elastic/go-sysinfo => ../..
@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ecs renders the information collected by go-sysinfo using the
+// field names of the Elastic Common Schema (ECS) so that it can be indexed
+// alongside data from Beats and Elastic Agent without remapping.
+//
+//	doc := ecs.Host(host.Info())
+//	doc.Merge(ecs.Process(info))
+//	data, err := json.Marshal(doc)
+package ecs
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Fields is a document with nested objects keyed by the ECS field names
+// (e.g. {"host": {"os": {"name": ...}}}). It can be passed directly to
+// json.Marshal.
+type Fields map[string]interface{}
+
+// Put sets the value of a dotted key (e.g. host.os.name), creating the
+// intermediate objects as needed. Empty strings, empty slices, and zero
+// times are not set.
+func (f Fields) Put(key string, value interface{}) {
+	if isZero(value) {
+		return
+	}
+
+	m := f
+	path := strings.Split(key, ".")
+	for _, k := range path[:len(path)-1] {
+		next, ok := m[k].(Fields)
+		if !ok {
+			next = Fields{}
+			m[k] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+// Merge adds all fields of other to f. Values in other replace the values
+// in f with the same key unless both are objects, in which case they are
+// merged recursively.
+func (f Fields) Merge(other Fields) {
+	for k, v := range other {
+		if src, ok := v.(Fields); ok {
+			if dst, ok := f[k].(Fields); ok {
+				dst.Merge(src)
+				continue
+			}
+		}
+		f[k] = v
+	}
+}
+
+// Host returns the host.* and host.os.* fields of the host.
+func Host(info types.HostInfo) Fields {
+	f := Fields{}
+	f.Put("host.architecture", info.Architecture)
+	f.Put("host.hostname", info.Hostname)
+	f.Put("host.name", info.Hostname)
+	f.Put("host.id", info.UniqueID)
+	f.Put("host.ip", info.IPs)
+	f.Put("host.mac", info.MACs)
+	if info.Containerized != nil {
+		f.Put("host.containerized", *info.Containerized)
+	}
+	if !info.BootTime.IsZero() {
+		f.Put("host.uptime", int64(info.Uptime().Seconds()))
+	}
+	f.Put("host.os.kernel", info.KernelVersion)
+	if info.OS != nil {
+		f.Merge(OS(*info.OS))
+	}
+	return f
+}
+
+// OS returns the host.os.* fields of the operating system.
+func OS(info types.OSInfo) Fields {
+	f := Fields{}
+	f.Put("host.os.type", info.Type)
+	f.Put("host.os.family", info.Family)
+	f.Put("host.os.platform", info.Platform)
+	f.Put("host.os.name", info.Name)
+	f.Put("host.os.version", info.Version)
+	f.Put("host.os.build", info.Build)
+	f.Put("host.os.codename", info.Codename)
+	return f
+}
+
+// Process returns the process.* fields of the process.
+func Process(info types.ProcessInfo) Fields {
+	f := Fields{}
+	f.Put("process.pid", info.PID)
+	f.Put("process.name", info.Name)
+	f.Put("process.executable", info.Exe)
+	f.Put("process.args", info.Args)
+	if len(info.Args) > 0 {
+		f.Put("process.args_count", len(info.Args))
+	}
+	f.Put("process.working_directory", info.CWD)
+	f.Put("process.start", info.StartTime)
+	f.Put("process.parent.pid", info.PPID)
+	f.Put("process.parent.name", info.PPID_NAME)
+	return f
+}
+
+// Memory returns the system.memory.* fields of the host memory. ECS does not
+// define memory fields so the names used by the Metricbeat system module are
+// used. Percentages are fractions between 0 and 1. The actual fields exclude
+// memory that can be reclaimed without swapping.
+func Memory(mem types.HostMemoryInfo) Fields {
+	f := Fields{}
+	f.Put("system.memory.total", mem.Total)
+	f.Put("system.memory.free", mem.Free)
+	f.Put("system.memory.used.bytes", mem.Used)
+	f.Put("system.memory.actual.free", mem.Available)
+	if mem.Total > 0 {
+		f.Put("system.memory.used.pct", fraction(mem.Used, mem.Total))
+		f.Put("system.memory.actual.used.bytes", mem.Total-min(mem.Available, mem.Total))
+		f.Put("system.memory.actual.used.pct", round(mem.UsedPercent()/100))
+	}
+	f.Put("system.memory.swap.total", mem.VirtualTotal)
+	f.Put("system.memory.swap.free", mem.VirtualFree)
+	f.Put("system.memory.swap.used.bytes", mem.VirtualUsed)
+	if mem.VirtualTotal > 0 {
+		f.Put("system.memory.swap.used.pct", round(mem.SwapUsedPercent()/100))
+	}
+	return f
+}
+
+func fraction(part, total uint64) float64 {
+	if part > total {
+		return 1
+	}
+	return round(float64(part) / float64(total))
+}
+
+// round rounds a fraction to four decimal places like Metricbeat does.
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case time.Time:
+		return v.IsZero()
+	}
+	return false
+}
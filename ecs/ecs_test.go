@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ecs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestFieldsPut(t *testing.T) {
+	f := Fields{}
+	f.Put("host.os.name", "Ubuntu")
+	f.Put("host.os.version", "")
+	f.Put("host.name", "web-1")
+	f.Put("host.ip", []string(nil))
+
+	assert.Equal(t, Fields{
+		"host": Fields{
+			"name": "web-1",
+			"os":   Fields{"name": "Ubuntu"},
+		},
+	}, f)
+}
+
+func TestFieldsMerge(t *testing.T) {
+	f := Fields{"host": Fields{"name": "web-1", "os": Fields{"name": "Ubuntu"}}}
+	f.Merge(Fields{
+		"host":    Fields{"os": Fields{"version": "22.04"}},
+		"process": Fields{"pid": 1},
+	})
+
+	assert.Equal(t, Fields{
+		"host":    Fields{"name": "web-1", "os": Fields{"name": "Ubuntu", "version": "22.04"}},
+		"process": Fields{"pid": 1},
+	}, f)
+}
+
+func TestHost(t *testing.T) {
+	containerized := false
+	info := types.HostInfo{
+		Architecture:  "x86_64",
+		BootTime:      time.Now().Add(-time.Hour),
+		Containerized: &containerized,
+		Hostname:      "web-1",
+		IPs:           []string{"10.0.0.2"},
+		KernelVersion: "5.15.0-91-generic",
+		MACs:          []string{"00:16:3e:12:34:56"},
+		OS: &types.OSInfo{
+			Type:     "linux",
+			Family:   "debian",
+			Platform: "ubuntu",
+			Name:     "Ubuntu",
+			Version:  "22.04.3 LTS (Jammy Jellyfish)",
+			Codename: "jammy",
+		},
+		UniqueID: "6c1ec5e9ef1b4a0ea3a0b1c2d3e4f5a6",
+	}
+
+	data, err := json.Marshal(Host(info))
+	require.NoError(t, err)
+
+	var doc struct {
+		Host struct {
+			Architecture  string   `json:"architecture"`
+			Containerized *bool    `json:"containerized"`
+			Hostname      string   `json:"hostname"`
+			ID            string   `json:"id"`
+			IP            []string `json:"ip"`
+			MAC           []string `json:"mac"`
+			Name          string   `json:"name"`
+			Uptime        int64    `json:"uptime"`
+			OS            struct {
+				Codename string `json:"codename"`
+				Family   string `json:"family"`
+				Kernel   string `json:"kernel"`
+				Name     string `json:"name"`
+				Platform string `json:"platform"`
+				Type     string `json:"type"`
+				Version  string `json:"version"`
+			} `json:"os"`
+		} `json:"host"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	h := doc.Host
+	assert.Equal(t, "x86_64", h.Architecture)
+	require.NotNil(t, h.Containerized)
+	assert.False(t, *h.Containerized)
+	assert.Equal(t, "web-1", h.Hostname)
+	assert.Equal(t, "web-1", h.Name)
+	assert.Equal(t, info.UniqueID, h.ID)
+	assert.Equal(t, info.IPs, h.IP)
+	assert.Equal(t, info.MACs, h.MAC)
+	assert.InDelta(t, 3600, h.Uptime, 5)
+	assert.Equal(t, "jammy", h.OS.Codename)
+	assert.Equal(t, "debian", h.OS.Family)
+	assert.Equal(t, "5.15.0-91-generic", h.OS.Kernel)
+	assert.Equal(t, "Ubuntu", h.OS.Name)
+	assert.Equal(t, "ubuntu", h.OS.Platform)
+	assert.Equal(t, "linux", h.OS.Type)
+	assert.Equal(t, info.OS.Version, h.OS.Version)
+}
+
+func TestProcess(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := Process(types.ProcessInfo{
+		Name:      "nginx",
+		PID:       4242,
+		PPID:      1,
+		PPID_NAME: "systemd",
+		CWD:       "/",
+		Exe:       "/usr/sbin/nginx",
+		Args:      []string{"nginx", "-g", "daemon off;"},
+		StartTime: start,
+	})
+
+	assert.Equal(t, Fields{
+		"process": Fields{
+			"pid":               4242,
+			"name":              "nginx",
+			"executable":        "/usr/sbin/nginx",
+			"args":              []string{"nginx", "-g", "daemon off;"},
+			"args_count":        3,
+			"working_directory": "/",
+			"start":             start,
+			"parent":            Fields{"pid": 1, "name": "systemd"},
+		},
+	}, f)
+}
+
+func TestMemory(t *testing.T) {
+	f := Memory(types.HostMemoryInfo{
+		Total:        1000,
+		Used:         800,
+		Available:    600,
+		Free:         200,
+		VirtualTotal: 500,
+		VirtualUsed:  100,
+		VirtualFree:  400,
+	})
+
+	assert.Equal(t, Fields{
+		"system": Fields{
+			"memory": Fields{
+				"total": uint64(1000),
+				"free":  uint64(200),
+				"used":  Fields{"bytes": uint64(800), "pct": 0.8},
+				"actual": Fields{
+					"free": uint64(600),
+					"used": Fields{"bytes": uint64(400), "pct": 0.4},
+				},
+				"swap": Fields{
+					"total": uint64(500),
+					"free":  uint64(400),
+					"used":  Fields{"bytes": uint64(100), "pct": 0.2},
+				},
+			},
+		},
+	}, f)
+}
+
+func TestMemoryUnknownTotal(t *testing.T) {
+	f := Memory(types.HostMemoryInfo{})
+	mem := f["system"].(Fields)["memory"].(Fields)
+	assert.NotContains(t, mem["used"], "pct")
+	assert.NotContains(t, mem["swap"].(Fields)["used"], "pct")
+}
@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package eol reports whether the support of an operating system release has
+// ended, so that compliance tooling does not need to maintain its own table
+// of end-of-life dates.
+//
+//	if os := host.Info().OS; os != nil {
+//		status, err := eol.Check(nil, *os, time.Now())
+//		if err == nil && status.EOL {
+//			log.Printf("%v is no longer supported since %v", status.Name, status.EndOfSupport)
+//		}
+//	}
+//
+// The OS of the host is nil if it could not be read; Host returns the reason.
+//
+// The embedded dataset covers the common Linux distributions, Windows, and
+// macOS releases. Other datasets can be used by passing a Source to Check.
+package eol
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// ErrUnknownRelease is returned when a Source has no lifecycle for the OS
+// release.
+var ErrUnknownRelease = errors.New("unknown OS release")
+
+// Lifecycle contains the support dates of an OS release.
+type Lifecycle struct {
+	Platform             string     `json:"platform"`                          // OSInfo.Platform of the release (e.g. ubuntu, windows).
+	Release              string     `json:"release"`                           // Release as returned by Release (e.g. 22.04, 8, 19045).
+	Edition              string     `json:"edition,omitempty"`                 // Edition as returned by Release (e.g. server).
+	Name                 string     `json:"name"`                              // Name of the release (e.g. Ubuntu 22.04 LTS).
+	EndOfSupport         time.Time  `json:"end_of_support"`                    // End of the regular (security) support.
+	EndOfExtendedSupport *time.Time `json:"end_of_extended_support,omitempty"` // End of the paid extended support, if any.
+}
+
+// Status is the support status of an OS release at a point in time.
+type Status struct {
+	Lifecycle
+	EOL             bool `json:"eol"`              // The regular support has ended.
+	ExtendedSupport bool `json:"extended_support"` // The regular support has ended but extended support is still available.
+}
+
+// Source looks up the lifecycle of OS releases.
+type Source interface {
+	// Lookup returns the lifecycle of the release of os. It returns
+	// ErrUnknownRelease if the release is not known.
+	Lookup(os types.OSInfo) (*Lifecycle, error)
+}
+
+// Check returns the support status of os at now. If src is nil the embedded
+// dataset is used.
+func Check(src Source, os types.OSInfo, now time.Time) (*Status, error) {
+	if src == nil {
+		src = Embedded()
+	}
+	lc, err := src.Lookup(os)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Lifecycle: *lc, EOL: !now.Before(lc.EndOfSupport)}
+	if status.EOL && lc.EndOfExtendedSupport != nil {
+		status.ExtendedSupport = now.Before(*lc.EndOfExtendedSupport)
+	}
+	return status, nil
+}
+
+// Release returns the release and edition of os that lifecycles are keyed
+// by. The release is the major and minor version for Ubuntu and SUSE (e.g.
+// 22.04, 15.5), the build number for Windows (e.g. 19045), and the major
+// version for all other platforms. Windows editions with their own lifecycle
+// are server, enterprise (including education), and ltsc. CentOS Stream has
+// the edition stream.
+func Release(os types.OSInfo) (release, edition string) {
+	switch os.Platform {
+	case "ubuntu":
+		return fmt.Sprintf("%d.%02d", os.Major, os.Minor), ""
+	case "sles", "opensuse-leap":
+		return fmt.Sprintf("%d.%d", os.Major, os.Minor), ""
+	case "centos":
+		if strings.Contains(os.Name, "Stream") {
+			edition = "stream"
+		}
+		return strconv.Itoa(os.Major), edition
+	case "darwin":
+		if os.Major == 10 {
+			return fmt.Sprintf("%d.%d", os.Major, os.Minor), ""
+		}
+		return strconv.Itoa(os.Major), ""
+	case "windows":
+		build := strings.SplitN(os.Build, ".", 2)[0]
+		switch {
+		case strings.Contains(os.Name, "Server"):
+			edition = "server"
+		case strings.Contains(os.Name, "LTSC"):
+			edition = "ltsc"
+		case strings.Contains(os.Name, "Enterprise"), strings.Contains(os.Name, "Education"):
+			edition = "enterprise"
+		}
+		return build, edition
+	default:
+		return strconv.Itoa(os.Major), ""
+	}
+}
+
+//go:embed lifecycle.json
+var embeddedData []byte
+
+var embedded = struct {
+	once sync.Once
+	src  Source
+}{}
+
+// Embedded returns the Source of the dataset shipped with this package.
+// The dates of macOS releases are the end of their security updates since
+// Apple does not publish a lifecycle.
+func Embedded() Source {
+	embedded.once.Do(func() {
+		src, err := Load(bytes.NewReader(embeddedData))
+		if err != nil {
+			panic(fmt.Errorf("invalid embedded lifecycle dataset: %w", err))
+		}
+		embedded.src = src
+	})
+	return embedded.src
+}
+
+// Load reads a dataset in the JSON format of the embedded dataset: an array
+// of lifecycles whose dates are formatted as 2006-01-02.
+func Load(r io.Reader) (Source, error) {
+	var entries []struct {
+		Platform             string `json:"platform"`
+		Release              string `json:"release"`
+		Edition              string `json:"edition"`
+		Name                 string `json:"name"`
+		EndOfSupport         string `json:"end_of_support"`
+		EndOfExtendedSupport string `json:"end_of_extended_support"`
+	}
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode lifecycles: %w", err)
+	}
+
+	lifecycles := make([]Lifecycle, 0, len(entries))
+	for _, e := range entries {
+		lc := Lifecycle{Platform: e.Platform, Release: e.Release, Edition: e.Edition, Name: e.Name}
+		var err error
+		if lc.EndOfSupport, err = time.Parse("2006-01-02", e.EndOfSupport); err != nil {
+			return nil, fmt.Errorf("invalid end of support of %v: %w", e.Name, err)
+		}
+		if e.EndOfExtendedSupport != "" {
+			t, err := time.Parse("2006-01-02", e.EndOfExtendedSupport)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end of extended support of %v: %w", e.Name, err)
+			}
+			lc.EndOfExtendedSupport = &t
+		}
+		lifecycles = append(lifecycles, lc)
+	}
+	return NewSource(lifecycles), nil
+}
+
+// NewSource returns a Source that looks up the given lifecycles by the
+// platform, release, and edition of the OS.
+func NewSource(lifecycles []Lifecycle) Source {
+	src := make(lifecycleSource, len(lifecycles))
+	for _, lc := range lifecycles {
+		lc := lc
+		src[lifecycleKey{lc.Platform, lc.Release, lc.Edition}] = &lc
+	}
+	return src
+}
+
+type lifecycleKey struct {
+	platform, release, edition string
+}
+
+type lifecycleSource map[lifecycleKey]*Lifecycle
+
+func (s lifecycleSource) Lookup(os types.OSInfo) (*Lifecycle, error) {
+	release, edition := Release(os)
+	lc, found := s[lifecycleKey{os.Platform, release, edition}]
+	if !found {
+		name := os.Platform + " " + release
+		if edition != "" {
+			name += " " + edition
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnknownRelease, name)
+	}
+	c := *lc
+	return &c, nil
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eol
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCheck(t *testing.T) {
+	ubuntu := types.OSInfo{Platform: "ubuntu", Major: 20, Minor: 4}
+
+	status, err := Check(nil, ubuntu, date("2024-01-01"))
+	require.NoError(t, err)
+	assert.Equal(t, "Ubuntu 20.04 LTS", status.Name)
+	assert.False(t, status.EOL)
+	assert.False(t, status.ExtendedSupport)
+
+	status, err = Check(nil, ubuntu, date("2026-01-01"))
+	require.NoError(t, err)
+	assert.True(t, status.EOL)
+	assert.True(t, status.ExtendedSupport)
+
+	status, err = Check(nil, ubuntu, date("2031-01-01"))
+	require.NoError(t, err)
+	assert.True(t, status.EOL)
+	assert.False(t, status.ExtendedSupport)
+
+	_, err = Check(nil, types.OSInfo{Platform: "ubuntu", Major: 4, Minor: 10}, time.Now())
+	assert.ErrorIs(t, err, ErrUnknownRelease)
+}
+
+func TestRelease(t *testing.T) {
+	for _, tc := range []struct {
+		os      types.OSInfo
+		release string
+		edition string
+	}{
+		{types.OSInfo{Platform: "ubuntu", Major: 22, Minor: 4}, "22.04", ""},
+		{types.OSInfo{Platform: "sles", Major: 15, Minor: 5}, "15.5", ""},
+		{types.OSInfo{Platform: "rhel", Major: 9, Minor: 3}, "9", ""},
+		{types.OSInfo{Platform: "centos", Name: "CentOS Stream", Major: 9}, "9", "stream"},
+		{types.OSInfo{Platform: "darwin", Major: 10, Minor: 15}, "10.15", ""},
+		{types.OSInfo{Platform: "darwin", Major: 14, Minor: 2}, "14", ""},
+		{types.OSInfo{Platform: "windows", Name: "Windows 10 Pro", Build: "19045.4170"}, "19045", ""},
+		{types.OSInfo{Platform: "windows", Name: "Windows 11 Education", Build: "22631.3296"}, "22631", "enterprise"},
+		{types.OSInfo{Platform: "windows", Name: "Windows 10 Enterprise LTSC 2021", Build: "19044.4170"}, "19044", "ltsc"},
+		{types.OSInfo{Platform: "windows", Name: "Windows Server 2022 Datacenter", Build: "20348.2340"}, "20348", "server"},
+	} {
+		release, edition := Release(tc.os)
+		assert.Equal(t, tc.release, release, tc.os)
+		assert.Equal(t, tc.edition, edition, tc.os)
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	// The dataset is parsed on first use, so check that it is valid.
+	src := Embedded().(lifecycleSource)
+	assert.NotEmpty(t, src)
+	for key, lc := range src {
+		assert.NotEmpty(t, lc.Name, key)
+		if lc.EndOfExtendedSupport != nil {
+			assert.True(t, lc.EndOfExtendedSupport.After(lc.EndOfSupport), lc.Name)
+		}
+	}
+
+	server, err := Embedded().Lookup(types.OSInfo{Platform: "windows", Name: "Windows Server 2025 Standard", Build: "26100.1742"})
+	require.NoError(t, err)
+	assert.Equal(t, "Windows Server 2025", server.Name)
+
+	client, err := Embedded().Lookup(types.OSInfo{Platform: "windows", Name: "Windows 11 Pro", Build: "26100.1742"})
+	require.NoError(t, err)
+	assert.Equal(t, "Windows 11 24H2", client.Name)
+}
+
+func TestLoad(t *testing.T) {
+	src, err := Load(strings.NewReader(`[{"platform": "debian", "release": "13", "name": "Debian 13", "end_of_support": "2028-08-09"}]`))
+	require.NoError(t, err)
+
+	status, err := Check(src, types.OSInfo{Platform: "debian", Major: 13}, date("2030-01-01"))
+	require.NoError(t, err)
+	assert.True(t, status.EOL)
+	assert.Nil(t, status.EndOfExtendedSupport)
+
+	_, err = Load(strings.NewReader(`[{"platform": "debian", "release": "13", "end_of_support": "August 2028"}]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// The Format functions render the information returned by this package as
+// aligned text for terminal based tools. The layout is intended for humans
+// and may change between releases; use encoding/json for machine readable
+// output.
+
+// FormatHostInfo writes the host information to w as aligned key value
+// lines. Empty fields are omitted.
+func FormatHostInfo(w io.Writer, info types.HostInfo) error {
+	tw := newTabWriter(w)
+	row := rowWriter(tw)
+
+	row("hostname", info.Hostname)
+	row("fqdn", info.FQDN)
+	row("architecture", info.Architecture)
+	if info.OS != nil {
+		row("os", strings.TrimSpace(info.OS.Name+" "+info.OS.Version))
+		row("os family", info.OS.Family)
+	}
+	row("kernel", info.KernelVersion)
+	if !info.BootTime.IsZero() {
+		row("boot time", info.BootTime.Format(time.RFC3339))
+		row("uptime", FormatDuration(info.Uptime()))
+	}
+	row("timezone", info.Timezone)
+	row("id", info.UniqueID)
+	row("ips", strings.Join(info.IPs, ", "))
+	row("macs", strings.Join(info.MACs, ", "))
+	return tw.Flush()
+}
+
+// FormatMemory writes the host memory usage to w as aligned key value
+// lines with sizes in binary units.
+func FormatMemory(w io.Writer, mem types.HostMemoryInfo) error {
+	tw := newTabWriter(w)
+	row := rowWriter(tw)
+
+	row("total", FormatBytes(mem.Total))
+	row("used", fmt.Sprintf("%s (%.1f%%)", FormatBytes(mem.Used), mem.UsedPercent()))
+	row("available", FormatBytes(mem.Available))
+	row("free", FormatBytes(mem.Free))
+	if mem.VirtualTotal > 0 {
+		row("swap total", FormatBytes(mem.VirtualTotal))
+		row("swap used", fmt.Sprintf("%s (%.1f%%)", FormatBytes(mem.VirtualUsed), mem.SwapUsedPercent()))
+	}
+	return tw.Flush()
+}
+
+// FormatProcesses writes a table of the processes to w with their PID,
+// parent PID, user, name, resident memory and CPU time. The processes are
+// written in the given order. Values that cannot be read, for example
+// because the process exited, are left empty.
+func FormatProcesses(w io.Writer, procs []types.Process) error {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "PID\tPPID\tUSER\tNAME\tRESIDENT\tCPU")
+	for _, p := range procs {
+		var ppid, user, name, resident, cpu string
+		if info, err := p.Info(); err == nil {
+			ppid = fmt.Sprint(info.PPID)
+			name = info.Name
+		}
+		if u, err := p.User(); err == nil {
+			user = u.UID
+		}
+		if mem, err := p.Memory(); err == nil {
+			resident = FormatBytes(mem.Resident)
+		}
+		if times, err := p.CPUTime(); err == nil {
+			cpu = FormatDuration(times.Total())
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", p.PID(), ppid, user, name, resident, cpu)
+	}
+	return tw.Flush()
+}
+
+// FormatBytes formats a size in bytes using binary units (e.g. 1.5 GiB).
+// Use types.FormatBytes for decimal units.
+func FormatBytes(n uint64) string {
+	return types.FormatBytes(n, types.IEC)
+}
+
+// FormatDuration formats a duration with its two most significant units
+// (e.g. 3d4h, 5h12m, 2m30s, 1.25s).
+func FormatDuration(d time.Duration) string {
+	return types.FormatDuration(d)
+}
+
+func newTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
+
+// rowWriter returns a function that writes a key value line unless the
+// value is empty.
+func rowWriter(w io.Writer) func(key, value string) {
+	return func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%s\t%s\n", key, value)
+		}
+	}
+}
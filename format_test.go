@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/providers/fake"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestFormatHostInfo(t *testing.T) {
+	var sb strings.Builder
+	err := FormatHostInfo(&sb, types.HostInfo{
+		Hostname:     "example",
+		Architecture: "x86_64",
+		OS:           &types.OSInfo{Name: "Ubuntu", Version: "22.04", Family: "debian"},
+		IPs:          []string{"10.0.0.1", "fe80::1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"hostname      example\n"+
+		"architecture  x86_64\n"+
+		"os            Ubuntu 22.04\n"+
+		"os family     debian\n"+
+		"ips           10.0.0.1, fe80::1\n", sb.String())
+}
+
+func TestFormatMemory(t *testing.T) {
+	var sb strings.Builder
+	err := FormatMemory(&sb, types.HostMemoryInfo{
+		Total:     8 << 30,
+		Used:      2 << 30,
+		Available: 6 << 30,
+		Free:      1 << 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"total      8.0 GiB\n"+
+		"used       2.0 GiB (25.0%)\n"+
+		"available  6.0 GiB\n"+
+		"free       1.0 GiB\n", sb.String())
+}
+
+func TestFormatProcesses(t *testing.T) {
+	provider := &fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{
+				ProcessInfo: types.ProcessInfo{PID: 1, Name: "init"},
+				UserInfo:    types.UserInfo{UID: "0"},
+				MemoryInfo:  types.MemoryInfo{Resident: 8 << 20},
+				CPUTimes:    types.CPUTimes{User: 90 * time.Second, System: 30 * time.Second},
+			},
+			{
+				ProcessInfo: types.ProcessInfo{PID: 42, PPID: 1, Name: "worker"},
+				Errors:      map[string]error{"User": errors.New("access denied")},
+			},
+		},
+	}
+	procs, err := provider.Processes()
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, FormatProcesses(&sb, procs))
+	assert.Equal(t, ""+
+		"PID  PPID  USER  NAME    RESIDENT  CPU\n"+
+		"1    0     0     init    8.0 MiB   2m0s\n"+
+		"42   1           worker  0 B       0s\n", sb.String())
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.0 KiB", FormatBytes(1024))
+	assert.Equal(t, "1.5 MiB", FormatBytes(3<<19))
+	assert.Equal(t, "16.0 EiB", FormatBytes(1<<64-1))
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "1.25s", FormatDuration(1250*time.Millisecond))
+	assert.Equal(t, "2m30s", FormatDuration(150*time.Second))
+	assert.Equal(t, "5h12m", FormatDuration(5*time.Hour+12*time.Minute+5*time.Second))
+	assert.Equal(t, "3d4h", FormatDuration(76*time.Hour))
+	assert.Equal(t, "-2m0s", FormatDuration(-2*time.Minute))
+}
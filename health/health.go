@@ -0,0 +1,245 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package health combines host metrics into a single health score from 0
+// (unhealthy) to 100 (healthy) with a breakdown of the factors that lowered
+// it. The score is opinionated, but the thresholds and weights of the factors
+// can be configured.
+//
+//	ch, err := sysinfo.Stream(ctx, 10*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	for s := range ch {
+//		score := health.Compute(health.FromSnapshot(s))
+//		log.Printf("health %.0f", score.Score)
+//	}
+package health
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"time"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Factor names.
+const (
+	CPU    = "cpu"
+	Memory = "memory"
+	Disk   = "disk"
+	Swap   = "swap"
+)
+
+// Input contains the metrics that are scored. Metrics that are nil or empty
+// are not scored and do not lower the score.
+type Input struct {
+	CPUUsage          *float64    // Busy percentage (0-100) of all CPUs.
+	LoadPerCPU        *float64    // One minute load average divided by the number of CPUs.
+	MemoryUsedPercent *float64    // Percentage (0-100) of the physical memory in use.
+	Disks             []DiskUsage // Usage of the filesystems.
+	SwapPagesPerSec   *float64    // Pages swapped in and out per second.
+}
+
+// DiskUsage contains the usage of a filesystem. go-sysinfo does not list
+// filesystems, so the caller provides them.
+type DiskUsage struct {
+	Path  string // Mount point of the filesystem.
+	Total uint64 // Size in bytes.
+	Used  uint64 // Used bytes.
+}
+
+// Threshold configures how a factor lowers the score. The factor does not
+// lower the score while its value is at or below Warn. Above Warn its penalty
+// grows linearly up to Weight at Critical.
+type Threshold struct {
+	Weight   float64 // Relative importance of the factor. Zero disables it.
+	Warn     float64
+	Critical float64
+}
+
+// Config contains the thresholds of the factors.
+type Config struct {
+	CPU    Threshold // CPU saturation in percent. Load above the number of CPUs is more than 100%.
+	Memory Threshold // Memory in use in percent.
+	Disk   Threshold // Usage of the fullest filesystem in percent.
+	Swap   Threshold // Swap activity in pages per second.
+}
+
+// DefaultConfig returns the default thresholds.
+func DefaultConfig() Config {
+	return Config{
+		CPU:    Threshold{Weight: 30, Warn: 70, Critical: 100},
+		Memory: Threshold{Weight: 30, Warn: 80, Critical: 98},
+		Disk:   Threshold{Weight: 20, Warn: 85, Critical: 98},
+		Swap:   Threshold{Weight: 20, Warn: 10, Critical: 1000},
+	}
+}
+
+// Score is the health of a host.
+type Score struct {
+	Score   float64  `json:"score"`   // Health from 0 (unhealthy) to 100 (healthy).
+	Factors []Factor `json:"factors"` // The scored factors.
+}
+
+// Factor is the contribution of a metric to the score.
+type Factor struct {
+	Name     string  `json:"name"`             // Factor name (cpu, memory, disk, or swap).
+	Value    float64 `json:"value"`            // Value of the metric.
+	Severity float64 `json:"severity"`         // 0 when the value is at or below Warn and 1 at or above Critical.
+	Penalty  float64 `json:"penalty"`          // Points subtracted from the score.
+	Detail   string  `json:"detail,omitempty"` // Additional information, like the fullest filesystem.
+}
+
+// Compute scores the input with the DefaultConfig.
+func Compute(in Input) Score {
+	return DefaultConfig().Compute(in)
+}
+
+// Compute scores the input. The penalties of the factors are weighted so that
+// they add up to 100 when all factors are critical. Factors without input are
+// left out of the weighting.
+func (c Config) Compute(in Input) Score {
+	var factors []Factor
+	var thresholds []Threshold
+	add := func(t Threshold, f Factor) {
+		if t.Weight <= 0 {
+			return
+		}
+		f.Severity = severity(t, f.Value)
+		factors = append(factors, f)
+		thresholds = append(thresholds, t)
+	}
+
+	if cpu, ok := cpuSaturation(in); ok {
+		add(c.CPU, Factor{Name: CPU, Value: cpu})
+	}
+	if in.MemoryUsedPercent != nil {
+		add(c.Memory, Factor{Name: Memory, Value: *in.MemoryUsedPercent})
+	}
+	if d, ok := fullestDisk(in.Disks); ok {
+		add(c.Disk, Factor{Name: Disk, Value: d.usedPercent(), Detail: d.Path})
+	}
+	if in.SwapPagesPerSec != nil {
+		add(c.Swap, Factor{Name: Swap, Value: *in.SwapPagesPerSec})
+	}
+
+	var total float64
+	for _, t := range thresholds {
+		total += t.Weight
+	}
+
+	score := Score{Score: 100, Factors: []Factor{}}
+	for i, f := range factors {
+		f.Penalty = 100 * f.Severity * thresholds[i].Weight / total
+		score.Score -= f.Penalty
+		score.Factors = append(score.Factors, f)
+	}
+	score.Score = math.Max(0, score.Score)
+	return score
+}
+
+// severity returns the position of v between the warning and the critical
+// threshold, clamped to [0, 1].
+func severity(t Threshold, v float64) float64 {
+	if v <= t.Warn {
+		return 0
+	}
+	if t.Critical <= t.Warn || v >= t.Critical {
+		return 1
+	}
+	return (v - t.Warn) / (t.Critical - t.Warn)
+}
+
+// cpuSaturation returns the higher of the CPU usage and the load per CPU in
+// percent. The load shows runnable processes that are waiting for a CPU,
+// which the usage does not.
+func cpuSaturation(in Input) (float64, bool) {
+	switch {
+	case in.CPUUsage != nil && in.LoadPerCPU != nil:
+		return math.Max(*in.CPUUsage, *in.LoadPerCPU*100), true
+	case in.CPUUsage != nil:
+		return *in.CPUUsage, true
+	case in.LoadPerCPU != nil:
+		return *in.LoadPerCPU * 100, true
+	}
+	return 0, false
+}
+
+func fullestDisk(disks []DiskUsage) (DiskUsage, bool) {
+	var fullest DiskUsage
+	found := false
+	for _, d := range disks {
+		if d.Total == 0 {
+			continue
+		}
+		if !found || d.usedPercent() > fullest.usedPercent() {
+			fullest, found = d, true
+		}
+	}
+	return fullest, found
+}
+
+func (d DiskUsage) usedPercent() float64 {
+	return 100 * float64(d.Used) / float64(d.Total)
+}
+
+// FromSnapshot returns the CPU and memory input of a snapshot taken by
+// sysinfo.Stream. The load is divided by the number of CPUs usable by the
+// process.
+func FromSnapshot(s sysinfo.Snapshot) Input {
+	var in Input
+	in.CPUUsage = s.CPUUsage
+	if s.LoadAverage != nil {
+		load := s.LoadAverage.One / float64(runtime.NumCPU())
+		in.LoadPerCPU = &load
+	}
+	if s.Memory != nil && s.Memory.Total > 0 {
+		used := s.Memory.UsedPercent()
+		in.MemoryUsedPercent = &used
+	}
+	return in
+}
+
+// SwapRate returns the pages swapped in and out per second between two
+// samples of the swap counters taken elapsed apart. It returns an error if
+// the counters went backwards, like after a reboot.
+func SwapRate(prev, cur *types.SwapInfo, elapsed time.Duration) (float64, error) {
+	if elapsed <= 0 {
+		return 0, errors.New("elapsed time must be positive")
+	}
+	if cur.PagesIn < prev.PagesIn || cur.PagesOut < prev.PagesOut {
+		return 0, errors.New("swap counters decreased")
+	}
+	pages := (cur.PagesIn - prev.PagesIn) + (cur.PagesOut - prev.PagesOut)
+	return float64(pages) / elapsed.Seconds(), nil
+}
+
+// String returns the score and the factors that lowered it.
+func (s Score) String() string {
+	str := fmt.Sprintf("%.0f", s.Score)
+	for _, f := range s.Factors {
+		if f.Penalty > 0 {
+			str += fmt.Sprintf(" %s=-%.1f", f.Name, f.Penalty)
+		}
+	}
+	return str
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func float(v float64) *float64 { return &v }
+
+func TestComputeHealthy(t *testing.T) {
+	score := Compute(Input{
+		CPUUsage:          float(20),
+		MemoryUsedPercent: float(50),
+		Disks:             []DiskUsage{{Path: "/", Total: 100, Used: 40}},
+		SwapPagesPerSec:   float(0),
+	})
+	assert.Equal(t, 100.0, score.Score)
+	assert.Len(t, score.Factors, 4)
+	assert.Equal(t, "100", score.String())
+}
+
+func TestComputeBreakdown(t *testing.T) {
+	score := Compute(Input{
+		CPUUsage:          float(85), // Halfway between 70 and 100.
+		MemoryUsedPercent: float(99), // Critical.
+		Disks:             []DiskUsage{{Path: "/", Total: 100, Used: 40}, {Path: "/var", Total: 200, Used: 190}},
+		SwapPagesPerSec:   float(5), // Below warn.
+	})
+
+	require.Len(t, score.Factors, 4)
+	cpu, mem, disk, swap := score.Factors[0], score.Factors[1], score.Factors[2], score.Factors[3]
+	assert.Equal(t, CPU, cpu.Name)
+	assert.InDelta(t, 0.5, cpu.Severity, 1e-9)
+	assert.InDelta(t, 15, cpu.Penalty, 1e-9)
+	assert.Equal(t, 1.0, mem.Severity)
+	assert.InDelta(t, 30, mem.Penalty, 1e-9)
+	assert.Equal(t, "/var", disk.Detail)
+	assert.InDelta(t, 95, disk.Value, 1e-9)
+	assert.InDelta(t, 20*10.0/13, disk.Penalty, 1e-9)
+	assert.Zero(t, swap.Penalty)
+
+	assert.InDelta(t, 100-15-30-20*10.0/13, score.Score, 1e-9)
+	assert.Equal(t, "40 cpu=-15.0 memory=-30.0 disk=-15.4", score.String())
+}
+
+func TestComputeMissingFactors(t *testing.T) {
+	// Only memory is known, so it carries the whole weight.
+	score := Compute(Input{MemoryUsedPercent: float(98)})
+	require.Len(t, score.Factors, 1)
+	assert.Equal(t, 0.0, score.Score)
+
+	score = Compute(Input{})
+	assert.Equal(t, 100.0, score.Score)
+	assert.Empty(t, score.Factors)
+}
+
+func TestComputeConfig(t *testing.T) {
+	c := DefaultConfig()
+	c.CPU.Weight = 0
+	score := c.Compute(Input{CPUUsage: float(100), MemoryUsedPercent: float(10)})
+	require.Len(t, score.Factors, 1)
+	assert.Equal(t, Memory, score.Factors[0].Name)
+	assert.Equal(t, 100.0, score.Score)
+}
+
+func TestCPUSaturation(t *testing.T) {
+	// A load of twice the CPUs saturates the CPUs even if the usage is low.
+	score := Compute(Input{CPUUsage: float(50), LoadPerCPU: float(2)})
+	require.Len(t, score.Factors, 1)
+	assert.Equal(t, 200.0, score.Factors[0].Value)
+	assert.Equal(t, 0.0, score.Score)
+}
+
+func TestFromSnapshot(t *testing.T) {
+	in := FromSnapshot(sysinfo.Snapshot{
+		CPUUsage: float(12),
+		Memory:   &types.HostMemoryInfo{Total: 100, Used: 75, Available: 25},
+	})
+	assert.Equal(t, 12.0, *in.CPUUsage)
+	assert.Equal(t, 75.0, *in.MemoryUsedPercent)
+	assert.Nil(t, in.LoadPerCPU)
+}
+
+func TestSwapRate(t *testing.T) {
+	rate, err := SwapRate(&types.SwapInfo{PagesIn: 100, PagesOut: 50}, &types.SwapInfo{PagesIn: 150, PagesOut: 70}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, rate)
+
+	_, err = SwapRate(&types.SwapInfo{PagesIn: 100}, &types.SwapInfo{}, time.Second)
+	assert.Error(t, err)
+	_, err = SwapRate(&types.SwapInfo{}, &types.SwapInfo{}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package deadline divides the time left until a deadline among the probes
+// that collect host information, so that a probe that hangs (e.g. a WMI query
+// or a DNS lookup) cannot delay the collection beyond the deadline.
+package deadline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-sysinfo/internal/safe"
+)
+
+// Budget divides the time left until a deadline among a fixed number of
+// probes that are run one after the other. Each probe gets the time left
+// divided by the number of probes that have not run yet, so the time saved by
+// fast probes is available to the following ones.
+type Budget struct {
+	deadline time.Time
+	probes   int
+	exceeded bool
+}
+
+// New returns a Budget for running the given number of probes before
+// deadline. A zero deadline does not limit the probes.
+func New(deadline time.Time, probes int) *Budget {
+	return &Budget{deadline: deadline, probes: probes}
+}
+
+// Run runs probe and waits for it at most for its share of the time left.
+// When the share elapses Run returns an error that wraps
+// context.DeadlineExceeded. The probe keeps running in the background, so it
+// must not modify data that is used after Run returns. A panic of the probe
+// is returned as a *types.PanicError.
+func (b *Budget) Run(name string, probe func()) error {
+	probes := b.probes
+	if probes > 1 {
+		b.probes--
+	} else {
+		probes = 1
+	}
+
+	run := func() error {
+		return safe.Call(name, func() error {
+			probe()
+			return nil
+		})
+	}
+	if b.deadline.IsZero() {
+		return run()
+	}
+
+	share := time.Until(b.deadline) / time.Duration(probes)
+	if share <= 0 {
+		b.exceeded = true
+		return fmt.Errorf("%s was skipped because the deadline passed: %w", name, context.DeadlineExceeded)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run()
+	}()
+
+	timer := time.NewTimer(share)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		b.exceeded = true
+		return fmt.Errorf("%s did not finish within %v: %w", name, share, context.DeadlineExceeded)
+	}
+}
+
+// Err annotates err, the combined errors of the probes, so that errors.Is
+// reports context.DeadlineExceeded when any probe ran out of time. The
+// annotation is needed because the errors are combined by a
+// multierror.MultiError, which does not support errors.Is.
+func (b *Budget) Err(err error) error {
+	if err == nil || !b.exceeded {
+		return err
+	}
+	return &exceededError{err}
+}
+
+type exceededError struct{ error }
+
+func (e *exceededError) Is(target error) bool { return target == context.DeadlineExceeded }
+
+func (e *exceededError) Unwrap() error { return e.error }
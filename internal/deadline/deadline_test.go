@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deadline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joeshaw/multierror"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestBudgetUnlimited(t *testing.T) {
+	b := New(time.Time{}, 1)
+
+	var ran int
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, b.Run("probe", func() { ran++ }))
+	}
+	assert.Equal(t, 3, ran)
+}
+
+func TestBudgetHangingProbe(t *testing.T) {
+	b := New(time.Now().Add(200*time.Millisecond), 2)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	start := time.Now()
+	err := b.Run("stuck", func() { <-block })
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "err: %v", err)
+	assert.Less(t, time.Since(start), 200*time.Millisecond, "the first probe gets half of the budget")
+
+	// The last probe gets the rest of the budget.
+	var ran bool
+	assert.NoError(t, b.Run("fast", func() { ran = true }))
+	assert.True(t, ran)
+}
+
+func TestBudgetDeadlinePassed(t *testing.T) {
+	b := New(time.Now().Add(-time.Second), 1)
+
+	var ran bool
+	err := b.Run("late", func() { ran = true })
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "err: %v", err)
+	assert.False(t, ran)
+}
+
+func TestBudgetErr(t *testing.T) {
+	b := New(time.Now().Add(-time.Second), 1)
+	assert.NoError(t, b.Err(nil))
+
+	probeErr := b.Run("late", func() {})
+	probeErrs := &multierror.MultiError{Errors: []error{probeErr}}
+	err := b.Err(probeErrs)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var merr *multierror.MultiError
+	assert.True(t, errors.As(err, &merr))
+	assert.Equal(t, probeErrs.Error(), err.Error())
+
+	other := errors.New("other")
+	assert.Equal(t, other, New(time.Time{}, 1).Err(other))
+}
+
+func TestBudgetPanic(t *testing.T) {
+	for _, deadline := range []time.Time{{}, time.Now().Add(time.Minute)} {
+		b := New(deadline, 2)
+
+		err := b.Run("bad", func() { panic("unexpected format") })
+		var perr *types.PanicError
+		if assert.True(t, errors.As(err, &perr), "err: %v", err) {
+			assert.Equal(t, "bad", perr.Probe)
+		}
+
+		assert.NoError(t, b.Run("good", func() {}))
+	}
+}
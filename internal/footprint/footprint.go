@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package footprint implements the low-footprint collection mode, which
+// trades detail for memory usage on constrained devices.
+package footprint
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// MaxEntries is the maximum number of entries returned by list probes (e.g.
+// Processes) in low-footprint mode.
+const MaxEntries = 1024
+
+// maxPooledBuffer is the capacity above which buffers are not returned to
+// the pool so that a single large file does not pin memory.
+const maxPooledBuffer = 64 * 1024
+
+var low int32
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// SetLow enables or disables the low-footprint mode.
+func SetLow(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&low, v)
+}
+
+// Low returns true if the low-footprint mode is enabled.
+func Low() bool {
+	return atomic.LoadInt32(&low) == 1
+}
+
+// Limit returns the number of entries of a list of n entries to keep. Lists
+// are truncated to MaxEntries in low-footprint mode.
+func Limit(n int) int {
+	if Low() && n > MaxEntries {
+		return MaxEntries
+	}
+	return n
+}
+
+// ReadFile reads the file into a pooled buffer and passes its contents to
+// fn. The contents must not be retained after fn returns.
+func ReadFile(path string, fn func(content []byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			bufferPool.Put(buf)
+		}
+	}()
+
+	buf.Reset()
+	if _, err := buf.ReadFrom(f); err != nil {
+		return err
+	}
+	return fn(buf.Bytes())
+}
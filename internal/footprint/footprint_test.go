@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package footprint
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimit(t *testing.T) {
+	defer SetLow(false)
+
+	assert.Equal(t, 5000, Limit(5000))
+	SetLow(true)
+	assert.True(t, Low())
+	assert.Equal(t, MaxEntries, Limit(5000))
+	assert.Equal(t, 10, Limit(10))
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meminfo")
+	require.NoError(t, ioutil.WriteFile(path, []byte("MemTotal: 1 kB\n"), 0o644))
+
+	for i := 0; i < 2; i++ {
+		err := ReadFile(path, func(content []byte) error {
+			assert.Equal(t, "MemTotal: 1 kB\n", string(content))
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ratelimit limits how often expensive probes are executed. Calls
+// made within the configured interval of the previous execution of a probe
+// return the result of that execution.
+package ratelimit
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the rate limited probes.
+const (
+	Packages         = "packages"
+	InstalledUpdates = "installed_updates"
+	KernelModules    = "kernel_modules"
+
+	// StaticHostInfo is the collection of the host fields that do not
+	// change while the host is running. Its interval is chosen by the
+	// caller of Host.
+	StaticHostInfo = "static_host_info"
+)
+
+// defaultIntervals are the minimum intervals between executions of the
+// probes that are limited by default.
+var defaultIntervals = map[string]time.Duration{
+	Packages:         time.Minute,
+	InstalledUpdates: time.Minute,
+}
+
+// Limiter caches the results of probes for their configured interval.
+type Limiter struct {
+	mu        sync.Mutex
+	intervals map[string]time.Duration
+	entries   map[string]*entry
+	now       func() time.Time
+}
+
+type entry struct {
+	mu       sync.Mutex
+	value    interface{}
+	err      error
+	executed time.Time
+}
+
+// New returns a Limiter with the default intervals.
+func New() *Limiter {
+	l := &Limiter{
+		intervals: map[string]time.Duration{},
+		entries:   map[string]*entry{},
+		now:       time.Now,
+	}
+	for probe, interval := range defaultIntervals {
+		l.intervals[probe] = interval
+	}
+	return l
+}
+
+// SetInterval sets the minimum interval between executions of a probe. An
+// interval of zero disables the limit. Cached results are discarded.
+func (l *Limiter) SetInterval(probe string, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.intervals[probe] = interval
+	l.reset(probe)
+}
+
+// Reset discards the cached results of a probe so that the next call
+// executes it.
+func (l *Limiter) Reset(probe string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reset(probe)
+}
+
+func (l *Limiter) reset(probe string) {
+	for key := range l.entries {
+		if probeOf(key) == probe {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// Interval returns the minimum interval between executions of a probe.
+func (l *Limiter) Interval(probe string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.intervals[probe]
+}
+
+// Do executes fn unless the probe was executed for the same key within its
+// interval, in which case the previous result is returned. The key
+// distinguishes the targets of a probe (e.g. the root filesystem of a host).
+// Concurrent calls for the same key wait for a single execution.
+func (l *Limiter) Do(probe, key string, fn func() (interface{}, error)) (interface{}, error) {
+	return l.DoInterval(probe, key, l.Interval(probe), fn)
+}
+
+// DoInterval is like Do but uses the given interval instead of the one
+// configured for the probe. It is used to cache results for a duration
+// chosen by the caller.
+func (l *Limiter) DoInterval(probe, key string, interval time.Duration, fn func() (interface{}, error)) (interface{}, error) {
+	l.mu.Lock()
+	if interval <= 0 {
+		l.mu.Unlock()
+		return fn()
+	}
+	id := probe + "\x00" + key
+	e, found := l.entries[id]
+	if !found {
+		e = &entry{}
+		l.entries[id] = e
+	}
+	l.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := l.now()
+	if !e.executed.IsZero() && now.Sub(e.executed) < interval {
+		return e.value, e.err
+	}
+	e.value, e.err = fn()
+	e.executed = now
+	return e.value, e.err
+}
+
+func probeOf(id string) string {
+	if i := strings.IndexByte(id, 0); i >= 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// Default is the Limiter used by the providers.
+var Default = New()
+
+// Do executes fn using the Default limiter.
+func Do(probe, key string, fn func() (interface{}, error)) (interface{}, error) {
+	return Default.Do(probe, key, fn)
+}
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := New()
+	l.now = func() time.Time { return now }
+
+	calls := 0
+	probe := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	v, err := l.Do(Packages, "/", probe)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// Within the interval the cached result is returned.
+	now = now.Add(30 * time.Second)
+	v, _ = l.Do(Packages, "/", probe)
+	assert.Equal(t, 1, v)
+
+	// Keys are limited independently.
+	v, _ = l.Do(Packages, "/hostfs", probe)
+	assert.Equal(t, 2, v)
+
+	now = now.Add(31 * time.Second)
+	v, _ = l.Do(Packages, "/", probe)
+	assert.Equal(t, 3, v)
+
+	// Probes without an interval are always executed.
+	v, _ = l.Do(KernelModules, "/", probe)
+	assert.Equal(t, 4, v)
+	v, _ = l.Do(KernelModules, "/", probe)
+	assert.Equal(t, 5, v)
+
+	// Reset discards the cached results.
+	l.Reset(Packages)
+	v, _ = l.Do(Packages, "/", probe)
+	assert.Equal(t, 6, v)
+}
+
+func TestLimiterCachesErrors(t *testing.T) {
+	l := New()
+	calls := 0
+	probe := func() (interface{}, error) {
+		calls++
+		return nil, errors.New("failed")
+	}
+
+	_, err := l.Do(InstalledUpdates, "", probe)
+	assert.Error(t, err)
+	_, err = l.Do(InstalledUpdates, "", probe)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Changing the interval discards the cached results.
+	l.SetInterval(InstalledUpdates, time.Hour)
+	assert.Equal(t, time.Hour, l.Interval(InstalledUpdates))
+	_, _ = l.Do(InstalledUpdates, "", probe)
+	assert.Equal(t, 2, calls)
+}
@@ -19,11 +19,15 @@ package registry
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/elastic/go-sysinfo/types"
 )
 
 var (
+	providerLock    sync.RWMutex
 	hostProvider    HostProvider
 	processProvider ProcessProvider
 )
@@ -32,13 +36,99 @@ type HostProvider interface {
 	Host() (types.Host, error)
 }
 
+// HostOptions selects the optional host fields that are collected.
+type HostOptions struct {
+	SkipFQDN      bool // Skip the fully qualified domain name lookup.
+	SkipMachineID bool // Skip reading the machine ID.
+
+	// CacheTTL is how long the fields that do not change while the host is
+	// running (e.g. OS, architecture, machine ID, SMBIOS data) are cached.
+	CacheTTL time.Duration
+
+	// FQDNStrategy selects how the fully qualified domain name is looked up.
+	// It is one of the FQDN constants. Empty means FQDNPlatform.
+	FQDNStrategy string
+
+	// FQDNTimeout bounds the DNS lookups of the FQDN. Zero means no bound
+	// for FQDNPlatform and DefaultFQDNTimeout for FQDNHosts.
+	FQDNTimeout time.Duration
+
+	// MachineIDSources are the sources of the machine ID, tried in order
+	// until one returns an ID. Each is one of the MachineID constants. Empty
+	// means the default source of the platform.
+	MachineIDSources []string
+
+	// MachineIDPath is the file read by the MachineIDFile source.
+	MachineIDPath string
+
+	// Deadline is the time by which the host information must be collected.
+	// Its remaining time is divided among the probes and the fields of the
+	// probes that do not finish in time are left empty. The zero value means
+	// no deadline.
+	Deadline time.Time
+
+	// Timeout is the time allowed to collect the host information, counted
+	// from the start of the Host call. It is converted to a Deadline before
+	// the options are passed to the provider. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// FQDN lookup strategies.
+const (
+	FQDNPlatform = "platform" // The platform API: DNS lookups of the hostname on Linux and Darwin, GetComputerNameEx on Windows.
+	FQDNHostname = "hostname" // The hostname, without any lookup.
+	FQDNHosts    = "hosts"    // The hosts file, then DNS lookups bounded by FQDNTimeout.
+)
+
+// Machine ID sources.
+const (
+	MachineIDSMBIOS       = "smbios"        // The SMBIOS system UUID (Linux, Windows).
+	MachineIDEtc          = "machine-id"    // The systemd or D-Bus machine-id file (Linux).
+	MachineIDPlatformUUID = "platform-uuid" // The IOPlatformUUID hardware UUID (Darwin).
+	MachineIDMachineGUID  = "machine-guid"  // The MachineGuid registry value (Windows).
+	MachineIDUname        = "uname"         // The machine ID reported by uname (AIX).
+	MachineIDFile         = "file"          // The file at MachineIDPath, on all platforms.
+)
+
+// DefaultFQDNTimeout bounds the DNS lookups of the FQDNHosts strategy when
+// no FQDNTimeout is set.
+const DefaultFQDNTimeout = 2 * time.Second
+
+// HostOptionsProvider is implemented by the HostProviders that support
+// HostOptions.
+type HostOptionsProvider interface {
+	HostWithOptions(opts HostOptions) (types.Host, error)
+}
+
+// CacheInvalidator is implemented by the HostProviders that cache host
+// information beyond the HostOptions.CacheTTL.
+type CacheInvalidator interface {
+	InvalidateCache()
+}
+
 type ProcessProvider interface {
 	Processes() ([]types.Process, error)
 	Process(pid int) (types.Process, error)
 	Self() (types.Process, error)
 }
 
+// ProcessMatcher is implemented by the ProcessProviders that apply a
+// ProcessFilter while listing the processes, so that the details of the
+// processes that are filtered out are never read.
+type ProcessMatcher interface {
+	ProcessesMatching(filter types.ProcessFilter) ([]types.Process, error)
+}
+
+// ProcessIterator is implemented by the ProcessProviders that can yield the
+// processes one at a time instead of returning them all at once.
+type ProcessIterator interface {
+	ForEachProcess(fn func(types.Process) bool) error
+}
+
 func Register(provider interface{}) {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+
 	if h, ok := provider.(HostProvider); ok {
 		if hostProvider != nil {
 			panic(fmt.Sprintf("HostProvider already registered: %v", hostProvider))
@@ -54,5 +144,67 @@ func Register(provider interface{}) {
 	}
 }
 
-func GetHostProvider() HostProvider       { return hostProvider }
-func GetProcessProvider() ProcessProvider { return processProvider }
+func GetHostProvider() HostProvider {
+	providerLock.RLock()
+	defer providerLock.RUnlock()
+	return hostProvider
+}
+
+func GetProcessProvider() ProcessProvider {
+	providerLock.RLock()
+	defer providerLock.RUnlock()
+	return processProvider
+}
+
+// Override replaces the registered providers with the HostProvider and
+// ProcessProvider implemented by provider (e.g. a fake for testing) until
+// the returned function is called to restore them. Providers that are not
+// implemented by provider are set to nil.
+func Override(provider interface{}) (restore func()) {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+
+	prevHost, prevProcess := hostProvider, processProvider
+	hostProvider, _ = provider.(HostProvider)
+	processProvider, _ = provider.(ProcessProvider)
+
+	return func() {
+		providerLock.Lock()
+		defer providerLock.Unlock()
+		hostProvider, processProvider = prevHost, prevProcess
+	}
+}
+
+// Names of the optional subsystems.
+const (
+	SubsystemPackages   = "packages"   // Installed packages inventory (nosysinfo_packages).
+	SubsystemContainers = "containers" // Container discovery (nosysinfo_containers).
+)
+
+var (
+	subsystemsLock sync.Mutex
+	subsystems     = map[string]struct{}{}
+)
+
+// RegisterSubsystem records that an optional subsystem was compiled in.
+// Subsystems can be excluded at build time with a nosysinfo_<name> build
+// tag.
+func RegisterSubsystem(name string) {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	subsystems[name] = struct{}{}
+}
+
+// Subsystems returns the sorted names of the optional subsystems that were
+// compiled in.
+func Subsystems() []string {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+
+	names := make([]string, 0, len(subsystems))
+	for name := range subsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestSubsystems(t *testing.T) {
+	RegisterSubsystem("b")
+	RegisterSubsystem("a")
+	RegisterSubsystem("b")
+
+	names := Subsystems()
+	assert.Contains(t, names, "a")
+	assert.Contains(t, names, "b")
+	assert.IsNonDecreasing(t, names)
+}
+
+type hostOnlyProvider struct{}
+
+func (hostOnlyProvider) Host() (types.Host, error) { return nil, nil }
+
+func TestOverride(t *testing.T) {
+	prevHost, prevProcess := GetHostProvider(), GetProcessProvider()
+
+	restore := Override(hostOnlyProvider{})
+	assert.Equal(t, hostOnlyProvider{}, GetHostProvider())
+	assert.Nil(t, GetProcessProvider())
+
+	restore()
+	assert.Equal(t, prevHost, GetHostProvider())
+	assert.Equal(t, prevProcess, GetProcessProvider())
+}
@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package safe contains the panics of probes, so that data in an unexpected
+// format (e.g. a malformed /proc file on an exotic kernel) fails a single
+// probe instead of crashing the application.
+package safe
+
+import (
+	"runtime/debug"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Call runs fn and returns its error. If fn panics, the panic is recovered
+// and returned as a *types.PanicError for the probe.
+func Call(probe string, fn func() error) (err error) {
+	defer Recover(probe, &err)
+	return fn()
+}
+
+// Recover stores a panic of the calling function in *err as a
+// *types.PanicError for the probe. It must be deferred directly:
+//
+//	func (p *process) Memory() (_ types.MemoryInfo, err error) {
+//		defer safe.Recover("process memory", &err)
+//		...
+//	}
+func Recover(probe string, err *error) {
+	if r := recover(); r != nil {
+		*err = &types.PanicError{Probe: probe, Value: r, Stack: debug.Stack()}
+	}
+}
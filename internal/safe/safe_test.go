@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package safe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestCall(t *testing.T) {
+	assert.NoError(t, Call("ok", func() error { return nil }))
+
+	errFailed := errors.New("failed")
+	assert.Equal(t, errFailed, Call("failed", func() error { return errFailed }))
+
+	err := Call("index", func() error {
+		var fields []string
+		_ = fields[3]
+		return nil
+	})
+	var perr *types.PanicError
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, "index", perr.Probe)
+		assert.Contains(t, perr.Error(), "index panicked: runtime error: index out of range")
+		assert.NotEmpty(t, perr.Stack)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	memory := func() (_ int, err error) {
+		defer Recover("memory", &err)
+		var m map[string]int
+		m["x"] = 1
+		return 1, nil
+	}
+
+	_, err := memory()
+	var perr *types.PanicError
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, "memory", perr.Probe)
+	}
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// CounterDelta returns the increase of a counter from prev to cur. A counter
+// that is lower than prev either wrapped around or was reset. When max is
+// the largest value of the counter (e.g. math.MaxUint32 for the 32-bit
+// counters of /proc/net/dev on 32-bit kernels) the counter is assumed to
+// have wrapped around. When max is zero it is assumed to have restarted
+// from zero and cur is returned.
+func CounterDelta(prev, cur, max uint64) uint64 {
+	if cur >= prev {
+		return cur - prev
+	}
+	if max == 0 || prev > max {
+		return cur
+	}
+	return max - prev + cur + 1
+}
+
+// Rate returns the per-second rate of delta over elapsed. It returns zero
+// when elapsed is not positive.
+func Rate(delta float64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return delta / elapsed.Seconds()
+}
+
+// Percentage returns part as a percentage of total. It returns zero when total
+// is not positive.
+func Percentage(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+// CPUUtilization is the share of the CPU time spent in each state between
+// two samples, as percentages from 0 to 100.
+type CPUUtilization struct {
+	User    float64 `json:"user"`
+	System  float64 `json:"system"`
+	Idle    float64 `json:"idle"`
+	IOWait  float64 `json:"iowait"`
+	IRQ     float64 `json:"irq"`
+	Nice    float64 `json:"nice"`
+	SoftIRQ float64 `json:"soft_irq"`
+	Steal   float64 `json:"steal"`
+	Busy    float64 `json:"busy"` // All states but Idle and IOWait.
+}
+
+// HostCPUUtilization returns the utilization of the host CPUs between the
+// samples prev and cur of Host.CPUTime. The percentages are relative to the
+// CPU time of all CPUs, so a Busy of 100 means that all CPUs were busy. The
+// zero value is returned when no CPU time elapsed.
+func HostCPUUtilization(prev, cur types.CPUTimes) CPUUtilization {
+	d := cur.Delta(prev)
+	total := float64(d.Total())
+	if total <= 0 {
+		return CPUUtilization{}
+	}
+
+	return CPUUtilization{
+		User:    Percentage(float64(d.User), total),
+		System:  Percentage(float64(d.System), total),
+		Idle:    Percentage(float64(d.Idle), total),
+		IOWait:  Percentage(float64(d.IOWait), total),
+		IRQ:     Percentage(float64(d.IRQ), total),
+		Nice:    Percentage(float64(d.Nice), total),
+		SoftIRQ: Percentage(float64(d.SoftIRQ), total),
+		Steal:   Percentage(float64(d.Steal), total),
+		Busy:    Percentage(float64(d.TotalBusy()), total),
+	}
+}
+
+// ProcessCPUUtilization returns the CPU usage of a process between the
+// samples prev and cur of its CPUTime, taken elapsed apart, as a percentage
+// of one CPU. It exceeds 100 for processes that run on several CPUs at once.
+// Divide it by the number of CPUs to get the share of the host capacity.
+func ProcessCPUUtilization(prev, cur types.CPUTimes, elapsed time.Duration) float64 {
+	d := cur.Delta(prev)
+	return Percentage(float64(d.User+d.System), float64(elapsed))
+}
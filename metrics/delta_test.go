@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestCounterDelta(t *testing.T) {
+	assert.EqualValues(t, 5, CounterDelta(10, 15, 0))
+	// Reset.
+	assert.EqualValues(t, 3, CounterDelta(10, 3, 0))
+	// Wrap around of a 32-bit counter.
+	assert.EqualValues(t, 10, CounterDelta(math.MaxUint32-4, 5, math.MaxUint32))
+	assert.EqualValues(t, 10, CounterDelta(math.MaxUint64-4, 5, math.MaxUint64))
+	// prev cannot come from a counter with this max, so it was reset.
+	assert.EqualValues(t, 5, CounterDelta(math.MaxUint32+1, 5, math.MaxUint32))
+}
+
+func TestRate(t *testing.T) {
+	assert.Equal(t, 50.0, Rate(500, 10*time.Second))
+	assert.Zero(t, Rate(500, 0))
+}
+
+func TestHostCPUUtilization(t *testing.T) {
+	prev := types.CPUTimes{User: 10 * time.Second, System: 10 * time.Second, Idle: 10 * time.Second}
+	cur := types.CPUTimes{User: 13 * time.Second, System: 11 * time.Second, Idle: 15 * time.Second, IOWait: time.Second}
+
+	u := HostCPUUtilization(prev, cur)
+	assert.Equal(t, 30.0, u.User)
+	assert.Equal(t, 10.0, u.System)
+	assert.Equal(t, 50.0, u.Idle)
+	assert.Equal(t, 10.0, u.IOWait)
+	assert.Equal(t, 40.0, u.Busy)
+
+	assert.Equal(t, CPUUtilization{}, HostCPUUtilization(cur, cur))
+}
+
+func TestProcessCPUUtilization(t *testing.T) {
+	prev := types.CPUTimes{User: time.Second}
+	cur := types.CPUTimes{User: 3 * time.Second, System: time.Second}
+
+	assert.Equal(t, 150.0, ProcessCPUUtilization(prev, cur, 2*time.Second))
+	assert.Zero(t, ProcessCPUUtilization(prev, cur, 0))
+}
@@ -0,0 +1,424 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package metrics describes the numeric values reported by go-sysinfo. Every
+// metric has a stable identifier and is tagged with its type (gauge or
+// counter), unit, and subsystem, so that pipelines such as anomaly detection
+// can consume the values without a hand-written schema.
+//
+//	mem, err := host.Memory()
+//	if err != nil {
+//		return err
+//	}
+//	values, err := metrics.Values("host.memory", mem)
+//	if err != nil {
+//		return err
+//	}
+//	for _, v := range values {
+//		fmt.Println(v.Path, v.Value, v.Type, v.Unit)
+//	}
+package metrics
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Type is the type of a metric.
+type Type string
+
+// Metric types.
+const (
+	Gauge   Type = "gauge"   // The value can go up and down (e.g. memory in use).
+	Counter Type = "counter" // The value only increases until it is reset, usually at boot.
+)
+
+// Unit is the unit of a metric.
+type Unit string
+
+// Metric units.
+const (
+	None        Unit = ""        // Dimensionless values, like the load average.
+	Bytes       Unit = "bytes"   // Bytes.
+	Nanoseconds Unit = "ns"      // Durations. time.Duration values are in nanoseconds.
+	Seconds     Unit = "s"       // Seconds.
+	Percent     Unit = "percent" // Percentage from 0 to 100.
+	Pages       Unit = "pages"   // Memory pages.
+	Count       Unit = "count"   // Number of events or objects.
+	Bits        Unit = "bits"    // Bits, like the entropy of the kernel pool.
+)
+
+// Metadata describes a metric.
+type Metadata struct {
+	ID        string `json:"id"`             // Stable identifier (e.g. host.memory.used_bytes).
+	Type      Type   `json:"type"`           // Gauge or counter.
+	Unit      Unit   `json:"unit,omitempty"` // Unit of the value.
+	Subsystem string `json:"subsystem"`      // Subsystem that reports the metric (e.g. cpu, memory).
+}
+
+// Value is a numeric value together with its metadata.
+type Value struct {
+	Metadata
+	Path  string  `json:"path"`  // Location of the value. It differs from the ID for the elements of lists and maps (e.g. host.numa.nodes.1.memory_free_bytes).
+	Value float64 `json:"value"` // The value. Durations are in nanoseconds.
+}
+
+// source is a struct of the types package whose numeric fields are metrics,
+// or a map of metrics like the result of TCPStates. The IDs of the metrics
+// are the prefix followed by the JSON names of the fields or the map keys. Fields without a unit suffix (like _bytes) have the default unit.
+type source struct {
+	prefix    string
+	subsystem string
+	typ       reflect.Type
+	kind      Type
+	unit      Unit
+}
+
+var sources = []source{
+	{"host.cpu", "cpu", reflect.TypeOf(types.CPUTimes{}), Counter, None},
+	{"host.load", "cpu", reflect.TypeOf(types.LoadAverageInfo{}), Gauge, None},
+	{"host.memory", "memory", reflect.TypeOf(types.HostMemoryInfo{}), Gauge, Bytes},
+	{"host.vmstat", "memory", reflect.TypeOf(types.VMStatInfo{}), Counter, Count},
+	{"host.swap", "swap", reflect.TypeOf(types.SwapInfo{}), Gauge, Bytes},
+	{"host.pressure", "pressure", reflect.TypeOf(types.PressureInfo{}), Gauge, Percent},
+	{"host.network", "network", reflect.TypeOf(types.NetworkCountersInfo{}), Counter, Count},
+	{"host.numa", "numa", reflect.TypeOf(types.NUMAInfo{}), Gauge, Bytes},
+	{"host.gpu", "gpu", reflect.TypeOf(types.GPUInfo{}), Gauge, Bytes},
+	{"host.idle", "session", reflect.TypeOf(types.IdleInfo{}), Gauge, None},
+	{"host.suspend", "power", reflect.TypeOf(types.SuspendInfo{}), Counter, Count},
+	{"host.kernel_modules", "kernel", reflect.TypeOf(types.KernelModuleInfo{}), Gauge, Bytes},
+	{"host.containers", "containers", reflect.TypeOf(types.ContainerInfo{}), Gauge, Bytes},
+	{"host.sockets", "network", reflect.TypeOf(types.SocketSummaryInfo{}), Gauge, Count},
+	{"host.tcp_states", "network", reflect.TypeOf(map[string]int{}), Gauge, Count},
+	{"host.audit", "audit", reflect.TypeOf(types.AuditStatusInfo{}), Gauge, Count},
+	{"host.file_handles", "filesystem", reflect.TypeOf(types.FileHandlesInfo{}), Gauge, Count},
+	{"host.entropy", "kernel", reflect.TypeOf(types.EntropyInfo{}), Gauge, Bits},
+	{"host.time_sync", "time", reflect.TypeOf(types.TimeSyncInfo{}), Gauge, Nanoseconds},
+	{"process.cpu", "cpu", reflect.TypeOf(types.CPUTimes{}), Counter, None},
+	{"process.memory", "memory", reflect.TypeOf(types.MemoryInfo{}), Gauge, Bytes},
+	{"process.delays", "scheduler", reflect.TypeOf(types.DelayInfo{}), Counter, Count},
+	{"process.context_switches", "scheduler", reflect.TypeOf(types.ContextSwitchInfo{}), Counter, Count},
+	{"process.scheduler", "scheduler", reflect.TypeOf(types.SchedulerInfo{}), Gauge, None},
+	{"process.gui_resources", "gui", reflect.TypeOf(types.GUIResourceInfo{}), Gauge, Count},
+	{"process.job", "job", reflect.TypeOf(types.JobObjectInfo{}), Gauge, Count},
+}
+
+// identifiers are the JSON names of numeric fields that identify objects
+// rather than measure them.
+var identifiers = map[string]bool{
+	"id":   true,
+	"cpus": true,
+	"pid":  true,
+	"pids": true,
+	"uid":  true,
+}
+
+// overrides are the metrics whose type or unit differs from the defaults of
+// their source.
+var overrides = map[string]struct {
+	kind Type
+	unit Unit
+}{
+	"host.memory.hugepages.total":                 {Gauge, Pages},
+	"host.memory.hugepages.free":                  {Gauge, Pages},
+	"host.memory.hugepages.reserved":              {Gauge, Pages},
+	"host.memory.hugepages.surplus":               {Gauge, Pages},
+	"host.memory.hugepages.pools.total":           {Gauge, Pages},
+	"host.memory.hugepages.pools.free":            {Gauge, Pages},
+	"host.memory.hugepages.pools.reserved":        {Gauge, Pages},
+	"host.memory.hugepages.pools.surplus":         {Gauge, Pages},
+	"host.swap.pages_in":                          {Counter, Pages},
+	"host.swap.pages_out":                         {Counter, Pages},
+	"host.swap.devices.priority":                  {Gauge, None},
+	"host.pressure.cpu.some.total":                {Counter, Nanoseconds},
+	"host.pressure.cpu.full.total":                {Counter, Nanoseconds},
+	"host.pressure.memory.some.total":             {Counter, Nanoseconds},
+	"host.pressure.memory.full.total":             {Counter, Nanoseconds},
+	"host.pressure.io.some.total":                 {Counter, Nanoseconds},
+	"host.pressure.io.full.total":                 {Counter, Nanoseconds},
+	"host.pressure.irq.some.total":                {Counter, Nanoseconds},
+	"host.pressure.irq.full.total":                {Counter, Nanoseconds},
+	"host.containers.cpu.usage":                   {Counter, Nanoseconds},
+	"host.containers.cpu.user":                    {Counter, Nanoseconds},
+	"host.containers.cpu.system":                  {Counter, Nanoseconds},
+	"host.containers.cpu.throttled":               {Counter, Nanoseconds},
+	"process.delays.wait_reasons.*":               {Gauge, Count},
+	"host.audit.rate_limit":                       {Gauge, None},
+	"host.audit.lost":                             {Counter, Count},
+	"process.job.limits.process_memory":           {Gauge, Bytes},
+	"process.job.limits.job_memory":               {Gauge, Bytes},
+	"process.job.limits.cpu_rate":                 {Gauge, Percent},
+	"process.job.accounting.user_time":            {Counter, Nanoseconds},
+	"process.job.accounting.kernel_time":          {Counter, Nanoseconds},
+	"process.job.accounting.page_faults":          {Counter, Count},
+	"process.job.accounting.total_processes":      {Counter, Count},
+	"process.job.accounting.terminated_processes": {Counter, Count},
+	"process.job.accounting.read_bytes":           {Counter, Bytes},
+	"process.job.accounting.write_bytes":          {Counter, Bytes},
+	"process.job.accounting.peak_process_memory":  {Gauge, Bytes},
+	"process.job.accounting.peak_job_memory":      {Gauge, Bytes},
+
+	// Gauges of the SNMP counters (RFC 1213 and RFC 2012).
+	"host.network.snmp.ip.Forwarding":    {Gauge, None},
+	"host.network.snmp.ip.DefaultTTL":    {Gauge, None},
+	"host.network.snmp.tcp.RtoAlgorithm": {Gauge, None},
+	"host.network.snmp.tcp.RtoMin":       {Gauge, None},
+	"host.network.snmp.tcp.RtoMax":       {Gauge, None},
+	"host.network.snmp.tcp.MaxConn":      {Gauge, None},
+	"host.network.snmp.tcp.CurrEstab":    {Gauge, Count},
+}
+
+// vmstatCounters are the nr_ fields of /proc/vmstat that are event counters.
+// The other nr_ fields are gauges of pages.
+var vmstatCounters = map[string]bool{
+	"nr_dirtied":                   true,
+	"nr_written":                   true,
+	"nr_vmscan_write":              true,
+	"nr_vmscan_immediate_reclaim":  true,
+	"nr_pages_scanned":             true,
+	"nr_tlb_remote_flush":          true,
+	"nr_tlb_remote_flush_received": true,
+	"nr_tlb_local_flush_all":       true,
+	"nr_tlb_local_flush_one":       true,
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Metadata{}
+)
+
+func init() {
+	for _, s := range sources {
+		walkType(s, s.typ, s.prefix)
+	}
+	for id, o := range overrides {
+		if m, found := registry[id]; found {
+			m.Type, m.Unit = o.kind, o.unit
+			registry[id] = m
+		} else if m, found := registry[wildcard(id)]; found {
+			// An exact key of a map of counters.
+			m.ID, m.Type, m.Unit = id, o.kind, o.unit
+			registry[id] = m
+		}
+	}
+}
+
+// walkType registers the numeric fields of t.
+func walkType(s source, t reflect.Type, id string) {
+	switch {
+	case t == durationType:
+		register(s, id, Nanoseconds)
+		return
+	case t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice:
+		walkType(s, t.Elem(), id)
+		return
+	case t.Kind() == reflect.Map:
+		walkType(s, t.Elem(), id+".*")
+		return
+	case isNumeric(t.Kind()):
+		register(s, id, s.unit)
+		return
+	case t.Kind() != reflect.Struct:
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonName(f)
+		if name == "" || identifiers[name] {
+			continue
+		}
+		walkType(s, f.Type, id+"."+name)
+	}
+}
+
+func register(s source, id string, unit Unit) {
+	m := Metadata{ID: id, Type: s.kind, Unit: unit, Subsystem: s.subsystem}
+
+	name := id[strings.LastIndexByte(id, '.')+1:]
+	switch {
+	case strings.HasSuffix(name, "_bytes"):
+		m.Unit = Bytes
+	case strings.HasSuffix(name, "_pct"):
+		m.Unit = Percent
+	case strings.HasSuffix(name, "_sec"):
+		m.Unit = Seconds
+	}
+	if s.prefix == "host.vmstat" && strings.HasPrefix(name, "nr_") && !vmstatCounters[name] {
+		m.Type, m.Unit = Gauge, Pages
+	}
+	registry[id] = m
+}
+
+// jsonName returns the name of a field in the JSON encoding or an empty
+// string if the field is not encoded.
+func jsonName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+func isNumeric(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// wildcard replaces the last element of an ID with *, which is the ID of the
+// values of maps.
+func wildcard(id string) string {
+	return id[:strings.LastIndexByte(id, '.')+1] + "*"
+}
+
+// Register adds the metadata of a metric that is not reported by
+// go-sysinfo, like a metric derived from its values. It panics if a metric
+// with the same ID is registered.
+func Register(m Metadata) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, found := registry[m.ID]; found {
+		panic(fmt.Sprintf("metric %v is already registered", m.ID))
+	}
+	registry[m.ID] = m
+}
+
+// Lookup returns the metadata of a metric. The values of maps, like the raw
+// memory metrics, can be looked up with their key (e.g. host.memory.raw.Cached)
+// or with * (host.memory.raw.*).
+func Lookup(id string) (Metadata, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if m, found := registry[id]; found {
+		return m, true
+	}
+	if m, found := registry[wildcard(id)]; found {
+		m.ID = id
+		return m, true
+	}
+	return Metadata{}, false
+}
+
+// All returns the metadata of all metrics sorted by ID.
+func All() []Metadata {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Metadata, 0, len(registry))
+	for _, m := range registry {
+		all = append(all, m)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+// Values returns the numeric values of v with their metadata. v is a struct of
+// the types package, or a pointer to one, and prefix is the prefix of its
+// metric IDs (e.g. host.memory for types.HostMemoryInfo or process.cpu for
+// the types.CPUTimes of a process). Nil pointers are skipped.
+func Values(prefix string, v interface{}) ([]Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	for _, s := range sources {
+		if s.prefix != prefix {
+			continue
+		}
+		if rv.Type() != s.typ {
+			return nil, fmt.Errorf("metrics with prefix %v are reported by %v, not %v", prefix, s.typ, rv.Type())
+		}
+
+		var values []Value
+		walkValue(rv, prefix, prefix, &values)
+		return values, nil
+	}
+	return nil, fmt.Errorf("unknown metric prefix %v", prefix)
+}
+
+func walkValue(v reflect.Value, id, path string, values *[]Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			walkValue(v.Elem(), id, path, values)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkValue(v.Index(i), id, path+"."+strconv.Itoa(i), values)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			walkValue(v.MapIndex(k), id+"."+k.String(), path+"."+k.String(), values)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := jsonName(t.Field(i))
+			if name == "" || identifiers[name] {
+				continue
+			}
+			walkValue(v.Field(i), id+"."+name, path+"."+name, values)
+		}
+	default:
+		if !isNumeric(v.Kind()) {
+			return
+		}
+		m, found := Lookup(id)
+		if !found {
+			return
+		}
+		*values = append(*values, Value{Metadata: m, Path: path, Value: numericValue(v)})
+	}
+}
+
+func numericValue(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
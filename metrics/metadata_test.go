@@ -0,0 +1,304 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []Metadata{
+		{ID: "host.cpu.user", Type: Counter, Unit: Nanoseconds, Subsystem: "cpu"},
+		{ID: "host.load.one_min", Type: Gauge, Unit: None, Subsystem: "cpu"},
+		{ID: "host.memory.used_bytes", Type: Gauge, Unit: Bytes, Subsystem: "memory"},
+		{ID: "host.memory.raw.Cached", Type: Gauge, Unit: Bytes, Subsystem: "memory"},
+		{ID: "host.memory.hugepages.pools.free", Type: Gauge, Unit: Pages, Subsystem: "memory"},
+		{ID: "host.vmstat.nr_free_pages", Type: Gauge, Unit: Pages, Subsystem: "memory"},
+		{ID: "host.vmstat.nr_dirtied", Type: Counter, Unit: Count, Subsystem: "memory"},
+		{ID: "host.vmstat.pgfault", Type: Counter, Unit: Count, Subsystem: "memory"},
+		{ID: "host.swap.pages_in", Type: Counter, Unit: Pages, Subsystem: "swap"},
+		{ID: "host.swap.devices.used_bytes", Type: Gauge, Unit: Bytes, Subsystem: "swap"},
+		{ID: "host.pressure.memory.some.avg10", Type: Gauge, Unit: Percent, Subsystem: "pressure"},
+		{ID: "host.pressure.memory.some.total", Type: Counter, Unit: Nanoseconds, Subsystem: "pressure"},
+		{ID: "host.network.snmp.tcp.InSegs", Type: Counter, Unit: Count, Subsystem: "network"},
+		{ID: "host.network.snmp.tcp.CurrEstab", Type: Gauge, Unit: Count, Subsystem: "network"},
+		{ID: "host.gpu.utilization_pct", Type: Gauge, Unit: Percent, Subsystem: "gpu"},
+		{ID: "host.containers.cpu.usage", Type: Counter, Unit: Nanoseconds, Subsystem: "containers"},
+		{ID: "host.containers.memory.limit_bytes", Type: Gauge, Unit: Bytes, Subsystem: "containers"},
+		{ID: "host.suspend.suspend_count", Type: Counter, Unit: Count, Subsystem: "power"},
+		{ID: "host.tcp_states.established", Type: Gauge, Unit: Count, Subsystem: "network"},
+		{ID: "host.sockets.tcp_memory_bytes", Type: Gauge, Unit: Bytes, Subsystem: "network"},
+		{ID: "host.audit.lost", Type: Counter, Unit: Count, Subsystem: "audit"},
+		{ID: "host.file_handles.allocated", Type: Gauge, Unit: Count, Subsystem: "filesystem"},
+		{ID: "host.entropy.available", Type: Gauge, Unit: Bits, Subsystem: "kernel"},
+		{ID: "host.time_sync.offset", Type: Gauge, Unit: Nanoseconds, Subsystem: "time"},
+		{ID: "process.job.accounting.read_bytes", Type: Counter, Unit: Bytes, Subsystem: "job"},
+		{ID: "process.job.limits.cpu_rate", Type: Gauge, Unit: Percent, Subsystem: "job"},
+		{ID: "process.cpu.system", Type: Counter, Unit: Nanoseconds, Subsystem: "cpu"},
+		{ID: "process.delays.wait_reasons.Executive", Type: Gauge, Unit: Count, Subsystem: "scheduler"},
+	}
+	for _, want := range tests {
+		m, found := Lookup(want.ID)
+		if assert.True(t, found, want.ID) {
+			assert.Equal(t, want, m)
+		}
+	}
+
+	for _, id := range []string{"host.numa.nodes.id", "host.containers.pids", "host.audit.pid", "host.memory", "unknown"} {
+		_, found := Lookup(id)
+		assert.False(t, found, id)
+	}
+}
+
+// TestAllNumericFields checks that every numeric field of the sources has
+// metadata, so that new fields are not silently left out.
+func TestAllNumericFields(t *testing.T) {
+	ids := map[string]bool{}
+	for _, m := range All() {
+		ids[m.ID] = true
+		assert.NotEmpty(t, m.Type, m.ID)
+		assert.NotEmpty(t, m.Subsystem, m.ID)
+	}
+
+	var check func(reflect.Type, string)
+	check = func(typ reflect.Type, id string) {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice:
+			check(typ.Elem(), id)
+		case reflect.Map:
+			check(typ.Elem(), id+".*")
+		case reflect.Struct:
+			if typ == reflect.TypeOf(time.Time{}) {
+				return
+			}
+			for i := 0; i < typ.NumField(); i++ {
+				if name := jsonName(typ.Field(i)); name != "" && !identifiers[name] {
+					check(typ.Field(i).Type, id+"."+name)
+				}
+			}
+		default:
+			if isNumeric(typ.Kind()) {
+				assert.True(t, ids[id], "missing metadata for %v", id)
+			}
+		}
+	}
+	for _, s := range sources {
+		check(s.typ, s.prefix)
+	}
+}
+
+func TestValues(t *testing.T) {
+	used := uint64(5)
+	values, err := Values("host.numa", &types.NUMAInfo{Nodes: []types.NUMANode{
+		{ID: 0, CPUs: []int{0}, MemoryTotal: 100, MemoryFree: 10},
+		{ID: 1, CPUs: []int{1}, MemoryTotal: 200, MemoryFree: used},
+	}})
+	require.NoError(t, err)
+	require.Len(t, values, 4)
+	assert.Equal(t, Value{
+		Metadata: Metadata{ID: "host.numa.nodes.memory_free_bytes", Type: Gauge, Unit: Bytes, Subsystem: "numa"},
+		Path:     "host.numa.nodes.1.memory_free_bytes",
+		Value:    5,
+	}, values[3])
+
+	values, err = Values("process.memory", types.MemoryInfo{Resident: 1, Virtual: 2, Metrics: map[string]uint64{"b": 4, "a": 3}})
+	require.NoError(t, err)
+	var paths []string
+	for _, v := range values {
+		paths = append(paths, v.Path)
+	}
+	assert.Equal(t, []string{"process.memory.resident_bytes", "process.memory.virtual_bytes", "process.memory.raw.a", "process.memory.raw.b"}, paths)
+
+	values, err = Values("process.cpu", types.CPUTimes{User: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, 1e9, values[0].Value)
+
+	// Nil pointers are skipped.
+	values, err = Values("host.idle", &types.IdleInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = Values("host.memory", types.CPUTimes{})
+	assert.Error(t, err)
+	_, err = Values("host.unknown", types.CPUTimes{})
+	assert.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	m := Metadata{ID: "test.cpu.usage_pct", Type: Gauge, Unit: Percent, Subsystem: "cpu"}
+	Register(m)
+	defer func() {
+		mu.Lock()
+		delete(registry, m.ID)
+		mu.Unlock()
+	}()
+
+	found, ok := Lookup(m.ID)
+	assert.True(t, ok)
+	assert.Equal(t, m, found)
+	assert.Panics(t, func() { Register(m) })
+}
+
+// notMetrics are the result types of the interfaces of the types package
+// that have numeric fields that are not metrics.
+var notMetrics = map[string]bool{
+	"HostInfo":           true, // Timezone offset.
+	"ProcessInfo":        true, // Parent PID and session IDs.
+	"ExecutableHashInfo": true, // Size of the hashed file.
+	"SeccompInfo":        true, // Configuration of the process.
+	"Route":              true, // Configuration of the routing table.
+	"ListeningPort":      true, // Port numbers.
+	"WiFiInfo":           true, // Link properties of an interface, keyed by its name.
+}
+
+// TestAllTypesHaveSources checks that the results of the interfaces of the
+// types package that have numeric fields are sources, so that the metrics
+// of new APIs are not silently left out.
+func TestAllTypesHaveSources(t *testing.T) {
+	reachable := map[string]bool{}
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			walk(typ.Elem())
+		case reflect.Struct:
+			if typ.PkgPath() == reflect.TypeOf(types.HostInfo{}).PkgPath() {
+				reachable[typ.Name()] = true
+			}
+			for i := 0; i < typ.NumField(); i++ {
+				walk(typ.Field(i).Type)
+			}
+		}
+	}
+	for _, s := range sources {
+		walk(s.typ)
+	}
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), "../types", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	structs := map[string]*ast.StructType{}
+	var results []string
+	for _, f := range pkgs["types"].Files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				switch typ := ts.Type.(type) {
+				case *ast.StructType:
+					structs[ts.Name.Name] = typ
+				case *ast.InterfaceType:
+					for _, m := range typ.Methods.List {
+						if ft, ok := m.Type.(*ast.FuncType); ok && ft.Results != nil {
+							if name := typeName(ft.Results.List[0].Type); name != "" {
+								results = append(results, name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range results {
+		st, found := structs[name]
+		if !found || reachable[name] || notMetrics[name] || !hasNumericField(st) {
+			continue
+		}
+		t.Errorf("%v has numeric fields but is not a source of metrics", name)
+	}
+}
+
+// typeName returns the name of the type of expr without pointers and slices.
+func typeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return typeName(e.X)
+	case *ast.ArrayType:
+		return typeName(e.Elt)
+	case *ast.MapType:
+		return typeName(e.Value)
+	}
+	return ""
+}
+
+// hasNumericField reports whether st has a field with a numeric type or a
+// duration that is not an identifier.
+func hasNumericField(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		if f.Tag != nil {
+			tag := reflect.StructTag(strings.Trim(f.Tag.Value, "`"))
+			if name := strings.Split(tag.Get("json"), ",")[0]; name == "-" || identifiers[name] {
+				continue
+			}
+		}
+		switch typ := f.Type.(type) {
+		case *ast.StarExpr:
+			if isNumericExpr(typ.X) {
+				return true
+			}
+		case *ast.ArrayType:
+			if isNumericExpr(typ.Elt) {
+				return true
+			}
+		case *ast.MapType:
+			if isNumericExpr(typ.Value) {
+				return true
+			}
+		default:
+			if isNumericExpr(typ) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isNumericExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			return true
+		}
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "Duration"
+	}
+	return false
+}
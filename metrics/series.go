@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"time"
+)
+
+// bootTimeTolerance is the difference between boot times that is not
+// treated as a reboot. Boot times computed from the uptime drift by a few
+// milliseconds between samples.
+const bootTimeTolerance = time.Second
+
+// Series converts the counters of consecutive samples into series that
+// increase monotonically across reboots. Counters restart from zero when the
+// host reboots, which is detected by a change of the boot time. The value of
+// a counter before the reset is added to all following values.
+//
+// A Series can be persisted with encoding/json to keep the series across
+// restarts of the agent. It is not safe for concurrent use.
+type Series struct {
+	BootTime time.Time          `json:"boot_time"` // Boot time of the last sample.
+	Offsets  map[string]float64 `json:"offsets"`   // Sum of the counters before the resets, by path.
+	Last     map[string]float64 `json:"last"`      // Last value of the counters, by path.
+}
+
+// Point is the value of a counter in a series.
+type Point struct {
+	Metadata
+	Path      string  `json:"path"`       // Location of the value (see Value).
+	Value     float64 `json:"value"`      // Monotonic value across reboots.
+	BootValue float64 `json:"boot_value"` // Value reported since the last reset.
+	Reset     bool    `json:"reset"`      // Whether the counter was reset since the previous sample.
+}
+
+// NewSeries returns an empty Series.
+func NewSeries() *Series {
+	return &Series{
+		Offsets: map[string]float64{},
+		Last:    map[string]float64{},
+	}
+}
+
+// Add adds the counters of a sample taken while the host was booted at
+// bootTime, like the Values of the types.CPUTimes of the host. Gauges are
+// ignored. rebooted is true if the boot time changed since the previous
+// sample, in which case all counters are reset. A counter that decreases
+// without a reboot, like the counters of a restarted container or a counter
+// that wrapped around, is reset on its own.
+func (s *Series) Add(bootTime time.Time, values []Value) (points []Point, rebooted bool) {
+	if s.Offsets == nil {
+		s.Offsets = map[string]float64{}
+	}
+	if s.Last == nil {
+		s.Last = map[string]float64{}
+	}
+
+	if !s.BootTime.IsZero() && !withinTolerance(s.BootTime, bootTime) {
+		rebooted = true
+		for path, last := range s.Last {
+			s.Offsets[path] += last
+		}
+		s.Last = map[string]float64{}
+	}
+	s.BootTime = bootTime
+
+	for _, v := range values {
+		if v.Type != Counter {
+			continue
+		}
+
+		reset := rebooted
+		if last, found := s.Last[v.Path]; found && v.Value < last {
+			s.Offsets[v.Path] += last
+			reset = true
+		}
+		s.Last[v.Path] = v.Value
+
+		points = append(points, Point{
+			Metadata:  v.Metadata,
+			Path:      v.Path,
+			Value:     s.Offsets[v.Path] + v.Value,
+			BootValue: v.Value,
+			Reset:     reset,
+		})
+	}
+	return points, rebooted
+}
+
+func withinTolerance(a, b time.Time) bool {
+	d := a.Sub(b)
+	return d > -bootTimeTolerance && d < bootTimeTolerance
+}
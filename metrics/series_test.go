@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func cpuValues(t *testing.T, user time.Duration) []Value {
+	values, err := Values("host.cpu", types.CPUTimes{User: user})
+	require.NoError(t, err)
+	return values
+}
+
+func TestSeries(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSeries()
+
+	points, rebooted := s.Add(boot, cpuValues(t, 10*time.Second))
+	assert.False(t, rebooted)
+	require.Len(t, points, 8)
+	assert.Equal(t, "host.cpu.user", points[0].Path)
+	assert.Equal(t, 10e9, points[0].Value)
+	assert.False(t, points[0].Reset)
+
+	// Boot times computed from the uptime drift slightly.
+	points, rebooted = s.Add(boot.Add(3*time.Millisecond), cpuValues(t, 15*time.Second))
+	assert.False(t, rebooted)
+	assert.Equal(t, 15e9, points[0].Value)
+
+	// After a reboot the series continues from the last value.
+	points, rebooted = s.Add(boot.Add(time.Hour), cpuValues(t, 2*time.Second))
+	assert.True(t, rebooted)
+	assert.True(t, points[0].Reset)
+	assert.Equal(t, 17e9, points[0].Value)
+	assert.Equal(t, 2e9, points[0].BootValue)
+
+	points, _ = s.Add(boot.Add(time.Hour), cpuValues(t, 3*time.Second))
+	assert.False(t, points[0].Reset)
+	assert.Equal(t, 18e9, points[0].Value)
+}
+
+func TestSeriesCounterDecrease(t *testing.T) {
+	boot := time.Now()
+	s := NewSeries()
+
+	counters := func(in uint64) []Value {
+		values, err := Values("host.swap", types.SwapInfo{PagesIn: in, PageSize: 4096})
+		require.NoError(t, err)
+		return values
+	}
+
+	s.Add(boot, counters(100))
+	points, rebooted := s.Add(boot, counters(40))
+	assert.False(t, rebooted)
+
+	// Gauges, like the page size, are not part of the series.
+	require.Len(t, points, 2)
+	for _, p := range points {
+		assert.Equal(t, Counter, p.Type, p.Path)
+	}
+	assert.Equal(t, "host.swap.pages_in", points[0].Path)
+	assert.True(t, points[0].Reset)
+	assert.Equal(t, 140.0, points[0].Value)
+	assert.False(t, points[1].Reset)
+}
+
+func TestSeriesPersistence(t *testing.T) {
+	boot := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSeries()
+	s.Add(boot, cpuValues(t, 10*time.Second))
+	s.Add(boot.Add(time.Hour), cpuValues(t, 5*time.Second))
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	var restored Series
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	points, rebooted := restored.Add(boot.Add(time.Hour), cpuValues(t, 6*time.Second))
+	assert.False(t, rebooted)
+	assert.Equal(t, 16e9, points[0].Value)
+}
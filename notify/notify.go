@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package notify shows desktop notifications when host metrics sampled by
+// sysinfo.Stream cross a threshold. It is an optional helper for personal
+// system monitors built on go-sysinfo.
+//
+//	ch, err := sysinfo.Stream(ctx, 10*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	return notify.Watch(ctx, ch, notify.Desktop(),
+//		notify.MemoryUsedPercent(90),
+//		notify.CPUUsagePercent(95))
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+)
+
+// Notifier delivers notifications.
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// NotifierFunc is a Notifier implemented by a function.
+type NotifierFunc func(title, message string) error
+
+// Notify calls f(title, message).
+func (f NotifierFunc) Notify(title, message string) error {
+	return f(title, message)
+}
+
+// Desktop returns a Notifier that shows desktop notifications. On Linux the
+// notifications are sent to the org.freedesktop.Notifications D-Bus service
+// with notify-send or gdbus, and on Windows they are shown as toasts.
+// Notify returns types.ErrNotImplemented on other platforms, including
+// macOS, where the UserNotifications framework can only be used by signed
+// application bundles. Notify gives up after 10 seconds if the notification
+// service does not respond.
+func Desktop() Notifier {
+	return NotifierFunc(desktopNotify)
+}
+
+// notifyTimeout bounds the time spent running the command that shows a
+// desktop notification.
+const notifyTimeout = 10 * time.Second
+
+// Rule describes a metric threshold.
+type Rule struct {
+	Name      string  // Name of the metric used in the notification (e.g. Memory usage).
+	Threshold float64 // The rule is breached when the value exceeds the threshold.
+
+	// Value returns the value of the metric in the snapshot. It returns false
+	// if the snapshot does not contain the metric.
+	Value func(sysinfo.Snapshot) (float64, bool)
+}
+
+// MemoryUsedPercent returns a rule that is breached when the percentage of
+// the physical memory that is in use exceeds the threshold (0-100).
+func MemoryUsedPercent(threshold float64) Rule {
+	return Rule{
+		Name:      "Memory usage",
+		Threshold: threshold,
+		Value: func(s sysinfo.Snapshot) (float64, bool) {
+			if s.Memory == nil || s.Memory.Total == 0 {
+				return 0, false
+			}
+			return s.Memory.UsedPercent(), true
+		},
+	}
+}
+
+// CPUUsagePercent returns a rule that is breached when the busy percentage
+// of all CPUs exceeds the threshold (0-100).
+func CPUUsagePercent(threshold float64) Rule {
+	return Rule{
+		Name:      "CPU usage",
+		Threshold: threshold,
+		Value: func(s sysinfo.Snapshot) (float64, bool) {
+			if s.CPUUsage == nil {
+				return 0, false
+			}
+			return *s.CPUUsage, true
+		},
+	}
+}
+
+// Watch reads the snapshots and calls n when a rule is breached. A rule
+// notifies once when it is breached and again only after its value went back
+// under the threshold. Watch returns when ctx is done or the channel is
+// closed. Errors returned by n are ignored so that a temporarily unavailable
+// notification service does not stop the watch.
+func Watch(ctx context.Context, snapshots <-chan sysinfo.Snapshot, n Notifier, rules ...Rule) error {
+	breached := make([]bool, len(rules))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case s, ok := <-snapshots:
+			if !ok {
+				return nil
+			}
+
+			for i, r := range rules {
+				v, ok := r.Value(s)
+				if !ok {
+					continue
+				}
+
+				switch {
+				case v > r.Threshold && !breached[i]:
+					breached[i] = true
+					_ = n.Notify(r.Name+" is high", fmt.Sprintf("%s is %.1f%% (threshold %.1f%%).", r.Name, v, r.Threshold))
+				case v <= r.Threshold:
+					breached[i] = false
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// appName is the application name shown in the notifications.
+const appName = "sysinfo"
+
+func desktopNotify(title, message string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if path, err := exec.LookPath("notify-send"); err == nil {
+		cmd = exec.CommandContext(ctx, path, "--app-name="+appName, "--", title, message)
+	} else if path, err := exec.LookPath("gdbus"); err == nil {
+		cmd = exec.CommandContext(ctx, path, gdbusNotifyArgs(title, message)...)
+	} else {
+		return fmt.Errorf("notify-send and gdbus not found: %w", err)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v failed: %w: %s", cmd.Path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// gdbusNotifyArgs returns the gdbus arguments for calling the Notify method
+// of the org.freedesktop.Notifications service.
+func gdbusNotifyArgs(title, message string) []string {
+	return []string{
+		"call", "--session",
+		"--dest", "org.freedesktop.Notifications",
+		"--object-path", "/org/freedesktop/Notifications",
+		"--method", "org.freedesktop.Notifications.Notify",
+		gvariantString(appName), // app_name
+		"uint32 0",              // replaces_id
+		gvariantString(""),      // app_icon
+		gvariantString(title),   // summary
+		gvariantString(message), // body
+		"@as []",                // actions
+		"@a{sv} {}",             // hints
+		"int32 -1",              // expire_timeout
+	}
+}
+
+// gvariantString quotes s using the GVariant text format.
+func gvariantString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGdbusNotifyArgs(t *testing.T) {
+	args := gdbusNotifyArgs("Memory usage is high", `It's 95% of C:\`)
+	assert.Equal(t, "org.freedesktop.Notifications.Notify", args[7])
+	assert.Equal(t, []string{
+		"'sysinfo'",
+		"uint32 0",
+		"''",
+		"'Memory usage is high'",
+		`'It\'s 95% of C:\\'`,
+		"@as []",
+		"@a{sv} {}",
+		"int32 -1",
+	}, args[8:])
+}
@@ -0,0 +1,32 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package notify
+
+import (
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// On macOS the UserNotifications framework requires a signed application
+// bundle, which is not available to the command line programs that use this
+// package, so desktop notifications are not supported.
+func desktopNotify(title, message string) error {
+	return types.ErrNotImplemented
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestWatch(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+	mem := func(used uint64) *types.HostMemoryInfo {
+		return &types.HostMemoryInfo{Total: 100, Used: used}
+	}
+
+	snapshots := []sysinfo.Snapshot{
+		{CPUUsage: pct(10), Memory: mem(50)},
+		{CPUUsage: pct(99), Memory: mem(95)}, // Both breached.
+		{CPUUsage: pct(98), Memory: mem(96)}, // Still breached, no notification.
+		{CPUUsage: pct(20)},                  // CPU recovered, memory unknown.
+		{CPUUsage: pct(97), Memory: mem(97)}, // CPU breached again.
+	}
+
+	ch := make(chan sysinfo.Snapshot, len(snapshots))
+	for _, s := range snapshots {
+		ch <- s
+	}
+	close(ch)
+
+	var titles []string
+	n := NotifierFunc(func(title, message string) error {
+		titles = append(titles, title)
+		assert.NotEmpty(t, message)
+		return nil
+	})
+
+	err := Watch(context.Background(), ch, n, MemoryUsedPercent(90), CPUUsagePercent(95))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Memory usage is high", "CPU usage is high", "CPU usage is high"}, titles)
+}
+
+func TestWatchContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Watch(ctx, make(chan sysinfo.Snapshot), NotifierFunc(func(string, string) error { return nil }))
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -71,7 +71,7 @@ func bootTime(filename string) (time.Time, error) {
 		}
 
 		if utmp.Type == typeBootTime {
-			return time.Unix(utmp.Time, 0), nil
+			return time.Unix(utmp.Time, 0).UTC(), nil
 		}
 	}
 
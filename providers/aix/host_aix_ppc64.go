@@ -38,7 +38,9 @@ import (
 
 	"github.com/joeshaw/multierror"
 
+	"github.com/elastic/go-sysinfo/internal/deadline"
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -54,7 +56,13 @@ type aixSystem struct{}
 
 // Host returns a new AIX host.
 func (aixSystem) Host() (types.Host, error) {
-	return newHost()
+	return newHost(registry.HostOptions{})
+}
+
+// HostWithOptions returns the host information without the fields excluded
+// by opts.
+func (aixSystem) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	return newHost(opts)
 }
 
 type host struct {
@@ -72,10 +80,12 @@ func (h *host) Info() types.HostInfo {
 }
 
 // Info returns the current CPU usage of the host.
-func (*host) CPUTime() (types.CPUTimes, error) {
+func (*host) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("host cpu time", &err)
+
 	clock := uint64(C.sysconf(C._SC_CLK_TCK))
-	tick2nsec := func(val uint64) uint64 {
-		return val * 1e9 / clock
+	tick2duration := func(val uint64) time.Duration {
+		return shared.TicksToDuration(val, clock)
 	}
 
 	cpudata := C.perfstat_cpu_total_t{}
@@ -85,21 +95,23 @@ func (*host) CPUTime() (types.CPUTimes, error) {
 	}
 
 	return types.CPUTimes{
-		User:   time.Duration(tick2nsec(uint64(cpudata.user))),
-		System: time.Duration(tick2nsec(uint64(cpudata.sys))),
-		Idle:   time.Duration(tick2nsec(uint64(cpudata.idle))),
-		IOWait: time.Duration(tick2nsec(uint64(cpudata.wait))),
+		User:   tick2duration(uint64(cpudata.user)),
+		System: tick2duration(uint64(cpudata.sys)),
+		Idle:   tick2duration(uint64(cpudata.idle)),
+		IOWait: tick2duration(uint64(cpudata.wait)),
 	}, nil
 }
 
 // Memory returns the current memory usage of the host.
-func (*host) Memory() (*types.HostMemoryInfo, error) {
+func (*host) Memory() (_ *types.HostMemoryInfo, err error) {
+	defer safe.Recover("host memory", &err)
+
 	var mem types.HostMemoryInfo
 
 	pagesize := uint64(os.Getpagesize())
 
 	meminfo := C.perfstat_memory_total_t{}
-	_, err := C.perfstat_memory_total(nil, &meminfo, C.sizeof_perfstat_memory_total_t, 1)
+	_, err = C.perfstat_memory_total(nil, &meminfo, C.sizeof_perfstat_memory_total_t, 1)
 	if err != nil {
 		return nil, fmt.Errorf("perfstat_memory_total failed: %w", err)
 	}
@@ -113,23 +125,64 @@ func (*host) Memory() (*types.HostMemoryInfo, error) {
 
 	mem.VirtualTotal = uint64(meminfo.virt_total) * pagesize
 	mem.VirtualFree = mem.Free + uint64(meminfo.pgsp_free)*pagesize
-	mem.VirtualUsed = mem.VirtualTotal - mem.VirtualFree
+	mem.VirtualUsed = shared.SubSaturating(mem.VirtualTotal, mem.VirtualFree)
 
 	return &mem, nil
 }
 
-func newHost() (*host, error) {
+// Refresh updates the hostname, the network addresses, and the timezone.
+func (h *host) Refresh() error {
+	return h.RefreshFields(types.HostFieldHostname, types.HostFieldNetwork, types.HostFieldTimezone)
+}
+
+// RefreshFields updates the given fields of the host information. The FQDN
+// is not collected on AIX.
+func (h *host) RefreshFields(fields ...string) error {
+	r := &reader{}
+	for _, field := range fields {
+		switch field {
+		case types.HostFieldHostname:
+			r.hostname(h)
+		case types.HostFieldNetwork:
+			r.network(h)
+		case types.HostFieldTimezone:
+			r.time(h)
+		case types.HostFieldFQDN:
+			r.errs = append(r.errs, fmt.Errorf("host field %q: %w", field, types.ErrNotImplemented))
+		default:
+			r.addErr(fmt.Errorf("unknown host field %q", field))
+		}
+	}
+	return r.Err()
+}
+
+func newHost(opts registry.HostOptions) (*host, error) {
 	h := &host{}
 	r := &reader{}
-	r.architecture(h)
-	r.bootTime(h)
-	r.hostname(h)
-	r.network(h)
-	r.kernelVersion(h)
-	r.os(h)
-	r.time(h)
-	r.uniqueID(h)
-	return h, r.Err()
+	probes := []hostProbe{
+		{"architecture", (*reader).architecture},
+		{"boot time", (*reader).bootTime},
+		{"hostname", (*reader).hostname},
+		{"network", (*reader).network},
+		{"kernel version", (*reader).kernelVersion},
+		{"os", (*reader).os},
+		{"time", (*reader).time},
+	}
+	if !opts.SkipMachineID {
+		probes = append(probes, hostProbe{"machine id", func(r *reader, h *host) { r.uniqueID(h, opts) }})
+	}
+
+	b := deadline.New(opts.Deadline, len(probes))
+	for _, p := range probes {
+		r.probe(b, h, p.name, p.fn)
+	}
+	return h, b.Err(r.Err())
+}
+
+// hostProbe is a probe that collects some of the host fields.
+type hostProbe struct {
+	name string
+	fn   func(*reader, *host)
 }
 
 type reader struct {
@@ -146,6 +199,20 @@ func (r *reader) addErr(err error) bool {
 	return false
 }
 
+// probe runs fn with its share of the budget b. fn modifies a copy of h that
+// is kept only if fn finishes in time, so a probe that is still running after
+// its deadline cannot race with the caller. A panic in fn is reported as an
+// error instead of crashing the application.
+func (r *reader) probe(b *deadline.Budget, h *host, name string, fn func(*reader, *host)) {
+	ph, pr := *h, &reader{}
+	if err := b.Run(name, func() { fn(pr, &ph) }); err != nil {
+		r.addErr(err)
+		return
+	}
+	h.info = ph.info
+	r.errs = append(r.errs, pr.errs...)
+}
+
 func (r *reader) Err() error {
 	if len(r.errs) > 0 {
 		return &multierror.MultiError{Errors: r.errs}
@@ -203,13 +270,16 @@ func (r *reader) os(h *host) {
 }
 
 func (*reader) time(h *host) {
-	h.info.Timezone, h.info.TimezoneOffsetSec = time.Now().Zone()
+	h.info.Timezone, h.info.TimezoneOffsetSec = shared.Timezone("/etc/localtime", time.Now())
 }
 
-func (r *reader) uniqueID(h *host) {
-	v, err := MachineID()
+func (r *reader) uniqueID(h *host, opts registry.HostOptions) {
+	v, source, err := shared.MachineID(opts, []string{registry.MachineIDUname}, map[string]func() (string, error){
+		registry.MachineIDUname: MachineID,
+	})
 	if r.addErr(err) {
 		return
 	}
 	h.info.UniqueID = v
+	h.info.UniqueIDSource = source
 }
@@ -33,7 +33,6 @@ import "C"
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -45,6 +44,8 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/types"
 )
 
@@ -57,8 +58,12 @@ func (aixSystem) Processes() ([]types.Process, error) {
 		return nil, fmt.Errorf("error while reading /proc: %w", err)
 	}
 
-	processes := make([]types.Process, 0, len(files))
+	limit := footprint.Limit(len(files))
+	processes := make([]types.Process, 0, limit)
 	for _, f := range files {
+		if len(processes) == limit {
+			break
+		}
 		// Check that the file is a correct process directory.
 		// /proc also contains special files (/proc/version) and threads
 		// directories (/proc/pid directory but without any "as" file)
@@ -82,6 +87,10 @@ func (s aixSystem) Self() (types.Process, error) {
 	return s.Process(os.Getpid())
 }
 
+// maxEnvironmentSize bounds the buffer used to read the environment of a
+// process.
+const maxEnvironmentSize = 1 << 20
+
 type process struct {
 	pid  int
 	info *types.ProcessInfo
@@ -94,7 +103,9 @@ func (p *process) PID() int {
 }
 
 // Parent returns the parent of a process.
-func (p *process) Parent() (types.Process, error) {
+func (p *process) Parent() (_ types.Process, err error) {
+	defer safe.Recover("process parent", &err)
+
 	info, err := p.Info()
 	if err != nil {
 		return nil, err
@@ -103,7 +114,9 @@ func (p *process) Parent() (types.Process, error) {
 }
 
 // Info returns all information about the process.
-func (p *process) Info() (types.ProcessInfo, error) {
+func (p *process) Info() (_ types.ProcessInfo, err error) {
+	defer safe.Recover("process info", &err)
+
 	if p.info != nil {
 		return *p.info, nil
 	}
@@ -113,26 +126,20 @@ func (p *process) Info() (types.ProcessInfo, error) {
 	}
 
 	// Retrieve PPID and StartTime
-	info := C.struct_procsinfo64{}
-	cpid := C.pid_t(p.pid)
-
-	num, err := C.getprocs(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, nil, 0, &cpid, 1)
-	if num != 1 {
-		err = syscall.ESRCH
-	}
+	info, err := getProcsInfo(p.pid)
 	if err != nil {
-		return types.ProcessInfo{}, fmt.Errorf("error while calling getprocs: %w", err)
+		return types.ProcessInfo{}, err
 	}
 
 	p.info.PPID = int(info.pi_ppid)
 	// pi_start is the time in second since the process have started.
-	p.info.StartTime = time.Unix(0, int64(uint64(info.pi_start)*1000*uint64(time.Millisecond)))
+	p.info.StartTime = time.Unix(0, int64(uint64(info.pi_start)*1000*uint64(time.Millisecond))).UTC()
 
 	// Retrieve arguments and executable name
 	// If buffer is not large enough, args are truncated
 	buf := make([]byte, 8192)
 	var args []string
-	if _, err := C.getargs(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, (*C.char)(&buf[0]), 8192); err != nil {
+	if _, err := C.getargs(unsafe.Pointer(info), C.sizeof_struct_procsinfo64, (*C.char)(&buf[0]), 8192); err != nil {
 		return types.ProcessInfo{}, fmt.Errorf("error while calling getargs: %w", err)
 	}
 
@@ -197,21 +204,25 @@ func (p *process) Environment() (map[string]string, error) {
 	if p.env != nil {
 		return p.env, nil
 	}
-	p.env = map[string]string{}
 
-	/* If buffer is not large enough, args are truncated */
-	buf := make([]byte, 8192)
 	info := C.struct_procsinfo64{}
 	info.pi_pid = C.pid_t(p.pid)
 
-	if _, err := C.getevars(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, (*C.char)(&buf[0]), 8192); err != nil {
-		return nil, fmt.Errorf("error while calling getevars: %w", err)
+	// getevars truncates the environment to the size of the buffer, so
+	// retry with a larger buffer until the terminating empty string fits.
+	var buf []byte
+	for size := 8192; ; size *= 2 {
+		buf = make([]byte, size)
+		if _, err := C.getevars(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, (*C.char)(&buf[0]), C.int(size)); err != nil {
+			return nil, fmt.Errorf("error while calling getevars: %w", err)
+		}
+		if bytes.Contains(buf, []byte{0, 0}) || size >= maxEnvironmentSize {
+			break
+		}
 	}
 
+	env := map[string]string{}
 	bbuf := bytes.NewBuffer(buf)
-
-	delim := []byte{61} // "="
-
 	for {
 		line, err := bbuf.ReadBytes(0)
 		if err == io.EOF || line[0] == 0 {
@@ -221,18 +232,23 @@ func (p *process) Environment() (map[string]string, error) {
 			return nil, fmt.Errorf("error while calling getevars: %w", err)
 		}
 
-		pair := bytes.SplitN(chop(line), delim, 2)
-		if len(pair) != 2 {
-			return nil, errors.New("error reading process environment")
+		// Variables without a value are kept with an empty value.
+		pair := bytes.SplitN(chop(line), []byte{'='}, 2)
+		if len(pair) == 2 {
+			env[string(pair[0])] = string(pair[1])
+		} else {
+			env[string(pair[0])] = ""
 		}
-		p.env[string(pair[0])] = string(pair[1])
 	}
 
+	p.env = env
 	return p.env, nil
 }
 
 // User returns the user IDs of a process.
-func (p *process) User() (types.UserInfo, error) {
+func (p *process) User() (_ types.UserInfo, err error) {
+	defer safe.Recover("process user", &err)
+
 	var prcred prcred
 	if err := p.decodeProcfsFile("cred", &prcred); err != nil {
 		return types.UserInfo{}, err
@@ -248,29 +264,58 @@ func (p *process) User() (types.UserInfo, error) {
 }
 
 // Memory returns the current memory usage of a process.
-func (p *process) Memory() (types.MemoryInfo, error) {
+func (p *process) Memory() (_ types.MemoryInfo, err error) {
+	defer safe.Recover("process memory", &err)
+
 	var mem types.MemoryInfo
 	pagesize := uint64(os.Getpagesize())
 
-	info := C.struct_procsinfo64{}
-	cpid := C.pid_t(p.pid)
-
-	num, err := C.getprocs(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, nil, 0, &cpid, 1)
-	if num != 1 {
-		err = syscall.ESRCH
-	}
+	info, err := getProcsInfo(p.pid)
 	if err != nil {
-		return types.MemoryInfo{}, fmt.Errorf("error while calling getprocs: %w", err)
+		return types.MemoryInfo{}, err
 	}
 
 	mem.Resident = uint64(info.pi_drss+info.pi_trss) * pagesize
 	mem.Virtual = uint64(info.pi_dvm) * pagesize
+	if !footprint.Low() {
+		mem.Metrics = map[string]uint64{
+			"page_ins":    uint64(info.pi_majflt),
+			"page_faults": uint64(info.pi_majflt + info.pi_minflt),
+		}
+	}
 
 	return mem, nil
 }
 
+// ContextSwitches returns the context switches of a process.
+func (p *process) ContextSwitches() (*types.ContextSwitchInfo, error) {
+	info, err := getProcsInfo(p.pid)
+	if err != nil {
+		return nil, err
+	}
+
+	voluntary := uint64(info.pi_ru.ru_nvcsw)
+	involuntary := uint64(info.pi_ru.ru_nivcsw)
+	return &types.ContextSwitchInfo{
+		Voluntary:   &voluntary,
+		Involuntary: &involuntary,
+		Total:       voluntary + involuntary,
+	}, nil
+}
+
+// OpenHandleCount returns the number of open file descriptors of a process.
+func (p *process) OpenHandleCount() (int, error) {
+	fds, err := os.ReadDir("/proc/" + strconv.Itoa(p.pid) + "/fd")
+	if err != nil {
+		return 0, fmt.Errorf("error while reading /proc/%d/fd: %w", p.pid, err)
+	}
+	return len(fds), nil
+}
+
 // CPUTime returns the current CPU usage of a process.
-func (p *process) CPUTime() (types.CPUTimes, error) {
+func (p *process) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("process cpu time", &err)
+
 	var pstatus pstatus
 	if err := p.decodeProcfsFile("status", &pstatus); err != nil {
 		return types.CPUTimes{}, err
@@ -281,6 +326,21 @@ func (p *process) CPUTime() (types.CPUTimes, error) {
 	}, nil
 }
 
+// getProcsInfo returns the procsinfo64 entry of the process.
+func getProcsInfo(pid int) (*C.struct_procsinfo64, error) {
+	info := C.struct_procsinfo64{}
+	cpid := C.pid_t(pid)
+
+	num, err := C.getprocs(unsafe.Pointer(&info), C.sizeof_struct_procsinfo64, nil, 0, &cpid, 1)
+	if num != 1 {
+		err = syscall.ESRCH
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while calling getprocs: %w", err)
+	}
+	return &info, nil
+}
+
 func (p *process) decodeProcfsFile(name string, data interface{}) error {
 	fileName := "/proc/" + strconv.Itoa(p.pid) + "/" + name
 
@@ -21,14 +21,30 @@
 package darwin
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"golang.org/x/sys/unix"
 )
 
-const hardwareMIB = "hw.machine"
+const (
+	hardwareMIB   = "hw.machine"
+	arm64MIB      = "hw.optional.arm64"
+	translatedMIB = "sysctl.proc_translated"
+)
+
+// pTranslated is the P_TRANSLATED flag of kinfo_proc. It is set for
+// processes that run under Rosetta translation.
+const pTranslated = 0x00020000
 
 func Architecture() (string, error) {
+	// hw.machine reports x86_64 to processes that run under Rosetta, so
+	// Apple Silicon is detected by its arm64 feature flag.
+	if arm64, err := unix.SysctlUint32(arm64MIB); err == nil && arm64 == 1 {
+		return "arm64", nil
+	}
+
 	arch, err := unix.Sysctl(hardwareMIB)
 	if err != nil {
 		return "", fmt.Errorf("failed to get architecture: %w", err)
@@ -36,3 +52,46 @@ func Architecture() (string, error) {
 
 	return arch, nil
 }
+
+// processArchitecture returns the architecture that the process runs as,
+// the native architecture of the hardware, and whether the process runs
+// under Rosetta translation. Translation is only reported on Apple Silicon.
+func processArchitecture(pid int) (arch, native string, translated *bool) {
+	native, err := Architecture()
+	if err != nil {
+		return "", "", nil
+	}
+	if native != "arm64" {
+		return native, native, nil
+	}
+
+	t, err := processTranslated(pid)
+	if err != nil {
+		return "", native, nil
+	}
+	if t {
+		return "x86_64", native, &t
+	}
+	return native, native, &t
+}
+
+// processTranslated reports whether the process runs under Rosetta.
+func processTranslated(pid int) (bool, error) {
+	if pid == os.Getpid() {
+		v, err := unix.SysctlUint32(translatedMIB)
+		if errors.Is(err, unix.ENOENT) {
+			// Only exists on systems that support Rosetta.
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read %v: %w", translatedMIB, err)
+		}
+		return v == 1, nil
+	}
+
+	kproc, err := unix.SysctlKinfoProc("kern.proc.pid", pid)
+	if err != nil {
+		return false, err
+	}
+	return kproc.Proc.P_flag&pTranslated != 0, nil
+}
@@ -18,6 +18,8 @@
 package darwin
 
 import (
+	"os"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +30,23 @@ func TestArchitecture(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, a)
 }
+
+func TestProcessArchitecture(t *testing.T) {
+	arch, native, translated := processArchitecture(os.Getpid())
+	assert.NotEmpty(t, native)
+	if native != "arm64" {
+		assert.Nil(t, translated)
+		assert.Equal(t, native, arch)
+		return
+	}
+
+	// An amd64 binary can only run on Apple Silicon under Rosetta.
+	if assert.NotNil(t, translated) {
+		assert.Equal(t, runtime.GOARCH == "amd64", *translated)
+	}
+	if runtime.GOARCH == "amd64" {
+		assert.Equal(t, "x86_64", arch)
+	} else {
+		assert.Equal(t, "arm64", arch)
+	}
+}
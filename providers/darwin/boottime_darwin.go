@@ -35,6 +35,6 @@ func BootTime() (time.Time, error) {
 		return time.Time{}, fmt.Errorf("failed to get host uptime: %w", err)
 	}
 
-	bootTime := time.Unix(int64(tv.Sec), int64(tv.Usec)*int64(time.Microsecond))
+	bootTime := time.Unix(int64(tv.Sec), int64(tv.Usec)*int64(time.Microsecond)).UTC()
 	return bootTime, nil
 }
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// CanCollect reports whether the process has the privileges needed to
+// collect the data.
+func (s darwinSystem) CanCollect(capability types.Capability) (bool, error) {
+	switch capability {
+	case types.CapabilityProcessEnvironment, types.CapabilityProcessCommandLine:
+		// KERN_PROCARGS2 is denied for processes owned by other users
+		// unless the caller is root.
+		return os.Geteuid() == 0, nil
+	case types.CapabilityHardwareSerial:
+		// The platform serial number is readable by any user.
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown capability %q: %w", capability, types.ErrNotImplemented)
+	}
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// DNSConfig reports the resolver configuration of the System Configuration
+// framework from the output of "scutil --dns". /etc/resolv.conf only
+// contains the default resolver and is not used by macOS itself. The
+// default resolver provides the nameservers and search domains and the
+// resolvers for scoped queries the per-interface settings.
+func (h *host) DNSConfig() (*types.DNSConfigInfo, error) {
+	out, err := exec.Command("scutil", "--dns").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run scutil --dns: %w", err)
+	}
+	return parseScutilDNS(out), nil
+}
+
+// scutilResolver is a resolver of the scutil --dns output.
+type scutilResolver struct {
+	domain      string // Domain of supplemental resolvers.
+	nameservers []string
+	search      []string
+	ifc         string
+}
+
+// parseScutilDNS parses the output of scutil --dns.
+func parseScutilDNS(out []byte) *types.DNSConfigInfo {
+	var (
+		scoped    bool
+		resolvers []*scutilResolver
+		scopedRs  []*scutilResolver
+		current   *scutilResolver
+	)
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case strings.HasPrefix(line, "DNS configuration"):
+			scoped = strings.Contains(line, "scoped")
+			current = nil
+			continue
+		case strings.HasPrefix(line, "resolver #"):
+			current = &scutilResolver{}
+			if scoped {
+				scopedRs = append(scopedRs, current)
+			} else {
+				resolvers = append(resolvers, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch {
+		case key == "domain":
+			current.domain = value
+		case strings.HasPrefix(key, "nameserver["):
+			current.nameservers = append(current.nameservers, value)
+		case strings.HasPrefix(key, "search domain["):
+			current.search = append(current.search, value)
+		case key == "if_index":
+			// e.g. 6 (en0)
+			if i, j := strings.Index(value, "("), strings.LastIndex(value, ")"); i >= 0 && j > i {
+				current.ifc = value[i+1 : j]
+			}
+		}
+	}
+
+	info := &types.DNSConfigInfo{Nameservers: []string{}}
+	for _, r := range resolvers {
+		// The default resolver is the first one without a domain.
+		if r.domain == "" {
+			info.Nameservers = append(info.Nameservers, r.nameservers...)
+			info.Search = r.search
+			break
+		}
+	}
+	for _, r := range scopedRs {
+		if r.ifc != "" && len(r.nameservers) > 0 {
+			info.Interfaces = append(info.Interfaces, types.InterfaceDNS{
+				Interface:   r.ifc,
+				Nameservers: r.nameservers,
+				Search:      r.search,
+			})
+		}
+	}
+	return info
+}
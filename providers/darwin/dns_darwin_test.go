@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.DNSConfig = (*host)(nil)
+
+const scutilDNSOutput = `DNS configuration
+
+resolver #1
+  search domain[0] : lan
+  search domain[1] : corp.example.com
+  nameserver[0] : 192.168.1.1
+  nameserver[1] : 10.8.0.1
+  if_index : 6 (en0)
+  flags    : Request A records
+  reach    : 0x00020002 (Reachable,Directly Reachable Address)
+
+resolver #2
+  domain   : local
+  options  : mdns
+  timeout  : 5
+  flags    : Request A records
+  reach    : 0x00000000 (Not Reachable)
+  order    : 300000
+
+DNS configuration (for scoped queries)
+
+resolver #1
+  search domain[0] : lan
+  nameserver[0] : 192.168.1.1
+  if_index : 6 (en0)
+  flags    : Scoped, Request A records
+  reach    : 0x00020002 (Reachable,Directly Reachable Address)
+
+resolver #2
+  search domain[0] : corp.example.com
+  nameserver[0] : 10.8.0.1
+  if_index : 14 (utun3)
+  flags    : Scoped, Request A records
+  reach    : 0x00000003 (Reachable,Transient Connection)
+`
+
+func TestParseScutilDNS(t *testing.T) {
+	assert.Equal(t, &types.DNSConfigInfo{
+		Nameservers: []string{"192.168.1.1", "10.8.0.1"},
+		Search:      []string{"lan", "corp.example.com"},
+		Interfaces: []types.InterfaceDNS{
+			{Interface: "en0", Nameservers: []string{"192.168.1.1"}, Search: []string{"lan"}},
+			{Interface: "utun3", Nameservers: []string{"10.8.0.1"}, Search: []string{"corp.example.com"}},
+		},
+	}, parseScutilDNS([]byte(scutilDNSOutput)))
+}
@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// FileHandles reports the number of open files and the system-wide limit
+// from the kern.num_files and kern.maxfiles sysctls.
+func (h *host) FileHandles() (*types.FileHandlesInfo, error) {
+	allocated, err := unix.SysctlUint32("kern.num_files")
+	if err != nil {
+		return nil, err
+	}
+	maximum, err := unix.SysctlUint32("kern.maxfiles")
+	if err != nil {
+		return nil, err
+	}
+
+	max := uint64(maximum)
+	return &types.FileHandlesInfo{Allocated: uint64(allocated), Maximum: &max}, nil
+}
@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const socketFilterFW = "/usr/libexec/ApplicationFirewall/socketfilterfw"
+
+var firewallStateRegexp = regexp.MustCompile(`\(State = (\d+)\)`)
+
+// Firewall reports whether the Application Firewall is enabled, which is
+// the firewall of the Network settings. Its state is queried with
+// socketfilterfw because the com.apple.alf preferences are no longer used
+// by macOS 15.
+func (h *host) Firewall() (*types.FirewallInfo, error) {
+	out, err := exec.Command(socketFilterFW, "--getglobalstate").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run socketfilterfw: %w", err)
+	}
+
+	enabled := parseFirewallState(out)
+	return &types.FirewallInfo{
+		Enabled:   enabled,
+		Firewalls: []types.FirewallStatus{{Name: types.FirewallApplication, Enabled: enabled}},
+	}, nil
+}
+
+// parseFirewallState parses the output of "socketfilterfw --getglobalstate",
+// like "Firewall is enabled. (State = 1)". State 2 blocks all incoming
+// connections.
+func parseFirewallState(out []byte) bool {
+	if m := firewallStateRegexp.FindSubmatch(out); m != nil {
+		return string(m[1]) != "0"
+	}
+	return strings.Contains(string(out), "enabled")
+}
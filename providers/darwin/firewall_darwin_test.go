@@ -0,0 +1,38 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Firewall = (*host)(nil)
+
+func TestParseFirewallState(t *testing.T) {
+	assert.True(t, parseFirewallState([]byte("Firewall is enabled. (State = 1)\n")))
+	assert.True(t, parseFirewallState([]byte("Firewall is blocking all non-essential incoming connections. (State = 2)\n")))
+	assert.False(t, parseFirewallState([]byte("Firewall is disabled. (State = 0)\n")))
+	assert.True(t, parseFirewallState([]byte("Firewall is enabled.\n")))
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"errors"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// FirmwareInfo returns the firmware version from the device tree. Intel Macs
+// boot using EFI and expose the boot ROM in IODeviceTree:/rom. On Apple
+// silicon the iBoot version is found in IODeviceTree:/chosen.
+func FirmwareInfo() (*types.FirmwareInfo, error) {
+	if rom, err := ioEntryFromPath("IODeviceTree:/rom"); err == nil {
+		defer rom.Release()
+
+		info := &types.FirmwareInfo{BootMode: types.BootModeUEFI}
+		info.Vendor, _ = rom.GetString("vendor")
+		info.Version, _ = rom.GetString("version")
+		info.ReleaseDate, _ = rom.GetString("release-date")
+		return info, nil
+	} else if errors.Is(err, types.ErrNotImplemented) {
+		return nil, err
+	}
+
+	chosen, err := ioEntryFromPath("IODeviceTree:/chosen")
+	if err != nil {
+		return nil, err
+	}
+	defer chosen.Release()
+
+	info := &types.FirmwareInfo{Vendor: "Apple Inc."}
+	info.Version, _ = chosen.GetString("system-firmware-version")
+	return info, nil
+}
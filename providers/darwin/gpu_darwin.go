@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// GPUs reports the graphics accelerators registered in the I/O Registry.
+// On Apple silicon the vendor and device IDs are not reported because the
+// GPU is not attached to the PCI bus.
+func (h *host) GPUs() ([]types.GPUInfo, error) {
+	accelerators, err := ioServices("IOAccelerator")
+	if err != nil {
+		return nil, err
+	}
+
+	gpus := make([]types.GPUInfo, 0, len(accelerators))
+	for _, accel := range accelerators {
+		gpus = append(gpus, readAccelerator(accel))
+		accel.Release()
+	}
+	return gpus, nil
+}
+
+func readAccelerator(accel ioObject) types.GPUInfo {
+	gpu := types.GPUInfo{
+		Driver: accel.ClassName(),
+	}
+	gpu.Model, _ = accel.SearchString("model")
+
+	// The PCI IDs are properties of the parent IOPCIDevice.
+	if vendor, found := accel.SearchUint("vendor-id"); found {
+		gpu.VendorID = shared.FormatPCIID(uint16(vendor))
+		gpu.Vendor = shared.PCIVendorName(uint16(vendor))
+	} else if strings.HasPrefix(gpu.Driver, "AGX") {
+		gpu.Vendor = "Apple Inc."
+	}
+	if device, found := accel.SearchUint("device-id"); found {
+		gpu.DeviceID = shared.FormatPCIID(uint16(device))
+	}
+
+	if vram, found := accel.SearchUint("VRAM,totalMB"); found {
+		gpu.MemoryTotal = vram * 1024 * 1024
+	}
+	if used, found := accel.DictUint("PerformanceStatistics", "vramUsedBytes"); found {
+		gpu.MemoryUsed = &used
+	}
+	if busy, found := accel.DictUint("PerformanceStatistics", "Device Utilization %"); found {
+		pct := float64(busy)
+		gpu.Utilization = &pct
+	}
+	return gpu
+}
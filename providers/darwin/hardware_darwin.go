@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"errors"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// HardwareInfo reports the system identity from the IOPlatformExpertDevice
+// entry of the I/O Registry.
+func (h *host) HardwareInfo() (*types.HardwareInfo, error) {
+	return hardwareInfo()
+}
+
+func hardwareInfo() (*types.HardwareInfo, error) {
+	devices, err := ioServices("IOPlatformExpertDevice")
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, errors.New("IOPlatformExpertDevice not found")
+	}
+	defer func() {
+		for _, d := range devices {
+			d.Release()
+		}
+	}()
+
+	device := devices[0]
+	info := &types.HardwareInfo{}
+	info.Manufacturer, _ = device.SearchString("manufacturer")
+	info.ProductName, _ = device.SearchString("model") // e.g. MacBookPro18,3
+	info.SerialNumber, _ = device.SearchString("IOPlatformSerialNumber")
+	info.UUID, _ = device.SearchString("IOPlatformUUID")
+	return info, nil
+}
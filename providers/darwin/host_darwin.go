@@ -28,7 +28,11 @@ import (
 
 	"github.com/joeshaw/multierror"
 
+	"github.com/elastic/go-sysinfo/internal/deadline"
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -40,34 +44,48 @@ func init() {
 type darwinSystem struct{}
 
 func (s darwinSystem) Host() (types.Host, error) {
-	return newHost()
+	return newHost(registry.HostOptions{})
+}
+
+// HostWithOptions returns the host information without the fields excluded
+// by opts.
+func (s darwinSystem) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	return newHost(opts)
 }
 
 type host struct {
 	info types.HostInfo
+
+	// opts are the options the host was created with. They are used to
+	// look up the FQDN again on refresh.
+	opts registry.HostOptions
 }
 
 func (h *host) Info() types.HostInfo {
 	return h.info
 }
 
-func (h *host) CPUTime() (types.CPUTimes, error) {
+func (h *host) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("host cpu time", &err)
+
 	cpu, err := getHostCPULoadInfo()
 	if err != nil {
 		return types.CPUTimes{}, fmt.Errorf("failed to get host CPU usage: %w", err)
 	}
 
-	ticksPerSecond := time.Duration(getClockTicks())
+	ticksPerSecond := uint64(getClockTicks())
 
 	return types.CPUTimes{
-		User:   time.Duration(cpu.User) * time.Second / ticksPerSecond,
-		System: time.Duration(cpu.System) * time.Second / ticksPerSecond,
-		Idle:   time.Duration(cpu.Idle) * time.Second / ticksPerSecond,
-		Nice:   time.Duration(cpu.Nice) * time.Second / ticksPerSecond,
+		User:   shared.TicksToDuration(uint64(cpu.User), ticksPerSecond),
+		System: shared.TicksToDuration(uint64(cpu.System), ticksPerSecond),
+		Idle:   shared.TicksToDuration(uint64(cpu.Idle), ticksPerSecond),
+		Nice:   shared.TicksToDuration(uint64(cpu.Nice), ticksPerSecond),
 	}, nil
 }
 
-func (h *host) Memory() (*types.HostMemoryInfo, error) {
+func (h *host) Memory() (_ *types.HostMemoryInfo, err error) {
+	defer safe.Recover("host memory", &err)
+
 	var mem types.HostMemoryInfo
 
 	// Total physical memory.
@@ -106,6 +124,16 @@ func (h *host) Memory() (*types.HostMemoryInfo, error) {
 
 	inactiveBytes := uint64(vmStat.Inactive_count) * pageSizeBytes
 	purgeableBytes := uint64(vmStat.Purgeable_count) * pageSizeBytes
+
+	// From Activity Monitor: Memory Used = App Memory (internal) + Wired + Compressed
+	// https://support.apple.com/en-us/HT201538
+	mem.Used = (uint64(vmStat.Internal_page_count) + uint64(vmStat.Wire_count) + uint64(vmStat.Compressor_page_count)) * pageSizeBytes
+	mem.Free = uint64(vmStat.Free_count) * pageSizeBytes
+	mem.Available = mem.Free + inactiveBytes + purgeableBytes
+
+	if footprint.Low() {
+		return &mem, nil
+	}
 	mem.Metrics = map[string]uint64{
 		"active_bytes":         uint64(vmStat.Active_count) * pageSizeBytes,
 		"compressed_bytes":     uint64(vmStat.Compressor_page_count) * pageSizeBytes,
@@ -130,12 +158,6 @@ func (h *host) Memory() (*types.HostMemoryInfo, error) {
 		"zero_filled_bytes":    uint64(vmStat.Zero_fill_count) * pageSizeBytes,
 	}
 
-	// From Activity Monitor: Memory Used = App Memory (internal) + Wired + Compressed
-	// https://support.apple.com/en-us/HT201538
-	mem.Used = uint64(vmStat.Internal_page_count+vmStat.Wire_count+vmStat.Compressor_page_count) * pageSizeBytes
-	mem.Free = uint64(vmStat.Free_count) * pageSizeBytes
-	mem.Available = mem.Free + inactiveBytes + purgeableBytes
-
 	return &mem, nil
 }
 
@@ -154,19 +176,67 @@ func (h *host) LoadAverage() (*types.LoadAverageInfo, error) {
 	}, nil
 }
 
-func newHost() (*host, error) {
-	h := &host{}
+// Refresh updates the hostname, the network addresses, and the timezone. The
+// FQDN is looked up again when the hostname changed.
+func (h *host) Refresh() error {
+	hostname := h.info.Hostname
 	r := &reader{}
-	r.architecture(h)
-	r.bootTime(h)
 	r.hostname(h)
-	r.fqdn(h)
 	r.network(h)
-	r.kernelVersion(h)
-	r.os(h)
 	r.time(h)
-	r.uniqueID(h)
-	return h, r.Err()
+	if h.info.Hostname != hostname && !h.opts.SkipFQDN {
+		r.fqdn(h, h.opts)
+	}
+	return r.Err()
+}
+
+// RefreshFields updates the given fields of the host information.
+func (h *host) RefreshFields(fields ...string) error {
+	r := &reader{}
+	for _, field := range fields {
+		switch field {
+		case types.HostFieldHostname:
+			r.hostname(h)
+		case types.HostFieldFQDN:
+			r.fqdn(h, h.opts)
+		case types.HostFieldNetwork:
+			r.network(h)
+		case types.HostFieldTimezone:
+			r.time(h)
+		default:
+			r.addErr(fmt.Errorf("unknown host field %q", field))
+		}
+	}
+	return r.Err()
+}
+
+func newHost(opts registry.HostOptions) (*host, error) {
+	h := &host{opts: opts}
+	r := &reader{}
+	probes := []hostProbe{
+		{"static host info", func(r *reader, h *host) { r.staticInfo(h, opts) }},
+		{"boot time", (*reader).bootTime},
+		{"hostname", (*reader).hostname},
+	}
+	if !opts.SkipFQDN {
+		probes = append(probes, hostProbe{"fqdn", func(r *reader, h *host) { r.fqdn(h, opts) }})
+	}
+	probes = append(probes,
+		hostProbe{"network", (*reader).network},
+		hostProbe{"time", (*reader).time},
+	)
+
+	b := deadline.New(opts.Deadline, len(probes))
+	for _, p := range probes {
+		r.probe(b, h, p.name, p.fn)
+	}
+	return h, b.Err(r.Err())
+}
+
+// hostProbe is a probe that collects some of the host fields.
+type hostProbe struct {
+	name string
+	fn   func(*reader, *host)
 }
 
 type reader struct {
@@ -183,6 +253,44 @@ func (r *reader) addErr(err error) bool {
 	return false
 }
 
+// staticInfo collects the host fields that do not change while the host is
+// running. The fields are cached for opts.CacheTTL.
+func (r *reader) staticInfo(h *host, opts registry.HostOptions) {
+	key := fmt.Sprint(opts.SkipMachineID, opts.MachineIDSources, opts.MachineIDPath)
+	v, err := ratelimit.Default.DoInterval(ratelimit.StaticHostInfo, key, opts.CacheTTL, func() (interface{}, error) {
+		sh := &host{}
+		sr := &reader{}
+		sb := deadline.New(time.Time{}, 0)
+		sr.probe(sb, sh, "architecture", (*reader).architecture)
+		sr.probe(sb, sh, "firmware", (*reader).firmware)
+		sr.probe(sb, sh, "hardware", (*reader).hardware)
+		sr.probe(sb, sh, "kernel version", (*reader).kernelVersion)
+		sr.probe(sb, sh, "os", (*reader).os)
+		if !opts.SkipMachineID {
+			sr.probe(sb, sh, "machine id", func(r *reader, h *host) { r.uniqueID(h, opts) })
+		}
+		return sh.info, sr.Err()
+	})
+	if info, ok := v.(types.HostInfo); ok {
+		shared.CopyStaticHostInfo(&h.info, info)
+	}
+	r.addErr(err)
+}
+
+// probe runs fn with its share of the budget b. fn modifies a copy of h that
+// is kept only if fn finishes in time, so a probe that is still running after
+// its deadline cannot race with the caller. A panic in fn is reported as an
+// error instead of crashing the application.
+func (r *reader) probe(b *deadline.Budget, h *host, name string, fn func(*reader, *host)) {
+	ph, pr := *h, &reader{}
+	if err := b.Run(name, func() { fn(pr, &ph) }); err != nil {
+		r.addErr(err)
+		return
+	}
+	h.info = ph.info
+	r.errs = append(r.errs, pr.errs...)
+}
+
 func (r *reader) Err() error {
 	if len(r.errs) > 0 {
 		return &multierror.MultiError{Errors: r.errs}
@@ -214,14 +322,31 @@ func (r *reader) hostname(h *host) {
 	h.info.Hostname = v
 }
 
-func (r *reader) fqdn(h *host) {
-	v, err := shared.FQDN()
+func (r *reader) fqdn(h *host, opts registry.HostOptions) {
+	v, err := shared.FQDNWithStrategy(opts.FQDNStrategy, "/etc/hosts", opts.FQDNTimeout, shared.LookupFQDN)
 	if r.addErr(err) {
 		return
 	}
 
 	h.info.FQDN = v
 }
+func (r *reader) firmware(h *host) {
+	v, err := FirmwareInfo()
+	if r.addErr(err) {
+		return
+	}
+	h.info.Firmware = v
+}
+
+func (r *reader) hardware(h *host) {
+	v, err := hardwareInfo()
+	if r.addErr(err) {
+		return
+	}
+	h.info.SerialNumber = v.SerialNumber
+	h.info.AssetTag = v.AssetTag
+}
+
 func (r *reader) network(h *host) {
 	ips, macs, err := shared.Network()
 	if r.addErr(err) {
@@ -248,13 +373,16 @@ func (r *reader) os(h *host) {
 }
 
 func (r *reader) time(h *host) {
-	h.info.Timezone, h.info.TimezoneOffsetSec = time.Now().Zone()
+	h.info.Timezone, h.info.TimezoneOffsetSec = shared.Timezone("/etc/localtime", time.Now())
 }
 
-func (r *reader) uniqueID(h *host) {
-	v, err := MachineID()
+func (r *reader) uniqueID(h *host, opts registry.HostOptions) {
+	v, source, err := shared.MachineID(opts, []string{registry.MachineIDPlatformUUID}, map[string]func() (string, error){
+		registry.MachineIDPlatformUUID: MachineID,
+	})
 	if r.addErr(err) {
 		return
 	}
 	h.info.UniqueID = v
+	h.info.UniqueIDSource = source
 }
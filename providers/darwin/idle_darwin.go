@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// IdleState reports the time since the last keyboard or mouse input from the
+// HIDIdleTime property of IOHIDSystem. The lock state is not reported.
+func (h *host) IdleState() (*types.IdleInfo, error) {
+	services, err := ioServices("IOHIDSystem")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, s := range services {
+			s.Release()
+		}
+	}()
+
+	for _, s := range services {
+		// Nanoseconds since the last input event.
+		if ns, found := s.GetUint("HIDIdleTime"); found {
+			idle := time.Duration(ns)
+			return &types.IdleInfo{Idle: &idle}, nil
+		}
+	}
+	return nil, errors.New("IOHIDSystem HIDIdleTime not found")
+}
@@ -0,0 +1,260 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && cgo) || (arm64 && cgo)
+// +build amd64,cgo arm64,cgo
+
+package darwin
+
+/*
+#cgo LDFLAGS: -framework CoreFoundation -framework IOKit
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+#include <CoreFoundation/CoreFoundation.h>
+#include <IOKit/IOKitLib.h>
+
+// sysinfo_property returns a property of the entry. If search is set, the
+// parents of the entry are searched as well.
+static CFTypeRef sysinfo_property(io_registry_entry_t entry, const char *key, int search) {
+	CFStringRef k = CFStringCreateWithCString(kCFAllocatorDefault, key, kCFStringEncodingUTF8);
+	if (k == NULL) {
+		return NULL;
+	}
+	CFTypeRef v;
+	if (search) {
+		v = IORegistryEntrySearchCFProperty(entry, kIOServicePlane, k, kCFAllocatorDefault,
+			kIORegistryIterateRecursively | kIORegistryIterateParents);
+	} else {
+		v = IORegistryEntryCreateCFProperty(entry, k, kCFAllocatorDefault, 0);
+	}
+	CFRelease(k);
+	return v;
+}
+
+static int sysinfo_cf_string(CFTypeRef v, char *buf, size_t len) {
+	if (v == NULL || len == 0) {
+		return 0;
+	}
+	if (CFGetTypeID(v) == CFStringGetTypeID()) {
+		return CFStringGetCString((CFStringRef)v, buf, len, kCFStringEncodingUTF8) ? 1 : 0;
+	}
+	if (CFGetTypeID(v) == CFDataGetTypeID()) {
+		size_t n = (size_t)CFDataGetLength((CFDataRef)v);
+		if (n >= len) {
+			n = len - 1;
+		}
+		memcpy(buf, CFDataGetBytePtr((CFDataRef)v), n);
+		buf[n] = '\0';
+		return 1;
+	}
+	return 0;
+}
+
+static int sysinfo_cf_uint(CFTypeRef v, uint64_t *out) {
+	if (v == NULL) {
+		return 0;
+	}
+	if (CFGetTypeID(v) == CFNumberGetTypeID()) {
+		long long n;
+		if (!CFNumberGetValue((CFNumberRef)v, kCFNumberLongLongType, &n)) {
+			return 0;
+		}
+		*out = (uint64_t)n;
+		return 1;
+	}
+	if (CFGetTypeID(v) == CFDataGetTypeID()) {
+		// Numeric properties of device tree nodes are little-endian data.
+		CFIndex n = CFDataGetLength((CFDataRef)v);
+		const UInt8 *b = CFDataGetBytePtr((CFDataRef)v);
+		if (n <= 0 || n > 8) {
+			return 0;
+		}
+		*out = 0;
+		for (CFIndex i = n - 1; i >= 0; i--) {
+			*out = (*out << 8) | b[i];
+		}
+		return 1;
+	}
+	return 0;
+}
+
+static int sysinfo_string(io_registry_entry_t entry, const char *key, int search, char *buf, size_t len) {
+	CFTypeRef v = sysinfo_property(entry, key, search);
+	int ok = sysinfo_cf_string(v, buf, len);
+	if (v != NULL) {
+		CFRelease(v);
+	}
+	return ok;
+}
+
+static int sysinfo_uint(io_registry_entry_t entry, const char *key, int search, uint64_t *out) {
+	CFTypeRef v = sysinfo_property(entry, key, search);
+	int ok = sysinfo_cf_uint(v, out);
+	if (v != NULL) {
+		CFRelease(v);
+	}
+	return ok;
+}
+
+static int sysinfo_dict_uint(io_registry_entry_t entry, const char *dict, const char *key, uint64_t *out) {
+	CFTypeRef d = sysinfo_property(entry, dict, 1);
+	if (d == NULL) {
+		return 0;
+	}
+	int ok = 0;
+	if (CFGetTypeID(d) == CFDictionaryGetTypeID()) {
+		CFStringRef k = CFStringCreateWithCString(kCFAllocatorDefault, key, kCFStringEncodingUTF8);
+		if (k != NULL) {
+			ok = sysinfo_cf_uint(CFDictionaryGetValue((CFDictionaryRef)d, k), out);
+			CFRelease(k);
+		}
+	}
+	CFRelease(d);
+	return ok;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ioObject is a handle to an entry of the I/O Registry.
+type ioObject C.io_object_t
+
+// ioServices returns the registered services that are instances of the
+// given IOKit class or of one of its subclasses. The caller must release the
+// returned objects.
+func ioServices(class string) ([]ioObject, error) {
+	cClass := C.CString(class)
+	defer C.free(unsafe.Pointer(cClass))
+
+	// IOServiceGetMatchingServices consumes the matching dictionary.
+	var iter C.io_iterator_t
+	ret := C.IOServiceGetMatchingServices(C.kIOMasterPortDefault, C.IOServiceMatching(cClass), &iter)
+	if ret != C.KERN_SUCCESS {
+		return nil, fmt.Errorf("IOServiceGetMatchingServices(%v) returned status %d", class, ret)
+	}
+	defer C.IOObjectRelease(iter)
+
+	var services []ioObject
+	for {
+		obj := C.IOIteratorNext(iter)
+		if obj == 0 {
+			break
+		}
+		services = append(services, ioObject(obj))
+	}
+	return services, nil
+}
+
+// ioEntryFromPath returns the registry entry at the given path (e.g.
+// IODeviceTree:/chosen). The caller must release the returned object.
+func ioEntryFromPath(path string) (ioObject, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	entry := C.IORegistryEntryFromPath(C.kIOMasterPortDefault, cPath)
+	if entry == 0 {
+		return 0, fmt.Errorf("registry entry %v not found", path)
+	}
+	return ioObject(entry), nil
+}
+
+// Release releases the reference to the object.
+func (o ioObject) Release() {
+	C.IOObjectRelease(C.io_object_t(o))
+}
+
+// ClassName returns the name of the IOKit class of the object.
+func (o ioObject) ClassName() string {
+	var name C.io_name_t
+	if C.IOObjectGetClass(C.io_object_t(o), &name[0]) != C.KERN_SUCCESS {
+		return ""
+	}
+	return C.GoString(&name[0])
+}
+
+// GetString returns a string property of the object. Data properties are
+// interpreted as NUL terminated strings.
+func (o ioObject) GetString(key string) (string, bool) {
+	return o.stringProperty(key, false)
+}
+
+// SearchString looks up a string property of the object or of its parents.
+// Data properties are interpreted as NUL terminated strings.
+func (o ioObject) SearchString(key string) (string, bool) {
+	return o.stringProperty(key, true)
+}
+
+func (o ioObject) stringProperty(key string, search bool) (string, bool) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var buf [256]C.char
+	if C.sysinfo_string(C.io_registry_entry_t(o), cKey, cBool(search), &buf[0], C.size_t(len(buf))) == 0 {
+		return "", false
+	}
+	return C.GoString(&buf[0]), true
+}
+
+// GetUint returns a numeric property of the object. Data properties are
+// interpreted as little-endian integers.
+func (o ioObject) GetUint(key string) (uint64, bool) {
+	return o.uintProperty(key, false)
+}
+
+// SearchUint looks up a numeric property of the object or of its parents.
+// Data properties are interpreted as little-endian integers.
+func (o ioObject) SearchUint(key string) (uint64, bool) {
+	return o.uintProperty(key, true)
+}
+
+func (o ioObject) uintProperty(key string, search bool) (uint64, bool) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var v C.uint64_t
+	if C.sysinfo_uint(C.io_registry_entry_t(o), cKey, cBool(search), &v) == 0 {
+		return 0, false
+	}
+	return uint64(v), true
+}
+
+// DictUint looks up a numeric value in a dictionary property of the object
+// or of its parents.
+func (o ioObject) DictUint(dict, key string) (uint64, bool) {
+	cDict := C.CString(dict)
+	defer C.free(unsafe.Pointer(cDict))
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var v C.uint64_t
+	if C.sysinfo_dict_uint(C.io_registry_entry_t(o), cDict, cKey, &v) == 0 {
+		return 0, false
+	}
+	return uint64(v), true
+}
+
+func cBool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && !cgo) || (arm64 && !cgo)
+
+package darwin
+
+import (
+	"fmt"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+type ioObject uint32
+
+func ioServices(class string) ([]ioObject, error) {
+	return nil, fmt.Errorf("iokit requires cgo: %w", types.ErrNotImplemented)
+}
+
+func ioEntryFromPath(path string) (ioObject, error) {
+	return 0, fmt.Errorf("iokit requires cgo: %w", types.ErrNotImplemented)
+}
+
+func (o ioObject) Release() {}
+
+func (o ioObject) ClassName() string { return "" }
+
+func (o ioObject) GetString(key string) (string, bool) { return "", false }
+
+func (o ioObject) SearchString(key string) (string, bool) { return "", false }
+
+func (o ioObject) GetUint(key string) (uint64, bool) { return 0, false }
+
+func (o ioObject) SearchUint(key string) (uint64, bool) { return 0, false }
+
+func (o ioObject) DictUint(dict, key string) (uint64, bool) { return 0, false }
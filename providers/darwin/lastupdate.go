@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package darwin
+
+import (
+	"fmt"
+	"time"
+
+	"howett.net/plist"
+)
+
+// installHistoryPlist records the installations made by the macOS
+// installers, including the updates installed by softwareupdate.
+const installHistoryPlist = "/Library/Receipts/InstallHistory.plist"
+
+// updateProcesses are the installer processes in InstallHistory.plist
+// whose installations are operating system updates.
+var updateProcesses = map[string]bool{
+	"softwareupdated": true,
+	"Software Update": true,
+	"macOS Installer": true,
+}
+
+// installHistoryEntry contains the keys of an InstallHistory.plist entry.
+type installHistoryEntry struct {
+	Date        time.Time `plist:"date"`
+	DisplayName string    `plist:"displayName"`
+	ProcessName string    `plist:"processName"`
+}
+
+// parseInstallHistory returns the time of the last installation made by
+// softwareupdate or the macOS installer.
+func parseInstallHistory(data []byte) (time.Time, error) {
+	var entries []installHistoryEntry
+	if _, err := plist.Unmarshal(data, &entries); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal install history: %w", err)
+	}
+
+	var last time.Time
+	for _, e := range entries {
+		if updateProcesses[e.ProcessName] && e.Date.After(last) {
+			last = e.Date
+		}
+	}
+	return last.UTC(), nil
+}
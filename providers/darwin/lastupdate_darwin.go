@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"io/ioutil"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// LastUpdate reports the time of the last update installed by softwareupdate
+// (including background security updates) or the macOS installer, as
+// recorded in the install history.
+func (h *host) LastUpdate() (*types.LastUpdateInfo, error) {
+	data, err := ioutil.ReadFile(installHistoryPlist)
+	if err != nil {
+		return nil, err
+	}
+	last, err := parseInstallHistory(data)
+	if err != nil {
+		return nil, err
+	}
+	if last.IsZero() {
+		return nil, types.ErrNotImplemented
+	}
+	return &types.LastUpdateInfo{Time: last, Source: "softwareupdate"}, nil
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package darwin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const installHistory = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<array>
+	<dict>
+		<key>date</key>
+		<date>2024-01-22T18:04:11Z</date>
+		<key>displayName</key>
+		<string>macOS Sonoma 14.3</string>
+		<key>processName</key>
+		<string>softwareupdated</string>
+	</dict>
+	<dict>
+		<key>date</key>
+		<date>2024-02-10T09:12:40Z</date>
+		<key>displayName</key>
+		<string>Slack</string>
+		<key>processName</key>
+		<string>appstoreagent</string>
+	</dict>
+	<dict>
+		<key>date</key>
+		<date>2024-02-01T03:00:05Z</date>
+		<key>displayName</key>
+		<string>XProtectPlistConfigData</string>
+		<key>processName</key>
+		<string>softwareupdated</string>
+	</dict>
+</array>
+</plist>
+`
+
+func TestParseInstallHistory(t *testing.T) {
+	last, err := parseInstallHistory([]byte(installHistory))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 3, 0, 5, 0, time.UTC), last)
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && cgo) || (arm64 && cgo)
+// +build amd64,cgo arm64,cgo
+
+package darwin
+
+/*
+#cgo LDFLAGS: -lproc
+#include <stdlib.h>
+#include <string.h>
+#include <libproc.h>
+#include <sys/proc_info.h>
+#include <sys/socket.h>
+#include <netinet/in.h>
+#include <arpa/inet.h>
+
+typedef struct {
+	int pid;
+	char name[2 * MAXCOMLEN + 1];
+	int protocol;
+	int ipv6;
+	unsigned char addr[16];
+	int port;
+	uint64_t so;
+} sysinfo_listening_socket;
+
+// sysinfo_listening_sockets fills out with up to max listening TCP and bound
+// UDP sockets and returns the number of sockets found, or -1 on error.
+static int sysinfo_listening_sockets(sysinfo_listening_socket *out, int max) {
+	int npids = proc_listallpids(NULL, 0);
+	if (npids <= 0) {
+		return -1;
+	}
+	npids += 64;
+	pid_t *pids = calloc(npids, sizeof(pid_t));
+	if (pids == NULL) {
+		return -1;
+	}
+	npids = proc_listallpids(pids, npids * sizeof(pid_t));
+
+	int n = 0;
+	for (int i = 0; i < npids; i++) {
+		int size = proc_pidinfo(pids[i], PROC_PIDLISTFDS, 0, NULL, 0);
+		if (size <= 0) {
+			continue;
+		}
+		struct proc_fdinfo *fds = malloc(size);
+		if (fds == NULL) {
+			continue;
+		}
+		size = proc_pidinfo(pids[i], PROC_PIDLISTFDS, 0, fds, size);
+
+		char name[2 * MAXCOMLEN + 1] = {0};
+		for (int j = 0; j < size / (int)PROC_PIDLISTFD_SIZE; j++) {
+			if (fds[j].proc_fdtype != PROX_FDTYPE_SOCKET) {
+				continue;
+			}
+			struct socket_fdinfo si;
+			if (proc_pidfdinfo(pids[i], fds[j].proc_fd, PROC_PIDFDSOCKETINFO, &si, sizeof(si)) != sizeof(si)) {
+				continue;
+			}
+
+			struct in_sockinfo *in;
+			if (si.psi.soi_kind == SOCKINFO_TCP && si.psi.soi_proto.pri_tcp.tcpsi_state == TSI_S_LISTEN) {
+				in = &si.psi.soi_proto.pri_tcp.tcpsi_ini;
+			} else if (si.psi.soi_kind == SOCKINFO_IN && si.psi.soi_protocol == IPPROTO_UDP) {
+				in = &si.psi.soi_proto.pri_in;
+				// Skip the UDP sockets that are unbound or connected.
+				if (in->insi_lport == 0 || in->insi_fport != 0) {
+					continue;
+				}
+			} else {
+				continue;
+			}
+
+			if (n < max) {
+				if (name[0] == '\0') {
+					proc_name(pids[i], name, sizeof(name));
+				}
+				sysinfo_listening_socket *s = &out[n];
+				memset(s, 0, sizeof(*s));
+				s->pid = pids[i];
+				memcpy(s->name, name, sizeof(name));
+				s->protocol = si.psi.soi_protocol;
+				s->ipv6 = si.psi.soi_family == AF_INET6;
+				if (s->ipv6) {
+					memcpy(s->addr, &in->insi_laddr.ina_6, 16);
+				} else {
+					memcpy(s->addr, &in->insi_laddr.ina_46.i46a_addr4, 4);
+				}
+				s->port = ntohs((uint16_t)in->insi_lport);
+				s->so = si.psi.soi_so;
+			}
+			n++;
+		}
+		free(fds);
+	}
+	free(pids);
+	return n;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"net"
+	"unsafe"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func listeningSockets() ([]listeningSocket, error) {
+	buf := make([]C.sysinfo_listening_socket, 256)
+	for {
+		n := int(C.sysinfo_listening_sockets(&buf[0], C.int(len(buf))))
+		if n < 0 {
+			return nil, errors.New("failed to list the processes with proc_listallpids")
+		}
+		if n > len(buf) {
+			// Sockets were opened since the last attempt.
+			buf = make([]C.sysinfo_listening_socket, n+64)
+			continue
+		}
+
+		sockets := make([]listeningSocket, 0, n)
+		for _, s := range buf[:n] {
+			port := types.ListeningPort{
+				Protocol:    types.ProtocolTCP,
+				Family:      types.FamilyIPv4,
+				IP:          net.IP(C.GoBytes(unsafe.Pointer(&s.addr[0]), 4)).String(),
+				Port:        int(s.port),
+				PID:         int(s.pid),
+				ProcessName: C.GoString(&s.name[0]),
+			}
+			if s.protocol == C.IPPROTO_UDP {
+				port.Protocol = types.ProtocolUDP
+			}
+			if s.ipv6 != 0 {
+				port.Family = types.FamilyIPv6
+				port.IP = net.IP(C.GoBytes(unsafe.Pointer(&s.addr[0]), 16)).String()
+			}
+			sockets = append(sockets, listeningSocket{ListeningPort: port, socket: uint64(s.so)})
+		}
+		return sockets, nil
+	}
+}
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// listeningSocket is a listening socket of a process and the kernel address
+// that identifies the socket.
+type listeningSocket struct {
+	types.ListeningPort
+	socket uint64
+}
+
+// ListeningPorts reports the listening TCP and bound UDP sockets by walking
+// the file descriptors of the processes with libproc, which is what
+// "lsof -i" does. Without root privileges only the sockets of the processes
+// of the current user are visible.
+func (h *host) ListeningPorts() ([]types.ListeningPort, error) {
+	sockets, err := listeningSockets()
+	if err != nil {
+		return nil, err
+	}
+	return uniqueListeningPorts(sockets), nil
+}
+
+// uniqueListeningPorts returns the ports of the sockets. A socket that is
+// shared by several processes, like after a fork, is reported once with the
+// first process.
+func uniqueListeningPorts(sockets []listeningSocket) []types.ListeningPort {
+	seen := make(map[uint64]struct{}, len(sockets))
+	ports := make([]types.ListeningPort, 0, len(sockets))
+	for _, s := range sockets {
+		if _, found := seen[s.socket]; found {
+			continue
+		}
+		seen[s.socket] = struct{}{}
+		ports = append(ports, s.ListeningPort)
+	}
+	return ports
+}
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ListeningPorts = (*host)(nil)
+
+func TestUniqueListeningPorts(t *testing.T) {
+	httpd := types.ListeningPort{Protocol: types.ProtocolTCP, Family: types.FamilyIPv6, IP: "::", Port: 80, PID: 100, ProcessName: "httpd"}
+	worker := httpd
+	worker.PID = 101
+	mdns := types.ListeningPort{Protocol: types.ProtocolUDP, Family: types.FamilyIPv4, IP: "0.0.0.0", Port: 5353, PID: 200, ProcessName: "mDNSResponder"}
+
+	ports := uniqueListeningPorts([]listeningSocket{
+		{ListeningPort: httpd, socket: 0xff01},
+		{ListeningPort: mdns, socket: 0xff02},
+		{ListeningPort: worker, socket: 0xff01},
+	})
+	assert.Equal(t, []types.ListeningPort{httpd, mdns}, ports)
+}
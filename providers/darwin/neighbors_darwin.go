@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Neighbors reports the ARP and NDP entries, which are the cloned routes of
+// the routing table with link-layer information, like "arp -an" and "ndp
+// -an". Darwin does not expose the NDP states through the routing table, so
+// resolved dynamic entries are reported as reachable.
+func (h *host) Neighbors() ([]types.Neighbor, error) {
+	buf, err := unix.SysctlRaw("net.routetable", 0, unix.AF_UNSPEC, unix.NET_RT_FLAGS, unix.RTF_LLINFO)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump neighbor table: %w", err)
+	}
+	return parseNeighborMessages(buf, shared.InterfaceName)
+}
+
+// parseNeighborMessages parses the rt_msghdr messages of a dump of the
+// RTF_LLINFO routes.
+func parseNeighborMessages(buf []byte, interfaceName func(int) string) ([]types.Neighbor, error) {
+	neighbors := []types.Neighbor{}
+	err := routeMessages(buf, func(hdr *unix.RtMsghdr, addrs [unix.RTAX_MAX]routeSockaddr) {
+		if hdr.Flags&unix.RTF_LLINFO == 0 {
+			return
+		}
+		dst, gw := addrs[unix.RTAX_DST], addrs[unix.RTAX_GATEWAY]
+		family := dst.familyName()
+		if family == "" || gw.family != unix.AF_LINK {
+			return
+		}
+
+		n := types.Neighbor{
+			Family:    family,
+			IP:        dst.ip(dst.family).String(),
+			Interface: interfaceName(int(hdr.Index)),
+			State:     types.NeighborStateReachable,
+		}
+		if mac := gw.linkAddr(); len(mac) > 0 {
+			n.MAC = mac.String()
+			if hdr.Flags&unix.RTF_STATIC != 0 {
+				n.State = types.NeighborStatePermanent
+			}
+		} else {
+			n.State = types.NeighborStateIncomplete
+		}
+		neighbors = append(neighbors, n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return neighbors, nil
+}
+
+// linkAddr returns the link-layer address of a sockaddr_dl, which follows
+// the interface name in its data.
+func (sa routeSockaddr) linkAddr() net.HardwareAddr {
+	// sdl_len, sdl_family, sdl_index, sdl_type, sdl_nlen, sdl_alen, sdl_slen
+	const dataOffset = 8
+	if len(sa.data) < dataOffset {
+		return nil
+	}
+	nlen, alen := int(sa.data[5]), int(sa.data[6])
+	if alen == 0 || dataOffset+nlen+alen > len(sa.data) {
+		return nil
+	}
+	return net.HardwareAddr(append([]byte(nil), sa.data[dataOffset+nlen:dataOffset+nlen+alen]...))
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Neighbors = (*host)(nil)
+
+func TestParseNeighborMessages(t *testing.T) {
+	const flags = unix.RTF_UP | unix.RTF_HOST | unix.RTF_LLINFO
+	// sockaddr_dl of en0 with a MAC address and without one.
+	resolved := []byte{23, unix.AF_LINK, 4, 0, 6, 3, 6, 0, 'e', 'n', '0', 0x52, 0x54, 0x00, 0x12, 0x34, 0x56, 0, 0, 0, 0, 0, 0}
+	unresolved := []byte{20, unix.AF_LINK, 4, 0, 6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	var buf []byte
+	buf = append(buf, routeMessage(4, flags, unix.RTA_DST|unix.RTA_GATEWAY, sockaddrIn(192, 168, 1, 1), resolved)...)
+	buf = append(buf, routeMessage(4, flags|unix.RTF_STATIC, unix.RTA_DST|unix.RTA_GATEWAY, sockaddrIn(192, 168, 1, 2), resolved)...)
+	buf = append(buf, routeMessage(4, flags, unix.RTA_DST|unix.RTA_GATEWAY, sockaddrIn(192, 168, 1, 7), unresolved)...)
+	// A route without link-layer information, omitted.
+	buf = append(buf, routeMessage(4, unix.RTF_UP|unix.RTF_GATEWAY, unix.RTA_DST|unix.RTA_GATEWAY, sockaddrIn(0, 0, 0, 0), sockaddrIn(192, 168, 1, 1))...)
+
+	neighbors, err := parseNeighborMessages(buf, func(index int) string { return map[int]string{4: "en0"}[index] })
+	require.NoError(t, err)
+	assert.Equal(t, []types.Neighbor{
+		{Family: types.FamilyIPv4, IP: "192.168.1.1", MAC: "52:54:00:12:34:56", Interface: "en0", State: types.NeighborStateReachable},
+		{Family: types.FamilyIPv4, IP: "192.168.1.2", MAC: "52:54:00:12:34:56", Interface: "en0", State: types.NeighborStatePermanent},
+		{Family: types.FamilyIPv4, IP: "192.168.1.7", Interface: "en0", State: types.NeighborStateIncomplete},
+	}, neighbors)
+}
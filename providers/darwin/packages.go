@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_packages
+// +build !nosysinfo_packages
+
+package darwin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"howett.net/plist"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	applicationsDir = "/Applications"
+	receiptsDir     = "/var/db/receipts"
+)
+
+// bundleInfo contains the keys of an application Info.plist.
+type bundleInfo struct {
+	DisplayName string `plist:"CFBundleDisplayName"`
+	Name        string `plist:"CFBundleName"`
+	Identifier  string `plist:"CFBundleIdentifier"`
+	Version     string `plist:"CFBundleShortVersionString"`
+}
+
+// receiptInfo contains the keys of a pkgutil receipt.
+type receiptInfo struct {
+	PackageIdentifier string    `plist:"PackageIdentifier"`
+	PackageVersion    string    `plist:"PackageVersion"`
+	InstallDate       time.Time `plist:"InstallDate"`
+}
+
+// applicationPackages returns the application bundles found in dir.
+func applicationPackages(dir string) ([]types.PackageInfo, error) {
+	bundles, err := filepath.Glob(filepath.Join(dir, "*.app"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []types.PackageInfo
+	for _, bundle := range bundles {
+		data, err := ioutil.ReadFile(filepath.Join(bundle, "Contents", "Info.plist"))
+		if err != nil {
+			continue
+		}
+		pkg, err := parseBundleInfo(data)
+		if err != nil {
+			continue
+		}
+		if pkg.Name == "" {
+			pkg.Name = filepath.Base(bundle[:len(bundle)-len(".app")])
+		}
+		if fi, err := os.Stat(bundle); err == nil {
+			pkg.InstallTime = fi.ModTime().UTC()
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func parseBundleInfo(data []byte) (types.PackageInfo, error) {
+	var info bundleInfo
+	if _, err := plist.Unmarshal(data, &info); err != nil {
+		return types.PackageInfo{}, fmt.Errorf("failed to unmarshal plist data: %w", err)
+	}
+
+	name := info.DisplayName
+	if name == "" {
+		name = info.Name
+	}
+	return types.PackageInfo{
+		Name:    name,
+		Version: info.Version,
+		Source:  "app",
+	}, nil
+}
+
+// receiptPackages returns the packages recorded in the installer receipts
+// found in dir. These are the packages listed by pkgutil --pkgs.
+func receiptPackages(dir string) ([]types.PackageInfo, error) {
+	receipts, err := filepath.Glob(filepath.Join(dir, "*.plist"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []types.PackageInfo
+	for _, receipt := range receipts {
+		data, err := ioutil.ReadFile(receipt)
+		if err != nil {
+			continue
+		}
+		pkg, err := parseReceipt(data)
+		if err != nil || pkg.Name == "" {
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func parseReceipt(data []byte) (types.PackageInfo, error) {
+	var info receiptInfo
+	if _, err := plist.Unmarshal(data, &info); err != nil {
+		return types.PackageInfo{}, fmt.Errorf("failed to unmarshal plist data: %w", err)
+	}
+
+	return types.PackageInfo{
+		Name:        info.PackageIdentifier,
+		Version:     info.PackageVersion,
+		InstallTime: info.InstallDate.UTC(),
+		Source:      "pkgutil",
+	}, nil
+}
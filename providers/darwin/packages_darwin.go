@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 || arm64) && !nosysinfo_packages
+// +build amd64 arm64
+// +build !nosysinfo_packages
+
+package darwin
+
+import (
+	"fmt"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func init() {
+	registry.RegisterSubsystem(registry.SubsystemPackages)
+}
+
+// Packages reports the application bundles installed in /Applications and
+// the packages installed by the macOS installer.
+func (h *host) Packages() ([]types.PackageInfo, error) {
+	v, err := ratelimit.Do(ratelimit.Packages, "", func() (interface{}, error) {
+		return packages()
+	})
+	pkgs, _ := v.([]types.PackageInfo)
+	return append([]types.PackageInfo(nil), pkgs...), err
+}
+
+func packages() ([]types.PackageInfo, error) {
+	apps, err := applicationPackages(applicationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	receipts, err := receiptPackages(receiptsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installer receipts: %w", err)
+	}
+	return append(apps, receipts...), nil
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_packages
+// +build !nosysinfo_packages
+
+package darwin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const safariInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleDisplayName</key>
+	<string>Safari</string>
+	<key>CFBundleIdentifier</key>
+	<string>com.apple.Safari</string>
+	<key>CFBundleName</key>
+	<string>Safari</string>
+	<key>CFBundleShortVersionString</key>
+	<string>16.4</string>
+	<key>CFBundleVersion</key>
+	<string>18615.1.26.11.23</string>
+</dict>
+</plist>
+`
+
+const receiptPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>InstallDate</key>
+	<date>2023-04-11T08:15:42Z</date>
+	<key>InstallPrefixPath</key>
+	<string>/</string>
+	<key>InstallProcessName</key>
+	<string>installer</string>
+	<key>PackageFileName</key>
+	<string>python-3.11.3-macos11.pkg</string>
+	<key>PackageIdentifier</key>
+	<string>org.python.Python.PythonFramework-3.11</string>
+	<key>PackageVersion</key>
+	<string>3.11.3</string>
+</dict>
+</plist>
+`
+
+func TestParseBundleInfo(t *testing.T) {
+	pkg, err := parseBundleInfo([]byte(safariInfoPlist))
+	require.NoError(t, err)
+
+	assert.Equal(t, types.PackageInfo{
+		Name:    "Safari",
+		Version: "16.4",
+		Source:  "app",
+	}, pkg)
+}
+
+func TestParseReceipt(t *testing.T) {
+	pkg, err := parseReceipt([]byte(receiptPlist))
+	require.NoError(t, err)
+
+	assert.Equal(t, "org.python.Python.PythonFramework-3.11", pkg.Name)
+	assert.Equal(t, "3.11.3", pkg.Version)
+	assert.Equal(t, "pkgutil", pkg.Source)
+	assert.True(t, time.Date(2023, 4, 11, 8, 15, 42, 0, time.UTC).Equal(pkg.InstallTime))
+}
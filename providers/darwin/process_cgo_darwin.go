@@ -57,3 +57,17 @@ func getProcVnodePathInfo(pid int, info *procVnodePathInfo) error {
 
 	return nil
 }
+
+func getProcPath(pid int) (string, error) {
+	var buf [C.PROC_PIDPATHINFO_MAXSIZE]byte
+
+	n, err := C.proc_pidpath(C.int(pid), unsafe.Pointer(&buf[0]), C.uint32_t(len(buf)))
+	if n <= 0 {
+		if err == nil {
+			err = errors.New("failed to read executable path with proc_pidpath")
+		}
+		return "", err
+	}
+
+	return string(buf[:n]), nil
+}
@@ -27,35 +27,119 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"golang.org/x/sys/unix"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/types"
 )
 
 func (s darwinSystem) Processes() ([]types.Process, error) {
+	return s.ProcessesMatching(types.ProcessFilter{})
+}
+
+// ProcessesMatching returns the processes selected by filter. The PIDs,
+// states, users, and names are matched against the process table, so the
+// details of the processes are only read to get the full name of the
+// processes whose command name is truncated.
+func (s darwinSystem) ProcessesMatching(filter types.ProcessFilter) ([]types.Process, error) {
 	ps, err := unix.SysctlKinfoProcSlice("kern.proc.all")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read process table: %w", err)
 	}
 
-	processes := make([]types.Process, 0, len(ps))
+	limit := footprint.Limit(len(ps))
+	processes := make([]types.Process, 0, limit)
 	for _, kp := range ps {
+		if len(processes) == limit {
+			break
+		}
 		pid := kp.Proc.P_pid
 		if pid == 0 {
 			continue
 		}
+		if !filter.MatchPID(int(pid)) || !filter.MatchState(kinfoProcState(kp.Proc.P_stat)) {
+			continue
+		}
+		if !filter.MatchUID(strconv.FormatUint(uint64(kp.Eproc.Pcred.P_ruid), 10)) {
+			continue
+		}
 
-		processes = append(processes, &process{
-			pid: int(pid),
-		})
+		p := &process{pid: int(pid)}
+		if !matchProcessName(filter, p, unix.ByteSliceToString(kp.Proc.P_comm[:])) {
+			continue
+		}
+		processes = append(processes, p)
 	}
 
 	return processes, nil
 }
 
+// ForEachProcess calls fn for each process of the process table until fn
+// returns false.
+func (s darwinSystem) ForEachProcess(fn func(types.Process) bool) error {
+	ps, err := unix.SysctlKinfoProcSlice("kern.proc.all")
+	if err != nil {
+		return fmt.Errorf("failed to read process table: %w", err)
+	}
+
+	for _, kp := range ps {
+		if kp.Proc.P_pid == 0 {
+			continue
+		}
+		if !fn(&process{pid: int(kp.Proc.P_pid)}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// matchProcessName reports whether the process with the given command name
+// is accepted by the Names of filter. The command name is truncated to
+// MAXCOMLEN (16) characters, so the full name is read from the process when
+// a longer name in the filter starts with it.
+func matchProcessName(filter types.ProcessFilter, p *process, comm string) bool {
+	if filter.MatchName(comm) {
+		return true
+	}
+	if len(comm) < maxComLen {
+		return false
+	}
+	for _, name := range filter.Names {
+		if len(name) > len(comm) && strings.HasPrefix(name, comm) {
+			info, err := p.Info()
+			return err == nil && filter.MatchName(info.Name)
+		}
+	}
+	return false
+}
+
+// maxComLen is the maximum length of the command name in the process table
+// (MAXCOMLEN from sys/param.h).
+const maxComLen = 16
+
+// kinfoProcState converts the p_stat value of a kinfo_proc to a ProcessState
+// constant. See sys/proc.h.
+func kinfoProcState(stat int8) string {
+	switch stat {
+	case 2: // SRUN
+		return types.ProcessStateRunning
+	case 3: // SSLEEP
+		return types.ProcessStateSleeping
+	case 4: // SSTOP
+		return types.ProcessStateStopped
+	case 5: // SZOMB
+		return types.ProcessStateZombie
+	default:
+		return ""
+	}
+}
+
 func (s darwinSystem) Process(pid int) (types.Process, error) {
 	p := process{pid: pid}
 
@@ -73,13 +157,18 @@ type process struct {
 	exe  string
 	args []string
 	env  map[string]string
+
+	procArgsOnce sync.Once
+	procArgsErr  error
 }
 
 func (p *process) PID() int {
 	return p.pid
 }
 
-func (p *process) Parent() (types.Process, error) {
+func (p *process) Parent() (_ types.Process, err error) {
+	defer safe.Recover("process parent", &err)
+
 	info, err := p.Info()
 	if err != nil {
 		return nil, err
@@ -88,7 +177,9 @@ func (p *process) Parent() (types.Process, error) {
 	return &process{pid: info.PPID}, nil
 }
 
-func (p *process) Info() (types.ProcessInfo, error) {
+func (p *process) Info() (_ types.ProcessInfo, err error) {
+	defer safe.Recover("process info", &err)
+
 	if p.info != nil {
 		return *p.info, nil
 	}
@@ -103,8 +194,14 @@ func (p *process) Info() (types.ProcessInfo, error) {
 		return types.ProcessInfo{}, err
 	}
 
-	if err := kern_procargs(p.pid, p); err != nil {
-		return types.ProcessInfo{}, err
+	if err := p.loadProcArgs(); err != nil {
+		if !errors.Is(err, errProcArgsDenied) {
+			return types.ProcessInfo{}, err
+		}
+		// Without KERN_PROCARGS2 only the executable path is available.
+		if exe, err := getProcPath(p.pid); err == nil {
+			p.exe = exe
+		}
 	}
 
 	p.info = &types.ProcessInfo{
@@ -115,13 +212,16 @@ func (p *process) Info() (types.ProcessInfo, error) {
 		Exe:  p.exe,
 		Args: p.args,
 		StartTime: time.Unix(int64(task.Pbsd.Pbi_start_tvsec),
-			int64(task.Pbsd.Pbi_start_tvusec)*int64(time.Microsecond)),
+			int64(task.Pbsd.Pbi_start_tvusec)*int64(time.Microsecond)).UTC(),
 	}
+	p.info.Architecture, p.info.NativeArchitecture, p.info.Translated = processArchitecture(p.pid)
 
 	return *p.info, nil
 }
 
-func (p *process) User() (types.UserInfo, error) {
+func (p *process) User() (_ types.UserInfo, err error) {
+	defer safe.Recover("process user", &err)
+
 	kproc, err := unix.SysctlKinfoProc("kern.proc.pid", p.pid)
 	if err != nil {
 		return types.UserInfo{}, err
@@ -142,11 +242,18 @@ func (p *process) User() (types.UserInfo, error) {
 	}, nil
 }
 
+// Environment returns the environment of the process. The environment of
+// the processes of other users can only be read when running as root.
 func (p *process) Environment() (map[string]string, error) {
+	if err := p.loadProcArgs(); err != nil {
+		return nil, err
+	}
 	return p.env, nil
 }
 
-func (p *process) CPUTime() (types.CPUTimes, error) {
+func (p *process) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("process cpu time", &err)
+
 	var task procTaskAllInfo
 	if err := getProcTaskAllInfo(p.pid, &task); err != nil {
 		return types.CPUTimes{}, err
@@ -157,47 +264,91 @@ func (p *process) CPUTime() (types.CPUTimes, error) {
 	}, nil
 }
 
-func (p *process) Memory() (types.MemoryInfo, error) {
+// ContextSwitches reports the context switches of the process. Darwin does
+// not distinguish voluntary and involuntary switches.
+func (p *process) ContextSwitches() (*types.ContextSwitchInfo, error) {
+	var task procTaskAllInfo
+	if err := getProcTaskAllInfo(p.pid, &task); err != nil {
+		return nil, err
+	}
+	return &types.ContextSwitchInfo{Total: uint64(uint32(task.Ptinfo.Csw))}, nil
+}
+
+func (p *process) Memory() (_ types.MemoryInfo, err error) {
+	defer safe.Recover("process memory", &err)
+
 	var task procTaskAllInfo
 	if err := getProcTaskAllInfo(p.pid, &task); err != nil {
 		return types.MemoryInfo{}, err
 	}
-	return types.MemoryInfo{
+	mem := types.MemoryInfo{
 		Virtual:  task.Ptinfo.Virtual_size,
 		Resident: task.Ptinfo.Resident_size,
-		Metrics: map[string]uint64{
+	}
+	if !footprint.Low() {
+		mem.Metrics = map[string]uint64{
 			"page_ins":    uint64(task.Ptinfo.Pageins),
 			"page_faults": uint64(task.Ptinfo.Faults),
-		},
-	}, nil
+		}
+	}
+	return mem, nil
 }
 
 var nullTerminator = []byte{0}
 
+// errProcArgsDenied is returned by kern_procargs when the process exists
+// but its arguments cannot be read. KERN_PROCARGS2 is restricted to the
+// processes of the same user unless running as root.
+var errProcArgsDenied = fmt.Errorf("KERN_PROCARGS2 not permitted: %w", syscall.EPERM)
+
+// loadProcArgs reads the executable, arguments, and environment of the
+// process once.
+func (p *process) loadProcArgs() error {
+	p.procArgsOnce.Do(func() {
+		p.procArgsErr = kern_procargs(p.pid, p)
+	})
+	return p.procArgsErr
+}
+
 // wrapper around sysctl KERN_PROCARGS2
-// callbacks params are optional,
-// up to the caller as to which pieces of data they want
 func kern_procargs(pid int, p *process) error {
 	data, err := unix.SysctlRaw("kern.procargs2", pid)
 	if err != nil {
 		if errors.Is(err, syscall.EINVAL) {
 			// sysctl returns "invalid argument" for both "no such process"
 			// and "operation not permitted" errors.
-			return fmt.Errorf("no such process or operation not permitted: %w", err)
+			if err := unix.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+				return fmt.Errorf("no such process: %w", err)
+			}
+			return errProcArgsDenied
 		}
 		return err
 	}
+
+	exe, args, env, err := parseProcArgs2(data)
+	if err != nil {
+		return err
+	}
+	p.exe, p.args, p.env = exe, args, env
+	return nil
+}
+
+// parseProcArgs2 parses the KERN_PROCARGS2 buffer. It contains argc
+// followed by the NUL terminated executable path, padding NULs, argc
+// arguments, the environment, and finally the "apple" strings that are
+// separated from the environment by an empty string.
+func parseProcArgs2(data []byte) (exe string, args []string, env map[string]string, err error) {
 	buf := bytes.NewBuffer(data)
 
 	// argc
 	var argc int32
 	if err := binary.Read(buf, binary.LittleEndian, &argc); err != nil {
-		return err
+		return "", nil, nil, fmt.Errorf("failed to read argc: %w", err)
 	}
 
 	// exe
 	lines := bytes.Split(buf.Bytes(), nullTerminator)
-	p.exe = string(lines[0])
+	exe = string(lines[0])
 	lines = lines[1:]
 
 	// skip nulls
@@ -210,13 +361,16 @@ func kern_procargs(pid int, p *process) error {
 	}
 
 	// args
+	if int(argc) > len(lines) {
+		return "", nil, nil, fmt.Errorf("argc %d exceeds the %d strings in KERN_PROCARGS2", argc, len(lines))
+	}
 	for i := 0; i < int(argc); i++ {
-		p.args = append(p.args, string(lines[0]))
+		args = append(args, string(lines[0]))
 		lines = lines[1:]
 	}
 
 	// env vars
-	env := make(map[string]string, len(lines))
+	env = make(map[string]string, len(lines))
 	for _, l := range lines {
 		if len(l) == 0 {
 			break
@@ -230,9 +384,8 @@ func kern_procargs(pid int, p *process) error {
 		}
 		env[key] = value
 	}
-	p.env = env
 
-	return nil
+	return exe, args, env, nil
 }
 
 func int8SliceToString(s []int8) string {
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"strconv"
 	"syscall"
 	"testing"
 
@@ -31,11 +32,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
 )
 
 var (
 	_ registry.HostProvider    = darwinSystem{}
 	_ registry.ProcessProvider = darwinSystem{}
+	_ registry.ProcessMatcher  = darwinSystem{}
+	_ registry.ProcessIterator = darwinSystem{}
 )
 
 func TestKernProcInfo(t *testing.T) {
@@ -52,6 +56,27 @@ func TestKernProcInfo(t *testing.T) {
 	assert.Equal(t, os.Args, p.args)
 }
 
+func TestParseProcArgs2(t *testing.T) {
+	data := []byte{2, 0, 0, 0}
+	data = append(data, "/bin/sleep\x00\x00\x00\x00"...)
+	data = append(data, "sleep\x0060\x00"...)
+	data = append(data, "HOME=/var/root\x00EMPTY=\x00NOVALUE\x00\x00"...)
+	data = append(data, "executable_path=/bin/sleep\x00"...)
+
+	exe, args, env, err := parseProcArgs2(data)
+	require.NoError(t, err)
+	assert.Equal(t, "/bin/sleep", exe)
+	assert.Equal(t, []string{"sleep", "60"}, args)
+	assert.Equal(t, map[string]string{"HOME": "/var/root", "EMPTY": "", "NOVALUE": ""}, env)
+
+	// argc must not exceed the number of strings.
+	_, _, _, err = parseProcArgs2([]byte("\x05\x00\x00\x00/bin/sleep\x00sleep\x00"))
+	assert.Error(t, err)
+
+	_, _, _, err = parseProcArgs2([]byte{1})
+	assert.Error(t, err)
+}
+
 const (
 	noValueEnvVar    = "_GO_SYSINFO_NO_VALUE"
 	emptyValueEnvVar = "_GO_SYSINFO_EMPTY_VALUE"
@@ -140,3 +165,20 @@ func TestProcesses(t *testing.T) {
 
 	assert.NotZero(t, count, "failed to get process info for any processes")
 }
+
+func TestProcessesMatching(t *testing.T) {
+	procs, err := darwinSystem{}.ProcessesMatching(types.ProcessFilter{
+		PIDs: []int{os.Getpid()},
+		UIDs: []string{strconv.Itoa(os.Getuid())},
+	})
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	assert.Equal(t, os.Getpid(), procs[0].PID())
+
+	procs, err = darwinSystem{}.ProcessesMatching(types.ProcessFilter{
+		PIDs:   []int{os.Getpid()},
+		States: []string{types.ProcessStateZombie},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, procs)
+}
@@ -28,3 +28,7 @@ func getProcTaskAllInfo(pid int, info *procTaskAllInfo) error {
 func getProcVnodePathInfo(pid int, info *procVnodePathInfo) error {
 	return types.ErrNotImplemented
 }
+
+func getProcPath(pid int) (string, error) {
+	return "", types.ErrNotImplemented
+}
@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"fmt"
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Routes reports the routing table from a NET_RT_DUMP of the net.routetable
+// sysctl, which is what "netstat -rn" shows. The cloned routes of the
+// neighbors (ARP and NDP entries) are omitted. Darwin does not have route
+// metrics.
+func (h *host) Routes() ([]types.Route, error) {
+	buf, err := unix.SysctlRaw("net.routetable", 0, unix.AF_UNSPEC, unix.NET_RT_DUMP, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump routing table: %w", err)
+	}
+	return parseRouteMessages(buf, shared.InterfaceName)
+}
+
+// parseRouteMessages parses the rt_msghdr messages of a routing table dump.
+func parseRouteMessages(buf []byte, interfaceName func(int) string) ([]types.Route, error) {
+	routes := []types.Route{}
+	err := routeMessages(buf, func(hdr *unix.RtMsghdr, addrs [unix.RTAX_MAX]routeSockaddr) {
+		if hdr.Flags&unix.RTF_UP == 0 || hdr.Flags&(unix.RTF_LLINFO|unix.RTF_REJECT) != 0 {
+			return
+		}
+
+		dst := addrs[unix.RTAX_DST]
+		family := dst.familyName()
+		if family == "" {
+			return
+		}
+
+		ip := dst.ip(dst.family)
+		bits := 8 * len(ip)
+		mask := net.CIDRMask(bits, bits)
+		if hdr.Flags&unix.RTF_HOST == 0 {
+			// The netmask of the default route has no address bytes.
+			mask = net.IPMask(addrs[unix.RTAX_NETMASK].ip(dst.family))
+		}
+
+		route := types.Route{
+			Family:      family,
+			Destination: (&net.IPNet{IP: ip, Mask: mask}).String(),
+			Interface:   interfaceName(int(hdr.Index)),
+		}
+		// Directly connected networks have a link-layer gateway.
+		if gw := addrs[unix.RTAX_GATEWAY]; gw.family == dst.family {
+			if gwIP := gw.ip(dst.family); !gwIP.IsUnspecified() {
+				route.Gateway = gwIP.String()
+			}
+		}
+		routes = append(routes, route)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+// routeMessages calls fn with the header and socket addresses of each
+// RTM_GET message of a routing table dump.
+func routeMessages(buf []byte, fn func(hdr *unix.RtMsghdr, addrs [unix.RTAX_MAX]routeSockaddr)) error {
+	for len(buf) >= unix.SizeofRtMsghdr {
+		var hdr unix.RtMsghdr
+		copy((*[unix.SizeofRtMsghdr]byte)(unsafe.Pointer(&hdr))[:], buf)
+		if int(hdr.Msglen) < unix.SizeofRtMsghdr || int(hdr.Msglen) > len(buf) {
+			return fmt.Errorf("invalid routing message length %d", hdr.Msglen)
+		}
+		msg := buf[:hdr.Msglen]
+		buf = buf[hdr.Msglen:]
+
+		if hdr.Version != unix.RTM_VERSION || hdr.Type != unix.RTM_GET {
+			continue
+		}
+		fn(&hdr, parseRouteSockaddrs(msg[unix.SizeofRtMsghdr:], hdr.Addrs))
+	}
+	return nil
+}
+
+// routeSockaddr is a socket address of a routing message.
+type routeSockaddr struct {
+	family uint8
+	data   []byte // The sockaddr including the length and family.
+}
+
+// parseRouteSockaddrs returns the socket addresses that follow a routing
+// message header indexed by their RTAX values. The addresses that are not
+// present in the addrs bitmask have a zero family.
+func parseRouteSockaddrs(b []byte, addrs int32) [unix.RTAX_MAX]routeSockaddr {
+	var sas [unix.RTAX_MAX]routeSockaddr
+	for i := 0; i < unix.RTAX_MAX && len(b) > 0; i++ {
+		if addrs&(1<<i) == 0 {
+			continue
+		}
+		l := int(b[0])
+		if l > len(b) {
+			break
+		}
+		if l >= 2 {
+			sas[i] = routeSockaddr{family: b[1], data: b[:l]}
+		}
+		// Socket addresses are aligned to 4 bytes and a zero length one
+		// takes 4 bytes.
+		n := 4
+		if l > 0 {
+			n = (l + 3) &^ 3
+		}
+		if n > len(b) {
+			break
+		}
+		b = b[n:]
+	}
+	return sas
+}
+
+// familyName returns the types.Family value of an IPv4 or IPv6 address or
+// an empty string for other address families.
+func (sa routeSockaddr) familyName() string {
+	switch sa.family {
+	case unix.AF_INET:
+		return types.FamilyIPv4
+	case unix.AF_INET6:
+		return types.FamilyIPv6
+	}
+	return ""
+}
+
+// ip returns the address of a sockaddr_in or sockaddr_in6. family is needed
+// because netmasks are truncated after their last non-zero byte and may lack
+// the family. The embedded scope of link-local IPv6 addresses is removed.
+func (sa routeSockaddr) ip(family uint8) net.IP {
+	var offset, size int
+	switch family {
+	case unix.AF_INET:
+		offset, size = 4, net.IPv4len
+	case unix.AF_INET6:
+		offset, size = 8, net.IPv6len
+	default:
+		return nil
+	}
+
+	ip := make(net.IP, size)
+	if len(sa.data) > offset {
+		copy(ip, sa.data[offset:])
+	}
+	if family == unix.AF_INET6 && ip.IsLinkLocalUnicast() {
+		ip[2], ip[3] = 0, 0
+	}
+	return ip
+}
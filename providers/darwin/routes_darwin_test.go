@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Routes = (*host)(nil)
+
+// routeMessage builds an RTM_GET message with the given socket addresses.
+func routeMessage(index uint16, flags int32, addrs int32, sockaddrs ...[]byte) []byte {
+	var body []byte
+	for _, sa := range sockaddrs {
+		body = append(body, sa...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		if len(sa) == 0 {
+			body = append(body, 0, 0, 0, 0)
+		}
+	}
+
+	hdr := unix.RtMsghdr{
+		Msglen:  uint16(unix.SizeofRtMsghdr + len(body)),
+		Version: unix.RTM_VERSION,
+		Type:    unix.RTM_GET,
+		Index:   index,
+		Flags:   flags,
+		Addrs:   addrs,
+	}
+	msg := append([]byte(nil), (*[unix.SizeofRtMsghdr]byte)(unsafe.Pointer(&hdr))[:]...)
+	return append(msg, body...)
+}
+
+func sockaddrIn(a, b, c, d byte) []byte {
+	return []byte{16, unix.AF_INET, 0, 0, a, b, c, d, 0, 0, 0, 0, 0, 0, 0, 0}
+}
+
+func TestParseRouteMessages(t *testing.T) {
+	const dstGwMask = unix.RTA_DST | unix.RTA_GATEWAY | unix.RTA_NETMASK
+	linkGw := []byte{20, unix.AF_LINK, 4, 0, 6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	fe80 := []byte{28, unix.AF_INET6, 0, 0, 0, 0, 0, 0, 0xfe, 0x80, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0}
+
+	var buf []byte
+	// default via 192.168.1.1 with a zero length netmask.
+	buf = append(buf, routeMessage(4, unix.RTF_UP|unix.RTF_GATEWAY, dstGwMask, sockaddrIn(0, 0, 0, 0), sockaddrIn(192, 168, 1, 1), nil)...)
+	// 192.168.1.0/24 on link with a truncated netmask.
+	buf = append(buf, routeMessage(4, unix.RTF_UP, dstGwMask, sockaddrIn(192, 168, 1, 0), linkGw, []byte{7, 0, 0, 0, 255, 255, 255})...)
+	// ARP entry, omitted.
+	buf = append(buf, routeMessage(4, unix.RTF_UP|unix.RTF_HOST|unix.RTF_LLINFO, unix.RTA_DST|unix.RTA_GATEWAY, sockaddrIn(192, 168, 1, 1), linkGw)...)
+	// IPv6 default via fe80::1%en0.
+	buf = append(buf, routeMessage(4, unix.RTF_UP|unix.RTF_GATEWAY, dstGwMask,
+		[]byte{28, unix.AF_INET6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, fe80, nil)...)
+
+	routes, err := parseRouteMessages(buf, func(index int) string { return map[int]string{4: "en0"}[index] })
+	require.NoError(t, err)
+	assert.Equal(t, []types.Route{
+		{Family: types.FamilyIPv4, Destination: "0.0.0.0/0", Gateway: "192.168.1.1", Interface: "en0"},
+		{Family: types.FamilyIPv4, Destination: "192.168.1.0/24", Interface: "en0"},
+		{Family: types.FamilyIPv6, Destination: "::/0", Gateway: "fe80::1", Interface: "en0"},
+	}, routes)
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && cgo) || (arm64 && cgo)
+// +build amd64,cgo arm64,cgo
+
+package darwin
+
+// #include <utmpx.h>
+import "C"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// utmpxLock serializes access to the utmpx database because getutxent is
+// not thread-safe.
+var utmpxLock sync.Mutex
+
+func getSessions() ([]types.SessionInfo, error) {
+	utmpxLock.Lock()
+	defer utmpxLock.Unlock()
+
+	C.setutxent()
+	defer C.endutxent()
+
+	var sessions []types.SessionInfo
+	for {
+		ent := C.getutxent()
+		if ent == nil {
+			break
+		}
+		if ent.ut_type != C.USER_PROCESS {
+			continue
+		}
+
+		sessions = append(sessions, types.SessionInfo{
+			ID:         utmpxString(ent.ut_id[:]),
+			User:       utmpxString(ent.ut_user[:]),
+			Terminal:   utmpxString(ent.ut_line[:]),
+			RemoteHost: utmpxString(ent.ut_host[:]),
+			LoginTime:  time.Unix(int64(ent.ut_tv.tv_sec), int64(ent.ut_tv.tv_usec)*int64(time.Microsecond)).UTC(),
+			PID:        int(ent.ut_pid),
+		})
+	}
+	return sessions, nil
+}
+
+// utmpxString returns the contents of a fixed size field, which is not NUL
+// terminated when the value fills the field.
+func utmpxString(b []C.char) string {
+	n := 0
+	for n < len(b) && b[n] != 0 {
+		n++
+	}
+	if n == 0 {
+		return ""
+	}
+	return C.GoStringN(&b[0], C.int(n))
+}
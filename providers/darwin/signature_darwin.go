@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	codesignPath = "/usr/bin/codesign"
+	spctlPath    = "/usr/sbin/spctl"
+)
+
+// SignatureInfo verifies the code signature of the executable of the
+// process with codesign and checks whether it is notarized with spctl.
+func (p *process) SignatureInfo() (*types.SignatureInfo, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	return verifySignature(info.Exe)
+}
+
+func verifySignature(path string) (*types.SignatureInfo, error) {
+	sig := &types.SignatureInfo{Path: path}
+
+	out, err := runSigningTool(codesignPath, "--display", "--verbose=2", path)
+	if err != nil {
+		return nil, err
+	}
+	parseCodesignDisplay(out, sig)
+	if !sig.Signed {
+		sig.Status = types.SignatureStatusUnsigned
+		return sig, nil
+	}
+
+	out, err = runSigningTool(codesignPath, "--verify", "--strict", path)
+	if err != nil {
+		return nil, err
+	}
+	sig.Status = codesignVerifyStatus(out)
+	if sig.Status == types.SignatureStatusValid && sig.AdHoc {
+		// Ad-hoc signatures are not issued by a trusted authority.
+		sig.Status = types.SignatureStatusUntrusted
+	}
+
+	out, err = runSigningTool(spctlPath, "--assess", "--type", "execute", "--verbose=2", path)
+	if err != nil {
+		return nil, err
+	}
+	sig.Notarized = parseSpctlNotarized(out)
+	return sig, nil
+}
+
+// runSigningTool runs codesign or spctl and returns its combined output.
+// Both report problems with the signature through their exit status, so it
+// is not an error.
+func runSigningTool(name string, args ...string) ([]byte, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run %v: %w", name, err)
+	}
+	return out, nil
+}
+
+// parseCodesignDisplay parses the output of "codesign --display --verbose=2".
+// The first Authority is the signing certificate and the second its issuer.
+func parseCodesignDisplay(out []byte, sig *types.SignatureInfo) {
+	if bytes.Contains(out, []byte("not signed at all")) {
+		return
+	}
+
+	var authorities []string
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		key, value, found := strings.Cut(s.Text(), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "Authority":
+			authorities = append(authorities, value)
+		case "TeamIdentifier":
+			if value != "not set" {
+				sig.TeamID = value
+			}
+		case "Signature":
+			sig.AdHoc = value == "adhoc"
+		case "CodeDirectory v":
+			// Only signed code has a code directory.
+			sig.Signed = true
+		}
+	}
+	if len(authorities) > 0 {
+		sig.Subject = authorities[0]
+	}
+	if len(authorities) > 1 {
+		sig.Issuer = authorities[1]
+	}
+}
+
+// codesignVerifyStatus returns the SignatureStatus for the output of
+// "codesign --verify", which is empty when the signature is valid.
+func codesignVerifyStatus(out []byte) string {
+	msg := string(bytes.TrimSpace(out))
+	switch {
+	case msg == "":
+		return types.SignatureStatusValid
+	case strings.Contains(msg, "REVOKED"):
+		return types.SignatureStatusRevoked
+	case strings.Contains(msg, "EXPIRED"):
+		return types.SignatureStatusExpired
+	case strings.Contains(msg, "NOT_TRUSTED"):
+		return types.SignatureStatusUntrusted
+	case strings.Contains(msg, "modified"), strings.Contains(msg, "invalid"), strings.Contains(msg, "sealed resource"):
+		return types.SignatureStatusInvalid
+	default:
+		return types.SignatureStatusUnknown
+	}
+}
+
+// parseSpctlNotarized parses the source line of "spctl --assess --verbose=2"
+// (e.g. source=Notarized Developer ID). It returns nil when spctl does not
+// report a source.
+func parseSpctlNotarized(out []byte) *bool {
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		if line := s.Text(); strings.HasPrefix(line, "source=") {
+			notarized := strings.TrimPrefix(line, "source=") == "Notarized Developer ID"
+			return &notarized
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.CodeSignature = (*process)(nil)
+
+const codesignDisplayDeveloperID = `Executable=/Applications/Example.app/Contents/MacOS/Example
+Identifier=com.example.app
+Format=app bundle with Mach-O universal (x86_64 arm64)
+CodeDirectory v=20500 size=1024 flags=0x10000(runtime) hashes=21+7 location=embedded
+Signature size=9000
+Authority=Developer ID Application: Example Inc (ABCDE12345)
+Authority=Developer ID Certification Authority
+Authority=Apple Root CA
+Timestamp=Mar 1, 2023 at 12:00:00
+TeamIdentifier=ABCDE12345
+Runtime Version=13.1.0
+Sealed Resources version=2 rules=13 files=10
+Internal requirements count=1 size=220
+`
+
+const codesignDisplayAdHoc = `Executable=/usr/local/bin/tool
+Identifier=tool
+Format=Mach-O thin (arm64)
+CodeDirectory v=20400 size=500 flags=0x20002(adhoc,linker-signed) hashes=12+0 location=embedded
+Signature=adhoc
+Info.plist=not bound
+TeamIdentifier=not set
+Sealed Resources=none
+Internal requirements=none
+`
+
+func TestParseCodesignDisplay(t *testing.T) {
+	var sig types.SignatureInfo
+	parseCodesignDisplay([]byte(codesignDisplayDeveloperID), &sig)
+	assert.Equal(t, types.SignatureInfo{
+		Signed:  true,
+		Subject: "Developer ID Application: Example Inc (ABCDE12345)",
+		Issuer:  "Developer ID Certification Authority",
+		TeamID:  "ABCDE12345",
+	}, sig)
+
+	sig = types.SignatureInfo{}
+	parseCodesignDisplay([]byte(codesignDisplayAdHoc), &sig)
+	assert.Equal(t, types.SignatureInfo{Signed: true, AdHoc: true}, sig)
+
+	sig = types.SignatureInfo{}
+	parseCodesignDisplay([]byte("/tmp/a.out: code object is not signed at all\n"), &sig)
+	assert.False(t, sig.Signed)
+}
+
+func TestCodesignVerifyStatus(t *testing.T) {
+	assert.Equal(t, types.SignatureStatusValid, codesignVerifyStatus(nil))
+	assert.Equal(t, types.SignatureStatusInvalid, codesignVerifyStatus([]byte("/tmp/x: invalid signature (code or signature have been modified)\n")))
+	assert.Equal(t, types.SignatureStatusRevoked, codesignVerifyStatus([]byte("/tmp/x: CSSMERR_TP_CERT_REVOKED\n")))
+	assert.Equal(t, types.SignatureStatusUnknown, codesignVerifyStatus([]byte("/tmp/x: something else\n")))
+}
+
+func TestParseSpctlNotarized(t *testing.T) {
+	notarized := parseSpctlNotarized([]byte("/Applications/Example.app: accepted\nsource=Notarized Developer ID\norigin=Developer ID Application: Example Inc (ABCDE12345)\n"))
+	require.NotNil(t, notarized)
+	assert.True(t, *notarized)
+
+	notarized = parseSpctlNotarized([]byte("/bin/ls: rejected\nsource=Apple System\n"))
+	require.NotNil(t, notarized)
+	assert.False(t, *notarized)
+
+	assert.Nil(t, parseSpctlNotarized([]byte("/tmp/x: rejected (the code is valid but does not seem to be an app)\n")))
+}
+
+func TestVerifySignature(t *testing.T) {
+	sig, err := verifySignature("/bin/ls")
+	require.NoError(t, err)
+	assert.True(t, sig.Signed)
+	assert.Equal(t, types.SignatureStatusValid, sig.Status)
+	assert.Equal(t, "Software Signing", sig.Subject)
+}
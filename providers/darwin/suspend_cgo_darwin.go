@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && cgo) || (arm64 && cgo)
+// +build amd64,cgo arm64,cgo
+
+package darwin
+
+// #include <mach/mach_time.h>
+import "C"
+
+import (
+	"fmt"
+	"time"
+)
+
+// getSuspendTimes returns the time since boot including and excluding the
+// time spent asleep. mach_absolute_time does not advance while the system
+// sleeps, while mach_continuous_time does.
+func getSuspendTimes() (sinceBoot, active time.Duration, err error) {
+	var timebase C.mach_timebase_info_data_t
+	if ret := C.mach_timebase_info(&timebase); ret != C.KERN_SUCCESS {
+		return 0, 0, fmt.Errorf("mach_timebase_info returned status %d", ret)
+	}
+
+	continuous := uint64(C.mach_continuous_time())
+	absolute := uint64(C.mach_absolute_time())
+
+	toDuration := func(ticks uint64) time.Duration {
+		return time.Duration(ticks / uint64(timebase.denom) * uint64(timebase.numer))
+	}
+	return toDuration(continuous), toDuration(absolute), nil
+}
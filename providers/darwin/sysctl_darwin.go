@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const sysctlCommand = "/usr/sbin/sysctl"
+
+// Sysctl reads a kernel tunable with the sysctl command, which formats the
+// values of all types.
+func (h *host) Sysctl(name string) (string, bool, error) {
+	out, err := exec.Command(sysctlCommand, "-n", name).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && bytes.Contains(exitErr.Stderr, []byte("unknown oid")) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to run sysctl: %w", err)
+	}
+	return strings.TrimSpace(string(out)), true, nil
+}
+
+// KernelParameters reads the kernel tunables with "sysctl -a".
+func (h *host) KernelParameters(prefix string) (map[string]string, error) {
+	out, err := exec.Command(sysctlCommand, "-a").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run sysctl: %w", err)
+	}
+
+	params := parseSysctlOutput(out)
+	for name := range params {
+		if !strings.HasPrefix(name, prefix) {
+			delete(params, name)
+		}
+	}
+	return params, nil
+}
+
+// parseSysctlOutput parses the "name: value" lines of "sysctl -a". Lines
+// that do not start with a name continue the value of the previous line.
+func parseSysctlOutput(out []byte) map[string]string {
+	params := map[string]string{}
+	var last string
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := s.Text()
+		name, value, found := strings.Cut(line, ": ")
+		if !found && strings.HasSuffix(line, ":") {
+			// The value is empty.
+			name, found = strings.TrimSuffix(line, ":"), true
+		}
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			if last != "" {
+				params[last] += "\n" + line
+			}
+			continue
+		}
+		params[name] = value
+		last = name
+	}
+	return params
+}
@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.KernelParameters = (*host)(nil)
+
+func TestParseSysctlOutput(t *testing.T) {
+	out := []byte(`kern.ostype: Darwin
+kern.maxproc: 8000
+kern.bootargs:
+kern.version: Darwin Kernel Version 23.4.0: Fri Mar 15 00:10:42 PDT 2024; root:xnu-10063.101.17~1/RELEASE_ARM64_T6000
+net.inet.ip.forwarding: 0
+machdep.cpu.brand_string: Apple M1 Pro
+hw.optional.arm.caps: 868632
+ continued line
+`)
+	assert.Equal(t, map[string]string{
+		"kern.ostype":              "Darwin",
+		"kern.maxproc":             "8000",
+		"kern.bootargs":            "",
+		"kern.version":             "Darwin Kernel Version 23.4.0: Fri Mar 15 00:10:42 PDT 2024; root:xnu-10063.101.17~1/RELEASE_ARM64_T6000",
+		"net.inet.ip.forwarding":   "0",
+		"machdep.cpu.brand_string": "Apple M1 Pro",
+		"hw.optional.arm.caps":     "868632\n continued line",
+	}, parseSysctlOutput(out))
+}
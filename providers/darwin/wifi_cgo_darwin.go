@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build (amd64 && cgo) || (arm64 && cgo)
+// +build amd64,cgo arm64,cgo
+
+package darwin
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework CoreWLAN -framework Foundation
+#include <string.h>
+#import <CoreWLAN/CoreWLAN.h>
+
+typedef struct {
+	char name[32];
+	char ssid[33];
+	char bssid[18];
+	int rssi;
+	int noise;
+	int channel;
+	int phy_mode;
+	double tx_rate;
+} sysinfo_wifi_interface;
+
+// sysinfo_copy_string copies a string into a fixed size buffer.
+static void sysinfo_copy_string(char *dst, size_t size, NSString *s) {
+	if (s == nil || ![s getCString:dst maxLength:size encoding:NSUTF8StringEncoding]) {
+		dst[0] = '\0';
+	}
+}
+
+// sysinfo_wifi_interfaces fills out with up to max wireless interfaces and
+// returns the number of interfaces.
+static int sysinfo_wifi_interfaces(sysinfo_wifi_interface *out, int max) {
+	int n = 0;
+	@autoreleasepool {
+		for (CWInterface *iface in [[CWWiFiClient sharedWiFiClient] interfaces]) {
+			if (n >= max) {
+				break;
+			}
+			sysinfo_wifi_interface *w = &out[n++];
+			memset(w, 0, sizeof(*w));
+			sysinfo_copy_string(w->name, sizeof(w->name), [iface interfaceName]);
+			sysinfo_copy_string(w->ssid, sizeof(w->ssid), [iface ssid]);
+			sysinfo_copy_string(w->bssid, sizeof(w->bssid), [iface bssid]);
+			w->rssi = (int)[iface rssiValue];
+			w->noise = (int)[iface noiseMeasurement];
+			w->channel = (int)[[iface wlanChannel] channelNumber];
+			w->phy_mode = (int)[iface activePHYMode];
+			w->tx_rate = [iface transmitRate];
+		}
+	}
+	return n;
+}
+*/
+import "C"
+
+// maxWiFiInterfaces is the maximum number of wireless interfaces reported.
+const maxWiFiInterfaces = 16
+
+func wifiInterfaces() ([]wifiInterface, error) {
+	var buf [maxWiFiInterfaces]C.sysinfo_wifi_interface
+	n := int(C.sysinfo_wifi_interfaces(&buf[0], maxWiFiInterfaces))
+
+	interfaces := make([]wifiInterface, 0, n)
+	for _, w := range buf[:n] {
+		interfaces = append(interfaces, wifiInterface{
+			Name:    C.GoString(&w.name[0]),
+			SSID:    C.GoString(&w.ssid[0]),
+			BSSID:   C.GoString(&w.bssid[0]),
+			RSSI:    int(w.rssi),
+			Noise:   int(w.noise),
+			Channel: int(w.channel),
+			PHYMode: int(w.phy_mode),
+			TxRate:  float64(w.tx_rate),
+		})
+	}
+	return interfaces, nil
+}
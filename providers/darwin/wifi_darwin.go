@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// cwPHYModes maps the CWPHYMode values to the IEEE 802.11 standards.
+var cwPHYModes = map[int]string{
+	1: "802.11a",
+	2: "802.11b",
+	3: "802.11g",
+	4: "802.11n",
+	5: "802.11ac",
+	6: "802.11ax",
+}
+
+// wifiInterface is the state of a wireless interface as reported by
+// CoreWLAN.
+type wifiInterface struct {
+	Name    string
+	SSID    string
+	BSSID   string
+	RSSI    int // 0 when not associated.
+	Noise   int
+	Channel int
+	PHYMode int
+	TxRate  float64
+}
+
+// WiFi reports the wireless interfaces from CoreWLAN, which is what the
+// Wi-Fi menu shows when holding the option key. Since macOS 14 the SSID and
+// BSSID are only reported to processes that are authorized to use Location
+// Services.
+func (h *host) WiFi() ([]types.WiFiInfo, error) {
+	interfaces, err := wifiInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]types.WiFiInfo, 0, len(interfaces))
+	for _, i := range interfaces {
+		infos = append(infos, wifiInfo(i))
+	}
+	return infos, nil
+}
+
+// wifiInfo converts the state of a wireless interface. The link fields are
+// only set when the interface is associated.
+func wifiInfo(i wifiInterface) types.WiFiInfo {
+	info := types.WiFiInfo{Interface: i.Name}
+	if i.RSSI == 0 {
+		return info
+	}
+
+	info.SSID = i.SSID
+	info.BSSID = i.BSSID
+	info.Signal = &i.RSSI
+	info.Noise = &i.Noise
+	info.Channel = i.Channel
+	info.PHYMode = cwPHYModes[i.PHYMode]
+	info.TxRate = i.TxRate
+	return info
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package darwin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.WiFi = (*host)(nil)
+
+func TestWiFiInfo(t *testing.T) {
+	info := wifiInfo(wifiInterface{
+		Name:    "en0",
+		SSID:    "example",
+		BSSID:   "52:54:00:12:34:56",
+		RSSI:    -55,
+		Noise:   -92,
+		Channel: 36,
+		PHYMode: 5,
+		TxRate:  866,
+	})
+	signal, noise := -55, -92
+	assert.Equal(t, types.WiFiInfo{
+		Interface: "en0",
+		SSID:      "example",
+		BSSID:     "52:54:00:12:34:56",
+		Signal:    &signal,
+		Noise:     &noise,
+		Channel:   36,
+		PHYMode:   "802.11ac",
+		TxRate:    866,
+	}, info)
+
+	assert.Equal(t, types.WiFiInfo{Interface: "en1"}, wifiInfo(wifiInterface{Name: "en1", Noise: -92}))
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fake implements the HostProvider and ProcessProvider interfaces
+// with fixture data so that code using go-sysinfo can be unit tested without
+// depending on the host it runs on.
+//
+//	restore := sysinfo.UseProvider(&fake.Provider{
+//		HostFixture: &fake.Host{
+//			HostInfo:       types.HostInfo{Hostname: "web-1"},
+//			HostMemoryInfo: &types.HostMemoryInfo{Total: 8 << 30},
+//		},
+//	})
+//	defer restore()
+//
+// The fixtures implement all of the optional interfaces of the types
+// package. Methods whose fixture data is nil return types.ErrNotImplemented
+// and errors can be injected by method name with the Errors maps.
+package fake
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Provider is a HostProvider and ProcessProvider that returns fixture data.
+type Provider struct {
+	HostFixture      *Host      // Returned by Host. Nil means not implemented.
+	ProcessFixtures  []*Process // Returned by Processes and Process.
+	SelfPID          int        // PID of the process returned by Self.
+	CollectableState map[types.Capability]bool
+}
+
+var (
+	_ registry.HostProvider        = (*Provider)(nil)
+	_ registry.HostOptionsProvider = (*Provider)(nil)
+	_ registry.ProcessProvider     = (*Provider)(nil)
+	_ types.CapabilityChecker      = (*Provider)(nil)
+)
+
+// Host returns the host fixture.
+func (p *Provider) Host() (types.Host, error) {
+	if p.HostFixture == nil {
+		return nil, types.ErrNotImplemented
+	}
+	return p.HostFixture, nil
+}
+
+// HostWithOptions returns the host fixture. The options are ignored.
+func (p *Provider) HostWithOptions(registry.HostOptions) (types.Host, error) {
+	return p.Host()
+}
+
+// Processes returns the process fixtures.
+func (p *Provider) Processes() ([]types.Process, error) {
+	procs := make([]types.Process, 0, len(p.ProcessFixtures))
+	for _, proc := range p.ProcessFixtures {
+		proc.provider = p
+		procs = append(procs, proc)
+	}
+	return procs, nil
+}
+
+// Process returns the process fixture with the given PID.
+func (p *Provider) Process(pid int) (types.Process, error) {
+	for _, proc := range p.ProcessFixtures {
+		if proc.PID() == pid {
+			proc.provider = p
+			return proc, nil
+		}
+	}
+	return nil, fmt.Errorf("process %d not found: %w", pid, os.ErrNotExist)
+}
+
+// Self returns the process fixture whose PID is SelfPID.
+func (p *Provider) Self() (types.Process, error) {
+	return p.Process(p.SelfPID)
+}
+
+// CanCollect reports the state set in CollectableState. Capabilities that
+// are not set cannot be collected.
+func (p *Provider) CanCollect(capability types.Capability) (bool, error) {
+	return p.CollectableState[capability], nil
+}
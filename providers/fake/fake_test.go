@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fake
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestProvider(t *testing.T) {
+	p := &Provider{
+		HostFixture: &Host{
+			HostInfo:        types.HostInfo{Hostname: "web-1"},
+			HostMemoryInfo:  &types.HostMemoryInfo{Total: 1024},
+			LoadAverageInfo: &types.LoadAverageInfo{One: 1},
+			KernelConfig:    map[string]string{"CONFIG_BPF": "y"},
+			Errors:          map[string]error{"VMStat": errors.New("permission denied")},
+		},
+		ProcessFixtures: []*Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1, Name: "init"}},
+			{
+				ProcessInfo:     types.ProcessInfo{PID: 42, PPID: 1, Name: "app"},
+				OpenHandlePaths: []string{"/dev/null", "/var/log/app.log"},
+			},
+		},
+		SelfPID:          42,
+		CollectableState: map[types.Capability]bool{types.CapabilityProcessEnvironment: true},
+	}
+
+	h, err := p.Host()
+	require.NoError(t, err)
+	assert.Equal(t, "web-1", h.Info().Hostname)
+
+	mem, err := h.Memory()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1024, mem.Total)
+
+	_, err = h.(types.VMStat).VMStat()
+	assert.EqualError(t, err, "permission denied")
+
+	_, err = h.(types.TPM).TPM()
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+
+	v, found, err := h.(types.KernelConfig).KernelConfigValue("CONFIG_BPF")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "y", v)
+
+	self, err := p.Self()
+	require.NoError(t, err)
+	assert.Equal(t, 42, self.PID())
+
+	n, err := self.(types.OpenHandleCounter).OpenHandleCount()
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = self.(types.Environment).Environment()
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+
+	parent, err := self.Parent()
+	require.NoError(t, err)
+	info, err := parent.Info()
+	require.NoError(t, err)
+	assert.Equal(t, "init", info.Name)
+
+	_, err = parent.Parent()
+	assert.Error(t, err)
+
+	procs, err := p.Processes()
+	require.NoError(t, err)
+	assert.Len(t, procs, 2)
+
+	ok, err := p.CanCollect(types.CapabilityProcessEnvironment)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = p.CanCollect(types.CapabilityHardwareSerial)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestProviderWithoutHost(t *testing.T) {
+	_, err := (&Provider{}).Host()
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
+
+func TestProcessSetPriorityClass(t *testing.T) {
+	p := &Process{SchedulerInfo: &types.SchedulerInfo{PriorityClass: types.PriorityClassNormal}}
+	require.NoError(t, p.SetPriorityClass(types.PriorityClassHigh))
+	info, err := p.Scheduler()
+	require.NoError(t, err)
+	assert.Equal(t, types.PriorityClassHigh, info.PriorityClass)
+
+	p.Errors = map[string]error{"SetPriorityClass": os.ErrPermission}
+	assert.ErrorIs(t, p.SetPriorityClass(types.PriorityClassRealtime), os.ErrPermission)
+	assert.Equal(t, types.PriorityClassHigh, p.SchedulerInfo.PriorityClass)
+
+	assert.ErrorIs(t, (&Process{}).SetPriorityClass(types.PriorityClassHigh), types.ErrNotImplemented)
+}
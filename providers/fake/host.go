@@ -0,0 +1,457 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fake
+
+import (
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Host is a types.Host that returns fixture data. It implements all of the
+// optional host interfaces of the types package. The pointer, slice, and map
+// fixtures that are nil make the corresponding method return
+// types.ErrNotImplemented.
+type Host struct {
+	HostInfo            types.HostInfo
+	CPUTimes            types.CPUTimes
+	ContainerGuestInfo  *types.ContainerGuestInfo
+	ContainerInfo       []types.ContainerInfo
+	HostMemoryInfo      *types.HostMemoryInfo
+	LoadAverageInfo     *types.LoadAverageInfo
+	VMStatInfo          *types.VMStatInfo
+	NetworkCountersInfo *types.NetworkCountersInfo
+	Boot                *types.BootInfo
+	DisplaySessionInfo  *types.DisplaySessionInfo
+	GPUInfo             []types.GPUInfo
+	Hardware            *types.HardwareInfo
+	IdleInfo            *types.IdleInfo
+	KernelFeatures      map[types.KernelFeature]bool
+	KernelConfig        map[string]string
+	KernelModuleInfo    []types.KernelModuleInfo
+	NUMAInfo            *types.NUMAInfo
+	PackageInfo         []types.PackageInfo
+	PressureInfo        *types.PressureInfo
+	RawData             map[string]interface{}
+	SessionInfo         []types.SessionInfo
+	UserNames           []string
+	Suspend             *types.SuspendInfo
+	Swap                *types.SwapInfo
+	TPMInfo             *types.TPMInfo
+	UpdateInfo          []types.UpdateInfo
+	RouteInfo           []types.Route
+	NeighborInfo        []types.Neighbor
+	DNSConfigInfo       *types.DNSConfigInfo
+	WiFiInfo            []types.WiFiInfo
+	ListeningPortInfo   []types.ListeningPort
+	SocketSummaryInfo   *types.SocketSummaryInfo
+	TCPStateCounts      map[string]int
+	LSMStatusInfo       *types.LSMStatusInfo
+	WindowsSecurityInfo *types.WindowsSecurityInfo
+	FirewallInfo        *types.FirewallInfo
+	AuditStatusInfo     *types.AuditStatusInfo
+	KernelParameterInfo map[string]string
+	FileHandlesInfo     *types.FileHandlesInfo
+	EntropyInfo         *types.EntropyInfo
+	TimeSyncInfo        *types.TimeSyncInfo
+	RebootRequiredInfo  *types.RebootRequiredInfo
+	LastUpdateInfo      *types.LastUpdateInfo
+
+	// Errors are returned by the methods with the same name (e.g. Memory)
+	// instead of the fixture data.
+	Errors map[string]error
+}
+
+var (
+	_ types.Host                  = (*Host)(nil)
+	_ types.NetworkCounters       = (*Host)(nil)
+	_ types.VMStat                = (*Host)(nil)
+	_ types.LoadAverage           = (*Host)(nil)
+	_ types.Boot                  = (*Host)(nil)
+	_ types.ContainerGuest        = (*Host)(nil)
+	_ types.Containers            = (*Host)(nil)
+	_ types.DisplaySession        = (*Host)(nil)
+	_ types.GPU                   = (*Host)(nil)
+	_ types.Hardware              = (*Host)(nil)
+	_ types.IdleState             = (*Host)(nil)
+	_ types.KernelFeatureDetector = (*Host)(nil)
+	_ types.KernelConfig          = (*Host)(nil)
+	_ types.KernelModules         = (*Host)(nil)
+	_ types.NUMA                  = (*Host)(nil)
+	_ types.Packages              = (*Host)(nil)
+	_ types.Pressure              = (*Host)(nil)
+	_ types.Raw                   = (*Host)(nil)
+	_ types.Refresher             = (*Host)(nil)
+	_ types.FieldRefresher        = (*Host)(nil)
+	_ types.Sessions              = (*Host)(nil)
+	_ types.SuspendTimer          = (*Host)(nil)
+	_ types.Swap                  = (*Host)(nil)
+	_ types.TPM                   = (*Host)(nil)
+	_ types.InstalledUpdates      = (*Host)(nil)
+	_ types.Routes                = (*Host)(nil)
+	_ types.Neighbors             = (*Host)(nil)
+	_ types.DNSConfig             = (*Host)(nil)
+	_ types.WiFi                  = (*Host)(nil)
+	_ types.ListeningPorts        = (*Host)(nil)
+	_ types.SocketSummary         = (*Host)(nil)
+	_ types.TCPStateCounter       = (*Host)(nil)
+	_ types.LSMStatus             = (*Host)(nil)
+	_ types.WindowsSecurity       = (*Host)(nil)
+	_ types.Firewall              = (*Host)(nil)
+	_ types.AuditStatus           = (*Host)(nil)
+	_ types.KernelParameters      = (*Host)(nil)
+	_ types.FileHandles           = (*Host)(nil)
+	_ types.Entropy               = (*Host)(nil)
+	_ types.TimeSync              = (*Host)(nil)
+	_ types.RebootRequired        = (*Host)(nil)
+	_ types.LastUpdate            = (*Host)(nil)
+)
+
+func (h *Host) Info() types.HostInfo { return h.HostInfo }
+
+func (h *Host) CPUTime() (types.CPUTimes, error) {
+	return h.CPUTimes, h.Errors["CPUTime"]
+}
+
+func (h *Host) Memory() (*types.HostMemoryInfo, error) {
+	if err := fixtureErr(h.Errors, "Memory", h.HostMemoryInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.HostMemoryInfo, nil
+}
+
+func (h *Host) LoadAverage() (*types.LoadAverageInfo, error) {
+	if err := fixtureErr(h.Errors, "LoadAverage", h.LoadAverageInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.LoadAverageInfo, nil
+}
+
+func (h *Host) VMStat() (*types.VMStatInfo, error) {
+	if err := fixtureErr(h.Errors, "VMStat", h.VMStatInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.VMStatInfo, nil
+}
+
+func (h *Host) NetworkCounters() (*types.NetworkCountersInfo, error) {
+	if err := fixtureErr(h.Errors, "NetworkCounters", h.NetworkCountersInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.NetworkCountersInfo, nil
+}
+
+func (h *Host) BootInfo() (*types.BootInfo, error) {
+	if err := fixtureErr(h.Errors, "BootInfo", h.Boot == nil); err != nil {
+		return nil, err
+	}
+	return h.Boot, nil
+}
+
+func (h *Host) ContainerGuest() (*types.ContainerGuestInfo, error) {
+	if err := fixtureErr(h.Errors, "ContainerGuest", h.ContainerGuestInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.ContainerGuestInfo, nil
+}
+
+func (h *Host) Containers() ([]types.ContainerInfo, error) {
+	if err := fixtureErr(h.Errors, "Containers", h.ContainerInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.ContainerInfo, nil
+}
+
+func (h *Host) DisplaySession() (*types.DisplaySessionInfo, error) {
+	if err := fixtureErr(h.Errors, "DisplaySession", h.DisplaySessionInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.DisplaySessionInfo, nil
+}
+
+func (h *Host) GPUs() ([]types.GPUInfo, error) {
+	if err := fixtureErr(h.Errors, "GPUs", h.GPUInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.GPUInfo, nil
+}
+
+func (h *Host) HardwareInfo() (*types.HardwareInfo, error) {
+	if err := fixtureErr(h.Errors, "HardwareInfo", h.Hardware == nil); err != nil {
+		return nil, err
+	}
+	return h.Hardware, nil
+}
+
+func (h *Host) IdleState() (*types.IdleInfo, error) {
+	if err := fixtureErr(h.Errors, "IdleState", h.IdleInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.IdleInfo, nil
+}
+
+// KernelSupports reports the state of the feature in KernelFeatures.
+// Features that are not set are not supported.
+func (h *Host) KernelSupports(feature types.KernelFeature) (bool, error) {
+	if err := fixtureErr(h.Errors, "KernelSupports", h.KernelFeatures == nil); err != nil {
+		return false, err
+	}
+	return h.KernelFeatures[feature], nil
+}
+
+func (h *Host) KernelConfigValue(option string) (string, bool, error) {
+	if err := fixtureErr(h.Errors, "KernelConfigValue", h.KernelConfig == nil); err != nil {
+		return "", false, err
+	}
+	v, found := h.KernelConfig[option]
+	return v, found, nil
+}
+
+func (h *Host) KernelModules() ([]types.KernelModuleInfo, error) {
+	if err := fixtureErr(h.Errors, "KernelModules", h.KernelModuleInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.KernelModuleInfo, nil
+}
+
+func (h *Host) NUMA() (*types.NUMAInfo, error) {
+	if err := fixtureErr(h.Errors, "NUMA", h.NUMAInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.NUMAInfo, nil
+}
+
+func (h *Host) Packages() ([]types.PackageInfo, error) {
+	if err := fixtureErr(h.Errors, "Packages", h.PackageInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.PackageInfo, nil
+}
+
+func (h *Host) Pressure() (*types.PressureInfo, error) {
+	if err := fixtureErr(h.Errors, "Pressure", h.PressureInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.PressureInfo, nil
+}
+
+func (h *Host) Sessions() ([]types.SessionInfo, error) {
+	if err := fixtureErr(h.Errors, "Sessions", h.SessionInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.SessionInfo, nil
+}
+
+func (h *Host) Users() ([]string, error) {
+	if err := fixtureErr(h.Errors, "Users", h.UserNames == nil); err != nil {
+		return nil, err
+	}
+	return h.UserNames, nil
+}
+
+func (h *Host) SuspendInfo() (*types.SuspendInfo, error) {
+	if err := fixtureErr(h.Errors, "SuspendInfo", h.Suspend == nil); err != nil {
+		return nil, err
+	}
+	return h.Suspend, nil
+}
+
+func (h *Host) SwapInfo() (*types.SwapInfo, error) {
+	if err := fixtureErr(h.Errors, "SwapInfo", h.Swap == nil); err != nil {
+		return nil, err
+	}
+	return h.Swap, nil
+}
+
+func (h *Host) TPM() (*types.TPMInfo, error) {
+	if err := fixtureErr(h.Errors, "TPM", h.TPMInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.TPMInfo, nil
+}
+
+func (h *Host) InstalledUpdates() ([]types.UpdateInfo, error) {
+	if err := fixtureErr(h.Errors, "InstalledUpdates", h.UpdateInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.UpdateInfo, nil
+}
+
+// Refresh returns the error of the Refresh fixture. The fixture data does not
+// change.
+func (h *Host) Refresh() error {
+	return fixtureErr(h.Errors, "Refresh", false)
+}
+
+// RefreshFields returns the error of the RefreshFields fixture. The fixture
+// data does not change.
+func (h *Host) RefreshFields(fields ...string) error {
+	return fixtureErr(h.Errors, "RefreshFields", false)
+}
+
+func (h *Host) Raw() (map[string]interface{}, error) {
+	if err := fixtureErr(h.Errors, "Raw", h.RawData == nil); err != nil {
+		return nil, err
+	}
+	return h.RawData, nil
+}
+
+func (h *Host) Routes() ([]types.Route, error) {
+	if err := fixtureErr(h.Errors, "Routes", h.RouteInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.RouteInfo, nil
+}
+
+func (h *Host) Neighbors() ([]types.Neighbor, error) {
+	if err := fixtureErr(h.Errors, "Neighbors", h.NeighborInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.NeighborInfo, nil
+}
+
+func (h *Host) DNSConfig() (*types.DNSConfigInfo, error) {
+	if err := fixtureErr(h.Errors, "DNSConfig", h.DNSConfigInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.DNSConfigInfo, nil
+}
+
+func (h *Host) WiFi() ([]types.WiFiInfo, error) {
+	if err := fixtureErr(h.Errors, "WiFi", h.WiFiInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.WiFiInfo, nil
+}
+
+func (h *Host) ListeningPorts() ([]types.ListeningPort, error) {
+	if err := fixtureErr(h.Errors, "ListeningPorts", h.ListeningPortInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.ListeningPortInfo, nil
+}
+
+func (h *Host) SocketSummary() (*types.SocketSummaryInfo, error) {
+	if err := fixtureErr(h.Errors, "SocketSummary", h.SocketSummaryInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.SocketSummaryInfo, nil
+}
+
+func (h *Host) TCPStates() (map[string]int, error) {
+	if err := fixtureErr(h.Errors, "TCPStates", h.TCPStateCounts == nil); err != nil {
+		return nil, err
+	}
+	return h.TCPStateCounts, nil
+}
+
+func (h *Host) LSMStatus() (*types.LSMStatusInfo, error) {
+	if err := fixtureErr(h.Errors, "LSMStatus", h.LSMStatusInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.LSMStatusInfo, nil
+}
+
+func (h *Host) WindowsSecurity() (*types.WindowsSecurityInfo, error) {
+	if err := fixtureErr(h.Errors, "WindowsSecurity", h.WindowsSecurityInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.WindowsSecurityInfo, nil
+}
+
+func (h *Host) Firewall() (*types.FirewallInfo, error) {
+	if err := fixtureErr(h.Errors, "Firewall", h.FirewallInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.FirewallInfo, nil
+}
+
+func (h *Host) AuditStatus() (*types.AuditStatusInfo, error) {
+	if err := fixtureErr(h.Errors, "AuditStatus", h.AuditStatusInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.AuditStatusInfo, nil
+}
+
+func (h *Host) Sysctl(name string) (string, bool, error) {
+	if err := fixtureErr(h.Errors, "Sysctl", h.KernelParameterInfo == nil); err != nil {
+		return "", false, err
+	}
+	v, found := h.KernelParameterInfo[name]
+	return v, found, nil
+}
+
+func (h *Host) KernelParameters(prefix string) (map[string]string, error) {
+	if err := fixtureErr(h.Errors, "KernelParameters", h.KernelParameterInfo == nil); err != nil {
+		return nil, err
+	}
+	params := map[string]string{}
+	for name, v := range h.KernelParameterInfo {
+		if strings.HasPrefix(name, prefix) {
+			params[name] = v
+		}
+	}
+	return params, nil
+}
+
+func (h *Host) FileHandles() (*types.FileHandlesInfo, error) {
+	if err := fixtureErr(h.Errors, "FileHandles", h.FileHandlesInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.FileHandlesInfo, nil
+}
+
+func (h *Host) Entropy() (*types.EntropyInfo, error) {
+	if err := fixtureErr(h.Errors, "Entropy", h.EntropyInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.EntropyInfo, nil
+}
+
+func (h *Host) TimeSync() (*types.TimeSyncInfo, error) {
+	if err := fixtureErr(h.Errors, "TimeSync", h.TimeSyncInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.TimeSyncInfo, nil
+}
+
+func (h *Host) RebootRequired() (*types.RebootRequiredInfo, error) {
+	if err := fixtureErr(h.Errors, "RebootRequired", h.RebootRequiredInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.RebootRequiredInfo, nil
+}
+
+func (h *Host) LastUpdate() (*types.LastUpdateInfo, error) {
+	if err := fixtureErr(h.Errors, "LastUpdate", h.LastUpdateInfo == nil); err != nil {
+		return nil, err
+	}
+	return h.LastUpdateInfo, nil
+}
+
+// fixtureErr returns the error injected for the method or
+// types.ErrNotImplemented if the fixture data is missing.
+func fixtureErr(errs map[string]error, method string, missing bool) error {
+	if err := errs[method]; err != nil {
+		return err
+	}
+	if missing {
+		return types.ErrNotImplemented
+	}
+	return nil
+}
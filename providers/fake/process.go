@@ -0,0 +1,283 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fake
+
+import (
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Process is a types.Process that returns fixture data. It implements all
+// of the optional process interfaces of the types package. The pointer,
+// slice, and map fixtures that are nil make the corresponding method return
+// types.ErrNotImplemented.
+type Process struct {
+	ProcessInfo         types.ProcessInfo // The PID is read from ProcessInfo.PID.
+	CPUTimes            types.CPUTimes
+	MemoryInfo          types.MemoryInfo
+	UserInfo            types.UserInfo
+	Env                 map[string]string
+	OpenHandlePaths     []string // Returned by OpenHandles and counted by OpenHandleCount.
+	DelayInfo           *types.DelayInfo
+	ContextSwitchInfo   *types.ContextSwitchInfo
+	SchedulerInfo       *types.SchedulerInfo // Its PriorityClass is changed by SetPriorityClass.
+	CapabilityInfo      *types.CapabilityInfo
+	SeccompInfo         *types.SeccompInfo
+	NetworkCountersInfo *types.NetworkCountersInfo
+	ContainerInfo       *types.ProcessContainerInfo
+	PrivilegeInfo       *types.PrivilegeInfo
+	RawData             map[string]interface{}
+	GUIResourceInfo     *types.GUIResourceInfo
+	NiceValue           *int  // Returned by Nice and changed by SetNice.
+	AffinityCPUs        []int // Returned by Affinity and changed by SetAffinity.
+	Suspended           bool  // Set by Suspend and cleared by Resume.
+	JobObjectInfo       *types.JobObjectInfo
+	NamespaceInodes     map[string]uint64
+	ExecutableHashInfo  *types.ExecutableHashInfo // Returned by ExecutableHash regardless of the algorithms.
+	CodeSignatureInfo   *types.SignatureInfo
+
+	// Errors are returned by the methods with the same name (e.g. Info)
+	// instead of the fixture data.
+	Errors map[string]error
+
+	provider *Provider
+}
+
+var (
+	_ types.Process              = (*Process)(nil)
+	_ types.Environment          = (*Process)(nil)
+	_ types.OpenHandleEnumerator = (*Process)(nil)
+	_ types.OpenHandleCounter    = (*Process)(nil)
+	_ types.Delays               = (*Process)(nil)
+	_ types.ContextSwitches      = (*Process)(nil)
+	_ types.Scheduler            = (*Process)(nil)
+	_ types.Capabilities         = (*Process)(nil)
+	_ types.Seccomp              = (*Process)(nil)
+	_ types.NetworkCounters      = (*Process)(nil)
+	_ types.ProcessContainer     = (*Process)(nil)
+	_ types.Privileges           = (*Process)(nil)
+	_ types.Raw                  = (*Process)(nil)
+	_ types.GUIResources         = (*Process)(nil)
+	_ types.Niceness             = (*Process)(nil)
+	_ types.PriorityClassSetter  = (*Process)(nil)
+	_ types.Affinity             = (*Process)(nil)
+	_ types.Suspender            = (*Process)(nil)
+	_ types.ExecutableHasher     = (*Process)(nil)
+	_ types.JobObject            = (*Process)(nil)
+	_ types.Namespaces           = (*Process)(nil)
+	_ types.CodeSignature        = (*Process)(nil)
+)
+
+func (p *Process) PID() int { return p.ProcessInfo.PID }
+
+func (p *Process) Info() (types.ProcessInfo, error) {
+	return p.ProcessInfo, p.Errors["Info"]
+}
+
+func (p *Process) CPUTime() (types.CPUTimes, error) {
+	return p.CPUTimes, p.Errors["CPUTime"]
+}
+
+func (p *Process) Memory() (types.MemoryInfo, error) {
+	return p.MemoryInfo, p.Errors["Memory"]
+}
+
+func (p *Process) User() (types.UserInfo, error) {
+	return p.UserInfo, p.Errors["User"]
+}
+
+// Parent returns the process fixture of the provider whose PID is the PPID
+// of this process.
+func (p *Process) Parent() (types.Process, error) {
+	if err := fixtureErr(p.Errors, "Parent", p.provider == nil); err != nil {
+		return nil, err
+	}
+	return p.provider.Process(p.ProcessInfo.PPID)
+}
+
+func (p *Process) Environment() (map[string]string, error) {
+	if err := fixtureErr(p.Errors, "Environment", p.Env == nil); err != nil {
+		return nil, err
+	}
+	return p.Env, nil
+}
+
+func (p *Process) OpenHandles() ([]string, error) {
+	if err := fixtureErr(p.Errors, "OpenHandles", p.OpenHandlePaths == nil); err != nil {
+		return nil, err
+	}
+	return p.OpenHandlePaths, nil
+}
+
+func (p *Process) OpenHandleCount() (int, error) {
+	if err := fixtureErr(p.Errors, "OpenHandleCount", p.OpenHandlePaths == nil); err != nil {
+		return 0, err
+	}
+	return len(p.OpenHandlePaths), nil
+}
+
+func (p *Process) Delays() (*types.DelayInfo, error) {
+	if err := fixtureErr(p.Errors, "Delays", p.DelayInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.DelayInfo, nil
+}
+
+func (p *Process) ContextSwitches() (*types.ContextSwitchInfo, error) {
+	if err := fixtureErr(p.Errors, "ContextSwitches", p.ContextSwitchInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.ContextSwitchInfo, nil
+}
+
+func (p *Process) Scheduler() (*types.SchedulerInfo, error) {
+	if err := fixtureErr(p.Errors, "Scheduler", p.SchedulerInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.SchedulerInfo, nil
+}
+
+func (p *Process) Capabilities() (*types.CapabilityInfo, error) {
+	if err := fixtureErr(p.Errors, "Capabilities", p.CapabilityInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.CapabilityInfo, nil
+}
+
+func (p *Process) Seccomp() (*types.SeccompInfo, error) {
+	if err := fixtureErr(p.Errors, "Seccomp", p.SeccompInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.SeccompInfo, nil
+}
+
+func (p *Process) NetworkCounters() (*types.NetworkCountersInfo, error) {
+	if err := fixtureErr(p.Errors, "NetworkCounters", p.NetworkCountersInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.NetworkCountersInfo, nil
+}
+
+func (p *Process) Container() (*types.ProcessContainerInfo, error) {
+	if err := fixtureErr(p.Errors, "Container", p.ContainerInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.ContainerInfo, nil
+}
+
+func (p *Process) Privileges() (*types.PrivilegeInfo, error) {
+	if err := fixtureErr(p.Errors, "Privileges", p.PrivilegeInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.PrivilegeInfo, nil
+}
+
+func (p *Process) GUIResources() (*types.GUIResourceInfo, error) {
+	if err := fixtureErr(p.Errors, "GUIResources", p.GUIResourceInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.GUIResourceInfo, nil
+}
+
+func (p *Process) Raw() (map[string]interface{}, error) {
+	if err := fixtureErr(p.Errors, "Raw", p.RawData == nil); err != nil {
+		return nil, err
+	}
+	return p.RawData, nil
+}
+
+func (p *Process) Nice() (int, error) {
+	if err := fixtureErr(p.Errors, "Nice", p.NiceValue == nil); err != nil {
+		return 0, err
+	}
+	return *p.NiceValue, nil
+}
+
+func (p *Process) SetNice(nice int) error {
+	if err := fixtureErr(p.Errors, "SetNice", p.NiceValue == nil); err != nil {
+		return err
+	}
+	*p.NiceValue = nice
+	return nil
+}
+
+func (p *Process) SetPriorityClass(class string) error {
+	if err := fixtureErr(p.Errors, "SetPriorityClass", p.SchedulerInfo == nil); err != nil {
+		return err
+	}
+	p.SchedulerInfo.PriorityClass = class
+	return nil
+}
+
+func (p *Process) Affinity() ([]int, error) {
+	if err := fixtureErr(p.Errors, "Affinity", p.AffinityCPUs == nil); err != nil {
+		return nil, err
+	}
+	return p.AffinityCPUs, nil
+}
+
+func (p *Process) SetAffinity(cpus []int) error {
+	if err := fixtureErr(p.Errors, "SetAffinity", p.AffinityCPUs == nil); err != nil {
+		return err
+	}
+	p.AffinityCPUs = append([]int(nil), cpus...)
+	return nil
+}
+
+func (p *Process) Suspend() error {
+	if err := fixtureErr(p.Errors, "Suspend", false); err != nil {
+		return err
+	}
+	p.Suspended = true
+	return nil
+}
+
+func (p *Process) Resume() error {
+	if err := fixtureErr(p.Errors, "Resume", false); err != nil {
+		return err
+	}
+	p.Suspended = false
+	return nil
+}
+
+func (p *Process) JobObject() (*types.JobObjectInfo, error) {
+	if err := fixtureErr(p.Errors, "JobObject", p.JobObjectInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.JobObjectInfo, nil
+}
+
+func (p *Process) Namespaces() (map[string]uint64, error) {
+	if err := fixtureErr(p.Errors, "Namespaces", p.NamespaceInodes == nil); err != nil {
+		return nil, err
+	}
+	return p.NamespaceInodes, nil
+}
+
+func (p *Process) ExecutableHash(algorithms ...types.HashAlgorithm) (*types.ExecutableHashInfo, error) {
+	if err := fixtureErr(p.Errors, "ExecutableHash", p.ExecutableHashInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.ExecutableHashInfo, nil
+}
+
+func (p *Process) SignatureInfo() (*types.SignatureInfo, error) {
+	if err := fixtureErr(p.Errors, "SignatureInfo", p.CodeSignatureInfo == nil); err != nil {
+		return nil, err
+	}
+	return p.CodeSignatureInfo, nil
+}
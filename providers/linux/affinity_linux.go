@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// maxAffinityCPUs is the number of CPUs that fit in a unix.CPUSet.
+const maxAffinityCPUs = int(unsafe.Sizeof(unix.CPUSet{})) * 8
+
+// Affinity reports the CPU affinity of the main thread of the process.
+func (p *process) Affinity() ([]int, error) {
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(p.PID(), &set); err != nil {
+		return nil, fmt.Errorf("sched_getaffinity failed: %w", err)
+	}
+	return cpuSetCPUs(&set), nil
+}
+
+// SetAffinity changes the CPU affinity of all threads of the process. Like
+// setpriority(2), sched_setaffinity(2) only changes the thread it is given.
+func (p *process) SetAffinity(cpus []int) error {
+	set, err := newCPUSet(cpus)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := processTasks(p.fs, p.PID())
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		tid, err := strconv.Atoi(filepath.Base(task))
+		if err != nil {
+			return fmt.Errorf("invalid task directory %v: %w", task, err)
+		}
+		// Threads can exit while they are being changed.
+		if err := unix.SchedSetaffinity(tid, set); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("sched_setaffinity failed for thread %d: %w", tid, err)
+		}
+	}
+	return nil
+}
+
+func cpuSetCPUs(set *unix.CPUSet) []int {
+	cpus := make([]int, 0, set.Count())
+	for cpu := 0; cpu < maxAffinityCPUs; cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
+
+func newCPUSet(cpus []int) (*unix.CPUSet, error) {
+	if len(cpus) == 0 {
+		return nil, errors.New("no CPUs given")
+	}
+
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		if cpu < 0 || cpu >= maxAffinityCPUs {
+			return nil, fmt.Errorf("invalid CPU number %d", cpu)
+		}
+		set.Set(cpu)
+	}
+	return &set, nil
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Affinity = (*process)(nil)
+
+func TestNewCPUSet(t *testing.T) {
+	set, err := newCPUSet([]int{0, 3, 64})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 64}, cpuSetCPUs(set))
+
+	_, err = newCPUSet(nil)
+	assert.Error(t, err)
+	_, err = newCPUSet([]int{-1})
+	assert.Error(t, err)
+	_, err = newCPUSet([]int{maxAffinityCPUs})
+	assert.Error(t, err)
+}
+
+func TestSetAffinity(t *testing.T) {
+	cmd := exec.Command("sleep", "60")
+	require.NoError(t, cmd.Start())
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	proc, err := newLinuxSystem("").Process(cmd.Process.Pid)
+	require.NoError(t, err)
+	p := proc.(types.Affinity)
+
+	cpus, err := p.Affinity()
+	require.NoError(t, err)
+	require.NotEmpty(t, cpus)
+
+	// Pin the process to the first CPU it is allowed to run on.
+	require.NoError(t, p.SetAffinity(cpus[:1]))
+	pinned, err := p.Affinity()
+	require.NoError(t, err)
+	assert.Equal(t, cpus[:1], pinned)
+}
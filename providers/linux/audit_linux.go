@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// auditStatusLen is the size of the fields of struct audit_status that are
+// reported by all kernels.
+const auditStatusLen = 32
+
+// auditFailureModes maps the AUDIT_FAIL values to the types.AuditFailure
+// values.
+var auditFailureModes = map[uint32]string{
+	0: types.AuditFailureSilent,
+	1: types.AuditFailurePrintk,
+	2: types.AuditFailurePanic,
+}
+
+// AuditStatus reports the status of the kernel audit subsystem with an
+// AUDIT_GET netlink request, which is what "auditctl -s" shows. It requires
+// CAP_AUDIT_CONTROL and is refused in network namespaces other than the
+// initial one.
+func (h *host) AuditStatus() (*types.AuditStatusInfo, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_AUDIT)
+	if err != nil {
+		if errors.Is(err, unix.EPROTONOSUPPORT) {
+			return nil, fmt.Errorf("the kernel does not support auditing: %w", types.ErrNotImplemented)
+		}
+		return nil, fmt.Errorf("failed to open audit netlink socket: %w", err)
+	}
+	defer unix.Close(fd)
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: genlReceiveTimeout}); err != nil {
+		return nil, err
+	}
+
+	// The reply is sent asynchronously by the kernel, so it can follow an
+	// acknowledgement. None is requested.
+	replies, err := netlinkExchange(fd, unix.AUDIT_GET, unix.NLM_F_REQUEST, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("AUDIT_GET failed: %w", err)
+	}
+	if len(replies) == 0 {
+		return nil, errors.New("no reply to AUDIT_GET")
+	}
+	return parseAuditStatus(replies[0])
+}
+
+// parseAuditStatus parses a struct audit_status.
+func parseAuditStatus(b []byte) (*types.AuditStatusInfo, error) {
+	if len(b) < auditStatusLen {
+		return nil, fmt.Errorf("audit status too short: %d bytes", len(b))
+	}
+
+	// mask, enabled, failure, pid, rate_limit, backlog_limit, lost, backlog.
+	enabled := nativeEndian.Uint32(b[4:])
+	failure := nativeEndian.Uint32(b[8:])
+	return &types.AuditStatusInfo{
+		Enabled:      enabled != 0,
+		Immutable:    enabled == 2,
+		FailureMode:  auditFailureModes[failure],
+		PID:          int(nativeEndian.Uint32(b[12:])),
+		RateLimit:    nativeEndian.Uint32(b[16:]),
+		BacklogLimit: nativeEndian.Uint32(b[20:]),
+		Lost:         nativeEndian.Uint32(b[24:]),
+		Backlog:      nativeEndian.Uint32(b[28:]),
+	}, nil
+}
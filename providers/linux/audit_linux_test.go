@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.AuditStatus = (*host)(nil)
+
+func TestParseAuditStatus(t *testing.T) {
+	b := make([]byte, 40)
+	for i, v := range []uint32{0, 2, 1, 1042, 0, 8192, 17, 3, 0x7f, 60000} {
+		nativeEndian.PutUint32(b[4*i:], v)
+	}
+
+	status, err := parseAuditStatus(b)
+	require.NoError(t, err)
+	assert.Equal(t, &types.AuditStatusInfo{
+		Enabled:      true,
+		Immutable:    true,
+		FailureMode:  types.AuditFailurePrintk,
+		PID:          1042,
+		BacklogLimit: 8192,
+		Lost:         17,
+		Backlog:      3,
+	}, status)
+
+	_, err = parseAuditStatus(b[:16])
+	assert.Error(t, err)
+}
+
+func TestAuditStatus(t *testing.T) {
+	h, err := newLinuxSystem("").Host()
+	require.NoError(t, err)
+
+	status, err := h.(types.AuditStatus).AuditStatus()
+	if err != nil {
+		t.Skipf("audit status is not available: %v", err)
+	}
+	t.Logf("%+v", status)
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"unicode/utf16"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// loaderInfoVar is the EFI variable set by boot loaders implementing the
+// Boot Loader Interface (e.g. systemd-boot).
+const loaderInfoVar = "LoaderInfo-4a67b082-0a4c-41cf-b6c7-440b29bb8c4f"
+
+// BootInfo reports the boot loader, the kernel image, and the boot mode.
+func (h *host) BootInfo() (*types.BootInfo, error) {
+	return bootInfo(h.procFS)
+}
+
+func bootInfo(fs procFS) (*types.BootInfo, error) {
+	info := &types.BootInfo{BootMode: types.BootModeLegacy}
+
+	efi := fs.rootPath("sys/firmware/efi")
+	if exists(efi) {
+		info.BootMode = types.BootModeUEFI
+
+		if data, err := ioutil.ReadFile(filepath.Join(efi, "efivars", loaderInfoVar)); err == nil {
+			info.Loader = decodeEFIString(data)
+		}
+	}
+
+	cmdline, err := ioutil.ReadFile(fs.path("cmdline"))
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range bytes.Fields(cmdline) {
+		if bytes.HasPrefix(param, []byte("BOOT_IMAGE=")) {
+			info.KernelImage = string(param[len("BOOT_IMAGE="):])
+			break
+		}
+	}
+
+	if info.Loader == "" {
+		// GRUB does not implement the Boot Loader Interface. It passes the
+		// kernel image as BOOT_IMAGE.
+		for _, dir := range []string{"boot/grub", "boot/grub2"} {
+			if exists(fs.rootPath(dir)) {
+				info.Loader = "GRUB"
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+// decodeEFIString decodes the NUL terminated UTF-16LE string of an EFI
+// variable, skipping the 4 byte attributes header.
+func decodeEFIString(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	data = data[4:]
+
+	s := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		c := binary.LittleEndian.Uint16(data[i:])
+		if c == 0 {
+			break
+		}
+		s = append(s, c)
+	}
+	return string(utf16.Decode(s))
+}
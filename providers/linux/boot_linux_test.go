@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestBootInfo(t *testing.T) {
+	testCases := []struct {
+		hostFS   string
+		expected types.BootInfo
+	}{
+		{"testdata/boot_grub", types.BootInfo{Loader: "GRUB", KernelImage: "/vmlinuz-5.15.0-101-generic", BootMode: types.BootModeLegacy}},
+		{"testdata/boot_systemd", types.BootInfo{Loader: "systemd-boot 255.4-1-arch", BootMode: types.BootModeUEFI}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hostFS, func(t *testing.T) {
+			info, err := bootInfo(newLinuxSystem(tc.hostFS).procFS)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, *info)
+		})
+	}
+}
@@ -42,6 +42,6 @@ func bootTime(fs procfs.FS) (time.Time, error) {
 		return time.Time{}, err
 	}
 
-	bootTimeValue = time.Unix(int64(stat.BootTime), 0)
+	bootTimeValue = time.Unix(int64(stat.BootTime), 0).UTC()
 	return bootTimeValue, nil
 }
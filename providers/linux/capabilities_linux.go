@@ -18,7 +18,9 @@
 package linux
 
 import (
+	"io/ioutil"
 	"strconv"
+	"strings"
 
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -116,3 +118,42 @@ func readCapabilities(content []byte) (*types.CapabilityInfo, error) {
 
 	return &cap, err
 }
+
+// Privileges reports the permitted capabilities of the process as its
+// privileges. A capability is enabled if it is in the effective set.
+func (p *process) Privileges() (*types.PrivilegeInfo, error) {
+	content, err := ioutil.ReadFile(p.path("status"))
+	if err != nil {
+		return nil, err
+	}
+
+	return readPrivileges(content)
+}
+
+func readPrivileges(content []byte) (*types.PrivilegeInfo, error) {
+	caps, err := readCapabilities(content)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := make(map[string]bool, len(caps.Effective))
+	for _, c := range caps.Effective {
+		effective[c] = true
+	}
+
+	info := &types.PrivilegeInfo{Privileges: make([]types.Privilege, 0, len(caps.Permitted))}
+	for _, c := range caps.Permitted {
+		info.Privileges = append(info.Privileges, types.Privilege{Name: c, Enabled: effective[c]})
+	}
+
+	// The process is elevated when its effective UID is root.
+	err = parseKeyValue(content, ":", func(key, value []byte) error {
+		if string(key) == "Uid" {
+			if ids := strings.Fields(string(value)); len(ids) >= 2 {
+				info.Elevated = ids[1] == "0"
+			}
+		}
+		return nil
+	})
+	return info, err
+}
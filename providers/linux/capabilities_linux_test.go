@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Privileges = (*process)(nil)
+
+func TestReadPrivileges(t *testing.T) {
+	status := []byte("Name:\tping\nUid:\t1000\t0\t0\t0\nCapInh:\t0000000000000000\nCapPrm:\t0000000000003000\nCapEff:\t0000000000001000\n")
+	info, err := readPrivileges(status)
+	require.NoError(t, err)
+	assert.Equal(t, &types.PrivilegeInfo{
+		Privileges: []types.Privilege{
+			{Name: "net_admin", Enabled: true},
+			{Name: "net_raw", Enabled: false},
+		},
+		Elevated: true,
+	}, info)
+
+	status, err = ioutil.ReadFile("testdata/capability_user/proc/self/status")
+	require.NoError(t, err)
+	info, err = readPrivileges(status)
+	require.NoError(t, err)
+	assert.Equal(t, &types.PrivilegeInfo{Privileges: []types.Privilege{}}, info)
+}
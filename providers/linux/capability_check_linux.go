@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// CanCollect reports whether the process has the credentials needed to
+// collect the data. The effective capabilities and user ID are read from
+// /proc/self/status.
+func (s linuxSystem) CanCollect(capability types.Capability) (bool, error) {
+	return canCollect(s.procFS, capability)
+}
+
+func canCollect(fs procFS, capability types.Capability) (bool, error) {
+	creds, err := readCredentials(fs)
+	if err != nil {
+		return false, err
+	}
+
+	switch capability {
+	case types.CapabilityProcessEnvironment:
+		// Reading the environment of a process owned by another user
+		// requires a ptrace read access check to pass.
+		return creds.has("sys_ptrace"), nil
+	case types.CapabilityProcessCommandLine:
+		hidden, err := procHidesPIDs(fs)
+		if err != nil {
+			return false, err
+		}
+		return !hidden || creds.has("sys_ptrace"), nil
+	case types.CapabilityHardwareSerial:
+		fi, err := os.Stat(fs.rootPath("sys/class/dmi/id/product_serial"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return creds.canRead(fi), nil
+	default:
+		return false, fmt.Errorf("unknown capability %q: %w", capability, types.ErrNotImplemented)
+	}
+}
+
+// credentials are the effective credentials of a process.
+type credentials struct {
+	euid uint32
+	caps []string
+}
+
+func readCredentials(fs procFS) (*credentials, error) {
+	content, err := ioutil.ReadFile(fs.path("self/status"))
+	if err != nil {
+		return nil, err
+	}
+
+	caps, err := readCapabilities(content)
+	if err != nil {
+		return nil, err
+	}
+	creds := &credentials{caps: caps.Effective}
+
+	err = parseKeyValue(content, ":", func(key, value []byte) error {
+		if string(key) != "Uid" {
+			return nil
+		}
+		// Real, effective, saved set, and filesystem UIDs.
+		fields := strings.Fields(string(value))
+		if len(fields) < 2 {
+			return fmt.Errorf("unexpected Uid format %q", value)
+		}
+		euid, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return err
+		}
+		creds.euid = uint32(euid)
+		return nil
+	})
+	return creds, err
+}
+
+func (c *credentials) has(capability string) bool {
+	for _, name := range c.caps {
+		if name == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// canRead checks the permission bits of the file.
+func (c *credentials) canRead(fi os.FileInfo) bool {
+	if fi.Mode().Perm()&0o004 != 0 || c.has("dac_override") || c.has("dac_read_search") {
+		return true
+	}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && st.Uid == c.euid {
+		return fi.Mode().Perm()&0o400 != 0
+	}
+	return false
+}
+
+// procHidesPIDs returns whether /proc is mounted with the hidepid option,
+// which hides the processes of other users.
+func procHidesPIDs(fs procFS) (bool, error) {
+	content, err := ioutil.ReadFile(fs.path("mounts"))
+	if err != nil {
+		return false, err
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		// Device, mount point, type, and options.
+		fields := strings.Fields(s.Text())
+		if len(fields) < 4 || fields[1] != "/proc" || fields[2] != "proc" {
+			continue
+		}
+		for _, opt := range strings.Split(fields[3], ",") {
+			if strings.HasPrefix(opt, "hidepid=") {
+				v := strings.TrimPrefix(opt, "hidepid=")
+				return v != "0" && v != "off", nil
+			}
+		}
+		return false, nil
+	}
+	return false, s.Err()
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestCanCollect(t *testing.T) {
+	tests := []struct {
+		dir        string
+		capability types.Capability
+		want       bool
+	}{
+		{"testdata/capability_root", types.CapabilityProcessEnvironment, true},
+		{"testdata/capability_root", types.CapabilityProcessCommandLine, true},
+		{"testdata/capability_user", types.CapabilityProcessEnvironment, false},
+		{"testdata/capability_user", types.CapabilityProcessCommandLine, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dir+"/"+string(tc.capability), func(t *testing.T) {
+			ok, err := canCollect(newLinuxSystem(tc.dir).procFS, tc.capability)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ok)
+		})
+	}
+
+	_, err := canCollect(newLinuxSystem("testdata/capability_root").procFS, "unknown")
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// ContainerGuest reports the container that the host is running in. The
+// container manager is read from /run/systemd/container or the container
+// variable in the environment of PID 1, which are set by systemd-nspawn, LXC,
+// and Podman. LXD is detected by its /dev/lxd socket and Docker by its
+// /.dockerenv file.
+func (h *host) ContainerGuest() (*types.ContainerGuestInfo, error) {
+	return containerGuest(h.procFS), nil
+}
+
+func containerGuest(fs procFS) *types.ContainerGuestInfo {
+	info := &types.ContainerGuestInfo{}
+	info.Runtime, _ = readFileString(fs.rootPath("run/systemd/container"))
+	if info.Runtime == "" {
+		// The environment of PID 1 is only readable by root.
+		info.Runtime = processEnvValue(fs.path("1/environ"), "container")
+	}
+
+	switch {
+	case info.Runtime == "" && exists(fs.rootPath("run/.containerenv")):
+		info.Runtime = "podman"
+	case info.Runtime == "" && exists(fs.rootPath(".dockerenv")):
+		info.Runtime = "docker"
+	}
+	// LXD containers are LXC containers with the LXD guest API.
+	if (info.Runtime == "" || info.Runtime == "lxc") && exists(fs.rootPath("dev/lxd/sock")) {
+		info.Runtime = "lxd"
+	}
+
+	switch info.Runtime {
+	case "systemd-nspawn":
+		info.ID, _ = readFileString(fs.rootPath("run/host/container-uuid"))
+		if info.ID == "" {
+			info.ID = processEnvValue(fs.path("1/environ"), "container_uuid")
+		}
+	case "podman":
+		// The file is empty unless the container runs with --privileged.
+		if content, err := ioutil.ReadFile(fs.rootPath("run/.containerenv")); err == nil {
+			_ = parseKeyValue(content, "=", func(key, value []byte) error {
+				v, err := strconv.Unquote(string(value))
+				if err != nil {
+					v = string(value)
+				}
+				switch string(key) {
+				case "id":
+					info.ID = v
+				case "name":
+					info.Name = v
+				}
+				return nil
+			})
+		}
+	}
+
+	info.Containerized = info.Runtime != ""
+	return info
+}
+
+// processEnvValue returns the value of a variable in a /proc/<pid>/environ
+// file or an empty string if it is not set or the file is not readable.
+func processEnvValue(path, key string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, kv := range strings.Split(string(content), "\x00") {
+		if strings.HasPrefix(kv, key+"=") {
+			return kv[len(key)+1:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ContainerGuest = (*host)(nil)
+
+func TestContainerGuest(t *testing.T) {
+	tests := []struct {
+		dir  string
+		info types.ContainerGuestInfo
+	}{
+		{"testdata/guest_nspawn", types.ContainerGuestInfo{Containerized: true, Runtime: "systemd-nspawn", ID: "3b2d0c7e-6f3a-4a52-9d7b-1c2e5f6a7b8c"}},
+		{"testdata/guest_lxd", types.ContainerGuestInfo{Containerized: true, Runtime: "lxd"}},
+		{"testdata/guest_podman", types.ContainerGuestInfo{Containerized: true, Runtime: "podman", ID: strings.Repeat("7", 64), Name: "toolbox"}},
+		{"testdata/ubuntu1710", types.ContainerGuestInfo{}},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, &tc.info, containerGuest(newLinuxSystem(tc.dir).procFS), tc.dir)
+	}
+}
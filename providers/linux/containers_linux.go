@@ -0,0 +1,427 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_containers
+// +build !nosysinfo_containers
+
+package linux
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func init() {
+	registry.RegisterSubsystem(registry.SubsystemContainers)
+}
+
+const (
+	cgroupRoot = "sys/fs/cgroup"
+
+	// cgroupV1Unlimited is the lowest value of memory.limit_in_bytes that
+	// means unlimited. The exact value depends on the page size.
+	cgroupV1Unlimited = 1 << 62
+)
+
+// containerCgroupRegexp matches the control groups of containers. Their
+// names are the container ID, optionally with the prefix of the runtime and
+// the .scope suffix when the systemd cgroup driver is used (e.g.
+// docker-<id>.scope, cri-containerd-<id>.scope, crio-<id>.scope).
+var containerCgroupRegexp = regexp.MustCompile(`^(?:([a-z-]+)-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+// cgroupPrefixRuntimes maps the cgroup name prefixes to the runtimes.
+var cgroupPrefixRuntimes = map[string]string{
+	"docker":         "docker",
+	"cri-containerd": "containerd",
+	"crio":           "cri-o",
+	"libpod":         "podman",
+}
+
+// userSliceRegexp matches the systemd slices of users (e.g. user-1000.slice),
+// under which the control groups of rootless containers are created.
+var userSliceRegexp = regexp.MustCompile(`^user-([0-9]+)\.slice$`)
+
+// Containers reports the containers of the Docker, containerd, CRI-O, and
+// Podman runtimes found in the cgroup hierarchy, which is read from
+// /sys/fs/cgroup (v1 or v2). The runtimes are identified by the cgroup names
+// or by their state directories, which are also used to look up the
+// container names. Rootless containers are found in the slices of their
+// users and their names are read from the user's container storage.
+// systemd-nspawn machines and LXC, LXD, and Incus containers are reported
+// with their machine name as the ID.
+func (h *host) Containers() ([]types.ContainerInfo, error) {
+	return containers(h.procFS)
+}
+
+func containers(fs procFS) ([]types.ContainerInfo, error) {
+	cg := newCgroupHierarchy(fs)
+	root, err := filepath.EvalSymlinks(cg.dir("cpuacct", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := []types.ContainerInfo{}
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			// Control groups are removed when containers stop.
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		m := containerCgroupRegexp.FindStringSubmatch(d.Name())
+		if m == nil {
+			runtime, name, payload := machineCgroup(fs, cg, rel)
+			if runtime == "" {
+				return nil
+			}
+			if info, ok := cg.container(payload, true); ok {
+				info.ID = name
+				info.Runtime = runtime
+				info.Name = name
+				infos = append(infos, info)
+			}
+			return filepath.SkipDir
+		}
+		runtime, known := cgroupPrefixRuntimes[m[1]]
+		if m[1] != "" && !known {
+			// Other scopes, like the crio-conmon-<id>.scope and
+			// libpod-conmon-<id>.scope of the container monitors.
+			return nil
+		}
+
+		id := m[2]
+		if runtime == "" {
+			runtime = containerRuntime(fs, id, rel)
+		}
+
+		info, ok := cg.container(rel, false)
+		if ok {
+			info.ID = id
+			info.Runtime = runtime
+			info.UID = cgroupOwner(rel)
+			info.Name = containerName(fs, runtime, id, info.UID)
+			infos = append(infos, info)
+		}
+
+		// Nested control groups belong to the container.
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// containerRuntime returns the runtime of a container whose cgroup name is
+// only its ID, as created by the cgroupfs cgroup driver.
+func containerRuntime(fs procFS, id, cgroup string) string {
+	switch {
+	case filepath.Base(filepath.Dir(cgroup)) == "docker",
+		exists(fs.rootPath("var/lib/docker/containers", id)):
+		return "docker"
+	case len(containerdBundles(fs, id)) > 0:
+		return "containerd"
+	case exists(fs.rootPath("run/containers/storage/overlay-containers", id)),
+		exists(fs.rootPath("var/lib/containers/storage/overlay-containers", id)):
+		return "cri-o"
+	}
+	return ""
+}
+
+// containerdBundles returns the OCI bundles of a containerd task in all
+// namespaces.
+func containerdBundles(fs procFS, id string) []string {
+	bundles, _ := filepath.Glob(fs.rootPath("run/containerd/io.containerd.runtime.v2.task/*", id))
+	return bundles
+}
+
+// cgroupOwner returns the UID of the user slice that contains the control
+// group or nil if it is not in a user slice.
+func cgroupOwner(cgroup string) *int {
+	for _, elem := range strings.Split(filepath.ToSlash(cgroup), "/") {
+		if m := userSliceRegexp.FindStringSubmatch(elem); m != nil {
+			if uid, err := strconv.Atoi(m[1]); err == nil {
+				return &uid
+			}
+		}
+	}
+	return nil
+}
+
+// containerName returns the name of a container from the state of its
+// runtime. The Kubernetes container name is used for containers of pods.
+// The uid is set for rootless containers.
+func containerName(fs procFS, runtime, id string, uid *int) string {
+	switch runtime {
+	case "docker":
+		var config struct {
+			Name string `json:"Name"`
+		}
+		if readJSONFile(fs.rootPath("var/lib/docker/containers", id, "config.v2.json"), &config) == nil {
+			return strings.TrimPrefix(config.Name, "/")
+		}
+	case "containerd":
+		for _, bundle := range containerdBundles(fs, id) {
+			if name := ociAnnotation(filepath.Join(bundle, "config.json"), "io.kubernetes.cri.container-name"); name != "" {
+				return name
+			}
+		}
+	case "podman":
+		for _, dir := range podmanStorageDirs(fs, uid) {
+			if name := storageContainerName(filepath.Join(dir, "overlay-containers/containers.json"), id); name != "" {
+				return name
+			}
+		}
+	case "cri-o":
+		for _, dir := range []string{"run/containers/storage/overlay-containers", "var/lib/containers/storage/overlay-containers"} {
+			if name := ociAnnotation(fs.rootPath(dir, id, "userdata/config.json"), "io.kubernetes.container.name"); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// podmanStorageDirs returns the directories of the container storage used by
+// Podman. Rootless Podman keeps its runtime state under $XDG_RUNTIME_DIR
+// (/run/user/<uid>/containers) and its storage in the home directory of the
+// user.
+func podmanStorageDirs(fs procFS, uid *int) []string {
+	if uid == nil {
+		return []string{
+			fs.rootPath("run/containers/storage"),
+			fs.rootPath("var/lib/containers/storage"),
+		}
+	}
+
+	dirs := []string{fs.rootPath("run/user", strconv.Itoa(*uid), "containers")}
+	if home := homeDir(fs, *uid); home != "" {
+		dirs = append(dirs, fs.rootPath(home, ".local/share/containers/storage"))
+	}
+	return dirs
+}
+
+// storageContainerName returns the first name of a container in the
+// containers.json index of a container storage.
+func storageContainerName(path, id string) string {
+	var containers []struct {
+		ID    string   `json:"id"`
+		Names []string `json:"names"`
+	}
+	if err := readJSONFile(path, &containers); err != nil {
+		return ""
+	}
+	for _, c := range containers {
+		if c.ID == id && len(c.Names) > 0 {
+			return c.Names[0]
+		}
+	}
+	return ""
+}
+
+// homeDir returns the home directory of a user from /etc/passwd.
+func homeDir(fs procFS, uid int) string {
+	content, err := ioutil.ReadFile(fs.rootPath("etc/passwd"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		// name:password:uid:gid:gecos:home:shell
+		fields := strings.Split(line, ":")
+		if len(fields) == 7 && fields[2] == strconv.Itoa(uid) {
+			return fields[5]
+		}
+	}
+	return ""
+}
+
+// ociAnnotation returns an annotation of an OCI runtime configuration.
+func ociAnnotation(path, key string) string {
+	var config struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := readJSONFile(path, &config); err != nil {
+		return ""
+	}
+	return config.Annotations[key]
+}
+
+func readJSONFile(path string, v interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+// cgroupHierarchy locates the files of the cgroup controllers.
+type cgroupHierarchy struct {
+	root    string
+	unified bool // cgroup v2
+}
+
+func newCgroupHierarchy(fs procFS) cgroupHierarchy {
+	root := fs.rootPath(cgroupRoot)
+	return cgroupHierarchy{
+		root:    root,
+		unified: exists(filepath.Join(root, "cgroup.controllers")),
+	}
+}
+
+// dir returns the directory of a control group in the hierarchy of the
+// controller. The controller is ignored for cgroup v2.
+func (c cgroupHierarchy) dir(controller, cgroup string) string {
+	if c.unified {
+		return filepath.Join(c.root, cgroup)
+	}
+	return filepath.Join(c.root, controller, cgroup)
+}
+
+// container reads the processes and resource usage of a control group. It
+// returns false if the control group has no processes. The processes of
+// nested control groups are included if nested is true, which is needed for
+// system containers that run systemd and place their processes in units.
+func (c cgroupHierarchy) container(cgroup string, nested bool) (types.ContainerInfo, bool) {
+	info := types.ContainerInfo{Cgroup: "/" + filepath.ToSlash(cgroup)}
+
+	dir := c.dir("cpuacct", cgroup)
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil
+		case d.IsDir():
+			if path != dir && !nested {
+				return filepath.SkipDir
+			}
+			return nil
+		case d.Name() != "cgroup.procs":
+			return nil
+		}
+
+		procs, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		for _, f := range strings.Fields(string(procs)) {
+			if pid, err := strconv.Atoi(f); err == nil {
+				info.PIDs = append(info.PIDs, pid)
+			}
+		}
+		return nil
+	})
+	if len(info.PIDs) == 0 {
+		return info, false
+	}
+
+	// The statistics of the controllers that are not enabled are omitted.
+	if c.unified {
+		c.readV2Stats(cgroup, &info)
+	} else {
+		c.readV1Stats(cgroup, &info)
+	}
+	return info, true
+}
+
+func (c cgroupHierarchy) readV2Stats(cgroup string, info *types.ContainerInfo) {
+	dir := c.dir("", cgroup)
+
+	if content, err := ioutil.ReadFile(filepath.Join(dir, "cpu.stat")); err == nil {
+		_ = parseKeyValue(content, " ", func(key, value []byte) error {
+			usec, err := strconv.ParseUint(string(value), 10, 64)
+			if err != nil {
+				return nil
+			}
+			d := time.Duration(usec) * time.Microsecond
+			switch string(key) {
+			case "usage_usec":
+				info.CPU.Usage = d
+			case "user_usec":
+				info.CPU.User = d
+			case "system_usec":
+				info.CPU.System = d
+			case "throttled_usec":
+				info.CPU.Throttled = &d
+			}
+			return nil
+		})
+	}
+
+	info.Memory.Usage, _ = readUintFile(filepath.Join(dir, "memory.current"))
+	if v, err := readUintFile(filepath.Join(dir, "memory.max")); err == nil {
+		// The value is "max" when unlimited, which does not parse.
+		info.Memory.Limit = &v
+	}
+}
+
+func (c cgroupHierarchy) readV1Stats(cgroup string, info *types.ContainerInfo) {
+	cpuacct := c.dir("cpuacct", cgroup)
+	if ns, err := readUintFile(filepath.Join(cpuacct, "cpuacct.usage")); err == nil {
+		info.CPU.Usage = time.Duration(ns)
+	}
+	if content, err := ioutil.ReadFile(filepath.Join(cpuacct, "cpuacct.stat")); err == nil {
+		_ = parseKeyValue(content, " ", func(key, value []byte) error {
+			ticks, err := strconv.ParseUint(string(value), 10, 64)
+			if err != nil {
+				return nil
+			}
+			switch string(key) {
+			case "user":
+				info.CPU.User = ticksToDuration(ticks)
+			case "system":
+				info.CPU.System = ticksToDuration(ticks)
+			}
+			return nil
+		})
+	}
+	if content, err := ioutil.ReadFile(filepath.Join(c.dir("cpu", cgroup), "cpu.stat")); err == nil {
+		_ = parseKeyValue(content, " ", func(key, value []byte) error {
+			if string(key) == "throttled_time" {
+				if ns, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+					d := time.Duration(ns)
+					info.CPU.Throttled = &d
+				}
+			}
+			return nil
+		})
+	}
+
+	memory := c.dir("memory", cgroup)
+	info.Memory.Usage, _ = readUintFile(filepath.Join(memory, "memory.usage_in_bytes"))
+	if v, err := readUintFile(filepath.Join(memory, "memory.limit_in_bytes")); err == nil && v < cgroupV1Unlimited {
+		info.Memory.Limit = &v
+	}
+}
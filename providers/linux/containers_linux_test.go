@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_containers
+// +build !nosysinfo_containers
+
+package linux
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Containers = (*host)(nil)
+
+func TestContainersCgroupV2(t *testing.T) {
+	infos, err := containers(newLinuxSystem("testdata/containers_v2").procFS)
+	require.NoError(t, err)
+
+	limit := uint64(512 << 20)
+	throttled := 250 * time.Microsecond
+	uid := 1000
+	nspawnLimit := uint64(1 << 30)
+	assert.Equal(t, []types.ContainerInfo{
+		{
+			ID:      strings.Repeat("b", 63) + "2",
+			Runtime: "containerd",
+			Name:    "nginx",
+			Cgroup:  "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1234.slice/cri-containerd-" + strings.Repeat("b", 63) + "2.scope",
+			PIDs:    []int{2000},
+			CPU:     types.ContainerCPU{Usage: time.Millisecond, User: 600 * time.Microsecond, System: 400 * time.Microsecond, Throttled: &throttled},
+			Memory:  types.ContainerMemory{Usage: 4096, Limit: &limit},
+		},
+		{
+			ID:      "web",
+			Runtime: "lxd",
+			Name:    "web",
+			Cgroup:  "/lxc.payload.web",
+			PIDs:    []int{7000, 7100, 7101},
+			CPU:     types.ContainerCPU{Usage: 5 * time.Millisecond, User: 4 * time.Millisecond, System: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 1 << 20},
+		},
+		{
+			ID:      strings.Repeat("f", 63) + "6",
+			Runtime: "podman",
+			Name:    "db",
+			Cgroup:  "/machine.slice/libpod-" + strings.Repeat("f", 63) + "6.scope",
+			PIDs:    []int{5000},
+			CPU:     types.ContainerCPU{Usage: 3 * time.Millisecond, User: 2 * time.Millisecond, System: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 64 << 10},
+		},
+		{
+			ID:      "arch",
+			Runtime: "systemd-nspawn",
+			Name:    "arch",
+			Cgroup:  "/machine.slice/machine-arch.scope",
+			PIDs:    []int{8000},
+			CPU:     types.ContainerCPU{Usage: 6 * time.Millisecond, User: 5 * time.Millisecond, System: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 2 << 20},
+		},
+		{
+			ID:      "debian",
+			Runtime: "systemd-nspawn",
+			Name:    "debian",
+			Cgroup:  "/machine.slice/systemd-nspawn@debian.service/payload",
+			PIDs:    []int{8200, 8300},
+			CPU:     types.ContainerCPU{Usage: 7 * time.Millisecond, User: 6 * time.Millisecond, System: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 3 << 20, Limit: &nspawnLimit},
+		},
+		{
+			ID:      strings.Repeat("a", 63) + "1",
+			Runtime: "docker",
+			Name:    "web",
+			Cgroup:  "/system.slice/docker-" + strings.Repeat("a", 63) + "1.scope",
+			PIDs:    []int{1234, 1240},
+			CPU:     types.ContainerCPU{Usage: 2500 * time.Millisecond, User: 2 * time.Second, System: 500 * time.Millisecond, Throttled: new(time.Duration)},
+			Memory:  types.ContainerMemory{Usage: 10 << 20},
+		},
+		{
+			ID:      strings.Repeat("7", 64),
+			Runtime: "podman",
+			Name:    "toolbox",
+			Cgroup:  "/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-" + strings.Repeat("7", 64) + ".scope",
+			UID:     &uid,
+			PIDs:    []int{6000},
+			CPU:     types.ContainerCPU{Usage: 4 * time.Millisecond, User: 3 * time.Millisecond, System: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 128 << 10},
+		},
+	}, infos)
+}
+
+func TestContainersCgroupV1(t *testing.T) {
+	infos, err := containers(newLinuxSystem("testdata/containers_v1").procFS)
+	require.NoError(t, err)
+
+	limit := uint64(256 << 20)
+	throttled := 5 * time.Millisecond
+	assert.Equal(t, []types.ContainerInfo{
+		{
+			ID:      strings.Repeat("c", 63) + "3",
+			Runtime: "docker",
+			Cgroup:  "/docker/" + strings.Repeat("c", 63) + "3",
+			PIDs:    []int{300},
+			CPU:     types.ContainerCPU{Usage: 3 * time.Second, User: 2 * time.Second, System: time.Second, Throttled: &throttled},
+			Memory:  types.ContainerMemory{Usage: 20 << 20},
+		},
+		{
+			ID:      strings.Repeat("d", 63) + "4",
+			Runtime: "cri-o",
+			Name:    "redis",
+			Cgroup:  "/kubepods/besteffort/pod5678/" + strings.Repeat("d", 63) + "4",
+			PIDs:    []int{400, 401},
+			CPU:     types.ContainerCPU{Usage: time.Microsecond},
+			Memory:  types.ContainerMemory{Usage: 8192, Limit: &limit},
+		},
+		{
+			ID:      "old",
+			Runtime: "lxc",
+			Name:    "old",
+			Cgroup:  "/lxc/old",
+			PIDs:    []int{500},
+			CPU:     types.ContainerCPU{Usage: time.Millisecond},
+			Memory:  types.ContainerMemory{Usage: 4096},
+		},
+	}, infos)
+}
+
+func TestUnescapeSystemdName(t *testing.T) {
+	assert.Equal(t, "debian", unescapeSystemdName("debian"))
+	assert.Equal(t, "qemu-1-win", unescapeSystemdName(`qemu\x2d1\x2dwin`))
+	assert.Equal(t, `bad\xzz`, unescapeSystemdName(`bad\xzz`))
+	assert.Equal(t, `end\x2`, unescapeSystemdName(`end\x2`))
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// ContextSwitches reports the voluntary and involuntary context switches
+// from /proc/<pid>/task/*/status summed over all threads.
+func (p *process) ContextSwitches() (*types.ContextSwitchInfo, error) {
+	return processContextSwitches(p.fs, p.PID())
+}
+
+func processContextSwitches(fs procFS, pid int) (*types.ContextSwitchInfo, error) {
+	tasks, err := processTasks(fs, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	var voluntary, involuntary uint64
+	var found bool
+	for _, task := range tasks {
+		content, err := ioutil.ReadFile(filepath.Join(task, "status"))
+		if err != nil {
+			// Threads can exit while they are being read.
+			continue
+		}
+
+		err = parseKeyValue(content, ":", func(key, value []byte) error {
+			var counter *uint64
+			switch string(key) {
+			case "voluntary_ctxt_switches":
+				counter = &voluntary
+			case "nonvoluntary_ctxt_switches":
+				counter = &involuntary
+			default:
+				return nil
+			}
+
+			v, err := strconv.ParseUint(string(value), 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse %v: %w", string(key), err)
+			}
+			*counter += v
+			found = true
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %v: %w", filepath.Join(task, "status"), err)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context switches of process %d not found: %w", pid, types.ErrNotImplemented)
+	}
+
+	return &types.ContextSwitchInfo{
+		Voluntary:   &voluntary,
+		Involuntary: &involuntary,
+		Total:       voluntary + involuntary,
+	}, nil
+}
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ContextSwitches = (*process)(nil)
+
+func TestProcessContextSwitches(t *testing.T) {
+	fs := newLinuxSystem("testdata/context_switches").procFS
+
+	info, err := processContextSwitches(fs, 42)
+	require.NoError(t, err)
+
+	require.NotNil(t, info.Voluntary)
+	assert.EqualValues(t, 3125, *info.Voluntary)
+	require.NotNil(t, info.Involuntary)
+	assert.EqualValues(t, 95, *info.Involuntary)
+	assert.EqualValues(t, 3220, info.Total)
+
+	_, err = processContextSwitches(fs, 7)
+	assert.Error(t, err)
+}
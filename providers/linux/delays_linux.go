@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// statBlkIOTicksField is the index of the delayacct_blkio_ticks field of
+// /proc/<pid>/stat counting from the state field that follows the command.
+const statBlkIOTicksField = 39
+
+// Delays reports the run queue delay from /proc/<pid>/task/*/schedstat and
+// the block I/O delay from /proc/<pid>/task/*/stat summed over all threads.
+// The same counters are available through the taskstats netlink interface,
+// but it requires CAP_NET_ADMIN. The block I/O delay is only reported when
+// delay accounting is enabled (kernel.task_delayacct or, before Linux 5.14,
+// unless booted with nodelayacct).
+func (p *process) Delays() (*types.DelayInfo, error) {
+	return processDelays(p.fs, p.PID())
+}
+
+func processDelays(fs procFS, pid int) (*types.DelayInfo, error) {
+	tasks, err := processTasks(fs, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		runDelay, timeslices, blkIOTicks uint64
+		haveSchedstat, haveBlkIO         bool
+	)
+	for _, task := range tasks {
+		// Threads can exit while they are being read.
+		if content, err := ioutil.ReadFile(filepath.Join(task, "schedstat")); err == nil {
+			_, delay, slices, err := parseSchedstat(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %v: %w", filepath.Join(task, "schedstat"), err)
+			}
+			runDelay += delay
+			timeslices += slices
+			haveSchedstat = true
+		}
+		if content, err := ioutil.ReadFile(filepath.Join(task, "stat")); err == nil {
+			if ticks, err := parseStatBlkIOTicks(content); err == nil {
+				blkIOTicks += ticks
+				haveBlkIO = true
+			}
+		}
+	}
+
+	info := &types.DelayInfo{}
+	if haveSchedstat {
+		cpu := time.Duration(runDelay)
+		info.CPU = &cpu
+		info.Timeslices = &timeslices
+	}
+	if haveBlkIO && delayAccountingEnabled(fs) {
+		blkIO := shared.TicksToDuration(blkIOTicks, userHz)
+		info.BlockIO = &blkIO
+	}
+	return info, nil
+}
+
+// processTasks returns the /proc/<pid>/task/<tid> directories of the threads
+// of a process. The process directory is returned when the task directory
+// is not available.
+func processTasks(fs procFS, pid int) ([]string, error) {
+	dir := fs.path(strconv.Itoa(pid))
+	tasks, err := filepath.Glob(filepath.Join(dir, "task", "*"))
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+		tasks = []string{dir}
+	}
+	return tasks, nil
+}
+
+// parseSchedstat parses /proc/<pid>/schedstat which contains the time spent
+// on the CPU and waiting on a run queue in nanoseconds and the number of
+// timeslices.
+func parseSchedstat(content []byte) (running, waiting, timeslices uint64, err error) {
+	fields := bytes.Fields(content)
+	if len(fields) < 3 {
+		return 0, 0, 0, fmt.Errorf("expected 3 fields but got %d", len(fields))
+	}
+
+	values := make([]uint64, 3)
+	for i := range values {
+		if values[i], err = strconv.ParseUint(string(fields[i]), 10, 64); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// parseStatBlkIOTicks returns the delayacct_blkio_ticks field of
+// /proc/<pid>/stat.
+func parseStatBlkIOTicks(content []byte) (uint64, error) {
+	// The command can contain spaces and parentheses.
+	end := bytes.LastIndexByte(content, ')')
+	if end < 0 {
+		return 0, fmt.Errorf("command not found in stat")
+	}
+
+	fields := bytes.Fields(content[end+1:])
+	if len(fields) <= statBlkIOTicksField {
+		return 0, fmt.Errorf("delayacct_blkio_ticks not found in stat")
+	}
+	return strconv.ParseUint(string(fields[statBlkIOTicksField]), 10, 64)
+}
+
+// delayAccountingEnabled reports whether the kernel accounts for block I/O
+// delays. Delay accounting is enabled by default on kernels that predate the
+// kernel.task_delayacct sysctl.
+func delayAccountingEnabled(fs procFS) bool {
+	v, err := readFileString(fs.path("sys/kernel/task_delayacct"))
+	return err != nil || v != "0"
+}
@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Delays = (*process)(nil)
+
+func TestProcessDelays(t *testing.T) {
+	fs := newLinuxSystem("testdata/delays").procFS
+
+	info, err := processDelays(fs, 42)
+	require.NoError(t, err)
+
+	require.NotNil(t, info.CPU)
+	assert.Equal(t, 1750*time.Millisecond, *info.CPU)
+	require.NotNil(t, info.Timeslices)
+	assert.EqualValues(t, 1300, *info.Timeslices)
+	require.NotNil(t, info.BlockIO)
+	assert.Equal(t, 1750*time.Millisecond, *info.BlockIO)
+	assert.Nil(t, info.WaitReasons)
+
+	_, err = processDelays(fs, 7)
+	assert.Error(t, err)
+}
+
+func TestParseStatBlkIOTicks(t *testing.T) {
+	ticks, err := parseStatBlkIOTicks([]byte("1 (a) b) S 0 1 1 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 5 0 0 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 9 0 0"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, ticks)
+
+	// Kernels before 2.6.18 do not report the field.
+	_, err = parseStatBlkIOTicks([]byte("1 (init) S 0 1 1 0 -1 256 0 0 0 0"))
+	assert.Error(t, err)
+}
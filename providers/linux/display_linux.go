@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// logindSessionsDir contains the state of the logind sessions.
+const logindSessionsDir = "run/systemd/sessions"
+
+// DisplaySession reports the type of the session that is active on seat0.
+// When systemd-logind is not in use the display server sockets are used to
+// detect a graphical session. The host is reported as headless if no
+// graphical session is found.
+func (h *host) DisplaySession() (*types.DisplaySessionInfo, error) {
+	return displaySession(h.procFS)
+}
+
+func displaySession(fs procFS) (*types.DisplaySessionInfo, error) {
+	info := &types.DisplaySessionInfo{}
+
+	session, err := activeSeatSession(fs)
+	if err != nil && !errors.Is(err, types.ErrNotImplemented) {
+		return nil, err
+	}
+	if session != "" {
+		content, err := ioutil.ReadFile(filepath.Join(fs.rootPath(logindSessionsDir), session))
+		if err != nil {
+			return nil, err
+		}
+		info.SessionID = session
+		_ = parseKeyValue(content, "=", func(key, value []byte) error {
+			switch string(key) {
+			case "TYPE":
+				info.Type = string(value)
+			case "DESKTOP":
+				info.Desktop = string(value)
+			}
+			return nil
+		})
+		if info.Type == "unspecified" {
+			info.Type = ""
+		}
+	}
+
+	if !info.Graphical() {
+		if t := displayServerSocketType(fs); t != "" {
+			info.Type = t
+		}
+	}
+	info.Headless = !info.Graphical()
+	return info, nil
+}
+
+// displayServerSocketType returns the type of the display server whose
+// socket is found. Wayland is checked first because Xwayland also creates
+// X11 sockets.
+func displayServerSocketType(fs procFS) string {
+	sockets, _ := filepath.Glob(fs.rootPath("run/user/*/wayland-[0-9]*"))
+	for _, s := range sockets {
+		if !strings.HasSuffix(s, ".lock") {
+			return types.DisplaySessionWayland
+		}
+	}
+
+	if sockets, _ := filepath.Glob(fs.rootPath("tmp/.X11-unix/X[0-9]*")); len(sockets) > 0 {
+		return types.DisplaySessionX11
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	resolvConf          = "etc/resolv.conf"
+	resolvedResolvConf  = "run/systemd/resolve/resolv.conf"
+	resolvedNetifDir    = "run/systemd/resolve/netif"
+	resolvedStubAddress = "127.0.0.53"
+)
+
+// DNSConfig reports the nameservers and search domains of /etc/resolv.conf.
+// When it points at the stub resolver of systemd-resolved, the upstream
+// nameservers are read from the resolv.conf maintained by systemd-resolved
+// instead, and the per-interface settings (e.g. from DHCP or a VPN) from its
+// runtime state in /run/systemd/resolve/netif.
+func (h *host) DNSConfig() (*types.DNSConfigInfo, error) {
+	return dnsConfig(h.procFS, shared.InterfaceName)
+}
+
+func dnsConfig(fs procFS, interfaceName func(int) string) (*types.DNSConfigInfo, error) {
+	content, err := ioutil.ReadFile(fs.rootPath(resolvConf))
+	if err != nil {
+		return nil, err
+	}
+	info := parseResolvConf(content)
+
+	if len(info.Nameservers) == 1 && info.Nameservers[0] == resolvedStubAddress {
+		if content, err := ioutil.ReadFile(fs.rootPath(resolvedResolvConf)); err == nil {
+			upstream := parseResolvConf(content)
+			info.Nameservers = upstream.Nameservers
+			if len(upstream.Search) > 0 {
+				info.Search = upstream.Search
+			}
+		}
+		if info.Interfaces, err = resolvedInterfaces(fs, interfaceName); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// parseResolvConf parses the nameserver, search, and domain options of a
+// resolv.conf file. Like in glibc, the last search or domain option wins.
+func parseResolvConf(content []byte) *types.DNSConfigInfo {
+	info := &types.DNSConfigInfo{Nameservers: []string{}}
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], ";") {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			info.Nameservers = append(info.Nameservers, fields[1])
+		case "search":
+			info.Search = fields[1:]
+		case "domain":
+			info.Search = fields[1:2]
+		}
+	}
+	return info
+}
+
+// resolvedInterfaces reads the DNS settings of the interfaces from the
+// runtime state of systemd-resolved. The state files are named after the
+// interface index.
+func resolvedInterfaces(fs procFS, interfaceName func(int) string) ([]types.InterfaceDNS, error) {
+	files, err := filepath.Glob(filepath.Join(fs.rootPath(resolvedNetifDir), "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	type indexed struct {
+		index int
+		dns   types.InterfaceDNS
+	}
+	var links []indexed
+	for _, f := range files {
+		index, err := strconv.Atoi(filepath.Base(f))
+		if err != nil {
+			continue
+		}
+		content, err := ioutil.ReadFile(f)
+		if err != nil {
+			continue
+		}
+
+		dns := types.InterfaceDNS{Interface: interfaceName(index)}
+		_ = parseKeyValue(content, "=", func(key, value []byte) error {
+			switch string(key) {
+			case "SERVERS":
+				for _, server := range strings.Fields(string(value)) {
+					// Servers can have a port and a name for DNS-over-TLS
+					// (e.g. 1.1.1.1:853#cloudflare-dns.com).
+					server = strings.SplitN(server, "#", 2)[0]
+					if host, _, err := net.SplitHostPort(server); err == nil {
+						server = host
+					}
+					dns.Nameservers = append(dns.Nameservers, server)
+				}
+			case "DOMAINS":
+				dns.Search = strings.Fields(string(value))
+			}
+			return nil
+		})
+		if len(dns.Nameservers) > 0 {
+			links = append(links, indexed{index, dns})
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool { return links[i].index < links[j].index })
+	var interfaces []types.InterfaceDNS
+	for _, l := range links {
+		interfaces = append(interfaces, l.dns)
+	}
+	return interfaces, nil
+}
@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.DNSConfig = (*host)(nil)
+
+func TestDNSConfig(t *testing.T) {
+	info, err := dnsConfig(newLinuxSystem("testdata/dns").procFS, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DNSConfigInfo{
+		Nameservers: []string{"192.168.1.1", "2001:db8::53"},
+		Search:      []string{"example.com", "corp.example.com"},
+	}, info)
+}
+
+func TestDNSConfigResolved(t *testing.T) {
+	names := func(index int) string { return map[int]string{2: "eth0", 5: "tun0"}[index] }
+	info, err := dnsConfig(newLinuxSystem("testdata/dns_resolved").procFS, names)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DNSConfigInfo{
+		Nameservers: []string{"192.168.1.1", "10.8.0.1"},
+		Search:      []string{"lan", "corp.example.com"},
+		Interfaces: []types.InterfaceDNS{
+			{Interface: "eth0", Nameservers: []string{"192.168.1.1"}, Search: []string{"lan"}},
+			{Interface: "tun0", Nameservers: []string{"10.8.0.1", "10.8.0.2"}, Search: []string{"corp.example.com"}},
+		},
+	}, info)
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	sssdConf       = "etc/sssd/sssd.conf"
+	sssdPubconfDir = "var/lib/sss/pubconf"
+	sambaConf      = "etc/samba/smb.conf"
+	krb5Conf       = "etc/krb5.conf"
+	krb5Keytab     = "etc/krb5.keytab"
+)
+
+// Clients that manage the domain membership of Linux hosts.
+const (
+	domainClientSSSD     = "sssd"
+	domainClientWinbind  = "winbind"
+	domainClientKerberos = "kerberos"
+)
+
+// domain detects the membership of the host in an Active Directory domain or
+// a Kerberos realm (e.g. FreeIPA) from the configuration of SSSD, winbind,
+// and Kerberos. This covers the joins made by realmd, which configures SSSD
+// or winbind. Files that cannot be read (sssd.conf is only readable by root)
+// are skipped.
+func domain(fs procFS) *types.DomainInfo {
+	for _, detect := range []struct {
+		client string
+		name   func(procFS) string
+	}{
+		{domainClientSSSD, sssdDomain},
+		{domainClientWinbind, winbindDomain},
+		{domainClientKerberos, kerberosRealm},
+	} {
+		if name := detect.name(fs); name != "" {
+			return &types.DomainInfo{
+				JoinType: types.DomainJoinDomain,
+				Name:     name,
+				Client:   detect.client,
+			}
+		}
+	}
+	return &types.DomainInfo{JoinType: types.DomainJoinNone}
+}
+
+// sssdDomain returns the first AD or IPA domain that is enabled in
+// sssd.conf. When sssd.conf cannot be read, the realm is taken from the KDC
+// info files that SSSD publishes for the Kerberos libraries.
+func sssdDomain(fs procFS) string {
+	content, err := ioutil.ReadFile(fs.rootPath(sssdConf))
+	if err != nil {
+		kdcInfos, _ := filepath.Glob(filepath.Join(fs.rootPath(sssdPubconfDir), "kdcinfo.*"))
+		if len(kdcInfos) == 0 {
+			return ""
+		}
+		return strings.TrimPrefix(filepath.Base(kdcInfos[0]), "kdcinfo.")
+	}
+
+	conf := parseINI(content)
+	for _, name := range strings.Split(conf["sssd"]["domains"], ",") {
+		name = strings.TrimSpace(name)
+		section, found := conf["domain/"+name]
+		if name == "" || !found {
+			continue
+		}
+		switch section["id_provider"] {
+		case "ad":
+			if v := section["ad_domain"]; v != "" {
+				return v
+			}
+		case "ipa":
+			if v := section["ipa_domain"]; v != "" {
+				return v
+			}
+		default:
+			continue
+		}
+		if v := section["krb5_realm"]; v != "" {
+			return v
+		}
+		return name
+	}
+	return ""
+}
+
+// winbindDomain returns the realm or domain that Samba is a member of.
+func winbindDomain(fs procFS) string {
+	content, err := ioutil.ReadFile(fs.rootPath(sambaConf))
+	if err != nil {
+		return ""
+	}
+
+	global := parseINI(content)["global"]
+	switch strings.ToLower(global["security"]) {
+	case "ads":
+		return global["realm"]
+	case "domain":
+		return global["workgroup"]
+	}
+	return ""
+}
+
+// kerberosRealm returns the default realm of krb5.conf if the host has a
+// keytab, i.e. it has been joined to the realm and not only configured as a
+// client of it.
+func kerberosRealm(fs procFS) string {
+	if _, err := os.Stat(fs.rootPath(krb5Keytab)); err != nil {
+		return ""
+	}
+	content, err := ioutil.ReadFile(fs.rootPath(krb5Conf))
+	if err != nil {
+		return ""
+	}
+	return parseINI(content)["libdefaults"]["default_realm"]
+}
+
+// parseINI parses the sections of an INI file such as sssd.conf, smb.conf,
+// or krb5.conf. Keys are lower-cased since Samba treats them case
+// insensitively.
+func parseINI(content []byte) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	var section map[string]string
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if section = sections[name]; section == nil {
+				section = map[string]string{}
+				sections[name] = section
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || section == nil {
+			continue
+		}
+		section[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return sections
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestDomain(t *testing.T) {
+	for _, tc := range []struct {
+		root     string
+		expected types.DomainInfo
+	}{
+		{"testdata/domain_sssd", types.DomainInfo{JoinType: types.DomainJoinDomain, Name: "example.com", Client: "sssd"}},
+		{"testdata/domain_sssd_pubconf", types.DomainInfo{JoinType: types.DomainJoinDomain, Name: "IPA.EXAMPLE.ORG", Client: "sssd"}},
+		{"testdata/domain_winbind", types.DomainInfo{JoinType: types.DomainJoinDomain, Name: "CORP.EXAMPLE.NET", Client: "winbind"}},
+		{"testdata/domain_kerberos", types.DomainInfo{JoinType: types.DomainJoinDomain, Name: "LAB.EXAMPLE", Client: "kerberos"}},
+		{"testdata/dns", types.DomainInfo{JoinType: types.DomainJoinNone}},
+	} {
+		t.Run(tc.root, func(t *testing.T) {
+			info := domain(newLinuxSystem(tc.root).procFS)
+			assert.Equal(t, tc.expected, *info)
+		})
+	}
+}
+
+func TestParseINI(t *testing.T) {
+	conf := parseINI([]byte("top = ignored\n[global]\n  Key = a = b\n; comment\n# comment\n[global]\nother=1\n"))
+	assert.Equal(t, map[string]map[string]string{
+		"global": {"key": "a = b", "other": "1"},
+	}, conf)
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Entropy reports the entropy pool status from /proc/sys/kernel/random and
+// probes getrandom(2) with GRND_NONBLOCK to determine whether reading random
+// data would block.
+func (h *host) Entropy() (*types.EntropyInfo, error) {
+	info, err := entropy(h.procFS)
+	if err != nil {
+		return nil, err
+	}
+	info.Blocking = getrandomBlocks()
+	return info, nil
+}
+
+func entropy(fs procFS) (*types.EntropyInfo, error) {
+	available, err := readUintFile(fs.path("sys/kernel/random/entropy_avail"))
+	if err != nil {
+		return nil, err
+	}
+	info := &types.EntropyInfo{Available: available}
+
+	// The pool size is fixed at 256 bits since Linux 5.18 but the file is
+	// still present.
+	poolSize, err := readUintFile(fs.path("sys/kernel/random/poolsize"))
+	switch {
+	case err == nil:
+		info.PoolSize = &poolSize
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+	return info, nil
+}
+
+// getrandomBlocks reports whether getrandom(2) would block. It returns nil
+// when the syscall is unavailable.
+func getrandomBlocks() *bool {
+	var buf [1]byte
+	_, err := unix.Getrandom(buf[:], unix.GRND_NONBLOCK)
+	var blocks bool
+	switch {
+	case err == nil:
+	case errors.Is(err, unix.EAGAIN):
+		blocks = true
+	default:
+		return nil
+	}
+	return &blocks
+}
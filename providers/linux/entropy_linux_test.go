@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Entropy = (*host)(nil)
+
+func TestEntropy(t *testing.T) {
+	info, err := entropy(newLinuxSystem("testdata/entropy").procFS)
+	require.NoError(t, err)
+
+	poolSize := uint64(256)
+	assert.Equal(t, &types.EntropyInfo{Available: 256, PoolSize: &poolSize}, info)
+}
+
+func TestGetrandomBlocks(t *testing.T) {
+	blocks := getrandomBlocks()
+	if blocks == nil {
+		t.Skip("getrandom is not available")
+	}
+	// The pool is initialized long before tests run.
+	assert.False(t, *blocks)
+}
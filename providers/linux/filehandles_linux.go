@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// FileHandles reports the allocated and maximum number of file handles from
+// /proc/sys/fs/file-nr.
+func (h *host) FileHandles() (*types.FileHandlesInfo, error) {
+	content, err := ioutil.ReadFile(h.procFS.path("sys/fs/file-nr"))
+	if err != nil {
+		return nil, err
+	}
+	return parseFileNr(string(content))
+}
+
+// parseFileNr parses the content of file-nr, which holds the number of
+// allocated file handles, the number of allocated but unused file handles
+// (always 0 since Linux 2.6), and the maximum number of file handles.
+func parseFileNr(content string) (*types.FileHandlesInfo, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected file-nr format: %q", content)
+	}
+
+	allocated, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allocated file handles: %w", err)
+	}
+	unused, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse unused file handles: %w", err)
+	}
+	maximum, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse maximum file handles: %w", err)
+	}
+	return &types.FileHandlesInfo{Allocated: allocated - unused, Maximum: &maximum}, nil
+}
@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.FileHandles = (*host)(nil)
+
+func TestParseFileNr(t *testing.T) {
+	info, err := parseFileNr("10624\t0\t9223372036854775807\n")
+	require.NoError(t, err)
+	maximum := uint64(9223372036854775807)
+	assert.Equal(t, &types.FileHandlesInfo{Allocated: 10624, Maximum: &maximum}, info)
+
+	_, err = parseFileNr("10624\t0\n")
+	assert.Error(t, err)
+}
+
+func TestFileHandles(t *testing.T) {
+	h, err := newLinuxSystem("").Host()
+	require.NoError(t, err)
+
+	info, err := h.(types.FileHandles).FileHandles()
+	require.NoError(t, err)
+	assert.NotZero(t, info.Allocated)
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Firewall reports the firewall managers that are installed: ufw and its
+// ENABLED setting, firewalld and whether its daemon is running, and the
+// nftables service and whether it is enabled in systemd.
+func (h *host) Firewall() (*types.FirewallInfo, error) {
+	return firewallInfo(h.procFS), nil
+}
+
+func firewallInfo(fs procFS) *types.FirewallInfo {
+	info := &types.FirewallInfo{Firewalls: []types.FirewallStatus{}}
+	add := func(name string, enabled bool) {
+		info.Firewalls = append(info.Firewalls, types.FirewallStatus{Name: name, Enabled: enabled})
+		info.Enabled = info.Enabled || enabled
+	}
+
+	if content, err := ioutil.ReadFile(fs.rootPath("etc/ufw/ufw.conf")); err == nil {
+		var enabled bool
+		_ = parseKeyValue(content, "=", func(key, value []byte) error {
+			if string(key) == "ENABLED" {
+				enabled = strings.Trim(string(value), `"'`) == "yes"
+			}
+			return nil
+		})
+		add(types.FirewallUFW, enabled)
+	}
+
+	if exists(fs.rootPath("usr/sbin/firewalld")) || exists(fs.rootPath("etc/firewalld")) {
+		add(types.FirewallFirewalld, firewalldRunning(fs))
+	}
+
+	if exists(fs.rootPath("usr/sbin/nft")) || exists(fs.rootPath("sbin/nft")) {
+		add(types.FirewallNftables, systemdUnitEnabled(fs, "nftables.service"))
+	}
+	return info
+}
+
+// firewalldRunning returns whether the process in the PID file of firewalld
+// is running.
+func firewalldRunning(fs procFS) bool {
+	for _, pidFile := range []string{"run/firewalld.pid", "var/run/firewalld.pid"} {
+		pid, err := readFileString(fs.rootPath(pidFile))
+		if err != nil {
+			continue
+		}
+		comm, err := readFileString(fs.path(pid, "comm"))
+		return err == nil && strings.HasPrefix(comm, "firewalld")
+	}
+	return false
+}
+
+// systemdUnitEnabled returns whether a systemd unit is enabled, which links
+// it into the .wants directory of a target.
+func systemdUnitEnabled(fs procFS, unit string) bool {
+	matches, _ := filepath.Glob(filepath.Join(fs.rootPath("etc/systemd/system"), "*.wants", unit))
+	return len(matches) > 0
+}
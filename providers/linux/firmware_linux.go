@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// secureBootVar is the EFI variable holding the Secure Boot state.
+const secureBootVar = "SecureBoot-8be4df61-93ca-11d2-aa0d-00e098032b8c"
+
+func firmwareInfo(fs procFS) (*types.FirmwareInfo, error) {
+	dmi := fs.rootPath("sys/class/dmi/id")
+	efi := fs.rootPath("sys/firmware/efi")
+	if !exists(dmi) && !exists(efi) {
+		return nil, fmt.Errorf("firmware information is not available: %w", types.ErrNotImplemented)
+	}
+
+	read := func(name string) string {
+		v, _ := readFileString(filepath.Join(dmi, name))
+		return shared.CleanSMBIOSString(v)
+	}
+
+	info := &types.FirmwareInfo{
+		Vendor:      read("bios_vendor"),
+		Version:     read("bios_version"),
+		ReleaseDate: read("bios_date"),
+		BootMode:    types.BootModeLegacy,
+	}
+
+	if exists(efi) {
+		info.BootMode = types.BootModeUEFI
+
+		// The variable contains 4 bytes of attributes followed by the value.
+		if data, err := ioutil.ReadFile(filepath.Join(efi, "efivars", secureBootVar)); err == nil && len(data) >= 5 {
+			enabled := data[4] == 1
+			info.SecureBoot = &enabled
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,289 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// drmCardRegexp matches DRM card devices (e.g. card0) but not their
+// connectors (e.g. card0-HDMI-A-1).
+var drmCardRegexp = regexp.MustCompile(`^card[0-9]+$`)
+
+// pciIDsFiles are the possible locations of the pci.ids database. These will
+// be searched in order.
+var pciIDsFiles = []string{
+	"usr/share/hwdata/pci.ids",
+	"usr/share/misc/pci.ids",
+	"usr/share/pci.ids",
+}
+
+// GPUs reports the graphics processors registered with the DRM subsystem.
+// Memory and utilization metrics are reported for drivers that expose them
+// through sysfs (e.g. amdgpu). The models of devices driven by the
+// proprietary NVIDIA driver are read from /proc/driver/nvidia and their
+// metrics from NVML through nvidia-smi. The metrics are left nil when
+// nvidia-smi is not installed.
+func (h *host) GPUs() ([]types.GPUInfo, error) {
+	return gpus(h.procFS)
+}
+
+func gpus(fs procFS) ([]types.GPUInfo, error) {
+	cards, err := filepath.Glob(fs.rootPath("sys/class/drm/card*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var gpus []types.GPUInfo
+	for _, card := range cards {
+		if !drmCardRegexp.MatchString(filepath.Base(card)) {
+			continue
+		}
+
+		gpu, err := readDRMDevice(filepath.Join(card, "device"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %v: %w", card, err)
+		}
+		gpus = append(gpus, *gpu)
+	}
+
+	gpus = addNvidiaGPUs(fs, gpus)
+	addNvidiaMetrics(gpus)
+	resolvePCINames(fs, gpus)
+	return gpus, nil
+}
+
+func readDRMDevice(dir string) (*types.GPUInfo, error) {
+	var gpu types.GPUInfo
+
+	vendor, err := readFileString(filepath.Join(dir, "vendor"))
+	if err != nil {
+		return nil, err
+	}
+	gpu.VendorID = vendor
+	gpu.DeviceID, _ = readFileString(filepath.Join(dir, "device"))
+
+	if uevent, err := ioutil.ReadFile(filepath.Join(dir, "uevent")); err == nil {
+		_ = parseKeyValue(uevent, "=", func(key, value []byte) error {
+			switch string(key) {
+			case "DRIVER":
+				gpu.Driver = string(value)
+			case "PCI_SLOT_NAME":
+				gpu.BusID = string(value)
+			}
+			return nil
+		})
+	}
+
+	// The following attributes are provided by the amdgpu driver.
+	if v, err := readUintFile(filepath.Join(dir, "mem_info_vram_total")); err == nil {
+		gpu.MemoryTotal = v
+	}
+	if v, err := readUintFile(filepath.Join(dir, "mem_info_vram_used")); err == nil {
+		gpu.MemoryUsed = &v
+	}
+	if v, err := readUintFile(filepath.Join(dir, "gpu_busy_percent")); err == nil {
+		pct := float64(v)
+		gpu.Utilization = &pct
+	}
+	if v, err := readFileString(filepath.Join(dir, "product_name")); err == nil {
+		gpu.Model = v
+	}
+
+	return &gpu, nil
+}
+
+// addNvidiaGPUs adds or enriches the GPUs managed by the proprietary NVIDIA
+// driver, which only registers a DRM device when modesetting is enabled.
+func addNvidiaGPUs(fs procFS, gpus []types.GPUInfo) []types.GPUInfo {
+	infos, _ := filepath.Glob(fs.path("driver/nvidia/gpus/*/information"))
+	for _, info := range infos {
+		content, err := ioutil.ReadFile(info)
+		if err != nil {
+			continue
+		}
+
+		busID := strings.ToLower(filepath.Base(filepath.Dir(info)))
+		var model string
+		_ = parseKeyValue(content, ":", func(key, value []byte) error {
+			if string(key) == "Model" {
+				model = string(value)
+			}
+			return nil
+		})
+
+		found := false
+		for i := range gpus {
+			if strings.EqualFold(gpus[i].BusID, busID) {
+				gpus[i].Model = model
+				found = true
+			}
+		}
+		if !found {
+			gpus = append(gpus, types.GPUInfo{
+				VendorID: shared.FormatPCIID(0x10de),
+				Model:    model,
+				Driver:   "nvidia",
+				BusID:    busID,
+			})
+		}
+	}
+	return gpus
+}
+
+// nvidiaSMITimeout bounds the time spent waiting for nvidia-smi.
+const nvidiaSMITimeout = 5 * time.Second
+
+// queryNvidiaSMI returns the PCI bus ID, the memory used in MiB, and the
+// utilization percentage of each NVIDIA GPU as CSV. nvidia-smi reads them
+// through NVML, which is only provided as a C library.
+var queryNvidiaSMI = func() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), nvidiaSMITimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, "nvidia-smi",
+		"--query-gpu=pci.bus_id,memory.used,utilization.gpu",
+		"--format=csv,noheader,nounits").Output()
+}
+
+// addNvidiaMetrics sets the memory used and the utilization of the GPUs
+// managed by the proprietary NVIDIA driver, which does not expose them
+// through sysfs.
+func addNvidiaMetrics(gpus []types.GPUInfo) {
+	found := false
+	for _, gpu := range gpus {
+		found = found || gpu.Driver == "nvidia"
+	}
+	if !found {
+		return
+	}
+
+	out, err := queryNvidiaSMI()
+	if err != nil {
+		return
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		fields := strings.Split(s.Text(), ",")
+		if len(fields) != 3 {
+			continue
+		}
+		busID := nvidiaBusID(strings.TrimSpace(fields[0]))
+		for i := range gpus {
+			gpu := &gpus[i]
+			if gpu.Driver != "nvidia" || !strings.EqualFold(gpu.BusID, busID) {
+				continue
+			}
+			// Unsupported values are reported as [N/A].
+			if mib, err := strconv.ParseUint(strings.TrimSpace(fields[1]), 10, 64); err == nil {
+				used := mib * 1024 * 1024
+				gpu.MemoryUsed = &used
+			}
+			if pct, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64); err == nil {
+				gpu.Utilization = &pct
+			}
+		}
+	}
+}
+
+// nvidiaBusID converts a PCI bus ID reported by nvidia-smi, which has an
+// 8 digit domain (e.g. 00000000:01:00.0), to the format used by sysfs.
+func nvidiaBusID(id string) string {
+	i := strings.IndexByte(id, ':')
+	if i < 0 {
+		return id
+	}
+	domain, err := strconv.ParseUint(id[:i], 16, 32)
+	if err != nil {
+		return id
+	}
+	return fmt.Sprintf("%04x%s", domain, strings.ToLower(id[i:]))
+}
+
+// resolvePCINames fills in the vendor and model names using the pci.ids
+// database when available or a list of well-known vendors otherwise.
+func resolvePCINames(fs procFS, gpus []types.GPUInfo) {
+	var db []byte
+	for _, f := range pciIDsFiles {
+		if content, err := ioutil.ReadFile(fs.rootPath(f)); err == nil {
+			db = content
+			break
+		}
+	}
+
+	for i := range gpus {
+		gpu := &gpus[i]
+		vendor, err := shared.ParsePCIID(gpu.VendorID)
+		if err != nil {
+			continue
+		}
+		device, _ := shared.ParsePCIID(gpu.DeviceID)
+
+		vendorName, deviceName := lookupPCIIDs(db, vendor, device)
+		if vendorName == "" {
+			vendorName = shared.PCIVendorName(vendor)
+		}
+		if gpu.Vendor == "" {
+			gpu.Vendor = vendorName
+		}
+		if gpu.Model == "" {
+			gpu.Model = deviceName
+		}
+	}
+}
+
+// lookupPCIIDs returns the vendor and device names from the contents of a
+// pci.ids database.
+func lookupPCIIDs(db []byte, vendor, device uint16) (vendorName, deviceName string) {
+	vendorPrefix := fmt.Sprintf("%04x  ", vendor)
+	devicePrefix := fmt.Sprintf("\t%04x  ", device)
+
+	s := bufio.NewScanner(bytes.NewReader(db))
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case vendorName == "":
+			if strings.HasPrefix(line, vendorPrefix) {
+				vendorName = strings.TrimSpace(line[len(vendorPrefix):])
+			}
+		case len(line) > 0 && line[0] != '\t' && line[0] != '#':
+			// Reached the next vendor.
+			return vendorName, ""
+		case strings.HasPrefix(line, devicePrefix):
+			return vendorName, strings.TrimSpace(line[len(devicePrefix):])
+		}
+	}
+	return vendorName, ""
+}
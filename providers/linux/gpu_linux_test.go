@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestGPUs(t *testing.T) {
+	gpus, err := gpus(newLinuxSystem("testdata/gpu").procFS)
+	require.NoError(t, err)
+	require.Len(t, gpus, 2)
+
+	used, busy := uint64(1040453632), 7.0
+	assert.Equal(t, types.GPUInfo{
+		Vendor:      "Advanced Micro Devices, Inc. [AMD/ATI]",
+		VendorID:    "0x1002",
+		Model:       "Navi 21 [Radeon RX 6800/6800 XT / 6900 XT]",
+		DeviceID:    "0x73bf",
+		Driver:      "amdgpu",
+		BusID:       "0000:0b:00.0",
+		MemoryTotal: 17163091968,
+		MemoryUsed:  &used,
+		Utilization: &busy,
+	}, gpus[0])
+
+	// The device is not listed in pci.ids so only the vendor is resolved.
+	assert.Equal(t, types.GPUInfo{
+		Vendor:   "Intel Corporation",
+		VendorID: "0x8086",
+		DeviceID: "0x4680",
+		Driver:   "i915",
+		BusID:    "0000:00:02.0",
+	}, gpus[1])
+}
+
+func TestAddNvidiaMetrics(t *testing.T) {
+	defer func(query func() ([]byte, error)) { queryNvidiaSMI = query }(queryNvidiaSMI)
+	queryNvidiaSMI = func() ([]byte, error) {
+		return []byte("00000000:01:00.0, 1024, 35\n00000000:02:00.0, [N/A], [N/A]\n"), nil
+	}
+
+	gpus := []types.GPUInfo{
+		{Driver: "nvidia", BusID: "0000:01:00.0"},
+		{Driver: "nvidia", BusID: "0000:02:00.0"},
+		{Driver: "amdgpu", BusID: "0000:03:00.0"},
+	}
+	addNvidiaMetrics(gpus)
+
+	used, busy := uint64(1<<30), 35.0
+	assert.Equal(t, &used, gpus[0].MemoryUsed)
+	assert.Equal(t, &busy, gpus[0].Utilization)
+	assert.Nil(t, gpus[1].MemoryUsed)
+	assert.Nil(t, gpus[1].Utilization)
+	assert.Nil(t, gpus[2].MemoryUsed)
+}
+
+func TestLookupPCIIDs(t *testing.T) {
+	db := []byte("1002  AMD\n\t73bf  Navi 21\n10de  NVIDIA Corporation\n\t2504  GA106\n")
+
+	vendor, device := lookupPCIIDs(db, 0x10de, 0x2504)
+	assert.Equal(t, "NVIDIA Corporation", vendor)
+	assert.Equal(t, "GA106", device)
+
+	vendor, device = lookupPCIIDs(db, 0x1002, 0x2504)
+	assert.Equal(t, "AMD", vendor)
+	assert.Empty(t, device)
+}
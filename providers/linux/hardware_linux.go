@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// HardwareInfo reports the system identity from /sys/class/dmi/id. The serial
+// number and UUID are only readable by root.
+func (h *host) HardwareInfo() (*types.HardwareInfo, error) {
+	return hardwareInfo(h.procFS)
+}
+
+func hardwareInfo(fs procFS) (*types.HardwareInfo, error) {
+	dir := fs.rootPath("sys/class/dmi/id")
+	if !exists(dir) {
+		return nil, fmt.Errorf("%v does not exist: %w", dir, types.ErrNotImplemented)
+	}
+
+	read := func(name string) string {
+		v, _ := readFileString(filepath.Join(dir, name))
+		return shared.CleanSMBIOSString(v)
+	}
+
+	return &types.HardwareInfo{
+		Manufacturer: read("sys_vendor"),
+		ProductName:  read("product_name"),
+		Version:      read("product_version"),
+		Family:       read("product_family"),
+		SKU:          read("product_sku"),
+		SerialNumber: read("product_serial"),
+		UUID:         read("product_uuid"),
+		AssetTag:     read("chassis_asset_tag"),
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestHardwareInfo(t *testing.T) {
+	info, err := hardwareInfo(newLinuxSystem("testdata/dmi").procFS)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.HardwareInfo{
+		Manufacturer: "Dell Inc.",
+		ProductName:  "PowerEdge R640",
+		Family:       "PowerEdge",
+		SKU:          "SKU=NotProvided;ModelName=PowerEdge R640",
+		SerialNumber: "7B5SNK2",
+		UUID:         "4C4C4544-0042-3510-8053-B7C04F4E3732",
+		AssetTag:     "IT-004217",
+	}, info)
+
+	_, err = hardwareInfo(newLinuxSystem("testdata/gpu").procFS)
+	assert.True(t, errors.Is(err, types.ErrNotImplemented))
+}
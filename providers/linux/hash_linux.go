@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// deletedSuffix is appended by the kernel to the /proc/<pid>/exe link when
+// the executable has been deleted.
+const deletedSuffix = " (deleted)"
+
+// ExecutableHash hashes the executable through /proc/<pid>/exe, which refers
+// to the file that the process runs even if it has been deleted or replaced.
+// The executable is replaced when the file at its path in the mount
+// namespace of the process is a different file, and deleted when there is
+// no file at its path.
+func (p *process) ExecutableHash(algorithms ...types.HashAlgorithm) (*types.ExecutableHashInfo, error) {
+	target, err := os.Readlink(p.path("exe"))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p.path("exe"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hashes, size, err := shared.Hash(f, algorithms...)
+	if err != nil {
+		return nil, err
+	}
+	info := &types.ExecutableHashInfo{
+		Path:   strings.TrimSuffix(target, deletedSuffix),
+		Size:   size,
+		Hashes: hashes,
+	}
+
+	// An executable that is upgraded by renaming a new file over it is
+	// reported as deleted by the kernel, but it has been replaced.
+	running, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	onDisk, err := os.Stat(p.path("root", info.Path))
+	switch {
+	case err == nil:
+		info.Replaced = !os.SameFile(running, onDisk)
+	case errors.Is(err, os.ErrNotExist):
+		info.Deleted = true
+	default:
+		info.Deleted = strings.HasSuffix(target, deletedSuffix)
+	}
+	return info, nil
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ExecutableHasher = (*process)(nil)
+
+// startCopiedSleep starts a copy of sleep from a temporary directory so
+// that its executable can be deleted and replaced.
+func startCopiedSleep(t *testing.T) (*exec.Cmd, string) {
+	sleep, err := exec.LookPath("sleep")
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(sleep)
+	require.NoError(t, err)
+
+	exe := filepath.Join(t.TempDir(), "sleep")
+	require.NoError(t, ioutil.WriteFile(exe, content, 0o755))
+
+	cmd := exec.Command(exe, "60")
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	return cmd, exe
+}
+
+func TestExecutableHash(t *testing.T) {
+	cmd, exe := startCopiedSleep(t)
+	content, err := ioutil.ReadFile(exe)
+	require.NoError(t, err)
+
+	f, err := os.Open(exe)
+	require.NoError(t, err)
+	defer f.Close()
+	expected, _, err := shared.Hash(f, types.HashSHA256, types.HashMD5)
+	require.NoError(t, err)
+
+	proc, err := newLinuxSystem("").Process(cmd.Process.Pid)
+	require.NoError(t, err)
+	hasher := proc.(types.ExecutableHasher)
+
+	info, err := hasher.ExecutableHash(types.HashSHA256, types.HashMD5)
+	require.NoError(t, err)
+	assert.Equal(t, exe, info.Path)
+	assert.EqualValues(t, len(content), info.Size)
+	assert.Equal(t, expected, info.Hashes)
+	assert.False(t, info.Deleted)
+	assert.False(t, info.Replaced)
+
+	// Replace the executable by renaming another file over it.
+	replacement := exe + ".new"
+	require.NoError(t, ioutil.WriteFile(replacement, []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, os.Rename(replacement, exe))
+
+	info, err = hasher.ExecutableHash()
+	require.NoError(t, err)
+	assert.Equal(t, expected[types.HashSHA256], info.Hashes[types.HashSHA256], "hash of the running binary")
+	assert.False(t, info.Deleted)
+	assert.True(t, info.Replaced)
+
+	require.NoError(t, os.Remove(exe))
+	info, err = hasher.ExecutableHash()
+	require.NoError(t, err)
+	assert.True(t, info.Deleted)
+	assert.False(t, info.Replaced)
+}
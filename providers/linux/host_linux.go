@@ -28,7 +28,11 @@ import (
 	"github.com/joeshaw/multierror"
 	"github.com/prometheus/procfs"
 
+	"github.com/elastic/go-sysinfo/internal/deadline"
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -45,41 +49,61 @@ func newLinuxSystem(hostFS string) linuxSystem {
 	mountPoint := filepath.Join(hostFS, procfs.DefaultMountPoint)
 	fs, _ := procfs.NewFS(mountPoint)
 	return linuxSystem{
-		procFS: procFS{FS: fs, mountPoint: mountPoint},
+		procFS: procFS{FS: fs, mountPoint: mountPoint, baseMount: hostFS},
 	}
 }
 
 func (s linuxSystem) Host() (types.Host, error) {
-	return newHost(s.procFS)
+	return newHost(s.procFS, registry.HostOptions{})
+}
+
+// HostWithOptions returns the host information without the fields excluded
+// by opts.
+func (s linuxSystem) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	return newHost(s.procFS, opts)
+}
+
+// InvalidateCache discards the cached OS information.
+func (s linuxSystem) InvalidateCache() {
+	InvalidateCache()
 }
 
 type host struct {
 	procFS procFS
 	stat   procfs.Stat
 	info   types.HostInfo
+
+	// opts are the options the host was created with. They are used to
+	// look up the FQDN again on refresh.
+	opts registry.HostOptions
 }
 
 func (h *host) Info() types.HostInfo {
 	return h.info
 }
 
-func (h *host) Memory() (*types.HostMemoryInfo, error) {
-	content, err := ioutil.ReadFile(h.procFS.path("meminfo"))
-	if err != nil {
-		return nil, err
-	}
+func (h *host) Memory() (_ *types.HostMemoryInfo, err error) {
+	defer safe.Recover("host memory", &err)
 
-	return parseMemInfo(content)
+	var mem *types.HostMemoryInfo
+	err = footprint.ReadFile(h.procFS.path("meminfo"), func(content []byte) (err error) {
+		mem, err = parseMemInfo(content)
+		return err
+	})
+	if err == nil && mem.Hugepages != nil {
+		addHugepageDetails(h.procFS, mem.Hugepages)
+	}
+	return mem, err
 }
 
 // VMStat reports data from /proc/vmstat on linux.
 func (h *host) VMStat() (*types.VMStatInfo, error) {
-	content, err := ioutil.ReadFile(h.procFS.path("vmstat"))
-	if err != nil {
-		return nil, err
-	}
-
-	return parseVMStat(content)
+	var vmstat *types.VMStatInfo
+	err := footprint.ReadFile(h.procFS.path("vmstat"), func(content []byte) (err error) {
+		vmstat, err = parseVMStat(content)
+		return err
+	})
+	return vmstat, err
 }
 
 // LoadAverage reports data from /proc/loadavg on linux.
@@ -119,7 +143,9 @@ func (h *host) NetworkCounters() (*types.NetworkCountersInfo, error) {
 	return &types.NetworkCountersInfo{SNMP: snmp, Netstat: netstat}, nil
 }
 
-func (h *host) CPUTime() (types.CPUTimes, error) {
+func (h *host) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("host cpu time", &err)
+
 	stat, err := h.procFS.Stat()
 	if err != nil {
 		return types.CPUTimes{}, err
@@ -137,26 +163,75 @@ func (h *host) CPUTime() (types.CPUTimes, error) {
 	}, nil
 }
 
-func newHost(fs procFS) (*host, error) {
+// Refresh updates the hostname, the network addresses, and the timezone. The
+// FQDN is looked up again when the hostname changed.
+func (h *host) Refresh() error {
+	hostname := h.info.Hostname
+	r := &reader{}
+	r.hostname(h)
+	r.network(h)
+	r.time(h)
+	if h.info.Hostname != hostname && !h.opts.SkipFQDN {
+		r.fqdn(h, h.opts)
+	}
+	return r.Err()
+}
+
+// RefreshFields updates the given fields of the host information.
+func (h *host) RefreshFields(fields ...string) error {
+	r := &reader{}
+	for _, field := range fields {
+		switch field {
+		case types.HostFieldHostname:
+			r.hostname(h)
+		case types.HostFieldFQDN:
+			r.fqdn(h, h.opts)
+		case types.HostFieldNetwork:
+			r.network(h)
+		case types.HostFieldTimezone:
+			r.time(h)
+		default:
+			r.addErr(fmt.Errorf("unknown host field %q", field))
+		}
+	}
+	return r.Err()
+}
+
+func newHost(fs procFS, opts registry.HostOptions) (*host, error) {
 	stat, err := fs.Stat()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read proc stat: %w", err)
 	}
 
-	h := &host{stat: stat, procFS: fs}
+	h := &host{stat: stat, procFS: fs, opts: opts}
 	r := &reader{}
-	r.architecture(h)
-	r.bootTime(h)
-	r.containerized(h)
-	r.hostname(h)
-	r.fqdn(h)
-	r.network(h)
-	r.kernelVersion(h)
-	r.os(h)
-	r.time(h)
-	r.uniqueID(h)
+	probes := []hostProbe{
+		{"static host info", func(r *reader, h *host) { r.staticInfo(h, opts) }},
+		{"boot time", (*reader).bootTime},
+		{"containerized", (*reader).containerized},
+		{"hostname", (*reader).hostname},
+	}
+	if !opts.SkipFQDN {
+		probes = append(probes, hostProbe{"fqdn", func(r *reader, h *host) { r.fqdn(h, opts) }})
+	}
+	probes = append(probes,
+		hostProbe{"network", (*reader).network},
+		hostProbe{"time", (*reader).time},
+		hostProbe{"domain", (*reader).domain},
+	)
+
+	b := deadline.New(opts.Deadline, len(probes))
+	for _, p := range probes {
+		r.probe(b, h, p.name, p.fn)
+	}
+
+	return h, b.Err(r.Err())
+}
 
-	return h, r.Err()
+// hostProbe is a probe that collects some of the host fields.
+type hostProbe struct {
+	name string
+	fn   func(*reader, *host)
 }
 
 type reader struct {
@@ -173,6 +248,44 @@ func (r *reader) addErr(err error) bool {
 	return false
 }
 
+// staticInfo collects the host fields that do not change while the host is
+// running. The fields are cached for opts.CacheTTL.
+func (r *reader) staticInfo(h *host, opts registry.HostOptions) {
+	key := fmt.Sprint(h.procFS.mountPoint, opts.SkipMachineID, opts.MachineIDSources, opts.MachineIDPath)
+	v, err := ratelimit.Default.DoInterval(ratelimit.StaticHostInfo, key, opts.CacheTTL, func() (interface{}, error) {
+		sh := &host{procFS: h.procFS}
+		sr := &reader{}
+		sb := deadline.New(time.Time{}, 0)
+		sr.probe(sb, sh, "architecture", (*reader).architecture)
+		sr.probe(sb, sh, "firmware", (*reader).firmware)
+		sr.probe(sb, sh, "hardware", (*reader).hardware)
+		sr.probe(sb, sh, "kernel version", (*reader).kernelVersion)
+		sr.probe(sb, sh, "os", (*reader).os)
+		if !opts.SkipMachineID {
+			sr.probe(sb, sh, "machine id", func(r *reader, h *host) { r.uniqueID(h, opts) })
+		}
+		return sh.info, sr.Err()
+	})
+	if info, ok := v.(types.HostInfo); ok {
+		shared.CopyStaticHostInfo(&h.info, info)
+	}
+	r.addErr(err)
+}
+
+// probe runs fn with its share of the budget b. fn modifies a copy of h that
+// is kept only if fn finishes in time, so a probe that is still running after
+// its deadline cannot race with the caller. A panic in fn is reported as an
+// error instead of crashing the application.
+func (r *reader) probe(b *deadline.Budget, h *host, name string, fn func(*reader, *host)) {
+	ph, pr := *h, &reader{}
+	if err := b.Run(name, func() { fn(pr, &ph) }); err != nil {
+		r.addErr(err)
+		return
+	}
+	h.info = ph.info
+	r.errs = append(r.errs, pr.errs...)
+}
+
 func (r *reader) Err() error {
 	if len(r.errs) > 0 {
 		return &multierror.MultiError{Errors: r.errs}
@@ -212,8 +325,8 @@ func (r *reader) hostname(h *host) {
 	h.info.Hostname = v
 }
 
-func (r *reader) fqdn(h *host) {
-	v, err := shared.FQDN()
+func (r *reader) fqdn(h *host, opts registry.HostOptions) {
+	v, err := shared.FQDNWithStrategy(opts.FQDNStrategy, h.procFS.rootPath("etc/hosts"), opts.FQDNTimeout, shared.LookupFQDN)
 	if r.addErr(err) {
 		return
 	}
@@ -221,6 +334,23 @@ func (r *reader) fqdn(h *host) {
 	h.info.FQDN = v
 }
 
+func (r *reader) firmware(h *host) {
+	v, err := firmwareInfo(h.procFS)
+	if r.addErr(err) {
+		return
+	}
+	h.info.Firmware = v
+}
+
+func (r *reader) hardware(h *host) {
+	v, err := hardwareInfo(h.procFS)
+	if r.addErr(err) {
+		return
+	}
+	h.info.SerialNumber = v.SerialNumber
+	h.info.AssetTag = v.AssetTag
+}
+
 func (r *reader) network(h *host) {
 	ips, macs, err := shared.Network()
 	if r.addErr(err) {
@@ -247,23 +377,48 @@ func (r *reader) os(h *host) {
 }
 
 func (r *reader) time(h *host) {
-	h.info.Timezone, h.info.TimezoneOffsetSec = time.Now().Zone()
+	h.info.Timezone, h.info.TimezoneOffsetSec = shared.Timezone(h.procFS.rootPath("etc/localtime"), time.Now())
+}
+
+func (r *reader) domain(h *host) {
+	h.info.Domain = domain(h.procFS)
 }
 
-func (r *reader) uniqueID(h *host) {
-	v, err := MachineID()
+func (r *reader) uniqueID(h *host, opts registry.HostOptions) {
+	v, source, err := shared.MachineID(opts, []string{registry.MachineIDEtc}, map[string]func() (string, error){
+		registry.MachineIDEtc: MachineID,
+		registry.MachineIDSMBIOS: func() (string, error) {
+			hw, err := hardwareInfo(h.procFS)
+			if err != nil {
+				return "", err
+			}
+			return hw.UUID, nil
+		},
+	})
 	if r.addErr(err) {
 		return
 	}
 	h.info.UniqueID = v
+	h.info.UniqueIDSource = source
 }
 
 type procFS struct {
 	procfs.FS
 	mountPoint string
+	baseMount  string
 }
 
 func (fs *procFS) path(p ...string) string {
 	elem := append([]string{fs.mountPoint}, p...)
 	return filepath.Join(elem...)
 }
+
+// rootPath returns the path of p relative to the root filesystem of the host.
+func (fs *procFS) rootPath(p ...string) string {
+	root := fs.baseMount
+	if root == "" {
+		root = "/"
+	}
+	elem := append([]string{root}, p...)
+	return filepath.Join(elem...)
+}
@@ -19,15 +19,23 @@ package linux
 
 import (
 	"encoding/json"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
 	"github.com/elastic/go-sysinfo/internal/registry"
 	"github.com/elastic/go-sysinfo/types"
 )
 
-var _ registry.HostProvider = linuxSystem{}
+var (
+	_ registry.HostProvider = linuxSystem{}
+	_ types.Refresher       = (*host)(nil)
+	_ types.FieldRefresher  = (*host)(nil)
+)
 
 func TestHost(t *testing.T) {
 	host, err := newLinuxSystem("").Host()
@@ -40,6 +48,40 @@ func TestHost(t *testing.T) {
 	t.Logf(string(data))
 }
 
+func TestHostRefreshTimezone(t *testing.T) {
+	if _, ok := os.LookupEnv("TZ"); ok {
+		t.Skip("TZ is set")
+	}
+
+	h := &host{
+		procFS: newLinuxSystem("testdata/timezone").procFS,
+		opts:   registry.HostOptions{SkipFQDN: true},
+	}
+	h.info.Timezone = "stale"
+	assert.NoError(t, h.Refresh())
+	assert.Contains(t, []string{"CET", "CEST"}, h.info.Timezone)
+	assert.Contains(t, []int{3600, 7200}, h.info.TimezoneOffsetSec)
+}
+
+func TestHostRefreshFields(t *testing.T) {
+	if _, ok := os.LookupEnv("TZ"); ok {
+		t.Skip("TZ is set")
+	}
+
+	h := &host{procFS: newLinuxSystem("testdata/timezone").procFS}
+	h.info.Hostname = "stale"
+	require.NoError(t, h.RefreshFields(types.HostFieldTimezone))
+	assert.Contains(t, []string{"CET", "CEST"}, h.info.Timezone)
+	assert.Equal(t, "stale", h.info.Hostname)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	require.NoError(t, h.RefreshFields(types.HostFieldHostname, types.HostFieldNetwork))
+	assert.Equal(t, hostname, h.info.Hostname)
+
+	assert.Error(t, h.RefreshFields("bogus"))
+}
+
 func TestHostMemoryInfo(t *testing.T) {
 	host, err := newLinuxSystem("testdata/ubuntu1710").Host()
 	if err != nil {
@@ -55,6 +97,48 @@ func TestHostMemoryInfo(t *testing.T) {
 	assert.Contains(t, m.Metrics, "Slab")
 }
 
+func TestHostStaticInfoCache(t *testing.T) {
+	s := newLinuxSystem("testdata/ubuntu1710")
+	opts := registry.HostOptions{CacheTTL: time.Minute}
+
+	first, err := s.HostWithOptions(opts)
+	if err != nil {
+		t.Logf("could not get all host info: %v", err)
+	}
+	info := first.Info()
+	if info.OS != nil {
+		info.OS.Name = "modified"
+	}
+
+	second, err := s.HostWithOptions(opts)
+	if err != nil {
+		t.Logf("could not get all host info: %v", err)
+	}
+	assert.Equal(t, first.Info().Architecture, second.Info().Architecture)
+	assert.Equal(t, first.Info().KernelVersion, second.Info().KernelVersion)
+	if second.Info().OS != nil {
+		assert.NotEqual(t, "modified", second.Info().OS.Name, "cached OS info must not be shared")
+	}
+}
+
+func TestHostMemoryInfoLowFootprint(t *testing.T) {
+	footprint.SetLow(true)
+	defer footprint.SetLow(false)
+
+	host, err := newLinuxSystem("testdata/ubuntu1710").Host()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := host.Memory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, 4139057152, m.Total)
+	assert.NotZero(t, m.Available)
+	assert.Nil(t, m.Metrics)
+}
+
 func TestHostVMStat(t *testing.T) {
 	host, err := newLinuxSystem("testdata/ubuntu1710").Host()
 	if err != nil {
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// hugepagePoolRegexp matches the directories of the huge page pools (e.g.
+// hugepages-2048kB).
+var hugepagePoolRegexp = regexp.MustCompile(`^hugepages-([0-9]+)kB$`)
+
+// addHugepageDetails adds the pools of each huge page size from
+// /sys/kernel/mm/hugepages and the transparent huge page modes from
+// /sys/kernel/mm/transparent_hugepage to the huge page information parsed
+// from /proc/meminfo.
+func addHugepageDetails(fs procFS, info *types.HugepagesInfo) {
+	info.Pools = hugepagePools(fs)
+
+	dir := fs.rootPath("sys/kernel/mm/transparent_hugepage")
+	if !exists(dir) {
+		return
+	}
+	if info.Transparent == nil {
+		info.Transparent = &types.TransparentHugepagesInfo{}
+	}
+	info.Transparent.Enabled = readSelectedMode(filepath.Join(dir, "enabled"))
+	info.Transparent.Defrag = readSelectedMode(filepath.Join(dir, "defrag"))
+}
+
+func hugepagePools(fs procFS) []types.HugepagePool {
+	dirs, _ := filepath.Glob(fs.rootPath("sys/kernel/mm/hugepages/hugepages-*"))
+
+	var pools []types.HugepagePool
+	for _, dir := range dirs {
+		m := hugepagePoolRegexp.FindStringSubmatch(filepath.Base(dir))
+		if m == nil {
+			continue
+		}
+		kb, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		pool := types.HugepagePool{Size: shared.MulSaturating(kb, shared.KiB)}
+		pool.Total, _ = readUintFile(filepath.Join(dir, "nr_hugepages"))
+		pool.Free, _ = readUintFile(filepath.Join(dir, "free_hugepages"))
+		pool.Reserved, _ = readUintFile(filepath.Join(dir, "resv_hugepages"))
+		pool.Surplus, _ = readUintFile(filepath.Join(dir, "surplus_hugepages"))
+		pools = append(pools, pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Size < pools[j].Size })
+	return pools
+}
+
+// readSelectedMode returns the selected value of a sysfs mode file, which
+// lists all modes with the selected one in brackets (e.g.
+// "always [madvise] never").
+func readSelectedMode(path string) string {
+	content, err := readFileString(path)
+	if err != nil {
+		return ""
+	}
+	for _, mode := range strings.Fields(content) {
+		if strings.HasPrefix(mode, "[") && strings.HasSuffix(mode, "]") {
+			return strings.Trim(mode, "[]")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestHugepages(t *testing.T) {
+	h := &host{procFS: newLinuxSystem("testdata/hugepages").procFS}
+	mem, err := h.Memory()
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.HugepagesInfo{
+		Total:       512,
+		Free:        384,
+		Reserved:    64,
+		Surplus:     0,
+		DefaultSize: 2 << 20,
+		Pools: []types.HugepagePool{
+			{Size: 2 << 20, Total: 512, Free: 384, Reserved: 64},
+			{Size: 1 << 30, Total: 2, Free: 1},
+		},
+		Transparent: &types.TransparentHugepagesInfo{
+			Enabled: "madvise",
+			Defrag:  "madvise",
+			Anon:    400 << 20,
+			File:    20 << 20,
+		},
+	}, mem.Hugepages)
+
+	// The fields are also reported in the raw metrics.
+	assert.EqualValues(t, 512, mem.Metrics["HugePages_Total"])
+}
+
+func TestHugepagesNotSupported(t *testing.T) {
+	mem, err := parseMemInfo([]byte("MemTotal: 1024 kB\nMemFree: 512 kB\n"))
+	require.NoError(t, err)
+	assert.Nil(t, mem.Hugepages)
+}
@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// seat0File is the state of the first seat, which owns the local console.
+const seat0File = "run/systemd/seats/seat0"
+
+// IdleState reports the idle and lock hints of the session that is active on
+// seat0, as maintained by systemd-logind. Desktop environments only set the
+// idle hint after their own idle delay (e.g. when the screen saver starts),
+// so the idle time is zero until then.
+func (h *host) IdleState() (*types.IdleInfo, error) {
+	session, err := activeSeatSession(h.procFS)
+	if err != nil {
+		return nil, err
+	}
+	if session == "" {
+		return &types.IdleInfo{}, nil
+	}
+
+	out, err := exec.Command("loginctl", "show-session", session,
+		"--property=IdleHint", "--property=IdleSinceHint", "--property=LockedHint").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logind session %v: %w", session, err)
+	}
+
+	info := parseSessionHints(out, time.Now())
+	info.SessionID = session
+	return info, nil
+}
+
+// activeSeatSession returns the ID of the session that is in the foreground
+// of seat0. An empty string is returned if no session is active.
+func activeSeatSession(fs procFS) (string, error) {
+	content, err := ioutil.ReadFile(fs.rootPath(seat0File))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("systemd-logind seat0 is not available: %w", types.ErrNotImplemented)
+		}
+		return "", err
+	}
+
+	var session string
+	err = parseKeyValue(content, "=", func(key, value []byte) error {
+		if string(key) == "ACTIVE" {
+			session = string(value)
+		}
+		return nil
+	})
+	return session, err
+}
+
+// parseSessionHints parses the output of loginctl show-session.
+func parseSessionHints(out []byte, now time.Time) *types.IdleInfo {
+	var idleHint bool
+	var idleSince time.Time
+	info := &types.IdleInfo{}
+	_ = parseKeyValue(out, "=", func(key, value []byte) error {
+		switch string(key) {
+		case "IdleHint":
+			idleHint = string(value) == "yes"
+		case "IdleSinceHint":
+			// Microseconds since the epoch.
+			if us, err := strconv.ParseInt(string(value), 10, 64); err == nil && us > 0 {
+				idleSince = time.Unix(0, us*int64(time.Microsecond))
+			}
+		case "LockedHint":
+			locked := string(value) == "yes"
+			info.Locked = &locked
+		}
+		return nil
+	})
+
+	var idle time.Duration
+	if idleHint && !idleSince.IsZero() && now.After(idleSince) {
+		idle = now.Sub(idleSince)
+	}
+	info.Idle = &idle
+	return info
+}
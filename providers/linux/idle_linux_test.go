@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveSeatSession(t *testing.T) {
+	session, err := activeSeatSession(newLinuxSystem("testdata/idle").procFS)
+	require.NoError(t, err)
+	assert.Equal(t, "2", session)
+}
+
+func TestParseSessionHints(t *testing.T) {
+	now := time.Unix(1700000600, 0)
+
+	info := parseSessionHints([]byte("IdleHint=yes\nIdleSinceHint=1700000000000000\nLockedHint=yes\n"), now)
+	require.NotNil(t, info.Idle)
+	require.NotNil(t, info.Locked)
+	assert.Equal(t, 10*time.Minute, *info.Idle)
+	assert.True(t, *info.Locked)
+
+	info = parseSessionHints([]byte("IdleHint=no\nIdleSinceHint=1700000000000000\nLockedHint=no\n"), now)
+	require.NotNil(t, info.Idle)
+	require.NotNil(t, info.Locked)
+	assert.Zero(t, *info.Idle)
+	assert.False(t, *info.Locked)
+}
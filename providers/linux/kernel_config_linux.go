@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	kernelConfigCache = map[string]map[string]string{} // Parsed kernel configs keyed by proc mount point.
+	kernelConfigLock  sync.Mutex                       // Lock that guards access to kernelConfigCache.
+)
+
+// KernelConfigValue returns the value of a kernel build configuration option.
+// The configuration is read from /proc/config.gz (CONFIG_IKCONFIG_PROC) or
+// /boot/config-$(uname -r) and is cached since it does not change until the
+// next reboot.
+func (h *host) KernelConfigValue(option string) (string, bool, error) {
+	return kernelConfigValue(h.procFS, option)
+}
+
+func kernelConfigValue(fs procFS, option string) (string, bool, error) {
+	config, err := kernelConfig(fs)
+	if err != nil {
+		return "", false, err
+	}
+
+	if !strings.HasPrefix(option, "CONFIG_") {
+		option = "CONFIG_" + option
+	}
+	v, found := config[option]
+	return v, found, nil
+}
+
+// kernelConfigEnabled returns whether the option is built-in or built as a
+// module. known is false when the kernel configuration is not available.
+func kernelConfigEnabled(fs procFS, option string) (enabled, known bool) {
+	v, found, err := kernelConfigValue(fs, option)
+	if err != nil {
+		return false, false
+	}
+	return found && (v == "y" || v == "m"), true
+}
+
+func kernelConfig(fs procFS) (map[string]string, error) {
+	kernelConfigLock.Lock()
+	defer kernelConfigLock.Unlock()
+
+	if config, found := kernelConfigCache[fs.mountPoint]; found {
+		return config, nil
+	}
+
+	content, err := readKernelConfig(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := parseKernelConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	kernelConfigCache[fs.mountPoint] = config
+	return config, nil
+}
+
+func readKernelConfig(fs procFS) ([]byte, error) {
+	f, err := os.Open(fs.path("config.gz"))
+	if err == nil {
+		defer f.Close()
+
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %v: %w", f.Name(), err)
+		}
+		defer gz.Close()
+		return ioutil.ReadAll(gz)
+	}
+
+	release, err := kernelRelease(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := ioutil.ReadFile(fs.rootPath("boot", "config-"+release))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("kernel config is not available: %w", types.ErrNotImplemented)
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// parseKernelConfig parses the options in Kconfig format. Options that are
+// not set (# CONFIG_FOO is not set) are omitted.
+func parseKernelConfig(content []byte) (map[string]string, error) {
+	config := map[string]string{}
+
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "CONFIG_") {
+			continue
+		}
+		config[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestKernelConfigValue(t *testing.T) {
+	testCases := []struct {
+		hostFS string
+		option string
+		value  string
+		found  bool
+	}{
+		// /proc/config.gz
+		{"testdata/kernel_config", "CONFIG_BPF_SYSCALL", "y", true},
+		{"testdata/kernel_config", "TCP_CONG_BBR", "m", true},
+		{"testdata/kernel_config", "CONFIG_IO_URING", "", false},
+		// /boot/config-$(uname -r)
+		{"testdata/kernel_features", "CONFIG_NR_CPUS", "8192", true},
+		{"testdata/kernel_features", "CONFIG_LOCALVERSION", "", true},
+		{"testdata/kernel_features", "CONFIG_IKCONFIG", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hostFS+"/"+tc.option, func(t *testing.T) {
+			v, found, err := kernelConfigValue(newLinuxSystem(tc.hostFS).procFS, tc.option)
+			require.NoError(t, err)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.value, v)
+		})
+	}
+
+	_, _, err := kernelConfigValue(newLinuxSystem("testdata/gpu").procFS, "CONFIG_BPF_SYSCALL")
+	assert.Error(t, err)
+}
+
+func TestKernelSupportsFromConfig(t *testing.T) {
+	fs := newLinuxSystem("testdata/kernel_config").procFS
+
+	supported, err := kernelSupports(fs, types.KernelFeatureIOUring)
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	supported, err = kernelSupports(fs, types.KernelFeatureTCPBBR)
+	require.NoError(t, err)
+	assert.True(t, supported)
+}
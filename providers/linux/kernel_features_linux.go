@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// KernelSupports reports whether the running kernel supports the given
+// feature. The checks are based on the kernel build configuration and the
+// interfaces that the kernel exposes through procfs and sysfs, falling back
+// to the kernel version when those are inconclusive.
+func (h *host) KernelSupports(feature types.KernelFeature) (bool, error) {
+	return kernelSupports(h.procFS, feature)
+}
+
+func kernelSupports(fs procFS, feature types.KernelFeature) (bool, error) {
+	release, err := kernelRelease(fs)
+	if err != nil {
+		return false, err
+	}
+	version, err := types.ParseVersion(release)
+	if err != nil {
+		return false, err
+	}
+
+	switch feature {
+	case types.KernelFeatureCgroupV2:
+		return supportsCgroupV2(fs, version), nil
+	case types.KernelFeatureIOUring:
+		return supportsIOUring(fs, version), nil
+	case types.KernelFeatureEBPF:
+		return supportsEBPF(fs, version), nil
+	case types.KernelFeatureTCPBBR:
+		return supportsTCPBBR(fs, release, version), nil
+	case types.KernelFeatureUserNamespaces:
+		return supportsUserNamespaces(fs), nil
+	default:
+		return false, fmt.Errorf("unknown kernel feature %q: %w", feature, types.ErrNotImplemented)
+	}
+}
+
+func supportsCgroupV2(fs procFS, version types.Version) bool {
+	if exists(fs.rootPath("sys/fs/cgroup/cgroup.controllers")) {
+		return true
+	}
+	if filesystems, err := ioutil.ReadFile(fs.path("filesystems")); err == nil {
+		return bytes.Contains(filesystems, []byte("\tcgroup2\n"))
+	}
+	return version.AtLeast(4, 5, 0)
+}
+
+func supportsIOUring(fs procFS, version types.Version) bool {
+	if !version.AtLeast(5, 1, 0) {
+		return false
+	}
+	if enabled, known := kernelConfigEnabled(fs, "CONFIG_IO_URING"); known && !enabled {
+		return false
+	}
+	// Since Linux 6.6 io_uring can be disabled at runtime. A value of 2
+	// disables the creation of new rings for all processes.
+	if v, err := readUintFile(fs.path("sys/kernel/io_uring_disabled")); err == nil && v == 2 {
+		return false
+	}
+	return true
+}
+
+func supportsEBPF(fs procFS, version types.Version) bool {
+	if enabled, known := kernelConfigEnabled(fs, "CONFIG_BPF_SYSCALL"); known {
+		return enabled
+	}
+	// The sysctl is only registered when CONFIG_BPF_SYSCALL is enabled.
+	if exists(fs.path("sys/kernel/unprivileged_bpf_disabled")) || exists(fs.rootPath("sys/fs/bpf")) {
+		return true
+	}
+	// Kernels older than 4.4 do not have the sysctl.
+	return version.AtLeast(3, 18, 0) && !version.AtLeast(4, 4, 0)
+}
+
+func supportsTCPBBR(fs procFS, release string, version types.Version) bool {
+	if !version.AtLeast(4, 9, 0) {
+		return false
+	}
+	if enabled, known := kernelConfigEnabled(fs, "CONFIG_TCP_CONG_BBR"); known {
+		return enabled
+	}
+	if available, err := readFileString(fs.path("sys/net/ipv4/tcp_available_congestion_control")); err == nil {
+		for _, algorithm := range strings.Fields(available) {
+			if algorithm == "bbr" {
+				return true
+			}
+		}
+	}
+	// The algorithm is only listed once its module is loaded, which happens
+	// on demand when it is selected.
+	modules, _ := filepath.Glob(fs.rootPath("lib/modules", release, "kernel/net/ipv4/tcp_bbr.ko*"))
+	return len(modules) > 0
+}
+
+func supportsUserNamespaces(fs procFS) bool {
+	if enabled, known := kernelConfigEnabled(fs, "CONFIG_USER_NS"); known && !enabled {
+		return false
+	}
+	if !exists(fs.path("self/ns/user")) {
+		return false
+	}
+	if v, err := readUintFile(fs.path("sys/user/max_user_namespaces")); err == nil && v == 0 {
+		return false
+	}
+	return true
+}
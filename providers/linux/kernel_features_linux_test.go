@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestKernelSupports(t *testing.T) {
+	fs := newLinuxSystem("testdata/kernel_features").procFS
+
+	testCases := []struct {
+		feature   types.KernelFeature
+		supported bool
+	}{
+		{types.KernelFeatureCgroupV2, true},
+		{types.KernelFeatureIOUring, true},
+		{types.KernelFeatureEBPF, true},
+		{types.KernelFeatureTCPBBR, true},
+		{types.KernelFeatureUserNamespaces, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.feature), func(t *testing.T) {
+			supported, err := kernelSupports(fs, tc.feature)
+			require.NoError(t, err)
+			assert.Equal(t, tc.supported, supported)
+		})
+	}
+
+	_, err := kernelSupports(fs, "unknown")
+	assert.True(t, errors.Is(err, types.ErrNotImplemented))
+}
@@ -38,3 +38,13 @@ func KernelVersion() (string, error) {
 
 	return string(data), nil
 }
+
+// kernelRelease returns the release of the running kernel from
+// /proc/sys/kernel/osrelease.
+func kernelRelease(fs procFS) (string, error) {
+	release, err := readFileString(fs.path("sys/kernel/osrelease"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read kernel release: %w", err)
+	}
+	return release, nil
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// KernelModules reports the modules listed in /proc/modules. Modules are
+// reported as unsigned when the kernel tainted itself while loading them
+// (flag E). The signature state is unknown when the kernel was built without
+// module signing support or its configuration is not available.
+func (h *host) KernelModules() ([]types.KernelModuleInfo, error) {
+	v, err := ratelimit.Do(ratelimit.KernelModules, h.procFS.mountPoint, func() (interface{}, error) {
+		return kernelModules(h.procFS)
+	})
+	modules, _ := v.([]types.KernelModuleInfo)
+	return append([]types.KernelModuleInfo(nil), modules...), err
+}
+
+func kernelModules(fs procFS) ([]types.KernelModuleInfo, error) {
+	content, err := ioutil.ReadFile(fs.path("modules"))
+	if err != nil {
+		return nil, err
+	}
+
+	signing, known := kernelConfigEnabled(fs, "CONFIG_MODULE_SIG")
+	return parseProcModules(content, signing && known), nil
+}
+
+// parseProcModules parses the contents of /proc/modules. Lines have the
+// format: name size refcount used_by state address [(taint flags)].
+func parseProcModules(content []byte, signing bool) []types.KernelModuleInfo {
+	var modules []types.KernelModuleInfo
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		m := types.KernelModuleInfo{
+			Name:  fields[0],
+			State: fields[4],
+		}
+		m.Size, _ = strconv.ParseUint(fields[1], 10, 64)
+		if fields[3] != "-" {
+			for _, user := range strings.Split(fields[3], ",") {
+				if user != "" {
+					m.UsedBy = append(m.UsedBy, user)
+				}
+			}
+		}
+
+		var taint string
+		if len(fields) > 6 {
+			taint = strings.Trim(fields[6], "()")
+		}
+		if signing || strings.Contains(taint, "E") {
+			signed := !strings.Contains(taint, "E")
+			m.Signed = &signed
+		}
+		modules = append(modules, m)
+	}
+	return modules
+}
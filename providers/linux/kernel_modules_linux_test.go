@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestKernelModules(t *testing.T) {
+	modules, err := kernelModules(newLinuxSystem("testdata/kernel_modules").procFS)
+	require.NoError(t, err)
+
+	// The kernel configuration is not available so only modules with the
+	// unsigned taint flag have a known signature state.
+	unsigned := false
+	assert.Equal(t, []types.KernelModuleInfo{
+		{Name: "nvidia_drm", Size: 77824, State: "Live", Signed: &unsigned},
+		{Name: "nf_tables", Size: 286720, State: "Live", UsedBy: []string{"nft_compat", "nft_chain_nat"}},
+		{Name: "nft_compat", Size: 20480, State: "Live"},
+	}, modules)
+}
+
+func TestParseProcModulesSigning(t *testing.T) {
+	modules := parseProcModules([]byte("nft_compat 20480 11 - Live 0x0000000000000000\n"), true)
+	require.Len(t, modules, 1)
+	require.NotNil(t, modules[0].Signed)
+	assert.True(t, *modules[0].Signed)
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	dpkgLogFile      = "var/log/dpkg.log"
+	apkInstalledFile = "lib/apk/db/installed"
+)
+
+// LastUpdate reports the time of the last package transaction. It is read
+// from the dpkg log, the install times in the RPM database, and the
+// modification time of the apk database. The latest time of the package
+// managers found on the host is returned.
+func (h *host) LastUpdate() (*types.LastUpdateInfo, error) {
+	return lastUpdate(h.procFS, rpmInstallTimes)
+}
+
+func lastUpdate(fs procFS, installTimes func(procFS, []string) (map[string]time.Time, error)) (*types.LastUpdateInfo, error) {
+	var last *types.LastUpdateInfo
+	update := func(t time.Time, source string) {
+		if !t.IsZero() && (last == nil || t.After(last.Time)) {
+			last = &types.LastUpdateInfo{Time: t.UTC(), Source: source}
+		}
+	}
+
+	// dpkg.log is rotated, so the previous log is read if the current
+	// one has no installations yet.
+	for _, name := range []string{dpkgLogFile, dpkgLogFile + ".1"} {
+		content, err := ioutil.ReadFile(fs.rootPath(name))
+		if err != nil {
+			continue
+		}
+		if t := parseDpkgLog(content); !t.IsZero() {
+			update(t, "dpkg")
+			break
+		}
+	}
+
+	if exists(fs.rootPath(rpmDBDir)) {
+		times, err := installTimes(fs, nil)
+		if err != nil {
+			return nil, err
+		}
+		for name, t := range times {
+			// gpg-pubkey entries are imported keys, not packages.
+			if name != "gpg-pubkey" {
+				update(t, "rpm")
+			}
+		}
+	}
+
+	if fi, err := os.Stat(fs.rootPath(apkInstalledFile)); err == nil {
+		update(fi.ModTime(), "apk")
+	}
+
+	if last == nil {
+		return nil, types.ErrNotImplemented
+	}
+	return last, nil
+}
+
+// parseDpkgLog returns the time of the last "status installed" entry of a
+// dpkg log. The times are in the local time zone.
+func parseDpkgLog(content []byte) time.Time {
+	var last time.Time
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		// 2024-03-01 10:11:12 status installed libc6:amd64 2.36-9+deb12u4
+		fields := strings.Fields(s.Text())
+		if len(fields) < 4 || fields[2] != "status" || fields[3] != "installed" {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", fields[0]+" "+fields[1], time.Local)
+		if err == nil && t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.LastUpdate = (*host)(nil)
+
+func TestLastUpdate(t *testing.T) {
+	installTimes := func(procFS, []string) (map[string]time.Time, error) {
+		return map[string]time.Time{
+			"bash":       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			"gpg-pubkey": time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		}, nil
+	}
+
+	info, err := lastUpdate(newLinuxSystem("testdata/lastupdate_dpkg").procFS, installTimes)
+	require.NoError(t, err)
+	assert.Equal(t, types.LastUpdateInfo{
+		Time:   time.Date(2024, 3, 2, 9, 0, 1, 0, time.Local).UTC(),
+		Source: "dpkg",
+	}, *info)
+
+	info, err = lastUpdate(newLinuxSystem("testdata/reboot_rpm").procFS, installTimes)
+	require.NoError(t, err)
+	assert.Equal(t, types.LastUpdateInfo{
+		Time:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Source: "rpm",
+	}, *info)
+
+	info, err = lastUpdate(newLinuxSystem("testdata/packages_apk").procFS, installTimes)
+	require.NoError(t, err)
+	assert.Equal(t, "apk", info.Source)
+
+	_, err = lastUpdate(newLinuxSystem("testdata/dns").procFS, installTimes)
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
@@ -0,0 +1,308 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Socket states of the kernel (include/net/tcp_states.h). UDP sockets that
+// are not connected are in the TCP_CLOSE state.
+const (
+	tcpListen = 0x0a
+	tcpClose  = 0x07
+)
+
+// sockDiagByFamily is the SOCK_DIAG_BY_FAMILY netlink message type.
+const sockDiagByFamily = 20
+
+// Sizes of struct inet_diag_req_v2 and struct inet_diag_msg.
+const (
+	inetDiagReqV2Len = 56
+	inetDiagMsgLen   = 72
+)
+
+// listeningSocket is a listening socket and the inode that identifies it in
+// the file descriptor tables of the processes.
+type listeningSocket struct {
+	types.ListeningPort
+	inode uint64
+}
+
+// ListeningPorts reports the listening TCP and bound UDP sockets of the
+// network namespace. The sockets are dumped with the sock_diag netlink
+// interface, which filters them by state in the kernel, or read from
+// /proc/net when it is not available. The owning processes are found by
+// matching the socket inodes with the file descriptors of the processes,
+// which requires CAP_SYS_PTRACE to see the processes of other users.
+func (h *host) ListeningPorts() ([]types.ListeningPort, error) {
+	sockets, err := sockDiagListening()
+	if err != nil {
+		if sockets, err = procNetListening(h.procFS); err != nil {
+			return nil, err
+		}
+	}
+
+	ports := make([]types.ListeningPort, 0, len(sockets))
+	owners := socketOwners(h.procFS, sockets)
+	for _, s := range sockets {
+		if o, found := owners[s.inode]; found {
+			s.PID, s.ProcessName = o.PID, o.ProcessName
+		}
+		ports = append(ports, s.ListeningPort)
+	}
+	return ports, nil
+}
+
+// sockDiagListening dumps the listening TCP and bound UDP sockets with
+// sock_diag.
+func sockDiagListening() ([]listeningSocket, error) {
+	fd, err := dialSockDiag()
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(fd)
+
+	var sockets []listeningSocket
+	for _, q := range []struct {
+		protocol uint8
+		state    uint8
+	}{
+		{unix.IPPROTO_TCP, tcpListen},
+		{unix.IPPROTO_UDP, tcpClose},
+	} {
+		for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+			msgs, err := sockDiagDump(fd, family, q.protocol, 1<<q.state)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range msgs {
+				if s, ok := parseInetDiagMsg(m, q.protocol); ok {
+					sockets = append(sockets, s)
+				}
+			}
+		}
+	}
+	return sockets, nil
+}
+
+// dialSockDiag opens a sock_diag netlink socket.
+func dialSockDiag() (int, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return -1, fmt.Errorf("failed to open sock_diag netlink socket: %w", err)
+	}
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: genlReceiveTimeout}); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+	return fd, nil
+}
+
+// sockDiagDump sends an inet_diag_req_v2 dump request and returns the
+// inet_diag_msg replies.
+func sockDiagDump(fd int, family, protocol uint8, states uint32) ([][]byte, error) {
+	req := make([]byte, inetDiagReqV2Len)
+	req[0] = family
+	req[1] = protocol
+	nativeEndian.PutUint32(req[4:], states)
+	return netlinkExchange(fd, sockDiagByFamily, unix.NLM_F_REQUEST|unix.NLM_F_DUMP, 1, req)
+}
+
+// parseInetDiagMsg converts a struct inet_diag_msg. It returns false for UDP
+// sockets that are not bound to a port.
+func parseInetDiagMsg(b []byte, protocol uint8) (listeningSocket, bool) {
+	if len(b) < inetDiagMsgLen {
+		return listeningSocket{}, false
+	}
+
+	var s listeningSocket
+	switch b[0] {
+	case unix.AF_INET:
+		s.Family = types.FamilyIPv4
+		s.IP = net.IP(b[8:12]).String()
+	case unix.AF_INET6:
+		s.Family = types.FamilyIPv6
+		s.IP = net.IP(b[8:24]).String()
+	default:
+		return listeningSocket{}, false
+	}
+	s.Protocol = protocolName(protocol)
+	s.Port = int(binary.BigEndian.Uint16(b[4:6]))
+	s.inode = uint64(nativeEndian.Uint32(b[68:72]))
+	return s, s.Port != 0
+}
+
+// procNetListening reads the listening TCP and bound UDP sockets from the
+// /proc/net/{tcp,tcp6,udp,udp6} files.
+func procNetListening(fs procFS) ([]listeningSocket, error) {
+	var sockets []listeningSocket
+	for _, f := range []struct {
+		name     string
+		protocol uint8
+		state    uint8
+	}{
+		{"net/tcp", unix.IPPROTO_TCP, tcpListen},
+		{"net/tcp6", unix.IPPROTO_TCP, tcpListen},
+		{"net/udp", unix.IPPROTO_UDP, tcpClose},
+		{"net/udp6", unix.IPPROTO_UDP, tcpClose},
+	} {
+		content, err := ioutil.ReadFile(fs.path(f.name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// IPv6 is disabled.
+				continue
+			}
+			return nil, err
+		}
+		s, err := parseProcNetSockets(content, f.protocol, f.state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %v: %w", f.name, err)
+		}
+		sockets = append(sockets, s...)
+	}
+	return sockets, nil
+}
+
+// parseProcNetSockets parses the sockets in the given state from the content
+// of a /proc/net/{tcp,tcp6,udp,udp6} file.
+func parseProcNetSockets(content []byte, protocol, state uint8) ([]listeningSocket, error) {
+	var sockets []listeningSocket
+	s := bufio.NewScanner(bytes.NewReader(content))
+	s.Scan() // Skip the header.
+	for s.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(s.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		if st, err := strconv.ParseUint(fields[3], 16, 8); err != nil || uint8(st) != state {
+			continue
+		}
+
+		addr, port, found := strings.Cut(fields[1], ":")
+		if !found {
+			return nil, fmt.Errorf("invalid local address %q", fields[1])
+		}
+		ip, err := parseProcNetIP(addr)
+		if err != nil {
+			return nil, err
+		}
+		p, err := strconv.ParseUint(port, 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local port %q: %w", port, err)
+		}
+		if p == 0 {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inode %q: %w", fields[9], err)
+		}
+
+		sock := listeningSocket{inode: inode}
+		sock.Protocol = protocolName(protocol)
+		sock.Family = types.FamilyIPv4
+		if len(ip) == net.IPv6len {
+			sock.Family = types.FamilyIPv6
+		}
+		sock.IP = ip.String()
+		sock.Port = int(p)
+		sockets = append(sockets, sock)
+	}
+	return sockets, s.Err()
+}
+
+// parseProcNetIP parses an address of /proc/net, which is written as 32 bit
+// words in the byte order of the host.
+func parseProcNetIP(s string) (net.IP, error) {
+	if len(s) != 8 && len(s) != 32 {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	ip := make(net.IP, len(s)/2)
+	for i := 0; i < len(ip); i += 4 {
+		v, err := strconv.ParseUint(s[2*i:2*i+8], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", s, err)
+		}
+		nativeEndian.PutUint32(ip[i:], uint32(v))
+	}
+	return ip, nil
+}
+
+func protocolName(protocol uint8) string {
+	if protocol == unix.IPPROTO_UDP {
+		return types.ProtocolUDP
+	}
+	return types.ProtocolTCP
+}
+
+// socketOwners returns the processes that hold the sockets by their inode.
+// The walk ends as soon as the owners of all sockets are found.
+func socketOwners(fs procFS, sockets []listeningSocket) map[uint64]types.ListeningPort {
+	pending := make(map[uint64]struct{}, len(sockets))
+	for _, s := range sockets {
+		pending[s.inode] = struct{}{}
+	}
+	owners := make(map[uint64]types.ListeningPort, len(sockets))
+
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return owners
+	}
+	for _, p := range procs {
+		if len(pending) == 0 {
+			break
+		}
+		targets, err := p.FileDescriptorTargets()
+		if err != nil {
+			continue
+		}
+		var name string
+		for _, t := range targets {
+			if !strings.HasPrefix(t, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(t[len("socket:["):], "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, found := pending[inode]; !found {
+				continue
+			}
+			if name == "" {
+				name, _ = p.Comm()
+			}
+			owners[inode] = types.ListeningPort{PID: p.PID, ProcessName: name}
+			delete(pending, inode)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ListeningPorts = (*host)(nil)
+
+func TestProcNetListening(t *testing.T) {
+	if nativeEndian != binary.LittleEndian {
+		t.Skip("the test data is from a little-endian host")
+	}
+
+	fs := newLinuxSystem("testdata/listening_ports").procFS
+	sockets, err := procNetListening(fs)
+	require.NoError(t, err)
+
+	owners := socketOwners(fs, sockets)
+	var ports []types.ListeningPort
+	for _, s := range sockets {
+		if o, found := owners[s.inode]; found {
+			s.PID, s.ProcessName = o.PID, o.ProcessName
+		}
+		ports = append(ports, s.ListeningPort)
+	}
+
+	assert.Equal(t, []types.ListeningPort{
+		{Protocol: types.ProtocolTCP, Family: types.FamilyIPv4, IP: "0.0.0.0", Port: 22, PID: 1234, ProcessName: "sshd"},
+		{Protocol: types.ProtocolTCP, Family: types.FamilyIPv4, IP: "127.0.0.1", Port: 3306},
+		{Protocol: types.ProtocolTCP, Family: types.FamilyIPv6, IP: "::", Port: 22, PID: 1234, ProcessName: "sshd"},
+		{Protocol: types.ProtocolUDP, Family: types.FamilyIPv4, IP: "127.0.0.53", Port: 53, PID: 5678, ProcessName: "systemd-resolve"},
+		{Protocol: types.ProtocolUDP, Family: types.FamilyIPv6, IP: "fe80::5400:ff:0:1", Port: 546},
+	}, ports)
+}
+
+func TestParseInetDiagMsg(t *testing.T) {
+	msg := make([]byte, inetDiagMsgLen)
+	msg[0] = unix.AF_INET6
+	msg[1] = tcpListen
+	binary.BigEndian.PutUint16(msg[4:], 443)
+	msg[23] = 1 // ::1
+	nativeEndian.PutUint32(msg[68:], 31337)
+
+	s, ok := parseInetDiagMsg(msg, unix.IPPROTO_TCP)
+	require.True(t, ok)
+	assert.Equal(t, listeningSocket{
+		ListeningPort: types.ListeningPort{Protocol: types.ProtocolTCP, Family: types.FamilyIPv6, IP: "::1", Port: 443},
+		inode:         31337,
+	}, s)
+
+	// Unbound UDP sockets have no port.
+	binary.BigEndian.PutUint16(msg[4:], 0)
+	_, ok = parseInetDiagMsg(msg, unix.IPPROTO_UDP)
+	assert.False(t, ok)
+}
+
+func TestListeningPorts(t *testing.T) {
+	h, err := newLinuxSystem("").Host()
+	require.NoError(t, err)
+
+	ports, err := h.(types.ListeningPorts).ListeningPorts()
+	require.NoError(t, err)
+	for _, p := range ports {
+		assert.NotZero(t, p.Port)
+		assert.Contains(t, []string{types.ProtocolTCP, types.ProtocolUDP}, p.Protocol)
+	}
+	t.Logf("%+v", ports)
+}
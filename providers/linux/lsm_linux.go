@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// LSMStatus reports the active Linux Security Modules from securityfs, the
+// SELinux mode from selinuxfs and /etc/selinux/config, and the AppArmor
+// profiles.
+func (h *host) LSMStatus() (*types.LSMStatusInfo, error) {
+	return lsmStatus(h.procFS), nil
+}
+
+func lsmStatus(fs procFS) *types.LSMStatusInfo {
+	info := &types.LSMStatusInfo{
+		SELinux:  selinuxStatus(fs),
+		AppArmor: apparmorStatus(fs),
+	}
+	if lsm, err := readFileString(fs.rootPath("sys/kernel/security/lsm")); err == nil && lsm != "" {
+		info.Modules = strings.Split(lsm, ",")
+	}
+	return info
+}
+
+// selinuxStatus returns the SELinux mode. selinuxfs is not mounted when
+// SELinux is disabled, in which case the configuration is reported if it
+// exists.
+func selinuxStatus(fs procFS) *types.SELinuxStatus {
+	status := &types.SELinuxStatus{Mode: types.SELinuxDisabled}
+
+	configured := false
+	if content, err := ioutil.ReadFile(fs.rootPath("etc/selinux/config")); err == nil {
+		configured = true
+		_ = parseKeyValue(content, "=", func(key, value []byte) error {
+			switch strings.TrimSpace(string(key)) {
+			case "SELINUX":
+				status.ConfigMode = strings.ToLower(string(value))
+			case "SELINUXTYPE":
+				status.Policy = string(value)
+			}
+			return nil
+		})
+	}
+
+	enforce, err := readFileString(fs.rootPath("sys/fs/selinux/enforce"))
+	if err != nil {
+		if !configured {
+			return nil
+		}
+		return status
+	}
+	switch enforce {
+	case "1":
+		status.Mode = types.SELinuxEnforcing
+	case "0":
+		status.Mode = types.SELinuxPermissive
+	}
+	if v, err := readUintFile(fs.rootPath("sys/fs/selinux/policyvers")); err == nil {
+		status.PolicyVersion = int(v)
+	}
+	return status
+}
+
+// apparmorStatus returns whether AppArmor is enabled and the number of
+// loaded profiles by mode.
+func apparmorStatus(fs procFS) *types.AppArmorStatus {
+	enabled, err := readFileString(fs.rootPath("sys/module/apparmor/parameters/enabled"))
+	if err != nil {
+		return nil
+	}
+	status := &types.AppArmorStatus{Enabled: enabled == "Y"}
+
+	if content, err := ioutil.ReadFile(fs.rootPath("sys/kernel/security/apparmor/profiles")); err == nil {
+		total, enforce, complain := countAppArmorProfiles(content)
+		status.Profiles, status.EnforceProfiles, status.ComplainProfiles = &total, &enforce, &complain
+	}
+	return status
+}
+
+// countAppArmorProfiles counts the profiles listed in the AppArmor profiles
+// file, whose lines are like "/usr/sbin/cupsd (enforce)".
+func countAppArmorProfiles(content []byte) (total, enforce, complain int) {
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		total++
+		switch {
+		case strings.HasSuffix(line, " (enforce)"):
+			enforce++
+		case strings.HasSuffix(line, " (complain)"):
+			complain++
+		}
+	}
+	return total, enforce, complain
+}
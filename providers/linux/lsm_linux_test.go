@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.LSMStatus = (*host)(nil)
+
+func TestLSMStatusSELinux(t *testing.T) {
+	info := lsmStatus(newLinuxSystem("testdata/lsm_selinux").procFS)
+	assert.Equal(t, &types.LSMStatusInfo{
+		Modules: []string{"lockdown", "capability", "yama", "selinux", "bpf"},
+		SELinux: &types.SELinuxStatus{
+			Mode:          types.SELinuxEnforcing,
+			ConfigMode:    types.SELinuxEnforcing,
+			Policy:        "targeted",
+			PolicyVersion: 33,
+		},
+	}, info)
+}
+
+func TestLSMStatusAppArmor(t *testing.T) {
+	info := lsmStatus(newLinuxSystem("testdata/lsm_apparmor").procFS)
+	total, enforce, complain := 6, 4, 1
+	assert.Equal(t, &types.LSMStatusInfo{
+		Modules: []string{"lockdown", "capability", "landlock", "yama", "apparmor"},
+		AppArmor: &types.AppArmorStatus{
+			Enabled:          true,
+			Profiles:         &total,
+			EnforceProfiles:  &enforce,
+			ComplainProfiles: &complain,
+		},
+	}, info)
+}
+
+func TestLSMStatusNone(t *testing.T) {
+	assert.Equal(t, &types.LSMStatusInfo{}, lsmStatus(newLinuxSystem("testdata/ubuntu1710").procFS))
+}
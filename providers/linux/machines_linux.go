@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_containers
+// +build !nosysinfo_containers
+
+package linux
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// nspawnCgroupRegexp matches the control groups of the
+	// systemd-nspawn@<name>.service units.
+	nspawnCgroupRegexp = regexp.MustCompile(`^systemd-nspawn@(.+)\.service$`)
+
+	// machineScopeRegexp matches the scopes of the machines registered with
+	// systemd-machined (e.g. machine-<name>.scope).
+	machineScopeRegexp = regexp.MustCompile(`^machine-(.+)\.scope$`)
+
+	// lxcCgroupRegexp matches the control groups of LXC containers, which
+	// are also used by LXD and Incus.
+	lxcCgroupRegexp = regexp.MustCompile(`^lxc\.payload\.(.+)$`)
+)
+
+// machineRuntimes maps the SERVICE of systemd-machined machines to the
+// runtimes.
+var machineRuntimes = map[string]string{
+	"nspawn": "systemd-nspawn",
+}
+
+// machineCgroup returns the runtime and the name of a system container
+// (systemd-nspawn, LXC, LXD, or Incus) and the control group that holds its
+// processes. The runtime is empty if the control group is not a system
+// container.
+func machineCgroup(fs procFS, cg cgroupHierarchy, cgroup string) (runtime, name, payload string) {
+	base := filepath.Base(cgroup)
+	switch {
+	case nspawnCgroupRegexp.MatchString(base):
+		name = unescapeSystemdName(nspawnCgroupRegexp.FindStringSubmatch(base)[1])
+		// systemd-nspawn moves the container into a payload control group,
+		// next to the one of its supervisor.
+		if payload := filepath.Join(cgroup, "payload"); exists(cg.dir("cpuacct", payload)) {
+			return "systemd-nspawn", name, payload
+		}
+		return "systemd-nspawn", name, cgroup
+	case machineScopeRegexp.MatchString(base):
+		name = unescapeSystemdName(machineScopeRegexp.FindStringSubmatch(base)[1])
+		if runtime = machinedRuntime(fs, name); runtime == "" {
+			return "", "", ""
+		}
+		return runtime, name, cgroup
+	case lxcCgroupRegexp.MatchString(base):
+		name = lxcCgroupRegexp.FindStringSubmatch(base)[1]
+		return lxcRuntime(fs, name), name, cgroup
+	case filepath.Dir(cgroup) == "lxc":
+		// LXC before 4.0 created the control groups in /lxc/<name>.
+		return lxcRuntime(fs, base), base, cgroup
+	}
+	return "", "", ""
+}
+
+// machinedRuntime returns the runtime of a container registered with
+// systemd-machined from its state in /run/systemd/machines. It returns an
+// empty string for virtual machines.
+func machinedRuntime(fs procFS, name string) string {
+	content, err := ioutil.ReadFile(fs.rootPath("run/systemd/machines", name))
+	if err != nil {
+		return ""
+	}
+
+	var class, service string
+	_ = parseKeyValue(content, "=", func(key, value []byte) error {
+		switch string(key) {
+		case "CLASS":
+			class = string(value)
+		case "SERVICE":
+			service = string(value)
+		}
+		return nil
+	})
+	if class != "container" {
+		return ""
+	}
+	if runtime, found := machineRuntimes[service]; found {
+		return runtime
+	}
+	return service
+}
+
+// lxcRuntime returns the manager of an LXC container. LXD and Incus keep the
+// containers in their own state directories.
+func lxcRuntime(fs procFS, name string) string {
+	switch {
+	case exists(fs.rootPath("var/snap/lxd/common/lxd/containers", name)),
+		exists(fs.rootPath("var/lib/lxd/containers", name)):
+		return "lxd"
+	case exists(fs.rootPath("var/lib/incus/containers", name)):
+		return "incus"
+	}
+	return "lxc"
+}
+
+// unescapeSystemdName reverses the escaping of systemd unit names, which
+// replaces characters like '-' with \x2d.
+func unescapeSystemdName(name string) string {
+	if !strings.Contains(name, `\x`) {
+		return name
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) && name[i+1] == 'x' {
+			if c, err := strconv.ParseUint(name[i+2:i+4], 16, 8); err == nil {
+				sb.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(name[i])
+	}
+	return sb.String()
+}
@@ -20,15 +20,22 @@ package linux
 import (
 	"fmt"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
 
 func parseMemInfo(content []byte) (*types.HostMemoryInfo, error) {
-	memInfo := &types.HostMemoryInfo{
-		Metrics: map[string]uint64{},
+	memInfo := &types.HostMemoryInfo{}
+	if !footprint.Low() {
+		memInfo.Metrics = map[string]uint64{}
 	}
 
 	hasAvailable := false
+	var buffers, cached uint64
+	hugepages := &types.HugepagesInfo{}
+	thp := &types.TransparentHugepagesInfo{}
+	var hasHugepages, hasTHP bool
 	err := parseKeyValue(content, ":", func(key, value []byte) error {
 		num, err := parseBytesOrNumber(value)
 		if err != nil {
@@ -48,7 +55,30 @@ func parseMemInfo(content []byte) (*types.HostMemoryInfo, error) {
 			memInfo.VirtualTotal = num
 		case "SwapFree":
 			memInfo.VirtualFree = num
-		default:
+		case "Buffers":
+			buffers = num
+		case "Cached":
+			cached = num
+		case "HugePages_Total":
+			hasHugepages = true
+			hugepages.Total = num
+		case "HugePages_Free":
+			hugepages.Free = num
+		case "HugePages_Rsvd":
+			hugepages.Reserved = num
+		case "HugePages_Surp":
+			hugepages.Surplus = num
+		case "Hugepagesize":
+			hugepages.DefaultSize = num
+		case "AnonHugePages":
+			hasTHP = true
+			thp.Anon = num
+		case "ShmemHugePages":
+			thp.Shmem = num
+		case "FileHugePages":
+			thp.File = num
+		}
+		if memInfo.Metrics != nil && !isMemInfoField(k) {
 			memInfo.Metrics[k] = num
 		}
 
@@ -58,15 +88,32 @@ func parseMemInfo(content []byte) (*types.HostMemoryInfo, error) {
 		return nil, err
 	}
 
-	memInfo.Used = memInfo.Total - memInfo.Free
-	memInfo.VirtualUsed = memInfo.VirtualTotal - memInfo.VirtualFree
+	if hasHugepages {
+		memInfo.Hugepages = hugepages
+		if hasTHP {
+			hugepages.Transparent = thp
+		}
+	}
+
+	memInfo.Used = shared.SubSaturating(memInfo.Total, memInfo.Free)
+	memInfo.VirtualUsed = shared.SubSaturating(memInfo.VirtualTotal, memInfo.VirtualFree)
 
 	// MemAvailable was added in kernel 3.14.
 	if !hasAvailable {
 		// Linux uses this for the calculation (but we are using a simpler calculation).
 		// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/commit/?id=34e431b0ae398fc54ea69ff85ec700722c9da773
-		memInfo.Available = memInfo.Free + memInfo.Metrics["Buffers"] + memInfo.Metrics["Cached"]
+		memInfo.Available = memInfo.Free + buffers + cached
 	}
 
 	return memInfo, nil
 }
+
+// isMemInfoField returns true for the meminfo keys that are reported in the
+// fields of HostMemoryInfo instead of its Metrics.
+func isMemInfoField(key string) bool {
+	switch key {
+	case "MemTotal", "MemAvailable", "MemFree", "SwapTotal", "SwapFree":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Namespaces reports the namespaces of the process from the symlinks in
+// /proc/<pid>/ns. Reading them requires the same permissions as ptrace.
+func (p *process) Namespaces() (map[string]uint64, error) {
+	return processNamespaces(p.fs, p.PID())
+}
+
+func processNamespaces(fs procFS, pid int) (map[string]uint64, error) {
+	dir := fs.path(strconv.Itoa(pid), "ns")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[string]uint64, len(entries))
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		inode, err := parseNamespaceLink(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %v: %w", filepath.Join(dir, entry.Name()), err)
+		}
+		namespaces[entry.Name()] = inode
+	}
+	return namespaces, nil
+}
+
+// parseNamespaceLink returns the inode number of a namespace symlink target
+// such as net:[4026531840].
+func parseNamespaceLink(target string) (uint64, error) {
+	_, inode, found := strings.Cut(target, ":")
+	if !found || !strings.HasPrefix(inode, "[") || !strings.HasSuffix(inode, "]") {
+		return 0, fmt.Errorf("unexpected namespace link %q", target)
+	}
+	return strconv.ParseUint(inode[1:len(inode)-1], 10, 64)
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Namespaces = (*process)(nil)
+
+func TestProcessNamespaces(t *testing.T) {
+	fs := newLinuxSystem("testdata/namespaces").procFS
+
+	namespaces, err := processNamespaces(fs, 42)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{
+		"cgroup":           4026531835,
+		"ipc":              4026532286,
+		"mnt":              4026532284,
+		"net":              4026532289,
+		"pid":              4026532287,
+		"pid_for_children": 4026532287,
+		"user":             4026531837,
+		"uts":              4026532285,
+	}, namespaces)
+
+	_, err = processNamespaces(fs, 7)
+	assert.Error(t, err)
+}
+
+func TestParseNamespaceLink(t *testing.T) {
+	inode, err := parseNamespaceLink("net:[4026531840]")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4026531840, inode)
+
+	for _, target := range []string{"net", "net:4026531840", "net:[x]"} {
+		_, err := parseNamespaceLink(target)
+		assert.Error(t, err, target)
+	}
+}
+
+func TestSelfNamespaces(t *testing.T) {
+	self, err := newLinuxSystem("").Self()
+	require.NoError(t, err)
+
+	namespaces, err := self.(types.Namespaces).Namespaces()
+	require.NoError(t, err)
+	assert.Contains(t, namespaces, "pid")
+	assert.Contains(t, namespaces, "net")
+}
@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Neighbors reports the neighbor cache of the network namespace of the
+// caller from an RTM_GETNEIGH netlink dump, which does not require any
+// privileges. Unlike /proc/net/arp, the dump includes the IPv6 neighbors and
+// the state of the entries. Like "ip neigh", the entries of addresses that
+// do not need resolution (NOARP) are omitted.
+func (h *host) Neighbors() ([]types.Neighbor, error) {
+	data, err := syscall.NetlinkRIB(unix.RTM_GETNEIGH, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump neighbor table: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse neighbor table: %w", err)
+	}
+	return parseNeighborMessages(msgs, shared.InterfaceName)
+}
+
+// neighborStates maps the NUD_* states to the types.NeighborState values.
+var neighborStates = map[uint16]string{
+	unix.NUD_INCOMPLETE: types.NeighborStateIncomplete,
+	unix.NUD_REACHABLE:  types.NeighborStateReachable,
+	unix.NUD_STALE:      types.NeighborStateStale,
+	unix.NUD_DELAY:      types.NeighborStateDelay,
+	unix.NUD_PROBE:      types.NeighborStateProbe,
+	unix.NUD_FAILED:     types.NeighborStateFailed,
+	unix.NUD_PERMANENT:  types.NeighborStatePermanent,
+}
+
+// parseNeighborMessages converts the RTM_NEWNEIGH messages of a neighbor
+// table dump.
+func parseNeighborMessages(msgs []syscall.NetlinkMessage, interfaceName func(int) string) ([]types.Neighbor, error) {
+	neighbors := []types.Neighbor{}
+	for _, msg := range msgs {
+		if msg.Header.Type != unix.RTM_NEWNEIGH {
+			continue
+		}
+		if len(msg.Data) < unix.SizeofNdMsg {
+			return nil, fmt.Errorf("neighbor message too short (%d bytes)", len(msg.Data))
+		}
+		ndm := (*unix.NdMsg)(unsafe.Pointer(&msg.Data[0]))
+		state, found := neighborStates[ndm.State]
+		if !found {
+			// NUD_NOARP, NUD_NONE, and combinations used by bridges.
+			continue
+		}
+
+		var family string
+		switch ndm.Family {
+		case unix.AF_INET:
+			family = types.FamilyIPv4
+		case unix.AF_INET6:
+			family = types.FamilyIPv6
+		default:
+			continue
+		}
+
+		n := types.Neighbor{
+			Family:    family,
+			Interface: interfaceName(int(ndm.Ifindex)),
+			State:     state,
+		}
+		attrs, err := parseNetlinkAttrs(msg.Data[unix.SizeofNdMsg:])
+		if err != nil {
+			return nil, err
+		}
+		if dst := attrs[unix.NDA_DST]; len(dst) > 0 {
+			n.IP = net.IP(dst).String()
+		}
+		if lladdr := attrs[unix.NDA_LLADDR]; len(lladdr) > 0 {
+			n.MAC = net.HardwareAddr(lladdr).String()
+		}
+		if n.IP == "" {
+			continue
+		}
+		neighbors = append(neighbors, n)
+	}
+	return neighbors, nil
+}
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"net"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Neighbors = (*host)(nil)
+
+// neighborMessage builds an RTM_NEWNEIGH message with the given NDA_DST and
+// NDA_LLADDR attributes.
+func neighborMessage(family uint8, index int32, state uint16, dst net.IP, lladdr net.HardwareAddr) syscall.NetlinkMessage {
+	ndm := unix.NdMsg{Family: family, Ifindex: index, State: state}
+	data := append([]byte(nil), (*[unix.SizeofNdMsg]byte)(unsafe.Pointer(&ndm))[:]...)
+	data = append(data, netlinkAttr(unix.NDA_DST, dst)...)
+	if lladdr != nil {
+		data = append(data, netlinkAttr(unix.NDA_LLADDR, lladdr)...)
+	}
+	return syscall.NetlinkMessage{Header: syscall.NlMsghdr{Type: unix.RTM_NEWNEIGH}, Data: data}
+}
+
+func TestParseNeighborMessages(t *testing.T) {
+	mac, err := net.ParseMAC("52:54:00:12:34:56")
+	require.NoError(t, err)
+
+	msgs := []syscall.NetlinkMessage{
+		neighborMessage(unix.AF_INET, 2, unix.NUD_REACHABLE, net.ParseIP("192.168.1.1").To4(), mac),
+		neighborMessage(unix.AF_INET, 2, unix.NUD_INCOMPLETE, net.ParseIP("192.168.1.7").To4(), nil),
+		neighborMessage(unix.AF_INET6, 2, unix.NUD_STALE, net.ParseIP("fe80::1"), mac),
+		neighborMessage(unix.AF_INET6, 1, unix.NUD_NOARP, net.ParseIP("::1"), nil),
+		{Header: syscall.NlMsghdr{Type: unix.NLMSG_DONE}},
+	}
+	names := func(index int) string { return map[int]string{1: "lo", 2: "eth0"}[index] }
+
+	neighbors, err := parseNeighborMessages(msgs, names)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Neighbor{
+		{Family: types.FamilyIPv4, IP: "192.168.1.1", MAC: "52:54:00:12:34:56", Interface: "eth0", State: types.NeighborStateReachable},
+		{Family: types.FamilyIPv4, IP: "192.168.1.7", Interface: "eth0", State: types.NeighborStateIncomplete},
+		{Family: types.FamilyIPv6, IP: "fe80::1", MAC: "52:54:00:12:34:56", Interface: "eth0", State: types.NeighborStateStale},
+	}, neighbors)
+}
+
+func TestParseNeighborMessagesShort(t *testing.T) {
+	_, err := parseNeighborMessages([]syscall.NetlinkMessage{{Header: syscall.NlMsghdr{Type: unix.RTM_NEWNEIGH}}}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// netlinkAttrTypeMask removes the NLA_F_NESTED and NLA_F_NET_BYTEORDER
+// flags from an attribute type.
+const netlinkAttrTypeMask = 0x3fff
+
+// netlinkAlign rounds n up to the 4 byte alignment of netlink messages and
+// attributes.
+func netlinkAlign(n int) int {
+	return (n + unix.NLA_ALIGNTO - 1) &^ (unix.NLA_ALIGNTO - 1)
+}
+
+// parseNetlinkAttrs parses a sequence of netlink attributes (struct nlattr
+// or struct rtattr) into their values indexed by type. Nested attributes can
+// be parsed by calling it again on their value.
+func parseNetlinkAttrs(b []byte) (map[uint16][]byte, error) {
+	attrs := map[uint16][]byte{}
+	for len(b) >= unix.NLA_HDRLEN {
+		l := int(nativeEndian.Uint16(b[0:]))
+		typ := nativeEndian.Uint16(b[2:]) & netlinkAttrTypeMask
+		if l < unix.NLA_HDRLEN || l > len(b) {
+			return nil, fmt.Errorf("invalid netlink attribute length %d", l)
+		}
+		attrs[typ] = b[unix.NLA_HDRLEN:l]
+
+		if l = netlinkAlign(l); l > len(b) {
+			break
+		}
+		b = b[l:]
+	}
+	return attrs, nil
+}
+
+// netlinkAttr encodes a netlink attribute.
+func netlinkAttr(typ uint16, value []byte) []byte {
+	b := make([]byte, netlinkAlign(unix.NLA_HDRLEN+len(value)))
+	nativeEndian.PutUint16(b[0:], uint16(unix.NLA_HDRLEN+len(value)))
+	nativeEndian.PutUint16(b[2:], typ)
+	copy(b[unix.NLA_HDRLEN:], value)
+	return b
+}
+
+// genlConn is a generic netlink socket.
+type genlConn struct {
+	fd  int
+	seq uint32
+}
+
+// genlReceiveTimeout bounds the time that a request waits for the kernel.
+const genlReceiveTimeout = 5
+
+func dialGenetlink() (*genlConn, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_GENERIC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open generic netlink socket: %w", err)
+	}
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: genlReceiveTimeout}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind generic netlink socket: %w", err)
+	}
+	return &genlConn{fd: fd}, nil
+}
+
+func (c *genlConn) Close() error {
+	return unix.Close(c.fd)
+}
+
+// familyID resolves the ID of a generic netlink family. unix.ENOENT is
+// returned if the family is not registered.
+func (c *genlConn) familyID(name string) (uint16, error) {
+	replies, err := c.request(unix.GENL_ID_CTRL, unix.CTRL_CMD_GETFAMILY, false,
+		netlinkAttr(unix.CTRL_ATTR_FAMILY_NAME, append([]byte(name), 0)))
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range replies {
+		attrs, err := parseNetlinkAttrs(r)
+		if err != nil {
+			return 0, err
+		}
+		if id := attrs[unix.CTRL_ATTR_FAMILY_ID]; len(id) == 2 {
+			return nativeEndian.Uint16(id), nil
+		}
+	}
+	return 0, fmt.Errorf("no ID for generic netlink family %v", name)
+}
+
+// request sends a generic netlink command and returns the attributes of the
+// replies. A dump returns all objects of the kind.
+func (c *genlConn) request(family uint16, cmd uint8, dump bool, attrs []byte) ([][]byte, error) {
+	const genlHdrLen = 4 // struct genlmsghdr: cmd, version, reserved.
+
+	flags := uint16(unix.NLM_F_REQUEST | unix.NLM_F_ACK)
+	if dump {
+		flags |= unix.NLM_F_DUMP
+	}
+	c.seq++
+
+	payload := make([]byte, genlHdrLen, genlHdrLen+len(attrs))
+	payload[0] = cmd
+	payload[1] = 1 // Version.
+	payload = append(payload, attrs...)
+	replies, err := netlinkExchange(c.fd, family, flags, c.seq, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	attrReplies := replies[:0]
+	for _, r := range replies {
+		if len(r) >= genlHdrLen {
+			attrReplies = append(attrReplies, r[genlHdrLen:])
+		}
+	}
+	return attrReplies, nil
+}
+
+// netlinkExchange sends a netlink message and returns the payloads of the
+// replies. A dump (NLM_F_DUMP) ends with NLMSG_DONE, a request with NLM_F_ACK
+// ends with the acknowledgement, and other requests end with the first
+// reply.
+func netlinkExchange(fd int, typ, flags uint16, seq uint32, payload []byte) ([][]byte, error) {
+	msg := make([]byte, unix.NLMSG_HDRLEN, unix.NLMSG_HDRLEN+len(payload))
+	msg = append(msg, payload...)
+	nativeEndian.PutUint32(msg[0:], uint32(len(msg)))
+	nativeEndian.PutUint16(msg[4:], typ)
+	nativeEndian.PutUint16(msg[6:], flags)
+	nativeEndian.PutUint32(msg[8:], seq)
+	if err := unix.Sendto(fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	dump := flags&unix.NLM_F_DUMP == unix.NLM_F_DUMP
+	ack := flags&unix.NLM_F_ACK != 0
+	var replies [][]byte
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != seq {
+				continue
+			}
+			switch m.Header.Type {
+			case unix.NLMSG_DONE:
+				return replies, nil
+			case unix.NLMSG_ERROR:
+				if len(m.Data) < 4 {
+					return nil, fmt.Errorf("netlink error message too short")
+				}
+				if errno := int32(nativeEndian.Uint32(m.Data)); errno != 0 {
+					return nil, syscall.Errno(-errno)
+				}
+				// The acknowledgement ends a request that is not a dump.
+				if !dump {
+					return replies, nil
+				}
+			default:
+				// The buffer is reused by the next receive.
+				replies = append(replies, append([]byte(nil), m.Data...))
+				if !dump && !ack {
+					return replies, nil
+				}
+			}
+		}
+	}
+}
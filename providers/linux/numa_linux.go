@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// numaNodeRegexp matches the directories of the NUMA nodes (e.g. node0).
+var numaNodeRegexp = regexp.MustCompile(`^node([0-9]+)$`)
+
+// NUMA reports the NUMA nodes listed in /sys/devices/system/node.
+func (h *host) NUMA() (*types.NUMAInfo, error) {
+	return numa(h.procFS)
+}
+
+func numa(fs procFS) (*types.NUMAInfo, error) {
+	root := fs.rootPath("sys/devices/system/node")
+	if !exists(root) {
+		return nil, fmt.Errorf("%v does not exist: %w", root, types.ErrNotImplemented)
+	}
+	dirs, err := filepath.Glob(filepath.Join(root, "node*"))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.NUMAInfo{Nodes: []types.NUMANode{}}
+	for _, dir := range dirs {
+		m := numaNodeRegexp.FindStringSubmatch(filepath.Base(dir))
+		if m == nil {
+			continue
+		}
+		id, _ := strconv.Atoi(m[1])
+		node := types.NUMANode{ID: id, CPUs: []int{}}
+
+		if cpulist, err := readFileString(filepath.Join(dir, "cpulist")); err == nil {
+			if node.CPUs, err = parseCPUList(cpulist); err != nil {
+				return nil, fmt.Errorf("failed to parse cpulist of node %d: %w", id, err)
+			}
+		}
+
+		if content, err := ioutil.ReadFile(filepath.Join(dir, "meminfo")); err == nil {
+			// The lines are prefixed with the node (e.g. "Node 0 MemTotal:").
+			prefix := fmt.Sprintf("Node %d ", id)
+			_ = parseKeyValue(content, ":", func(key, value []byte) error {
+				var field *uint64
+				switch strings.TrimPrefix(string(key), prefix) {
+				case "MemTotal":
+					field = &node.MemoryTotal
+				case "MemFree":
+					field = &node.MemoryFree
+				default:
+					return nil
+				}
+				*field, _ = parseBytesOrNumber(value)
+				return nil
+			})
+		}
+		info.Nodes = append(info.Nodes, node)
+	}
+
+	sort.Slice(info.Nodes, func(i, j int) bool { return info.Nodes[i].ID < info.Nodes[j].ID })
+	return info, nil
+}
+
+// parseCPUList parses a list of CPUs in the format used by sysfs (e.g.
+// 0-3,8-11).
+func parseCPUList(list string) ([]int, error) {
+	cpus := []int{}
+	for _, r := range strings.Split(strings.TrimSpace(list), ",") {
+		if r == "" {
+			continue
+		}
+
+		first, last := r, r
+		if i := strings.IndexByte(r, '-'); i >= 0 {
+			first, last = r[:i], r[i+1:]
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, err
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid range %q", r)
+		}
+
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.NUMA = (*host)(nil)
+
+func TestNUMA(t *testing.T) {
+	info, err := numa(newLinuxSystem("testdata/numa").procFS)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.NUMAInfo{
+		Nodes: []types.NUMANode{
+			{ID: 0, CPUs: []int{0, 1, 2, 3, 8, 9, 10, 11}, MemoryTotal: 16318816 * 1024, MemoryFree: 1234568 * 1024},
+			{ID: 1, CPUs: []int{4, 5, 6, 7, 12, 13, 14, 15}, MemoryTotal: 16515072 * 1024, MemoryFree: 8257536 * 1024},
+		},
+	}, info)
+}
+
+func TestNUMANotSupported(t *testing.T) {
+	_, err := numa(newLinuxSystem("testdata/ubuntu1710").procFS)
+	assert.True(t, errors.Is(err, types.ErrNotImplemented), err)
+}
+
+func TestParseCPUList(t *testing.T) {
+	tests := []struct {
+		list string
+		cpus []int
+	}{
+		{"", []int{}},
+		{"0", []int{0}},
+		{"0-2,5\n", []int{0, 1, 2, 5}},
+		{"0,2,4-5", []int{0, 2, 4, 5}},
+	}
+	for _, tc := range tests {
+		cpus, err := parseCPUList(tc.list)
+		require.NoError(t, err, tc.list)
+		assert.Equal(t, tc.cpus, cpus, tc.list)
+	}
+
+	for _, list := range []string{"a", "3-1", "1-"} {
+		_, err := parseCPUList(list)
+		assert.Error(t, err, list)
+	}
+}
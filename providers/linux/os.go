@@ -27,6 +27,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/joeshaw/multierror"
 
@@ -67,8 +68,46 @@ func init() {
 	}
 }
 
+// osInfoCache contains the OS information parsed from the release files of
+// each base directory. The files are only read when the OS information is
+// first requested and not again until InvalidateCache is called because
+// they do not change while the host is running.
+var osInfoCache = struct {
+	sync.Mutex
+	infos map[string]*types.OSInfo
+}{infos: map[string]*types.OSInfo{}}
+
+// OperatingSystem returns information about the operating system. The
+// result is cached for the lifetime of the process (see InvalidateCache).
 func OperatingSystem() (*types.OSInfo, error) {
-	return getOSInfo("")
+	return cachedOSInfo("")
+}
+
+func cachedOSInfo(baseDir string) (*types.OSInfo, error) {
+	osInfoCache.Lock()
+	defer osInfoCache.Unlock()
+
+	info, found := osInfoCache.infos[baseDir]
+	if !found {
+		var err error
+		if info, err = getOSInfo(baseDir); err != nil {
+			// Errors are not cached so that the files are read again.
+			return info, err
+		}
+		osInfoCache.infos[baseDir] = info
+	}
+
+	// Return a copy so that callers cannot modify the cached value.
+	c := *info
+	return &c, nil
+}
+
+// InvalidateCache discards the cached OS information so that the release
+// files are read again by the next call (e.g. after an in-place upgrade).
+func InvalidateCache() {
+	osInfoCache.Lock()
+	defer osInfoCache.Unlock()
+	osInfoCache.infos = map[string]*types.OSInfo{}
 }
 
 func getOSInfo(baseDir string) (*types.OSInfo, error) {
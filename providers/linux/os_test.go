@@ -21,9 +21,13 @@
 package linux
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -372,3 +376,31 @@ func TestOperatingSystem(t *testing.T) {
 		t.Logf("%#v", os)
 	})
 }
+
+func TestCachedOSInfo(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "etc"), 0o755))
+	write := func(version string) {
+		content := "ID=debian\nNAME=\"Debian GNU/Linux\"\nVERSION_ID=\"" + version + "\"\n"
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, osRelease), []byte(content), 0o644))
+	}
+	defer InvalidateCache()
+
+	write("11")
+	info, err := cachedOSInfo(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 11, info.Major)
+
+	// Modifying the result does not modify the cache.
+	info.Major = 0
+
+	write("12")
+	info, err = cachedOSInfo(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 11, info.Major, "expected the cached value")
+
+	InvalidateCache()
+	info, err = cachedOSInfo(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 12, info.Major)
+}
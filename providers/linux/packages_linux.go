@@ -0,0 +1,244 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_packages
+// +build !nosysinfo_packages
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func init() {
+	registry.RegisterSubsystem(registry.SubsystemPackages)
+}
+
+const (
+	dpkgStatusFile = "var/lib/dpkg/status"
+	dpkgInfoDir    = "var/lib/dpkg/info"
+
+	// rpmQueryFormat is the --queryformat used to list the RPM packages.
+	rpmQueryFormat = `%{NAME}\t%{VERSION}-%{RELEASE}\t%{ARCH}\t%{INSTALLTIME}\t%{VENDOR}\n`
+)
+
+// Packages reports the packages installed by dpkg, apk, and rpm. The dpkg
+// and apk databases are parsed directly. The RPM database is queried with
+// the rpm command because its storage format (Berkeley DB, NDB, or SQLite)
+// varies between distributions.
+func (h *host) Packages() ([]types.PackageInfo, error) {
+	v, err := ratelimit.Do(ratelimit.Packages, h.procFS.mountPoint, func() (interface{}, error) {
+		return packages(h.procFS)
+	})
+	pkgs, _ := v.([]types.PackageInfo)
+	return append([]types.PackageInfo(nil), pkgs...), err
+}
+
+func packages(fs procFS) ([]types.PackageInfo, error) {
+	var pkgs []types.PackageInfo
+	var errs []error
+
+	if exists(fs.rootPath(dpkgStatusFile)) {
+		p, err := dpkgPackages(fs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list dpkg packages: %w", err))
+		}
+		pkgs = append(pkgs, p...)
+	}
+
+	if exists(fs.rootPath(apkInstalledFile)) {
+		p, err := apkPackages(fs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list apk packages: %w", err))
+		}
+		pkgs = append(pkgs, p...)
+	}
+
+	if exists(fs.rootPath(rpmDBDir)) {
+		p, err := rpmPackages(fs)
+		if err != nil && !errors.Is(err, exec.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("failed to list rpm packages: %w", err))
+		}
+		pkgs = append(pkgs, p...)
+	}
+
+	if len(errs) > 0 {
+		return pkgs, &multierror.MultiError{Errors: errs}
+	}
+	return pkgs, nil
+}
+
+func dpkgPackages(fs procFS) ([]types.PackageInfo, error) {
+	f, err := os.Open(fs.rootPath(dpkgStatusFile))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pkgs []types.PackageInfo
+	var pkg types.PackageInfo
+	var installed bool
+	flush := func() {
+		if installed && pkg.Name != "" {
+			pkg.Source = "dpkg"
+			pkg.InstallTime = dpkgInstallTime(fs, pkg)
+			pkgs = append(pkgs, pkg)
+		}
+		pkg, installed = types.PackageInfo{}, false
+	}
+
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1024*1024)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			// Continuation of a multi-line field.
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		switch parts[0] {
+		case "Package":
+			pkg.Name = value
+		case "Status":
+			installed = strings.HasSuffix(value, " installed")
+		case "Version":
+			pkg.Version = value
+		case "Architecture":
+			pkg.Architecture = value
+		case "Maintainer":
+			pkg.Publisher = value
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return pkgs, nil
+}
+
+// dpkgInstallTime returns the modification time of the file list of the
+// package, which is written when the package is installed or upgraded.
+func dpkgInstallTime(fs procFS, pkg types.PackageInfo) time.Time {
+	for _, name := range []string{pkg.Name + ":" + pkg.Architecture, pkg.Name} {
+		if fi, err := os.Stat(filepath.Join(fs.rootPath(dpkgInfoDir), name+".list")); err == nil {
+			return fi.ModTime().UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func apkPackages(fs procFS) ([]types.PackageInfo, error) {
+	content, err := ioutil.ReadFile(fs.rootPath(apkInstalledFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []types.PackageInfo
+	for _, entry := range bytes.Split(content, []byte("\n\n")) {
+		var pkg types.PackageInfo
+		s := bufio.NewScanner(bytes.NewReader(entry))
+		for s.Scan() {
+			line := s.Text()
+			if len(line) < 2 || line[1] != ':' {
+				continue
+			}
+			switch line[0] {
+			case 'P':
+				pkg.Name = line[2:]
+			case 'V':
+				pkg.Version = line[2:]
+			case 'A':
+				pkg.Architecture = line[2:]
+			case 'm':
+				pkg.Publisher = line[2:]
+			}
+		}
+		if pkg.Name != "" {
+			pkg.Source = "apk"
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+func rpmPackages(fs procFS) ([]types.PackageInfo, error) {
+	args := []string{"--query", "--all", "--queryformat", rpmQueryFormat}
+	if fs.baseMount != "" {
+		args = append(args, "--dbpath", fs.rootPath(rpmDBDir))
+	}
+
+	out, err := exec.Command("rpm", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseRPMQuery(out), nil
+}
+
+// parseRPMQuery parses the output of rpm --query with rpmQueryFormat.
+func parseRPMQuery(out []byte) []types.PackageInfo {
+	var pkgs []types.PackageInfo
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		fields := strings.Split(s.Text(), "\t")
+		if len(fields) != 5 || fields[0] == "gpg-pubkey" {
+			continue
+		}
+
+		pkg := types.PackageInfo{
+			Name:         fields[0],
+			Version:      fields[1],
+			Architecture: fields[2],
+			Source:       "rpm",
+		}
+		if fields[2] == "(none)" {
+			pkg.Architecture = ""
+		}
+		if ts, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			pkg.InstallTime = time.Unix(ts, 0).UTC()
+		}
+		if fields[4] != "(none)" {
+			pkg.Publisher = fields[4]
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_packages
+// +build !nosysinfo_packages
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestPackagesDpkg(t *testing.T) {
+	pkgs, err := packages(newLinuxSystem("testdata/packages_dpkg").procFS)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2, "packages that are not installed must be skipped")
+
+	// The install time is the mtime of the file list, which is not
+	// preserved by git.
+	assert.False(t, pkgs[0].InstallTime.IsZero())
+	pkgs[0].InstallTime = time.Time{}
+
+	assert.Equal(t, []types.PackageInfo{
+		{
+			Name:         "bash",
+			Version:      "5.1-6ubuntu1",
+			Architecture: "amd64",
+			Publisher:    "Ubuntu Developers <ubuntu-devel-discuss@lists.ubuntu.com>",
+			Source:       "dpkg",
+		},
+		{
+			Name:         "libc6",
+			Version:      "2.35-0ubuntu3.1",
+			Architecture: "amd64",
+			Publisher:    "Ubuntu Developers <ubuntu-devel-discuss@lists.ubuntu.com>",
+			Source:       "dpkg",
+		},
+	}, pkgs)
+}
+
+func TestPackagesApk(t *testing.T) {
+	pkgs, err := packages(newLinuxSystem("testdata/packages_apk").procFS)
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.PackageInfo{
+		{
+			Name:         "musl",
+			Version:      "1.2.3-r4",
+			Architecture: "x86_64",
+			Publisher:    "Timo Teräs <timo.teras@iki.fi>",
+			Source:       "apk",
+		},
+		{
+			Name:         "busybox",
+			Version:      "1.36.0-r9",
+			Architecture: "x86_64",
+			Publisher:    "Sören Tempel <soeren+alpine@soeren-tempel.net>",
+			Source:       "apk",
+		},
+	}, pkgs)
+}
+
+func TestParseRPMQuery(t *testing.T) {
+	out := []byte("bash\t5.1.8-6.el9\tx86_64\t1667312345\tRed Hat, Inc.\n" +
+		"gpg-pubkey\tfd431d51-4ae0493b\t(none)\t1667312300\t(none)\n" +
+		"tzdata\t2022f-1.el9\tnoarch\t1667312346\t(none)\n")
+
+	assert.Equal(t, []types.PackageInfo{
+		{
+			Name:         "bash",
+			Version:      "5.1.8-6.el9",
+			Architecture: "x86_64",
+			InstallTime:  time.Unix(1667312345, 0).UTC(),
+			Publisher:    "Red Hat, Inc.",
+			Source:       "rpm",
+		},
+		{
+			Name:         "tzdata",
+			Version:      "2022f-1.el9",
+			Architecture: "noarch",
+			InstallTime:  time.Unix(1667312346, 0).UTC(),
+			Source:       "rpm",
+		},
+	}, parseRPMQuery(out))
+}
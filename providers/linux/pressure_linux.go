@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Pressure reports the pressure stall information of /proc/pressure. PSI is
+// available since Linux 4.20 when the kernel is built with CONFIG_PSI and it
+// is not disabled with the psi=0 boot parameter.
+func (h *host) Pressure() (*types.PressureInfo, error) {
+	return pressure(h.procFS)
+}
+
+func pressure(fs procFS) (*types.PressureInfo, error) {
+	dir := fs.path("pressure")
+	if !exists(dir) {
+		return nil, fmt.Errorf("%v does not exist: %w", dir, types.ErrNotImplemented)
+	}
+
+	var info types.PressureInfo
+	for _, r := range []struct {
+		name  string
+		field **types.ResourcePressure
+	}{
+		{"cpu", &info.CPU},
+		{"memory", &info.Memory},
+		{"io", &info.IO},
+		{"irq", &info.IRQ},
+	} {
+		content, err := ioutil.ReadFile(fs.path("pressure", r.name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			// Reading fails with EOPNOTSUPP when PSI is disabled at boot.
+			return nil, fmt.Errorf("failed to read PSI: %v: %w", err, types.ErrNotImplemented)
+		}
+
+		p, err := parsePressure(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %v: %w", fs.path("pressure", r.name), err)
+		}
+		*r.field = p
+	}
+	return &info, nil
+}
+
+// parsePressure parses a /proc/pressure file. The lines have the form
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//
+// where total is in microseconds.
+func parsePressure(content []byte) (*types.ResourcePressure, error) {
+	var p types.ResourcePressure
+
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		fields := bytes.Fields(s.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var stats types.PressureStats
+		for _, f := range fields[1:] {
+			kv := bytes.SplitN(f, []byte("="), 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid field %q", f)
+			}
+
+			var err error
+			switch string(kv[0]) {
+			case "avg10":
+				stats.Avg10, err = strconv.ParseFloat(string(kv[1]), 64)
+			case "avg60":
+				stats.Avg60, err = strconv.ParseFloat(string(kv[1]), 64)
+			case "avg300":
+				stats.Avg300, err = strconv.ParseFloat(string(kv[1]), 64)
+			case "total":
+				var us uint64
+				us, err = strconv.ParseUint(string(kv[1]), 10, 64)
+				stats.Total = time.Duration(us) * time.Microsecond
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value: %w", kv[0], err)
+			}
+		}
+
+		switch string(fields[0]) {
+		case "some":
+			p.Some = &stats
+		case "full":
+			p.Full = &stats
+		}
+	}
+	return &p, s.Err()
+}
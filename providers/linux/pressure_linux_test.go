@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Pressure = (*host)(nil)
+
+func TestPressure(t *testing.T) {
+	info, err := pressure(newLinuxSystem("testdata/pressure").procFS)
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.ResourcePressure{
+		Some: &types.PressureStats{Avg10: 1.53, Avg60: 0.87, Avg300: 0.32, Total: 123456789 * time.Microsecond},
+		Full: &types.PressureStats{},
+	}, info.CPU)
+	assert.Equal(t, &types.ResourcePressure{
+		Some: &types.PressureStats{Avg10: 12.5, Avg60: 8.25, Avg300: 3, Total: 987654 * time.Microsecond},
+		Full: &types.PressureStats{Avg10: 10, Avg60: 6, Avg300: 2, Total: 876543 * time.Microsecond},
+	}, info.IO)
+	require.NotNil(t, info.Memory)
+	assert.Equal(t, 2*time.Millisecond, info.Memory.Full.Total)
+	assert.Nil(t, info.IRQ)
+}
+
+func TestPressureNotSupported(t *testing.T) {
+	_, err := pressure(newLinuxSystem("testdata/ubuntu1710").procFS)
+	assert.True(t, errors.Is(err, types.ErrNotImplemented), err)
+}
+
+func TestParsePressureInvalid(t *testing.T) {
+	_, err := parsePressure([]byte("some avg10=x avg60=0.00 avg300=0.00 total=0\n"))
+	assert.Error(t, err)
+}
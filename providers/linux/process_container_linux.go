@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_containers
+// +build !nosysinfo_containers
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Container reports the container of the process from its control groups in
+// /proc/<pid>/cgroup. The cgroup v2 path is used when the process is in the
+// unified hierarchy, otherwise the cgroup v1 paths are searched.
+func (p *process) Container() (*types.ProcessContainerInfo, error) {
+	return processContainer(p.fs, p.PID())
+}
+
+func processContainer(fs procFS, pid int) (*types.ProcessContainerInfo, error) {
+	path := fs.path(strconv.Itoa(pid), "cgroup")
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process cgroups: %w", err)
+	}
+
+	// Each line is hierarchy-ID:controller-list:cgroup-path. The line of
+	// cgroup v2 has the ID 0 and no controllers.
+	var paths []string
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		fields := strings.SplitN(s.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			paths = append([]string{fields[2]}, paths...)
+		} else {
+			paths = append(paths, fields[2])
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+
+	cg := newCgroupHierarchy(fs)
+	for _, cgroup := range paths {
+		if info := cgroupContainer(fs, cg, cgroup); info != nil {
+			return info, nil
+		}
+	}
+	return nil, nil
+}
+
+// cgroupContainer returns the container that contains the control group. The
+// outermost container is returned for nested containers, like Containers
+// does.
+func cgroupContainer(fs procFS, cg cgroupHierarchy, cgroup string) *types.ProcessContainerInfo {
+	elems := strings.Split(strings.Trim(cgroup, "/"), "/")
+	for i, elem := range elems {
+		rel := filepath.Join(elems[:i+1]...)
+
+		if m := containerCgroupRegexp.FindStringSubmatch(elem); m != nil {
+			runtime, known := cgroupPrefixRuntimes[m[1]]
+			if m[1] != "" && !known {
+				continue
+			}
+			if runtime == "" {
+				runtime = containerRuntime(fs, m[2], rel)
+			}
+			return &types.ProcessContainerInfo{ID: m[2], Runtime: runtime, Cgroup: cgroup}
+		}
+
+		if runtime, name, _ := machineCgroup(fs, cg, rel); runtime != "" {
+			return &types.ProcessContainerInfo{ID: name, Runtime: runtime, Cgroup: cgroup}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_containers
+// +build !nosysinfo_containers
+
+package linux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ProcessContainer = (*process)(nil)
+
+func TestProcessContainer(t *testing.T) {
+	fs := newLinuxSystem("testdata/process_container").procFS
+
+	tests := []struct {
+		pid       int
+		container *types.ProcessContainerInfo
+	}{
+		{100, &types.ProcessContainerInfo{
+			ID:      strings.Repeat("a", 63) + "1",
+			Runtime: "docker",
+			Cgroup:  "/system.slice/docker-" + strings.Repeat("a", 63) + "1.scope",
+		}},
+		// cgroup v1 with an empty cgroup v2 hierarchy.
+		{200, &types.ProcessContainerInfo{
+			ID:      strings.Repeat("d", 63) + "4",
+			Runtime: "cri-o",
+			Cgroup:  "/kubepods/besteffort/pod5678/" + strings.Repeat("d", 63) + "4",
+		}},
+		{300, &types.ProcessContainerInfo{
+			ID:      "debian",
+			Runtime: "systemd-nspawn",
+			Cgroup:  "/machine.slice/systemd-nspawn@debian.service/payload/system.slice/cron.service",
+		}},
+		{400, nil},
+		{500, &types.ProcessContainerInfo{ID: "old", Runtime: "lxc", Cgroup: "/lxc/old"}},
+		// The container monitor is not part of the container.
+		{600, nil},
+	}
+	for _, tc := range tests {
+		container, err := processContainer(fs, tc.pid)
+		require.NoError(t, err, tc.pid)
+		assert.Equal(t, tc.container, container, tc.pid)
+	}
+
+	_, err := processContainer(fs, 7)
+	assert.Error(t, err)
+}
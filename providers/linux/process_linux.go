@@ -19,6 +19,7 @@ package linux
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -27,24 +28,145 @@ import (
 
 	"github.com/prometheus/procfs"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/safe"
+	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
 
+// userHz is the frequency of the clock ticks reported in /proc/<pid>/stat.
 const userHz = 100
 
 func (s linuxSystem) Processes() ([]types.Process, error) {
-	procs, err := s.procFS.AllProcs()
+	return s.ProcessesMatching(types.ProcessFilter{})
+}
+
+// ProcessesMatching returns the processes selected by filter. The PIDs are
+// checked before reading /proc. The name and state are read from
+// /proc/<pid>/stat and the user from /proc/<pid>/status only when the filter
+// needs them.
+func (s linuxSystem) ProcessesMatching(filter types.ProcessFilter) ([]types.Process, error) {
+	procs, err := s.candidateProcs(filter.PIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	processes := make([]types.Process, 0, len(procs))
+	limit := footprint.Limit(len(procs))
+	processes := make([]types.Process, 0, limit)
 	for _, proc := range procs {
-		processes = append(processes, &process{Proc: proc, fs: s.procFS})
+		if len(processes) == limit {
+			break
+		}
+		if !filter.MatchPID(proc.PID) {
+			continue
+		}
+
+		p := &process{Proc: proc, fs: s.procFS}
+		if len(filter.Names) > 0 || len(filter.States) > 0 {
+			stat, err := proc.NewStat()
+			if err != nil {
+				// The process exited.
+				continue
+			}
+			if !filter.MatchName(stat.Comm) || !filter.MatchState(processState(stat.State)) {
+				continue
+			}
+		}
+		if len(filter.UIDs) > 0 {
+			user, err := p.User()
+			if err != nil || !filter.MatchUID(user.UID) {
+				continue
+			}
+		}
+		processes = append(processes, p)
 	}
 	return processes, nil
 }
 
+// ForEachProcess calls fn for each process as the entries of /proc are read,
+// until fn returns false.
+func (s linuxSystem) ForEachProcess(fn func(types.Process) bool) error {
+	dir, err := os.Open(s.procFS.path())
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	for {
+		names, err := dir.Readdirnames(readDirBatchSize)
+		for _, name := range names {
+			pid, err := strconv.Atoi(name)
+			if err != nil {
+				continue
+			}
+			proc, err := s.procFS.NewProc(pid)
+			if err != nil {
+				// The process exited.
+				continue
+			}
+			if !fn(&process{Proc: proc, fs: s.procFS}) {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readDirBatchSize is the number of /proc entries read at a time by
+// ForEachProcess.
+const readDirBatchSize = 128
+
+// candidateProcs returns the processes with the given PIDs, or all processes
+// when pids is empty.
+func (s linuxSystem) candidateProcs(pids []int) (procfs.Procs, error) {
+	if len(pids) == 0 {
+		return s.procFS.AllProcs()
+	}
+
+	procs := make(procfs.Procs, 0, len(pids))
+	seen := make(map[int]struct{}, len(pids))
+	for _, pid := range pids {
+		if _, found := seen[pid]; found {
+			continue
+		}
+		seen[pid] = struct{}{}
+
+		proc, err := s.procFS.NewProc(pid)
+		if err != nil {
+			// The process does not exist.
+			continue
+		}
+		procs = append(procs, proc)
+	}
+	return procs, nil
+}
+
+// processState converts the state letter of /proc/<pid>/stat to a
+// ProcessState constant. See proc(5).
+func processState(state string) string {
+	switch state {
+	case "R":
+		return types.ProcessStateRunning
+	case "S":
+		return types.ProcessStateSleeping
+	case "D":
+		return types.ProcessStateDiskSleep
+	case "T", "t":
+		return types.ProcessStateStopped
+	case "Z":
+		return types.ProcessStateZombie
+	case "I":
+		return types.ProcessStateIdle
+	default:
+		return ""
+	}
+}
+
 func (s linuxSystem) Process(pid int) (types.Process, error) {
 	proc, err := s.procFS.NewProc(pid)
 	if err != nil {
@@ -73,7 +195,9 @@ func (p *process) PID() int {
 	return p.Proc.PID
 }
 
-func (p *process) Parent() (types.Process, error) {
+func (p *process) Parent() (_ types.Process, err error) {
+	defer safe.Recover("process parent", &err)
+
 	info, err := p.Info()
 	if err != nil {
 		return nil, err
@@ -101,7 +225,9 @@ func (p *process) CWD() (string, error) {
 	return cwd, err
 }
 
-func (p *process) Info() (types.ProcessInfo, error) {
+func (p *process) Info() (_ types.ProcessInfo, err error) {
+	defer safe.Recover("process info", &err)
+
 	if p.info != nil {
 		return *p.info, nil
 	}
@@ -144,7 +270,9 @@ func (p *process) Info() (types.ProcessInfo, error) {
 	return *p.info, nil
 }
 
-func (p *process) Memory() (types.MemoryInfo, error) {
+func (p *process) Memory() (_ types.MemoryInfo, err error) {
+	defer safe.Recover("process memory", &err)
+
 	stat, err := p.NewStat()
 	if err != nil {
 		return types.MemoryInfo{}, err
@@ -156,7 +284,9 @@ func (p *process) Memory() (types.MemoryInfo, error) {
 	}, nil
 }
 
-func (p *process) CPUTime() (types.CPUTimes, error) {
+func (p *process) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("process cpu time", &err)
+
 	stat, err := p.NewStat()
 	if err != nil {
 		return types.CPUTimes{}, err
@@ -222,7 +352,9 @@ func (p *process) Capabilities() (*types.CapabilityInfo, error) {
 	return readCapabilities(content)
 }
 
-func (p *process) User() (types.UserInfo, error) {
+func (p *process) User() (_ types.UserInfo, err error) {
+	defer safe.Recover("process user", &err)
+
 	content, err := ioutil.ReadFile(p.path("status"))
 	if err != nil {
 		return types.UserInfo{}, err
@@ -277,6 +409,5 @@ func (p *process) NetworkCounters() (*types.NetworkCountersInfo, error) {
 }
 
 func ticksToDuration(ticks uint64) time.Duration {
-	seconds := float64(ticks) / float64(userHz) * float64(time.Second)
-	return time.Duration(int64(seconds))
+	return shared.TicksToDuration(ticks, userHz)
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/go-sysinfo/internal/registry"
 	"github.com/elastic/go-sysinfo/types"
@@ -29,6 +30,8 @@ import (
 var (
 	_ registry.HostProvider    = linuxSystem{}
 	_ registry.ProcessProvider = linuxSystem{}
+	_ registry.ProcessMatcher  = linuxSystem{}
+	_ registry.ProcessIterator = linuxSystem{}
 )
 
 func TestProcessNetstat(t *testing.T) {
@@ -50,3 +53,46 @@ func TestProcessNetstat(t *testing.T) {
 	assert.NotEmpty(t, stats.SNMP.TCP, "TCP")
 	assert.NotEmpty(t, stats.SNMP.UDP, "UDP")
 }
+
+func TestProcessesMatching(t *testing.T) {
+	s := newLinuxSystem("testdata/processes_filter")
+
+	pids := func(filter types.ProcessFilter) []int {
+		t.Helper()
+		procs, err := s.ProcessesMatching(filter)
+		require.NoError(t, err)
+		pids := make([]int, 0, len(procs))
+		for _, p := range procs {
+			pids = append(pids, p.PID())
+		}
+		return pids
+	}
+
+	assert.ElementsMatch(t, []int{1, 200, 300, 400}, pids(types.ProcessFilter{}))
+	assert.ElementsMatch(t, []int{200, 300}, pids(types.ProcessFilter{PIDs: []int{200, 300, 300, 999}}))
+	assert.ElementsMatch(t, []int{200}, pids(types.ProcessFilter{Names: []string{"sshd"}}))
+	assert.ElementsMatch(t, []int{300, 400}, pids(types.ProcessFilter{UIDs: []string{"1000"}}))
+	assert.ElementsMatch(t, []int{400}, pids(types.ProcessFilter{
+		UIDs:   []string{"1000"},
+		States: []string{types.ProcessStateZombie},
+	}))
+	assert.Empty(t, pids(types.ProcessFilter{PIDs: []int{1}, Names: []string{"bash"}}))
+}
+
+func TestForEachProcess(t *testing.T) {
+	s := newLinuxSystem("testdata/processes_filter")
+
+	var pids []int
+	require.NoError(t, s.ForEachProcess(func(p types.Process) bool {
+		pids = append(pids, p.PID())
+		return true
+	}))
+	assert.ElementsMatch(t, []int{1, 200, 300, 400}, pids)
+
+	var n int
+	require.NoError(t, s.ForEachProcess(func(types.Process) bool {
+		n++
+		return n < 2
+	}))
+	assert.Equal(t, 2, n)
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Suspend stops the process with SIGSTOP.
+func (p *process) Suspend() error {
+	if err := syscall.Kill(p.PID(), syscall.SIGSTOP); err != nil {
+		return fmt.Errorf("failed to send SIGSTOP: %w", err)
+	}
+	return nil
+}
+
+// Resume continues the process with SIGCONT.
+func (p *process) Resume() error {
+	if err := syscall.Kill(p.PID(), syscall.SIGCONT); err != nil {
+		return fmt.Errorf("failed to send SIGCONT: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Suspender = (*process)(nil)
+
+func TestSuspendResume(t *testing.T) {
+	cmd := exec.Command("sleep", "60")
+	require.NoError(t, cmd.Start())
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	proc, err := newLinuxSystem("").Process(cmd.Process.Pid)
+	require.NoError(t, err)
+	p := proc.(*process)
+
+	state := func() string {
+		stat, err := p.NewStat()
+		require.NoError(t, err)
+		return stat.State
+	}
+
+	require.NoError(t, p.Suspend())
+	assert.Eventually(t, func() bool { return state() == "T" }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, p.Resume())
+	assert.Eventually(t, func() bool { return state() == "S" }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// rawFile is a /proc file exposed by Raw with the function that parses it.
+type rawFile struct {
+	key   string
+	path  string
+	parse func([]byte) interface{}
+}
+
+// Raw returns the contents of /proc/meminfo, /proc/vmstat, /proc/loadavg, and
+// /proc/stat. The values are the strings found in the files.
+func (h *host) Raw() (map[string]interface{}, error) {
+	return readRawFiles([]rawFile{
+		{"meminfo", h.procFS.path("meminfo"), rawKeyValues(":")},
+		{"vmstat", h.procFS.path("vmstat"), rawKeyValues(" ")},
+		{"loadavg", h.procFS.path("loadavg"), rawFields},
+		{"stat", h.procFS.path("stat"), rawLines},
+	})
+}
+
+// Raw returns the contents of /proc/<pid>/status, /proc/<pid>/stat, and
+// /proc/<pid>/io. The files that cannot be read (e.g. io of the processes of
+// other users) are omitted.
+func (p *process) Raw() (map[string]interface{}, error) {
+	return readRawFiles([]rawFile{
+		{"status", p.path("status"), rawKeyValues(":")},
+		{"stat", p.path("stat"), rawProcStat},
+		{"io", p.path("io"), rawKeyValues(":")},
+	})
+}
+
+// readRawFiles parses the files that can be read. An error is returned only
+// if none of them can be read.
+func readRawFiles(files []rawFile) (map[string]interface{}, error) {
+	raw := map[string]interface{}{}
+	var firstErr error
+	for _, f := range files {
+		content, err := os.ReadFile(f.path)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		raw[f.key] = f.parse(content)
+	}
+	if len(raw) == 0 {
+		return nil, firstErr
+	}
+	return raw, nil
+}
+
+// rawKeyValues parses lines in the key<separator>value format.
+func rawKeyValues(separator string) func([]byte) interface{} {
+	return func(content []byte) interface{} {
+		m := map[string]string{}
+		_ = parseKeyValue(content, separator, func(key, value []byte) error {
+			m[string(bytes.TrimSpace(key))] = string(value)
+			return nil
+		})
+		return m
+	}
+}
+
+// rawFields splits the content into whitespace separated fields.
+func rawFields(content []byte) interface{} {
+	return strings.Fields(string(content))
+}
+
+// rawLines maps the first field of each line to the other fields.
+func rawLines(content []byte) interface{} {
+	m := map[string][]string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			m[fields[0]] = fields[1:]
+		}
+	}
+	return m
+}
+
+// rawProcStat splits /proc/<pid>/stat into its fields. The command name,
+// which can contain spaces, is the second field without its parentheses.
+func rawProcStat(content []byte) interface{} {
+	s := strings.TrimSpace(string(content))
+	start, end := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if start < 0 || end < start {
+		return strings.Fields(s)
+	}
+	fields := []string{strings.TrimSpace(s[:start]), s[start+1 : end]}
+	return append(fields, strings.Fields(s[end+1:])...)
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Raw = (*host)(nil)
+	_ types.Raw = (*process)(nil)
+)
+
+func TestHostRaw(t *testing.T) {
+	h := &host{procFS: newLinuxSystem("testdata/ubuntu1710").procFS}
+
+	raw, err := h.Raw()
+	require.NoError(t, err)
+	assert.Equal(t, "4042048 kB", raw["meminfo"].(map[string]string)["MemTotal"])
+	assert.Contains(t, raw["vmstat"], "pgfault")
+	assert.Len(t, raw["loadavg"], 5)
+	assert.Contains(t, raw["stat"], "btime")
+}
+
+func TestProcessRaw(t *testing.T) {
+	proc, err := newLinuxSystem("testdata/raw").Process(42)
+	require.NoError(t, err)
+
+	raw, err := proc.(types.Raw).Raw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Name":  "my app",
+		"Umask": "0022",
+		"State": "S (sleeping)",
+		"Tgid":  "42",
+		"VmRSS": "2048 kB",
+	}, raw["status"])
+
+	stat := raw["stat"].([]string)
+	assert.Equal(t, []string{"42", "my app", "S", "1"}, stat[:4])
+	assert.Len(t, stat, 24)
+	assert.NotContains(t, raw, "io", "missing files are omitted")
+
+	_, err = (&process{fs: newLinuxSystem("testdata/raw").procFS}).Raw()
+	assert.Error(t, err)
+}
@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	rebootRequiredFile     = "run/reboot-required"
+	rebootRequiredPkgsFile = "run/reboot-required.pkgs"
+	kernelModulesDir       = "lib/modules"
+	rpmDBDir               = "var/lib/rpm"
+
+	// rpmTimeout bounds the time spent waiting for rpm.
+	rpmTimeout = 30 * time.Second
+)
+
+// rebootRPMPackages are the packages whose update requires a reboot. It is
+// the list used by dnf needs-restarting --reboothint.
+var rebootRPMPackages = []string{
+	"kernel", "kernel-core", "kernel-rt", "glibc", "linux-firmware", "systemd",
+	"dbus", "dbus-broker", "dbus-daemon", "microcode_ctl", "openssl-libs", "gnutls",
+}
+
+// RebootRequired reports whether the host must be restarted. It combines
+// the flag file that Debian and Ubuntu packages create in /run, the logic
+// of dnf needs-restarting (core RPM packages installed after the boot), and
+// a check that the modules of the running kernel have not been removed by a
+// kernel update.
+func (h *host) RebootRequired() (*types.RebootRequiredInfo, error) {
+	boot, err := bootTime(h.procFS.FS)
+	if err != nil {
+		return nil, err
+	}
+	return rebootRequired(h.procFS, boot, rpmInstallTimes)
+}
+
+func rebootRequired(fs procFS, boot time.Time, installTimes func(procFS, []string) (map[string]time.Time, error)) (*types.RebootRequiredInfo, error) {
+	reasons := map[string]struct{}{}
+
+	if exists(fs.rootPath(rebootRequiredFile)) {
+		content, _ := ioutil.ReadFile(fs.rootPath(rebootRequiredPkgsFile))
+		for _, pkg := range strings.Fields(string(content)) {
+			reasons[pkg] = struct{}{}
+		}
+		if len(reasons) == 0 {
+			reasons["reboot-required"] = struct{}{}
+		}
+	}
+
+	if exists(fs.rootPath(rpmDBDir)) {
+		times, err := installTimes(fs, rebootRPMPackages)
+		if err != nil {
+			return nil, err
+		}
+		for pkg, t := range times {
+			if t.After(boot) {
+				reasons[pkg] = struct{}{}
+			}
+		}
+	}
+
+	if kernelModulesRemoved(fs) {
+		reasons["kernel"] = struct{}{}
+	}
+
+	info := &types.RebootRequiredInfo{Required: len(reasons) > 0}
+	for r := range reasons {
+		info.Reasons = append(info.Reasons, r)
+	}
+	sort.Strings(info.Reasons)
+	return info, nil
+}
+
+// kernelModulesRemoved reports whether the modules of the running kernel are
+// missing while those of other kernels are installed, which happens when a
+// kernel update replaces the running kernel. Hosts without any modules (e.g.
+// containers) are not reported.
+func kernelModulesRemoved(fs procFS) bool {
+	release, err := ioutil.ReadFile(fs.path("sys/kernel/osrelease"))
+	if err != nil {
+		return false
+	}
+	installed, err := ioutil.ReadDir(fs.rootPath(kernelModulesDir))
+	if err != nil || len(installed) == 0 {
+		return false
+	}
+	return !exists(fs.rootPath(kernelModulesDir, strings.TrimSpace(string(release))))
+}
+
+// rpmCache contains the results of rpmInstallTimes by database and query.
+// They are reused until a file of the database is modified, so that polling
+// LastUpdate or RebootRequired does not run rpm each time.
+var rpmCache = struct {
+	sync.Mutex
+	entries map[string]rpmCacheEntry
+}{entries: map[string]rpmCacheEntry{}}
+
+type rpmCacheEntry struct {
+	modTime time.Time
+	times   map[string]time.Time
+}
+
+// rpmInstallTimes returns the latest install time of the given packages, or
+// of all packages if names is empty. Packages that are not installed are
+// omitted. No packages are returned if rpm is not installed. The returned
+// map must not be modified.
+func rpmInstallTimes(fs procFS, names []string) (map[string]time.Time, error) {
+	key := fs.rootPath(rpmDBDir) + "\x00" + strings.Join(names, " ")
+	modTime := rpmDBModTime(fs)
+
+	rpmCache.Lock()
+	defer rpmCache.Unlock()
+	if e, found := rpmCache.entries[key]; found && !modTime.IsZero() && e.modTime.Equal(modTime) {
+		return e.times, nil
+	}
+
+	args := []string{"--query", "--queryformat", `%{NAME}\t%{INSTALLTIME}\n`}
+	if fs.baseMount != "" {
+		args = append(args, "--dbpath", fs.rootPath(rpmDBDir))
+	}
+	if len(names) == 0 {
+		args = append(args, "--all")
+	}
+	args = append(args, names...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpmTimeout)
+	defer cancel()
+
+	// rpm exits with an error if any of the packages is not installed.
+	out, err := exec.CommandContext(ctx, "rpm", args...).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		// The database exists but rpm is not installed.
+		return nil, nil
+	}
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+
+	times := parseRPMInstallTimes(out)
+	rpmCache.entries[key] = rpmCacheEntry{modTime: modTime, times: times}
+	return times, nil
+}
+
+// rpmDBModTime returns the latest modification time of the files of the RPM
+// database, or the zero time if it cannot be read.
+func rpmDBModTime(fs procFS) time.Time {
+	files, err := ioutil.ReadDir(fs.rootPath(rpmDBDir))
+	if err != nil {
+		return time.Time{}
+	}
+	var latest time.Time
+	for _, fi := range files {
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest
+}
+
+// parseRPMInstallTimes parses the NAME and INSTALLTIME pairs printed by
+// rpmInstallTimes.
+func parseRPMInstallTimes(out []byte) map[string]time.Time {
+	times := map[string]time.Time{}
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		name, ts, found := strings.Cut(s.Text(), "\t")
+		if !found {
+			continue
+		}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		if t := time.Unix(sec, 0).UTC(); t.After(times[name]) {
+			times[name] = t
+		}
+	}
+	return times
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.RebootRequired = (*host)(nil)
+
+func TestRebootRequired(t *testing.T) {
+	boot := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	installTimes := func(procFS, []string) (map[string]time.Time, error) {
+		return map[string]time.Time{
+			"kernel-core": boot.Add(time.Hour),
+			"glibc":       boot.Add(-time.Hour),
+		}, nil
+	}
+
+	for _, tc := range []struct {
+		root     string
+		expected types.RebootRequiredInfo
+	}{
+		{"testdata/reboot_dpkg", types.RebootRequiredInfo{Required: true, Reasons: []string{"libc6", "linux-image-6.1.0-18-amd64"}}},
+		{"testdata/reboot_kernel", types.RebootRequiredInfo{Required: true, Reasons: []string{"kernel"}}},
+		{"testdata/reboot_rpm", types.RebootRequiredInfo{Required: true, Reasons: []string{"kernel-core"}}},
+		{"testdata/dns", types.RebootRequiredInfo{Required: false}},
+	} {
+		t.Run(tc.root, func(t *testing.T) {
+			info, err := rebootRequired(newLinuxSystem(tc.root).procFS, boot, installTimes)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, *info)
+		})
+	}
+}
+
+func TestParseRPMInstallTimes(t *testing.T) {
+	times := parseRPMInstallTimes([]byte("kernel-core\t1709280000\nkernel-core\t1709290000\npackage kernel-rt is not installed\n"))
+	assert.Equal(t, map[string]time.Time{"kernel-core": time.Unix(1709290000, 0).UTC()}, times)
+}
+
+func TestRPMInstallTimes(t *testing.T) {
+	root := t.TempDir()
+	db := filepath.Join(root, rpmDBDir, "rpmdb.sqlite")
+	require.NoError(t, os.MkdirAll(filepath.Dir(db), 0o755))
+	require.NoError(t, os.WriteFile(db, nil, 0o644))
+	fs := newLinuxSystem(root).procFS
+
+	// rpm is not installed.
+	t.Setenv("PATH", t.TempDir())
+	times, err := rpmInstallTimes(fs, nil)
+	require.NoError(t, err)
+	assert.Empty(t, times)
+
+	// A fake rpm that counts its calls.
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	script := "#!/bin/sh\necho >> " + calls + "\nprintf 'kernel-core\\t1709280000\\n'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "rpm"), []byte(script), 0o755))
+	t.Setenv("PATH", bin)
+
+	expected := map[string]time.Time{"kernel-core": time.Unix(1709280000, 0).UTC()}
+	for i := 0; i < 2; i++ {
+		times, err = rpmInstallTimes(fs, []string{"kernel-core"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, times)
+	}
+	content, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Len(t, content, 1, "rpm must not run again while the database is unchanged")
+
+	// A transaction modifies the database.
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(db, later, later))
+	_, err = rpmInstallTimes(fs, []string{"kernel-core"})
+	require.NoError(t, err)
+	content, err = os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Len(t, content, 2)
+}
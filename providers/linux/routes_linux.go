@@ -0,0 +1,185 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Route flags from linux/route.h.
+const (
+	rtfUp     = 0x0001
+	rtfReject = 0x0200
+)
+
+// Routes reports the routes from /proc/net/route and /proc/net/ipv6_route of
+// the network namespace of the caller. They contain the same routes as an
+// RTM_GETROUTE netlink dump without needing a netlink socket: the main table
+// for IPv4 and all tables for IPv6. Routes that are down or that reject
+// packets are omitted. The IPv6 routes are omitted when IPv6 is disabled.
+func (h *host) Routes() ([]types.Route, error) {
+	return routes(h.procFS)
+}
+
+func routes(fs procFS) ([]types.Route, error) {
+	content, err := ioutil.ReadFile(fs.path("net/route"))
+	if err != nil {
+		return nil, err
+	}
+	routes, err := parseIPv4Routes(content, nativeEndian)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", fs.path("net/route"), err)
+	}
+
+	content, err = ioutil.ReadFile(fs.path("net/ipv6_route"))
+	if err != nil {
+		if exists(fs.path("net/ipv6_route")) {
+			return nil, err
+		}
+		return routes, nil
+	}
+	v6, err := parseIPv6Routes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", fs.path("net/ipv6_route"), err)
+	}
+	return append(routes, v6...), nil
+}
+
+// parseIPv4Routes parses /proc/net/route. The addresses are hex encoded in
+// the byte order of the host.
+func parseIPv4Routes(content []byte, order binary.ByteOrder) ([]types.Route, error) {
+	parseIP := func(s string) (net.IP, error) {
+		v, err := strconv.ParseUint(s, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		ip := make(net.IP, net.IPv4len)
+		order.PutUint32(ip, uint32(v))
+		return ip, nil
+	}
+
+	routes := []types.Route{}
+	s := bufio.NewScanner(bytes.NewReader(content))
+	s.Scan() // Skip the header.
+	for s.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := bytes.Fields(s.Bytes())
+		if len(fields) < 8 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(string(fields[3]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flags %q: %w", fields[3], err)
+		}
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+
+		dst, err := parseIP(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %w", fields[1], err)
+		}
+		gw, err := parseIP(string(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway %q: %w", fields[2], err)
+		}
+		mask, err := parseIP(string(fields[7]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid mask %q: %w", fields[7], err)
+		}
+		metric, err := strconv.ParseUint(string(fields[6]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric %q: %w", fields[6], err)
+		}
+
+		m := uint32(metric)
+		route := types.Route{
+			Family:      types.FamilyIPv4,
+			Destination: (&net.IPNet{IP: dst, Mask: net.IPMask(mask)}).String(),
+			Interface:   string(fields[0]),
+			Metric:      &m,
+		}
+		if !gw.IsUnspecified() {
+			route.Gateway = gw.String()
+		}
+		routes = append(routes, route)
+	}
+	return routes, s.Err()
+}
+
+// parseIPv6Routes parses /proc/net/ipv6_route. The addresses are hex encoded
+// in network byte order and the prefix lengths and metric in hex.
+func parseIPv6Routes(content []byte) ([]types.Route, error) {
+	routes := []types.Route{}
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		// dst dst_len src src_len next_hop metric refcnt use flags iface
+		fields := bytes.Fields(s.Bytes())
+		if len(fields) < 10 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(string(fields[8]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flags %q: %w", fields[8], err)
+		}
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+
+		dst, err := hex.DecodeString(string(fields[0]))
+		if err != nil || len(dst) != net.IPv6len {
+			return nil, fmt.Errorf("invalid destination %q", fields[0])
+		}
+		ones, err := strconv.ParseUint(string(fields[1]), 16, 8)
+		if err != nil || ones > 128 {
+			return nil, fmt.Errorf("invalid prefix length %q", fields[1])
+		}
+		gw, err := hex.DecodeString(string(fields[4]))
+		if err != nil || len(gw) != net.IPv6len {
+			return nil, fmt.Errorf("invalid next hop %q", fields[4])
+		}
+		metric, err := strconv.ParseUint(string(fields[5]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric %q: %w", fields[5], err)
+		}
+
+		m := uint32(metric)
+		route := types.Route{
+			Family:      types.FamilyIPv6,
+			Destination: (&net.IPNet{IP: dst, Mask: net.CIDRMask(int(ones), 128)}).String(),
+			Interface:   string(fields[9]),
+			Metric:      &m,
+		}
+		if !net.IP(gw).IsUnspecified() {
+			route.Gateway = net.IP(gw).String()
+		}
+		routes = append(routes, route)
+	}
+	return routes, s.Err()
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Routes = (*host)(nil)
+
+func TestRoutes(t *testing.T) {
+	if nativeEndian != binary.LittleEndian {
+		t.Skip("the test data is from a little-endian host")
+	}
+
+	routes, err := routes(newLinuxSystem("testdata/routes").procFS)
+	require.NoError(t, err)
+
+	metric := func(v uint32) *uint32 { return &v }
+	assert.Equal(t, []types.Route{
+		{Family: types.FamilyIPv4, Destination: "0.0.0.0/0", Gateway: "192.168.1.1", Interface: "eth0", Metric: metric(100)},
+		{Family: types.FamilyIPv4, Destination: "192.168.1.0/24", Interface: "eth0", Metric: metric(100)},
+		{Family: types.FamilyIPv6, Destination: "2001:db8::/64", Interface: "eth0", Metric: metric(256)},
+		{Family: types.FamilyIPv6, Destination: "fe80::/64", Interface: "eth0", Metric: metric(256)},
+		{Family: types.FamilyIPv6, Destination: "::/0", Gateway: "fe80::1", Interface: "eth0", Metric: metric(1024)},
+	}, routes)
+}
+
+func TestParseIPv4RoutesBigEndian(t *testing.T) {
+	content := []byte("Iface\tDestination\tGateway\tFlags\tRefCnt\tUse\tMetric\tMask\tMTU\tWindow\tIRTT\n" +
+		"eth0\tC0A80100\t00000000\t0001\t0\t0\t0\tFFFFFF00\t0\t0\t0\n")
+	routes, err := parseIPv4Routes(content, binary.BigEndian)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, "192.168.1.0/24", routes[0].Destination)
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// schedulerPolicies maps the SCHED_* values to their names.
+var schedulerPolicies = map[uint]string{
+	0: types.SchedulerPolicyOther,
+	1: types.SchedulerPolicyFIFO,
+	2: types.SchedulerPolicyRR,
+	3: types.SchedulerPolicyBatch,
+	5: types.SchedulerPolicyIdle,
+	6: types.SchedulerPolicyDeadline,
+}
+
+// Scheduler reports the scheduling policy and real-time priority of the main
+// thread of the process from /proc/<pid>/stat.
+func (p *process) Scheduler() (*types.SchedulerInfo, error) {
+	stat, err := p.NewStat()
+	if err != nil {
+		return nil, err
+	}
+	return schedulerInfo(stat.Policy, stat.RTPriority), nil
+}
+
+func schedulerInfo(policy, rtPriority uint) *types.SchedulerInfo {
+	info := &types.SchedulerInfo{Policy: schedulerPolicies[policy]}
+	if info.Policy == "" {
+		info.Policy = fmt.Sprintf("unknown(%d)", policy)
+	}
+	if info.Policy == types.SchedulerPolicyFIFO || info.Policy == types.SchedulerPolicyRR {
+		prio := int(rtPriority)
+		info.RTPriority = &prio
+	}
+	return info
+}
+
+// Nice reports the nice value of the main thread of the process from
+// /proc/<pid>/stat.
+func (p *process) Nice() (int, error) {
+	stat, err := p.NewStat()
+	if err != nil {
+		return 0, err
+	}
+	return stat.Nice, nil
+}
+
+// SetNice changes the nice value of all threads of the process. On Linux
+// setpriority(2) only changes the thread it is given, so each thread listed
+// in /proc/<pid>/task is changed.
+func (p *process) SetNice(nice int) error {
+	tasks, err := processTasks(p.fs, p.PID())
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		tid, err := strconv.Atoi(filepath.Base(task))
+		if err != nil {
+			return fmt.Errorf("invalid task directory %v: %w", task, err)
+		}
+		// Threads can exit while they are being changed.
+		if err := unix.Setpriority(unix.PRIO_PROCESS, tid, nice); err != nil && !errors.Is(err, unix.ESRCH) {
+			return fmt.Errorf("setpriority failed for thread %d: %w", tid, err)
+		}
+	}
+	return nil
+}
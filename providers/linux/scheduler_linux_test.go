@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Scheduler = (*process)(nil)
+	_ types.Niceness  = (*process)(nil)
+)
+
+func TestSchedulerInfo(t *testing.T) {
+	rtPriority := func(v int) *int { return &v }
+
+	tests := []struct {
+		policy, rtPriority uint
+		expected           types.SchedulerInfo
+	}{
+		{0, 0, types.SchedulerInfo{Policy: types.SchedulerPolicyOther}},
+		{1, 50, types.SchedulerInfo{Policy: types.SchedulerPolicyFIFO, RTPriority: rtPriority(50)}},
+		{2, 99, types.SchedulerInfo{Policy: types.SchedulerPolicyRR, RTPriority: rtPriority(99)}},
+		{3, 0, types.SchedulerInfo{Policy: types.SchedulerPolicyBatch}},
+		{5, 0, types.SchedulerInfo{Policy: types.SchedulerPolicyIdle}},
+		{6, 0, types.SchedulerInfo{Policy: types.SchedulerPolicyDeadline}},
+		{7, 0, types.SchedulerInfo{Policy: "unknown(7)"}},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, *schedulerInfo(tc.policy, tc.rtPriority))
+	}
+}
+
+func TestSelfScheduler(t *testing.T) {
+	self, err := newLinuxSystem("").Self()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := self.(types.Scheduler).Scheduler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, info.Policy)
+}
+
+func TestSetNice(t *testing.T) {
+	cmd := exec.Command("sleep", "60")
+	require.NoError(t, cmd.Start())
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	proc, err := newLinuxSystem("").Process(cmd.Process.Pid)
+	require.NoError(t, err)
+	p := proc.(types.Niceness)
+
+	nice, err := p.Nice()
+	require.NoError(t, err)
+
+	// Lowering the priority does not require privileges.
+	expected := nice + 1
+	if expected > 19 {
+		expected = 19
+	}
+	require.NoError(t, p.SetNice(expected))
+
+	nice, err = p.Nice()
+	require.NoError(t, err)
+	assert.Equal(t, expected, nice)
+}
@@ -61,6 +61,12 @@ func readSeccompFields(content []byte) (*types.SeccompInfo, error) {
 				return err
 			}
 			seccomp.NoNewPrivs = &noNewPrivs
+		case "Seccomp_filters":
+			filters, err := strconv.Atoi(string(value))
+			if err != nil {
+				return err
+			}
+			seccomp.Filters = &filters
 		}
 		return nil
 	})
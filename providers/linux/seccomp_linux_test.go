@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Seccomp = (*process)(nil)
+
+func TestReadSeccompFields(t *testing.T) {
+	boolPtr := func(v bool) *bool { return &v }
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		status   string
+		expected types.SeccompInfo
+	}{
+		{
+			"Name:\tsleep\nNoNewPrivs:\t1\nSeccomp:\t2\nSeccomp_filters:\t3\n",
+			types.SeccompInfo{Mode: "filter", NoNewPrivs: boolPtr(true), Filters: intPtr(3)},
+		},
+		{
+			"Name:\tbash\nNoNewPrivs:\t0\nSeccomp:\t0\n",
+			types.SeccompInfo{Mode: "disabled", NoNewPrivs: boolPtr(false)},
+		},
+		{
+			"Name:\tinit\nSeccomp:\t1\n",
+			types.SeccompInfo{Mode: "strict"},
+		},
+	}
+
+	for _, tc := range tests {
+		info, err := readSeccompFields([]byte(tc.status))
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, *info)
+	}
+
+	_, err := readSeccompFields([]byte("Seccomp:\tx\n"))
+	assert.Error(t, err)
+}
+
+func TestSelfSeccomp(t *testing.T) {
+	self, err := newLinuxSystem("").Self()
+	require.NoError(t, err)
+
+	info, err := self.(types.Seccomp).Seccomp()
+	require.NoError(t, err)
+	assert.NotEmpty(t, info.Mode)
+}
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// utmp record layout as defined in glibc's bits/utmp.h. The record size is
+// the same for 32 and 64-bit architectures because ut_tv uses 32-bit fields.
+const (
+	utmpRecordSize  = 384
+	utmpUserProcess = 7
+
+	utmpOffType = 0
+	utmpOffPID  = 4
+	utmpOffLine = 8
+	utmpOffID   = 40
+	utmpOffUser = 44
+	utmpOffHost = 76
+	utmpOffTv   = 340
+
+	utmpLineSize = 32
+	utmpIDSize   = 4
+	utmpUserSize = 32
+	utmpHostSize = 256
+)
+
+// utmpFiles are the possible locations of the utmp database.
+var utmpFiles = []string{
+	"run/utmp",
+	"var/run/utmp",
+}
+
+// nativeEndian is the byte order of the host, which is used by utmp.
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// Sessions reports the login sessions recorded in utmp. Records whose login
+// process no longer exists are ignored.
+func (h *host) Sessions() ([]types.SessionInfo, error) {
+	return sessions(h.procFS)
+}
+
+// Users reports the distinct names of the users with a login session.
+func (h *host) Users() ([]string, error) {
+	s, err := sessions(h.procFS)
+	if err != nil {
+		return nil, err
+	}
+	return shared.SessionUsers(s), nil
+}
+
+func sessions(fs procFS) ([]types.SessionInfo, error) {
+	for _, f := range utmpFiles {
+		data, err := ioutil.ReadFile(fs.rootPath(f))
+		if err != nil {
+			continue
+		}
+
+		all, err := parseUtmp(data, nativeEndian)
+		if err != nil {
+			return nil, err
+		}
+
+		live := all[:0]
+		for _, s := range all {
+			if exists(fs.path(strconv.Itoa(s.PID))) {
+				live = append(live, s)
+			}
+		}
+		return live, nil
+	}
+	return nil, fmt.Errorf("utmp not found: %w", types.ErrNotImplemented)
+}
+
+// parseUtmp returns the USER_PROCESS records of a utmp file.
+func parseUtmp(data []byte, order binary.ByteOrder) ([]types.SessionInfo, error) {
+	if len(data)%utmpRecordSize != 0 {
+		return nil, fmt.Errorf("utmp size %d is not a multiple of the record size", len(data))
+	}
+
+	var sessions []types.SessionInfo
+	for ; len(data) > 0; data = data[utmpRecordSize:] {
+		rec := data[:utmpRecordSize]
+		if order.Uint16(rec[utmpOffType:]) != utmpUserProcess {
+			continue
+		}
+
+		sessions = append(sessions, types.SessionInfo{
+			ID:         cString(rec[utmpOffID : utmpOffID+utmpIDSize]),
+			User:       cString(rec[utmpOffUser : utmpOffUser+utmpUserSize]),
+			Terminal:   cString(rec[utmpOffLine : utmpOffLine+utmpLineSize]),
+			RemoteHost: cString(rec[utmpOffHost : utmpOffHost+utmpHostSize]),
+			LoginTime: time.Unix(
+				int64(int32(order.Uint32(rec[utmpOffTv:]))),
+				int64(int32(order.Uint32(rec[utmpOffTv+4:])))*int64(time.Microsecond)).UTC(),
+			PID: int(int32(order.Uint32(rec[utmpOffPID:]))),
+		})
+	}
+	return sessions, nil
+}
+
+// cString returns the string up to the first NUL byte.
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestSessions(t *testing.T) {
+	fs := newLinuxSystem("testdata/sessions").procFS
+
+	sessions, err := sessions(fs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.SessionInfo{
+		{
+			ID:        "tty2",
+			User:      "alice",
+			Terminal:  "tty2",
+			LoginTime: time.Unix(1700000100, 250000000).UTC(),
+			PID:       1234,
+		},
+		{
+			ID:         "ts/0",
+			User:       "bob",
+			Terminal:   "pts/0",
+			RemoteHost: "203.0.113.7",
+			LoginTime:  time.Unix(1700000200, 0).UTC(),
+			PID:        2345,
+		},
+	}, sessions)
+
+	h := &host{procFS: fs}
+	users, err := h.Users()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, users)
+}
+
+func TestParseUtmpInvalidSize(t *testing.T) {
+	_, err := parseUtmp(make([]byte, utmpRecordSize+1), nativeEndian)
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// tcpStates maps the socket states of the kernel to the types.TCPState
+// values. TCP_NEW_SYN_RECV is used for the request sockets of a listener.
+var tcpStates = map[uint8]string{
+	1:  types.TCPStateEstablished,
+	2:  types.TCPStateSynSent,
+	3:  types.TCPStateSynRecv,
+	4:  types.TCPStateFinWait1,
+	5:  types.TCPStateFinWait2,
+	6:  types.TCPStateTimeWait,
+	7:  types.TCPStateClose,
+	8:  types.TCPStateCloseWait,
+	9:  types.TCPStateLastAck,
+	10: types.TCPStateListen,
+	11: types.TCPStateClosing,
+	12: types.TCPStateSynRecv,
+}
+
+// SocketSummary reports the socket counters of /proc/net/sockstat and
+// /proc/net/sockstat6, the number of established connections of
+// /proc/net/snmp, and the TCP memory pressure flag of /proc/net/protocols.
+func (h *host) SocketSummary() (*types.SocketSummaryInfo, error) {
+	return socketSummary(h.procFS)
+}
+
+// TCPStates counts the TCP sockets by state from a sock_diag dump, which
+// only returns the fixed size socket headers, or from /proc/net/tcp and
+// /proc/net/tcp6 when it is not available.
+func (h *host) TCPStates() (map[string]int, error) {
+	states, err := sockDiagTCPStates()
+	if err != nil {
+		return procNetTCPStates(h.procFS)
+	}
+	return states, nil
+}
+
+// socketSummary reads the socket counters from /proc/net.
+func socketSummary(fs procFS) (*types.SocketSummaryInfo, error) {
+	content, err := ioutil.ReadFile(fs.path("net/sockstat"))
+	if err != nil {
+		return nil, err
+	}
+	stats, err := parseSockstat(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sockstat: %w", err)
+	}
+	if content, err = ioutil.ReadFile(fs.path("net/sockstat6")); err == nil {
+		// sockstat6 does not exist when IPv6 is disabled.
+		stats6, err := parseSockstat(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sockstat6: %w", err)
+		}
+		for k, v := range stats6 {
+			stats[k] = v
+		}
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	tcpMemory := stats["TCP"]["mem"] * pageSize
+	udpMemory := stats["UDP"]["mem"] * pageSize
+	orphaned := int(stats["TCP"]["orphan"])
+	info := &types.SocketSummaryInfo{
+		TCPInUse:    int(stats["TCP"]["inuse"] + stats["TCP6"]["inuse"]),
+		TCPOrphaned: &orphaned,
+		TCPMemory:   &tcpMemory,
+		UDPInUse:    int(stats["UDP"]["inuse"] + stats["UDP6"]["inuse"]),
+		UDPMemory:   &udpMemory,
+	}
+
+	content, err = ioutil.ReadFile(fs.path("net/snmp"))
+	if err != nil {
+		return nil, err
+	}
+	snmp, err := getNetSnmpStats(content)
+	if err != nil {
+		return nil, err
+	}
+	// CurrEstab counts the IPv4 and IPv6 connections.
+	info.TCPEstablished = int(snmp.TCP["CurrEstab"])
+
+	if content, err := ioutil.ReadFile(fs.path("net/protocols")); err == nil {
+		info.TCPMemoryPressure = parseMemoryPressure(content, "TCP")
+	}
+	return info, nil
+}
+
+// parseSockstat parses the lines of /proc/net/sockstat, like
+// "TCP: inuse 4 orphan 0 tw 0 alloc 4 mem 1", into the counters by protocol.
+func parseSockstat(content []byte) (map[string]map[string]uint64, error) {
+	stats := map[string]map[string]uint64{}
+	err := parseKeyValue(content, ":", func(key, value []byte) error {
+		fields := strings.Fields(string(value))
+		if len(fields)%2 != 0 {
+			return fmt.Errorf("odd number of fields in %q", value)
+		}
+		counters := make(map[string]uint64, len(fields)/2)
+		for i := 0; i < len(fields); i += 2 {
+			v, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value of %v %v: %w", string(key), fields[i], err)
+			}
+			counters[fields[i]] = v
+		}
+		stats[string(key)] = counters
+		return nil
+	})
+	return stats, err
+}
+
+// parseMemoryPressure returns the memory pressure flag of a protocol from
+// /proc/net/protocols. It returns nil if the protocol does not track memory
+// pressure ("NI").
+func parseMemoryPressure(content []byte, protocol string) *bool {
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		// protocol size sockets memory press maxhdr slab module ...
+		fields := strings.Fields(s.Text())
+		if len(fields) < 5 || fields[0] != protocol {
+			continue
+		}
+		var pressure bool
+		switch fields[4] {
+		case "yes":
+			pressure = true
+		case "no":
+		default:
+			return nil
+		}
+		return &pressure
+	}
+	return nil
+}
+
+// sockDiagTCPStates counts the IPv4 and IPv6 TCP sockets by state with
+// sock_diag.
+func sockDiagTCPStates() (map[string]int, error) {
+	fd, err := dialSockDiag()
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(fd)
+
+	states := map[string]int{}
+	for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+		msgs, err := sockDiagDump(fd, family, unix.IPPROTO_TCP, ^uint32(0))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			if len(m) >= inetDiagMsgLen {
+				if name, found := tcpStates[m[1]]; found {
+					states[name]++
+				}
+			}
+		}
+	}
+	return states, nil
+}
+
+// procNetTCPStates counts the IPv4 and IPv6 TCP sockets by state from
+// /proc/net/tcp and /proc/net/tcp6.
+func procNetTCPStates(fs procFS) (map[string]int, error) {
+	states := map[string]int{}
+	for _, name := range []string{"net/tcp", "net/tcp6"} {
+		content, err := ioutil.ReadFile(fs.path(name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// IPv6 is disabled.
+				continue
+			}
+			return nil, err
+		}
+
+		s := bufio.NewScanner(bytes.NewReader(content))
+		s.Scan() // Skip the header.
+		for s.Scan() {
+			fields := strings.Fields(s.Text())
+			if len(fields) < 4 {
+				continue
+			}
+			st, err := strconv.ParseUint(fields[3], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid state %q in %v: %w", fields[3], name, err)
+			}
+			if name, found := tcpStates[uint8(st)]; found {
+				states[name]++
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return states, nil
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.SocketSummary   = (*host)(nil)
+	_ types.TCPStateCounter = (*host)(nil)
+)
+
+func TestSocketSummaryCounters(t *testing.T) {
+	info, err := socketSummary(newLinuxSystem("testdata/socket_summary").procFS)
+	require.NoError(t, err)
+
+	pageSize := uint64(os.Getpagesize())
+	orphaned, tcpMemory, udpMemory, pressure := 1, 4*pageSize, 2*pageSize, true
+	assert.Equal(t, &types.SocketSummaryInfo{
+		TCPInUse:          14,
+		TCPEstablished:    9,
+		TCPOrphaned:       &orphaned,
+		TCPMemory:         &tcpMemory,
+		TCPMemoryPressure: &pressure,
+		UDPInUse:          6,
+		UDPMemory:         &udpMemory,
+	}, info)
+}
+
+func TestProcNetTCPStates(t *testing.T) {
+	states, err := procNetTCPStates(newLinuxSystem("testdata/socket_summary").procFS)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{types.TCPStateListen: 3, types.TCPStateEstablished: 1}, states)
+}
+
+func TestParseMemoryPressure(t *testing.T) {
+	content := []byte("protocol  size sockets  memory press maxhdr  slab module\n" +
+		"UDP       1344      0       0   NI       0   yes  kernel\n" +
+		"TCP       2304      4       0   no     192   yes  kernel\n")
+	if pressure := parseMemoryPressure(content, "TCP"); assert.NotNil(t, pressure) {
+		assert.False(t, *pressure)
+	}
+	assert.Nil(t, parseMemoryPressure(content, "UDP"))
+	assert.Nil(t, parseMemoryPressure(content, "SCTP"))
+}
+
+func TestSocketSummary(t *testing.T) {
+	h, err := newLinuxSystem("").Host()
+	require.NoError(t, err)
+
+	info, err := h.(types.SocketSummary).SocketSummary()
+	require.NoError(t, err)
+	t.Logf("%+v", info)
+
+	states, err := h.(types.TCPStateCounter).TCPStates()
+	require.NoError(t, err)
+	assert.NotNil(t, states)
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// SuspendInfo reports the time spent suspended since boot as the difference
+// between CLOCK_BOOTTIME and CLOCK_MONOTONIC, which does not advance while
+// the system is suspended. The number of suspends is read from
+// /sys/power/suspend_stats.
+func (h *host) SuspendInfo() (*types.SuspendInfo, error) {
+	var boot, mono unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_BOOTTIME, &boot); err != nil {
+		return nil, fmt.Errorf("failed to read CLOCK_BOOTTIME: %w", err)
+	}
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &mono); err != nil {
+		return nil, fmt.Errorf("failed to read CLOCK_MONOTONIC: %w", err)
+	}
+
+	return suspendInfo(h.procFS, time.Duration(boot.Nano()), time.Duration(mono.Nano())), nil
+}
+
+func suspendInfo(fs procFS, sinceBoot, active time.Duration) *types.SuspendInfo {
+	info := &types.SuspendInfo{Active: active}
+	if sinceBoot > active {
+		info.Suspended = sinceBoot - active
+	}
+	if count, err := readUintFile(fs.rootPath("sys/power/suspend_stats/success")); err == nil {
+		info.SuspendCount = &count
+	}
+	return info
+}
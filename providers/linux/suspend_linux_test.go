@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuspendInfo(t *testing.T) {
+	info := suspendInfo(newLinuxSystem("testdata/suspend").procFS, 10*time.Hour, 7*time.Hour)
+	assert.Equal(t, 7*time.Hour, info.Active)
+	assert.Equal(t, 3*time.Hour, info.Suspended)
+	require.NotNil(t, info.SuspendCount)
+	assert.EqualValues(t, 12, *info.SuspendCount)
+
+	h := &host{procFS: newLinuxSystem("").procFS}
+	info, err := h.SuspendInfo()
+	require.NoError(t, err)
+	assert.NotZero(t, info.Active)
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// SwapInfo reports the swap devices listed in /proc/swaps and the pswpin and
+// pswpout counters of /proc/vmstat.
+func (h *host) SwapInfo() (*types.SwapInfo, error) {
+	return swapInfo(h.procFS)
+}
+
+func swapInfo(fs procFS) (*types.SwapInfo, error) {
+	content, err := ioutil.ReadFile(fs.path("swaps"))
+	if err != nil {
+		return nil, err
+	}
+	devices, err := parseProcSwaps(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", fs.path("swaps"), err)
+	}
+
+	vmstatRaw, err := ioutil.ReadFile(fs.path("vmstat"))
+	if err != nil {
+		return nil, err
+	}
+	vmstat, err := parseVMStat(vmstatRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SwapInfo{
+		Devices:  devices,
+		PagesIn:  vmstat.Pswpin,
+		PagesOut: vmstat.Pswpout,
+		PageSize: uint64(os.Getpagesize()),
+	}, nil
+}
+
+// parseProcSwaps parses the contents of /proc/swaps. The sizes are reported
+// in KiB.
+func parseProcSwaps(content []byte) ([]types.SwapDevice, error) {
+	devices := []types.SwapDevice{}
+
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for first := true; s.Scan(); first = false {
+		fields := bytes.Fields(s.Bytes())
+		if first || len(fields) == 0 {
+			// Skip the header.
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected line %q", s.Text())
+		}
+
+		size, err := strconv.ParseUint(string(fields[2]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size: %w", err)
+		}
+		used, err := strconv.ParseUint(string(fields[3]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid used size: %w", err)
+		}
+		priority, err := strconv.Atoi(string(fields[4]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority: %w", err)
+		}
+
+		devices = append(devices, types.SwapDevice{
+			Name:     unescapeOctal(string(fields[0])),
+			Type:     string(fields[1]),
+			Total:    shared.MulSaturating(size, shared.KiB),
+			Used:     shared.MulSaturating(used, shared.KiB),
+			Priority: &priority,
+		})
+	}
+	return devices, s.Err()
+}
+
+// unescapeOctal decodes the \ooo escape sequences that the kernel uses for
+// whitespace and backslashes in paths (e.g. \040 for a space).
+func unescapeOctal(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Swap = (*host)(nil)
+
+func TestSwapInfo(t *testing.T) {
+	info, err := swapInfo(newLinuxSystem("testdata/swap").procFS)
+	require.NoError(t, err)
+
+	high, low := 10, -2
+	assert.Equal(t, &types.SwapInfo{
+		Devices: []types.SwapDevice{
+			{Name: "/dev/dm-1", Type: "partition", Total: 8388604 * 1024, Used: 1024 * 1024, Priority: &low},
+			{Name: "/var/lib/swap file", Type: "file", Total: 2097148 * 1024, Used: 0, Priority: &high},
+		},
+		PagesIn:  42,
+		PagesOut: 1337,
+		PageSize: uint64(os.Getpagesize()),
+	}, info)
+}
+
+func TestParseProcSwapsEmpty(t *testing.T) {
+	devices, err := parseProcSwaps([]byte("Filename\tType\tSize\tUsed\tPriority\n"))
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+	assert.NotNil(t, devices)
+}
@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sysctl reads a kernel tunable from /proc/sys. Like sysctl(8), the
+// components of the name are separated by dots and a dot within a component,
+// like in the settings of VLAN interfaces, is written as a slash (e.g.
+// net.ipv4.conf.eth0/100.rp_filter). Names whose first separator is a slash
+// are paths relative to /proc/sys (e.g. net/ipv4/conf/eth0.100/rp_filter).
+func (h *host) Sysctl(name string) (string, bool, error) {
+	return sysctl(h.procFS, name)
+}
+
+// KernelParameters reads the kernel tunables below /proc/sys. The names are
+// those of sysctl(8) (see Sysctl). Write-only tunables (e.g. vm.drop_caches)
+// and tunables that cannot be read without privileges are omitted.
+func (h *host) KernelParameters(prefix string) (map[string]string, error) {
+	return kernelParameters(h.procFS, prefix)
+}
+
+func sysctl(fs procFS, name string) (string, bool, error) {
+	rel, err := sysctlPath(name)
+	if err != nil {
+		return "", false, err
+	}
+	path := fs.path("sys", rel)
+	if fi, err := os.Stat(path); err != nil || fi.IsDir() {
+		// Directories are groups of tunables (e.g. net.ipv4).
+		return "", false, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(string(content)), true, nil
+}
+
+func kernelParameters(fs procFS, prefix string) (map[string]string, error) {
+	root := fs.path("sys")
+	if isSysctlPath(prefix) {
+		prefix = swapDotsAndSlashes(prefix)
+	}
+
+	// Only the directory that contains the prefix needs to be walked.
+	dir := root
+	if i := strings.LastIndex(prefix, "."); i > 0 {
+		if rel, err := sysctlPath(prefix[:i]); err == nil && exists(filepath.Join(root, rel)) {
+			dir = filepath.Join(root, rel)
+		}
+	}
+
+	params := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			// Directories that cannot be read are skipped.
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := swapDotsAndSlashes(filepath.ToSlash(rel))
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		if content, err := ioutil.ReadFile(path); err == nil {
+			params[name] = strings.TrimSpace(string(content))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return params, nil
+}
+
+// sysctlPath converts a sysctl name to a path relative to /proc/sys. Names
+// that are absolute or contain "." or ".." components are rejected so that
+// the path cannot refer to a file outside of /proc/sys.
+func sysctlPath(name string) (string, error) {
+	path := name
+	if !isSysctlPath(name) {
+		path = swapDotsAndSlashes(name)
+	}
+	if strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("invalid sysctl name %q", name)
+	}
+	for _, c := range strings.Split(path, "/") {
+		if c == "." || c == ".." {
+			return "", fmt.Errorf("invalid sysctl name %q", name)
+		}
+	}
+	return filepath.FromSlash(strings.TrimRight(path, "/")), nil
+}
+
+// isSysctlPath reports whether name is written as a path, that is whether
+// its first separator is a slash rather than a dot.
+func isSysctlPath(name string) bool {
+	i := strings.IndexAny(name, "./")
+	return i >= 0 && name[i] == '/'
+}
+
+// swapDotsAndSlashes converts between a sysctl name and its path.
+func swapDotsAndSlashes(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.':
+			return '/'
+		case '/':
+			return '.'
+		}
+		return r
+	}, s)
+}
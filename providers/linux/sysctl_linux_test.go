@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.KernelParameters = (*host)(nil)
+
+func TestSysctl(t *testing.T) {
+	fs := newLinuxSystem("testdata/sysctl").procFS
+
+	for name, expected := range map[string]string{
+		"vm.swappiness":                    "60",
+		"kernel.printk":                    "4\t4\t1\t7",
+		"net.ipv4.conf.eth0/100.rp_filter": "2",
+		"net/ipv4/conf/eth0.100/rp_filter": "2",
+		"vm/swappiness":                    "60",
+	} {
+		v, found, err := sysctl(fs, name)
+		require.NoError(t, err, name)
+		assert.True(t, found, name)
+		assert.Equal(t, expected, v, name)
+	}
+
+	for _, name := range []string{"vm.nonexistent", "net.ipv4", "net.ipv4.conf.eth0.100.rp_filter"} {
+		_, found, err := sysctl(fs, name)
+		require.NoError(t, err, name)
+		assert.False(t, found, name)
+	}
+
+	for _, name := range []string{"../../../etc/shadow", "/etc/shadow", "net/ipv4/../../../../etc/shadow", "net/ipv4/./conf", "vm.//.//.etc/shadow", ".etc.shadow"} {
+		_, found, err := sysctl(fs, name)
+		assert.Error(t, err, name)
+		assert.False(t, found, name)
+	}
+}
+
+func TestKernelParameters(t *testing.T) {
+	fs := newLinuxSystem("testdata/sysctl").procFS
+
+	params, err := kernelParameters(fs, "net.ipv4.")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"net.ipv4.ip_forward":              "1",
+		"net.ipv4.conf.eth0/100.rp_filter": "2",
+		"net.ipv4.route.min_pmtu":          "1",
+	}, params)
+
+	// The prefix can be written like the names or as a path, and the
+	// directory of the VLAN interface eth0.100 contains a dot.
+	for _, prefix := range []string{"net.ipv4.conf.eth0/100.", "net/ipv4/conf/eth0.100/", "net.ipv4.conf.eth0/1"} {
+		params, err = kernelParameters(fs, prefix)
+		require.NoError(t, err, prefix)
+		assert.Equal(t, map[string]string{"net.ipv4.conf.eth0/100.rp_filter": "2"}, params, prefix)
+	}
+
+	params, err = kernelParameters(fs, "kernel.pid")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"kernel.pid_max": "4194304"}, params)
+
+	params, err = kernelParameters(fs, "")
+	require.NoError(t, err)
+	assert.Len(t, params, 6)
+
+	params, err = kernelParameters(fs, "fs.")
+	require.NoError(t, err)
+	assert.Empty(t, params)
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Clock status bits from linux/timex.h.
+const (
+	staUnsync = 0x0040 // Clock is not synchronized.
+	staNano   = 0x2000 // Offset is in nanoseconds rather than microseconds.
+)
+
+// TimeSync reports the clock synchronization status maintained by the kernel
+// through adjtimex(2). This is updated by any NTP daemon (ntpd, chronyd or
+// systemd-timesyncd). The time of the last synchronization is only available
+// with systemd-timesyncd, which touches /run/systemd/timesync/synchronized.
+func (h *host) TimeSync() (*types.TimeSyncInfo, error) {
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return nil, err
+	}
+	info := timexInfo(state, &tx)
+
+	fi, err := os.Stat(h.procFS.rootPath("run/systemd/timesync/synchronized"))
+	switch {
+	case err == nil:
+		lastSync := fi.ModTime().UTC()
+		info.LastSync = &lastSync
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+	return info, nil
+}
+
+// timexInfo converts the result of adjtimex(2) to a TimeSyncInfo.
+func timexInfo(state int, tx *unix.Timex) *types.TimeSyncInfo {
+	offset := time.Duration(tx.Offset)
+	if tx.Status&staNano == 0 {
+		offset *= time.Microsecond
+	}
+	maxError := time.Duration(tx.Maxerror) * time.Microsecond
+	estError := time.Duration(tx.Esterror) * time.Microsecond
+
+	return &types.TimeSyncInfo{
+		Synchronized:   state != unix.TIME_ERROR && tx.Status&staUnsync == 0,
+		Offset:         &offset,
+		MaxError:       &maxError,
+		EstimatedError: &estError,
+	}
+}
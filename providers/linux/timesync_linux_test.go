@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.TimeSync = (*host)(nil)
+
+func TestTimexInfo(t *testing.T) {
+	info := timexInfo(unix.TIME_OK, &unix.Timex{Offset: -1500, Maxerror: 16000, Esterror: 250, Status: 0x2001})
+	assert.True(t, info.Synchronized)
+	assert.Equal(t, -1500*time.Nanosecond, *info.Offset)
+	assert.Equal(t, 16*time.Millisecond, *info.MaxError)
+	assert.Equal(t, 250*time.Microsecond, *info.EstimatedError)
+
+	info = timexInfo(unix.TIME_OK, &unix.Timex{Offset: 20, Status: staUnsync})
+	assert.False(t, info.Synchronized)
+	assert.Equal(t, 20*time.Microsecond, *info.Offset)
+
+	info = timexInfo(unix.TIME_ERROR, &unix.Timex{})
+	assert.False(t, info.Synchronized)
+}
+
+func TestTimeSync(t *testing.T) {
+	h, err := newLinuxSystem("").Host()
+	require.NoError(t, err)
+
+	info, err := h.(types.TimeSync).TimeSync()
+	require.NoError(t, err)
+	assert.NotNil(t, info.MaxError)
+}
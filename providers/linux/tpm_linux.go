@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// TPM reports the Trusted Platform Module registered in /sys/class/tpm. The
+// manufacturer of a TPM 2.0 is queried through the /dev/tpmrm0 resource
+// manager, which usually requires root or membership of the tss group.
+func (h *host) TPM() (*types.TPMInfo, error) {
+	return tpmInfo(h.procFS)
+}
+
+func tpmInfo(fs procFS) (*types.TPMInfo, error) {
+	dir := fs.rootPath("sys/class/tpm/tpm0")
+	if !exists(dir) {
+		return &types.TPMInfo{Present: false}, nil
+	}
+
+	info := &types.TPMInfo{Present: true}
+
+	// tpm_version_major was added in Linux 5.6.
+	major, err := readUintFile(filepath.Join(dir, "tpm_version_major"))
+	switch {
+	case err == nil && major == 1:
+		info.Version = "1.2"
+	case err == nil && major == 2:
+		info.Version = "2.0"
+	case exists(filepath.Join(dir, "device/caps")):
+		// The caps attribute is only provided by the TPM 1.2 drivers.
+		info.Version = "1.2"
+	case exists(fs.rootPath("sys/class/tpmrm/tpmrm0")):
+		info.Version = "2.0"
+	}
+
+	switch info.Version {
+	case "1.2":
+		if caps, err := ioutil.ReadFile(filepath.Join(dir, "device/caps")); err == nil {
+			info.ManufacturerID = parseTPMCapsManufacturer(caps)
+		}
+	case "2.0":
+		info.ManufacturerID, _ = tpm2Manufacturer(fs.rootPath("dev/tpmrm0"))
+	}
+	info.ManufacturerName = shared.TPMVendorName(info.ManufacturerID)
+
+	return info, nil
+}
+
+// parseTPMCapsManufacturer returns the vendor ID from the TPM 1.2 caps
+// attribute (e.g. Manufacturer: 0x49465800).
+func parseTPMCapsManufacturer(caps []byte) string {
+	var id string
+	_ = parseKeyValue(caps, ":", func(key, value []byte) error {
+		if string(key) == "Manufacturer" {
+			var v uint32
+			if _, err := fmt.Sscanf(string(value), "0x%x", &v); err == nil {
+				id = shared.TPMVendorID(v)
+			}
+		}
+		return nil
+	})
+	return id
+}
+
+// tpm2Manufacturer sends a TPM2_GetCapability command to the given TPM 2.0
+// resource manager device.
+func tpm2Manufacturer(device string) (string, error) {
+	fi, err := os.Stat(device)
+	if err != nil {
+		return "", err
+	}
+	if fi.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("%v is not a character device", device)
+	}
+
+	f, err := os.OpenFile(device, os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err = f.Write(shared.TPM2GetManufacturerCommand()); err != nil {
+		return "", fmt.Errorf("failed to write TPM command: %w", err)
+	}
+
+	resp := make([]byte, 4096)
+	n, err := f.Read(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to read TPM response: %w", err)
+	}
+	id, err := shared.ParseTPM2GetManufacturerResponse(resp[:n])
+	return strings.TrimSpace(id), err
+}
@@ -23,7 +23,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
 )
 
 func parseKeyValue(content []byte, separator string, callback func(key, value []byte) error) error {
@@ -101,11 +104,36 @@ func parseBytesOrNumber(data []byte) (uint64, error) {
 	if len(parts) >= 2 {
 		switch string(parts[1]) {
 		case "kB":
-			multiplier = 1024
+			multiplier = shared.KiB
 		default:
 			return 0, fmt.Errorf("unhandled unit %v", string(parts[1]))
 		}
 	}
 
-	return num * multiplier, nil
+	return shared.MulSaturating(num, multiplier), nil
+}
+
+// readFileString returns the contents of a file with the surrounding
+// whitespace removed. It is intended for reading single value files such as
+// sysfs attributes.
+func readFileString(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(content)), nil
+}
+
+// readUintFile reads a file containing a single unsigned decimal number.
+func readUintFile(path string) (uint64, error) {
+	v, err := readFileString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
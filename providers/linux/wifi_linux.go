@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// nl80211RateInfoEHTMCS is NL80211_RATE_INFO_EHT_MCS (Linux 6.0).
+const nl80211RateInfoEHTMCS = 0x15
+
+// WiFi reports the wireless interfaces in station (client) mode from the
+// nl80211 generic netlink interface, which is what "iw dev <interface> link"
+// shows. It does not require any privileges. The SSID is reported by Linux
+// 4.19 and newer.
+func (h *host) WiFi() ([]types.WiFiInfo, error) {
+	c, err := dialGenetlink()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	family, err := c.familyID(unix.NL80211_GENL_NAME)
+	if err != nil {
+		if errors.Is(err, unix.ENOENT) {
+			return nil, fmt.Errorf("nl80211 is not available: %w", types.ErrNotImplemented)
+		}
+		return nil, fmt.Errorf("failed to resolve nl80211: %w", err)
+	}
+
+	replies, err := c.request(family, unix.NL80211_CMD_GET_INTERFACE, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wireless interfaces: %w", err)
+	}
+
+	infos := []types.WiFiInfo{}
+	for _, r := range replies {
+		attrs, err := parseNetlinkAttrs(r)
+		if err != nil {
+			return nil, err
+		}
+		info, index, ok := wifiInterface(attrs)
+		if !ok {
+			continue
+		}
+
+		// The station of an interface in station mode is the access point.
+		stations, err := c.request(family, unix.NL80211_CMD_GET_STATION, true,
+			netlinkAttr(unix.NL80211_ATTR_IFINDEX, nativeUint32(index)))
+		if err == nil && len(stations) > 0 {
+			if attrs, err := parseNetlinkAttrs(stations[0]); err == nil {
+				wifiStation(&info, attrs)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// wifiInterface converts the attributes of an NL80211_CMD_GET_INTERFACE
+// reply. It returns false for interfaces that are not in station mode.
+func wifiInterface(attrs map[uint16][]byte) (info types.WiFiInfo, index uint32, ok bool) {
+	if t := attrs[unix.NL80211_ATTR_IFTYPE]; len(t) != 4 || nativeEndian.Uint32(t) != unix.NL80211_IFTYPE_STATION {
+		return info, 0, false
+	}
+	if i := attrs[unix.NL80211_ATTR_IFINDEX]; len(i) == 4 {
+		index = nativeEndian.Uint32(i)
+	}
+
+	info.Interface = string(bytes.TrimRight(attrs[unix.NL80211_ATTR_IFNAME], "\x00"))
+	info.SSID = string(attrs[unix.NL80211_ATTR_SSID])
+	if f := attrs[unix.NL80211_ATTR_WIPHY_FREQ]; len(f) == 4 {
+		info.Frequency = int(nativeEndian.Uint32(f))
+		info.Channel = wifiChannel(info.Frequency)
+	}
+	return info, index, true
+}
+
+// wifiStation adds the information of the access point from the attributes
+// of an NL80211_CMD_GET_STATION reply.
+func wifiStation(info *types.WiFiInfo, attrs map[uint16][]byte) {
+	if mac := attrs[unix.NL80211_ATTR_MAC]; len(mac) == 6 {
+		info.BSSID = net.HardwareAddr(mac).String()
+	}
+
+	sta, err := parseNetlinkAttrs(attrs[unix.NL80211_ATTR_STA_INFO])
+	if err != nil {
+		return
+	}
+	if s := sta[unix.NL80211_STA_INFO_SIGNAL]; len(s) == 1 {
+		signal := int(int8(s[0]))
+		info.Signal = &signal
+	}
+
+	rate, err := parseNetlinkAttrs(sta[unix.NL80211_STA_INFO_TX_BITRATE])
+	if err != nil {
+		return
+	}
+	// The bit rates are in units of 100 kbit/s.
+	if r := rate[unix.NL80211_RATE_INFO_BITRATE32]; len(r) == 4 {
+		info.TxRate = float64(nativeEndian.Uint32(r)) / 10
+	} else if r := rate[unix.NL80211_RATE_INFO_BITRATE]; len(r) == 2 {
+		info.TxRate = float64(nativeEndian.Uint16(r)) / 10
+	}
+
+	switch {
+	case rate[nl80211RateInfoEHTMCS] != nil:
+		info.PHYMode = "802.11be"
+	case rate[unix.NL80211_RATE_INFO_HE_MCS] != nil:
+		info.PHYMode = "802.11ax"
+	case rate[unix.NL80211_RATE_INFO_VHT_MCS] != nil:
+		info.PHYMode = "802.11ac"
+	case rate[unix.NL80211_RATE_INFO_MCS] != nil:
+		info.PHYMode = "802.11n"
+	case info.Frequency >= 5000:
+		info.PHYMode = "802.11a"
+	case info.Frequency > 0:
+		info.PHYMode = "802.11g"
+	}
+}
+
+// wifiChannel returns the channel number of a frequency in MHz, like
+// ieee80211_freq_khz_to_channel of the kernel.
+func wifiChannel(freq int) int {
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq < 2484:
+		return (freq - 2407) / 5
+	case freq >= 4910 && freq <= 4980:
+		return (freq - 4000) / 5
+	case freq > 5950 && freq <= 7115:
+		return (freq - 5950) / 5
+	case freq == 5935:
+		return 2
+	case freq >= 5000 && freq <= 5950:
+		return (freq - 5000) / 5
+	case freq >= 58320 && freq <= 70200:
+		return (freq - 56160) / 2160
+	}
+	return 0
+}
+
+// nativeUint32 encodes v in the byte order of the host.
+func nativeUint32(v uint32) []byte {
+	b := make([]byte, 4)
+	nativeEndian.PutUint32(b, v)
+	return b
+}
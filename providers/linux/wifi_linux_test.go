@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package linux
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.WiFi = (*host)(nil)
+
+func TestWiFiInterfaceAndStation(t *testing.T) {
+	ifaceAttrs, err := parseNetlinkAttrs(bytes.Join([][]byte{
+		netlinkAttr(unix.NL80211_ATTR_IFINDEX, nativeUint32(3)),
+		netlinkAttr(unix.NL80211_ATTR_IFNAME, []byte("wlan0\x00")),
+		netlinkAttr(unix.NL80211_ATTR_IFTYPE, nativeUint32(unix.NL80211_IFTYPE_STATION)),
+		netlinkAttr(unix.NL80211_ATTR_SSID, []byte("home")),
+		netlinkAttr(unix.NL80211_ATTR_WIPHY_FREQ, nativeUint32(5180)),
+	}, nil))
+	require.NoError(t, err)
+
+	info, index, ok := wifiInterface(ifaceAttrs)
+	require.True(t, ok)
+	assert.EqualValues(t, 3, index)
+
+	rate := bytes.Join([][]byte{
+		netlinkAttr(unix.NL80211_RATE_INFO_BITRATE32, nativeUint32(8667)),
+		netlinkAttr(unix.NL80211_RATE_INFO_VHT_MCS, []byte{9}),
+	}, nil)
+	sta := bytes.Join([][]byte{
+		netlinkAttr(unix.NL80211_STA_INFO_SIGNAL, []byte{0xc4}), // -60 dBm
+		netlinkAttr(unix.NL80211_STA_INFO_TX_BITRATE|unix.NLA_F_NESTED, rate),
+	}, nil)
+	stationAttrs, err := parseNetlinkAttrs(bytes.Join([][]byte{
+		netlinkAttr(unix.NL80211_ATTR_MAC, []byte{0x52, 0x54, 0x00, 0x12, 0x34, 0x56}),
+		netlinkAttr(unix.NL80211_ATTR_STA_INFO|unix.NLA_F_NESTED, sta),
+	}, nil))
+	require.NoError(t, err)
+	wifiStation(&info, stationAttrs)
+
+	signal := -60
+	assert.Equal(t, types.WiFiInfo{
+		Interface: "wlan0",
+		SSID:      "home",
+		BSSID:     "52:54:00:12:34:56",
+		Signal:    &signal,
+		Channel:   36,
+		Frequency: 5180,
+		PHYMode:   "802.11ac",
+		TxRate:    866.7,
+	}, info)
+
+	_, _, ok = wifiInterface(map[uint16][]byte{unix.NL80211_ATTR_IFTYPE: nativeUint32(unix.NL80211_IFTYPE_AP)})
+	assert.False(t, ok)
+}
+
+func TestWiFiChannel(t *testing.T) {
+	for freq, channel := range map[int]int{2412: 1, 2437: 6, 2484: 14, 5180: 36, 5825: 165, 5955: 1, 6115: 33, 58320: 1, 1000: 0} {
+		assert.Equal(t, channel, wifiChannel(freq), "frequency %d", freq)
+	}
+}
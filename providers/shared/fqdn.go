@@ -20,10 +20,9 @@
 package shared
 
 import (
+	"context"
 	"fmt"
-	"net"
 	"os"
-	"strings"
 )
 
 func FQDN() (string, error) {
@@ -32,28 +31,5 @@ func FQDN() (string, error) {
 		return "", fmt.Errorf("could not get hostname to look for FQDN: %w", err)
 	}
 
-	var errs error
-	cname, err := net.LookupCNAME(hostname)
-	if err != nil {
-		errs = fmt.Errorf("could not get FQDN, all methods failed: failed looking up CNAME: %w",
-			err)
-	}
-	if cname != "" {
-		return strings.TrimSuffix(cname, "."), nil
-	}
-
-	ips, err := net.LookupIP(hostname)
-	if err != nil {
-		errs = fmt.Errorf("%s: failed looking up IP: %w", errs, err)
-	}
-
-	for _, ip := range ips {
-		names, err := net.LookupAddr(ip.String())
-		if err != nil || len(names) == 0 {
-			continue
-		}
-		return strings.TrimSuffix(names[0], "."), nil
-	}
-
-	return "", errs
+	return LookupFQDN(context.Background(), hostname)
 }
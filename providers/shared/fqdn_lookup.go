@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo/internal/registry"
+)
+
+// FQDNWithStrategy returns the fully qualified domain name using one of the
+// registry.FQDN strategies. platform looks up the name for the FQDNPlatform
+// strategy. hostsFile is the path of the hosts file. timeout bounds the DNS
+// lookups, see registry.HostOptions.FQDNTimeout.
+func FQDNWithStrategy(strategy, hostsFile string, timeout time.Duration, platform func(ctx context.Context, hostname string) (string, error)) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("could not get hostname to look for FQDN: %w", err)
+	}
+
+	lookup := platform
+	switch strategy {
+	case "", registry.FQDNPlatform:
+	case registry.FQDNHostname:
+		return hostname, nil
+	case registry.FQDNHosts:
+		if fqdn, err := HostsFQDN(hostsFile, hostname); err == nil && fqdn != "" {
+			return fqdn, nil
+		}
+		lookup = LookupFQDN
+		if timeout <= 0 {
+			timeout = registry.DefaultFQDNTimeout
+		}
+	default:
+		return "", fmt.Errorf("unknown FQDN strategy %q", strategy)
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return lookup(ctx, hostname)
+}
+
+// LookupFQDN looks up the fully qualified domain name of hostname in DNS. The
+// canonical name (CNAME) of the hostname is used if it has one, otherwise the
+// reverse lookup of its addresses. The lookups give up when ctx is done.
+func LookupFQDN(ctx context.Context, hostname string) (string, error) {
+	var errs error
+	cname, err := net.DefaultResolver.LookupCNAME(ctx, hostname)
+	if err != nil {
+		errs = fmt.Errorf("could not get FQDN, all methods failed: failed looking up CNAME: %w",
+			err)
+	}
+	if cname != "" {
+		return strings.TrimSuffix(cname, "."), nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, hostname)
+	if err != nil {
+		errs = fmt.Errorf("%s: failed looking up IP: %w", errs, err)
+	}
+
+	for _, ip := range ips {
+		names, err := net.DefaultResolver.LookupAddr(ctx, ip.String())
+		if err != nil || len(names) == 0 {
+			continue
+		}
+		return strings.TrimSuffix(names[0], "."), nil
+	}
+
+	return "", errs
+}
+
+// HostsFQDN returns the fully qualified domain name of hostname from the
+// hosts file at path (e.g. /etc/hosts). It is the canonical name, which is
+// the first name after the address, of the first line that lists hostname.
+// An empty string is returned if the hostname is not listed or its canonical
+// name is not qualified.
+func HostsFQDN(path, hostname string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(name, hostname) || strings.HasPrefix(strings.ToLower(name), strings.ToLower(hostname)+".") {
+				if canonical := strings.TrimSuffix(fields[1], "."); strings.Contains(canonical, ".") {
+					return canonical, nil
+				}
+				return "", nil
+			}
+		}
+	}
+	return "", s.Err()
+}
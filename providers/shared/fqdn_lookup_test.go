@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostsFQDN(t *testing.T) {
+	for hostname, want := range map[string]string{
+		"web01":             "web01.example.com",
+		"WEB01":             "web01.example.com",
+		"web01.example.com": "web01.example.com",
+		"cache01":           "cache01.example.org",
+		"db01":              "",
+		"localhost":         "",
+		"missing":           "",
+		"primary":           "",
+	} {
+		fqdn, err := HostsFQDN("testdata/hosts", hostname)
+		require.NoError(t, err)
+		assert.Equal(t, want, fqdn, hostname)
+	}
+
+	_, err := HostsFQDN("testdata/missing", "web01")
+	assert.Error(t, err)
+}
+
+func TestFQDNWithStrategy(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	var platformCalls int
+	platform := func(ctx context.Context, hostname string) (string, error) {
+		platformCalls++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return hostname + ".platform", nil
+	}
+
+	fqdn, err := FQDNWithStrategy("hostname", "testdata/hosts", 0, platform)
+	require.NoError(t, err)
+	assert.Equal(t, hostname, fqdn)
+
+	fqdn, err = FQDNWithStrategy("", "testdata/hosts", time.Second, platform)
+	require.NoError(t, err)
+	assert.Equal(t, hostname+".platform", fqdn)
+	assert.Equal(t, 1, platformCalls)
+
+	_, err = FQDNWithStrategy("bogus", "testdata/hosts", 0, platform)
+	assert.Error(t, err)
+}
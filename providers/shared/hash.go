@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// newHashes maps the hash algorithms to their constructors.
+var newHashes = map[types.HashAlgorithm]func() hash.Hash{
+	types.HashMD5:    md5.New,
+	types.HashSHA1:   sha1.New,
+	types.HashSHA256: sha256.New,
+	types.HashSHA512: sha512.New,
+}
+
+// Hash reads r to the end and returns its hex encoded hashes computed with
+// the given algorithms, or with SHA-256 if none are given. It also returns
+// the number of bytes read.
+func Hash(r io.Reader, algorithms ...types.HashAlgorithm) (map[types.HashAlgorithm]string, int64, error) {
+	if len(algorithms) == 0 {
+		algorithms = []types.HashAlgorithm{types.HashSHA256}
+	}
+
+	hashes := make(map[types.HashAlgorithm]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, alg := range algorithms {
+		newHash, found := newHashes[alg]
+		if !found {
+			return nil, 0, fmt.Errorf("unsupported hash algorithm %q", alg)
+		}
+		if _, dup := hashes[alg]; dup {
+			continue
+		}
+		h := newHash()
+		hashes[alg] = h
+		writers = append(writers, h)
+	}
+
+	n, err := io.Copy(io.MultiWriter(writers...), r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sums := make(map[types.HashAlgorithm]string, len(hashes))
+	for alg, h := range hashes {
+		sums[alg] = hex.EncodeToString(h.Sum(nil))
+	}
+	return sums, n, nil
+}
+
+// HashFile returns the hashes of the file at path as an ExecutableHashInfo.
+func HashFile(path string, algorithms ...types.HashAlgorithm) (*types.ExecutableHashInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hashes, size, err := Hash(f, algorithms...)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ExecutableHashInfo{Path: path, Size: size, Hashes: hashes}, nil
+}
@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestHash(t *testing.T) {
+	hashes, n, err := Hash(strings.NewReader("abc"), types.HashMD5, types.HashSHA1, types.HashSHA256, types.HashSHA1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, n)
+	assert.Equal(t, map[types.HashAlgorithm]string{
+		types.HashMD5:    "900150983cd24fb0d6963f7d28e17f72",
+		types.HashSHA1:   "a9993e364706816aba3e25717850c26c9cd0d89d",
+		types.HashSHA256: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	}, hashes)
+
+	hashes, _, err = Hash(strings.NewReader("abc"))
+	require.NoError(t, err)
+	assert.Len(t, hashes, 1)
+	assert.Contains(t, hashes, types.HashSHA256)
+
+	_, _, err = Hash(strings.NewReader("abc"), "crc32")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import "github.com/elastic/go-sysinfo/types"
+
+// CopyStaticHostInfo copies the fields of src that do not change while the
+// host is running to dst. The OS and firmware information are copied so that
+// dst does not share them with src.
+func CopyStaticHostInfo(dst *types.HostInfo, src types.HostInfo) {
+	dst.Architecture = src.Architecture
+	dst.KernelVersion = src.KernelVersion
+	dst.UniqueID = src.UniqueID
+	dst.UniqueIDSource = src.UniqueIDSource
+	dst.SerialNumber = src.SerialNumber
+	dst.AssetTag = src.AssetTag
+	if src.OS != nil {
+		os := *src.OS
+		dst.OS = &os
+	}
+	if src.Firmware != nil {
+		fw := *src.Firmware
+		dst.Firmware = &fw
+	}
+}
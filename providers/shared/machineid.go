@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var knownMachineIDSources = map[string]bool{
+	registry.MachineIDSMBIOS:       true,
+	registry.MachineIDEtc:          true,
+	registry.MachineIDPlatformUUID: true,
+	registry.MachineIDMachineGUID:  true,
+	registry.MachineIDUname:        true,
+	registry.MachineIDFile:         true,
+}
+
+// MachineID returns the machine ID from the first of opts.MachineIDSources
+// that has one, together with the name of that source. readers maps the
+// sources supported by the platform to the functions that read them and
+// defaults are the sources used when opts.MachineIDSources is empty. The
+// MachineIDFile source is supported on all platforms. Sources that are not
+// supported by the platform are skipped. types.ErrNotImplemented is
+// returned if none of the sources is supported.
+func MachineID(opts registry.HostOptions, defaults []string, readers map[string]func() (string, error)) (id, source string, err error) {
+	sources := opts.MachineIDSources
+	if len(sources) == 0 {
+		sources = defaults
+	}
+	for _, s := range sources {
+		if !knownMachineIDSources[s] {
+			return "", "", fmt.Errorf("unknown machine ID source %q", s)
+		}
+	}
+
+	var errs []error
+	for _, s := range sources {
+		read, found := readers[s]
+		if !found && s == registry.MachineIDFile {
+			read, found = func() (string, error) { return ReadMachineIDFile(opts.MachineIDPath) }, true
+		}
+		if !found {
+			continue
+		}
+
+		id, err := read()
+		if err != nil {
+			if !errors.Is(err, types.ErrNotImplemented) {
+				errs = append(errs, fmt.Errorf("machine ID source %v: %w", s, err))
+			}
+			continue
+		}
+		if id != "" {
+			return id, s, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return "", "", &multierror.MultiError{Errors: errs}
+	}
+	return "", "", fmt.Errorf("no machine ID from sources %v: %w", sources, types.ErrNotImplemented)
+}
+
+// ReadMachineIDFile returns the contents of the machine ID file at path
+// without surrounding whitespace.
+func ReadMachineIDFile(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("machine ID file path is not set")
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	id := strings.TrimSpace(string(b))
+	if id == "" {
+		return "", fmt.Errorf("machine ID file %v is empty", path)
+	}
+	return id, nil
+}
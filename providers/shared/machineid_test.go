@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestMachineID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id")
+	require.NoError(t, ioutil.WriteFile(path, []byte(" from-file\n"), 0o600))
+
+	readers := map[string]func() (string, error){
+		registry.MachineIDEtc:    func() (string, error) { return "from-etc", nil },
+		registry.MachineIDSMBIOS: func() (string, error) { return "", errors.New("permission denied") },
+	}
+	defaults := []string{registry.MachineIDEtc}
+
+	for _, tc := range []struct {
+		name    string
+		opts    registry.HostOptions
+		id      string
+		source  string
+		wantErr bool
+	}{
+		{"default", registry.HostOptions{}, "from-etc", registry.MachineIDEtc, false},
+		{"fallback", registry.HostOptions{MachineIDSources: []string{registry.MachineIDSMBIOS, registry.MachineIDEtc}}, "from-etc", registry.MachineIDEtc, false},
+		{"unsupported skipped", registry.HostOptions{MachineIDSources: []string{registry.MachineIDMachineGUID, registry.MachineIDEtc}}, "from-etc", registry.MachineIDEtc, false},
+		{"file", registry.HostOptions{MachineIDSources: []string{registry.MachineIDFile}, MachineIDPath: path}, "from-file", registry.MachineIDFile, false},
+		{"all failed", registry.HostOptions{MachineIDSources: []string{registry.MachineIDSMBIOS}}, "", "", true},
+		{"unknown", registry.HostOptions{MachineIDSources: []string{"bogus"}}, "", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			id, source, err := MachineID(tc.opts, defaults, readers)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, id)
+			assert.Equal(t, tc.source, source)
+		})
+	}
+
+	_, _, err := MachineID(registry.HostOptions{MachineIDSources: []string{registry.MachineIDPlatformUUID}}, defaults, readers)
+	assert.True(t, errors.Is(err, types.ErrNotImplemented), "err: %v", err)
+}
@@ -46,3 +46,13 @@ func Network() (ips, macs []string, err error) {
 
 	return ips, macs, nil
 }
+
+// InterfaceName returns the name of the network interface with the given
+// index or an empty string if it does not exist.
+func InterfaceName(index int) string {
+	ifc, err := net.InterfaceByIndex(index)
+	if err != nil {
+		return ""
+	}
+	return ifc.Name
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pciVendors contains the names of the PCI vendors that commonly ship
+// display controllers. It is used when no pci.ids database is available.
+var pciVendors = map[uint16]string{
+	0x1002: "Advanced Micro Devices, Inc. [AMD/ATI]",
+	0x102b: "Matrox Electronics Systems Ltd.",
+	0x106b: "Apple Inc.",
+	0x10de: "NVIDIA Corporation",
+	0x1234: "QEMU",
+	0x13b5: "ARM",
+	0x1414: "Microsoft Corporation",
+	0x15ad: "VMware",
+	0x1a03: "ASPEED Technology, Inc.",
+	0x1af4: "Red Hat, Inc.",
+	0x1b36: "Red Hat, Inc.",
+	0x5143: "Qualcomm",
+	0x80ee: "InnoTek Systemberatung GmbH",
+	0x8086: "Intel Corporation",
+}
+
+// PCIVendorName returns the name of a well-known PCI vendor. An empty string
+// is returned if the vendor is unknown.
+func PCIVendorName(id uint16) string {
+	return pciVendors[id]
+}
+
+// ParsePCIID parses a PCI vendor or device ID in the formats used by sysfs
+// ("0x10de") and by Windows hardware IDs ("10DE").
+func ParsePCIID(s string) (uint16, error) {
+	s = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "0x")
+	id, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PCI ID %q: %w", s, err)
+	}
+	return uint16(id), nil
+}
+
+// FormatPCIID formats a PCI vendor or device ID as a 0x prefixed hex string.
+func FormatPCIID(id uint16) string {
+	return fmt.Sprintf("0x%04x", id)
+}
@@ -0,0 +1,38 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawStruct(t *testing.T) {
+	type counters struct {
+		length     uint32
+		PageFault  uint32
+		WorkingSet uint64
+	}
+	v := counters{length: 1, PageFault: 2, WorkingSet: 3}
+
+	want := map[string]interface{}{"PageFault": uint32(2), "WorkingSet": uint64(3)}
+	assert.Equal(t, want, RawStruct(v))
+	assert.Equal(t, want, RawStruct(&v))
+	assert.Nil(t, RawStruct(42))
+}
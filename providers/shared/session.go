@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"sort"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// SessionUsers returns the sorted, distinct user names of the sessions.
+func SessionUsers(sessions []types.SessionInfo) []string {
+	seen := make(map[string]struct{}, len(sessions))
+	users := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		if _, found := seen[s.User]; found || s.User == "" {
+			continue
+		}
+		seen[s.User] = struct{}{}
+		users = append(users, s.User)
+	}
+	sort.Strings(users)
+	return users
+}
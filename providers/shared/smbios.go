@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SMBIOS structure types.
+const (
+	SMBIOSTypeBIOS    = 0
+	SMBIOSTypeSystem  = 1
+	SMBIOSTypeChassis = 3
+	SMBIOSTypeEnd     = 127
+)
+
+// SMBIOSStructure is a single structure of an SMBIOS table.
+type SMBIOSStructure struct {
+	Type      uint8
+	Handle    uint16
+	Formatted []byte   // Formatted area including the 4 byte header.
+	Strings   []string // Unformed string-set. String numbers start at 1.
+}
+
+// String returns the string referenced by the string number stored at the
+// given offset of the formatted area. An empty string is returned if the
+// offset is out of bounds, the string is not set, or it is a placeholder.
+func (s SMBIOSStructure) String(offset int) string {
+	if offset >= len(s.Formatted) {
+		return ""
+	}
+	n := int(s.Formatted[offset])
+	if n == 0 || n > len(s.Strings) {
+		return ""
+	}
+	return CleanSMBIOSString(s.Strings[n-1])
+}
+
+// Byte returns the byte at the given offset of the formatted area.
+func (s SMBIOSStructure) Byte(offset int) (uint8, bool) {
+	if offset >= len(s.Formatted) {
+		return 0, false
+	}
+	return s.Formatted[offset], true
+}
+
+// Uint16 returns the little-endian word at the given offset of the formatted
+// area.
+func (s SMBIOSStructure) Uint16(offset int) (uint16, bool) {
+	if offset+2 > len(s.Formatted) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint16(s.Formatted[offset:]), true
+}
+
+// ParseSMBIOS parses the structures of an SMBIOS table. The table is the
+// concatenation of structures as exposed in /sys/firmware/dmi/tables/DMI or
+// returned by GetSystemFirmwareTable without the RawSMBIOSData header.
+func ParseSMBIOS(table []byte) ([]SMBIOSStructure, error) {
+	var structures []SMBIOSStructure
+	for len(table) >= 4 {
+		length := int(table[1])
+		if length < 4 || length > len(table) {
+			return nil, fmt.Errorf("invalid SMBIOS structure length %d", length)
+		}
+
+		s := SMBIOSStructure{
+			Type:      table[0],
+			Handle:    binary.LittleEndian.Uint16(table[2:4]),
+			Formatted: table[:length],
+		}
+
+		// The string-set is terminated by two NUL bytes.
+		end := bytes.Index(table[length:], []byte{0, 0})
+		if end == -1 {
+			return nil, errors.New("unterminated SMBIOS string-set")
+		}
+		if end > 0 {
+			s.Strings = strings.Split(string(table[length:length+end]), "\x00")
+		}
+		structures = append(structures, s)
+
+		table = table[length+end+2:]
+		if s.Type == SMBIOSTypeEnd {
+			break
+		}
+	}
+	return structures, nil
+}
+
+// FindSMBIOS returns the first structure of the given type.
+func FindSMBIOS(structures []SMBIOSStructure, typ uint8) (SMBIOSStructure, bool) {
+	for _, s := range structures {
+		if s.Type == typ {
+			return s, true
+		}
+	}
+	return SMBIOSStructure{}, false
+}
+
+// SMBIOSUUID returns the UUID stored at the given offset. Since SMBIOS 2.6
+// the first three fields are encoded in little-endian byte order. An empty
+// string is returned if the UUID is not present or not set.
+func SMBIOSUUID(s SMBIOSStructure, offset int, major, minor uint8) string {
+	if offset+16 > len(s.Formatted) {
+		return ""
+	}
+	b := s.Formatted[offset : offset+16]
+
+	// All bits set indicates that the ID is not present and all bits
+	// cleared indicates that it is not set.
+	if bytes.Equal(b, bytes.Repeat([]byte{0xff}, 16)) || bytes.Equal(b, make([]byte, 16)) {
+		return ""
+	}
+
+	if major > 2 || (major == 2 && minor >= 6) {
+		return fmt.Sprintf("%08X-%04X-%04X-%X-%X",
+			binary.LittleEndian.Uint32(b[0:4]),
+			binary.LittleEndian.Uint16(b[4:6]),
+			binary.LittleEndian.Uint16(b[6:8]),
+			b[8:10], b[10:16])
+	}
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// smbiosPlaceholders are values that vendors leave in SMBIOS strings that
+// were not customized.
+var smbiosPlaceholders = map[string]struct{}{
+	"":                        {},
+	"0":                       {},
+	"Default string":          {},
+	"None":                    {},
+	"Not Applicable":          {},
+	"Not Specified":           {},
+	"O.E.M.":                  {},
+	"System Product Name":     {},
+	"System Serial Number":    {},
+	"System Version":          {},
+	"System manufacturer":     {},
+	"To Be Filled By O.E.M.":  {},
+	"To be filled by O.E.M.":  {},
+	"Type1ProductConfigId":    {},
+	"Unknown":                 {},
+	"XXXXXXXXXXXXXXXXXXXXXXX": {},
+}
+
+// CleanSMBIOSString trims s and returns an empty string if it is a well-known
+// placeholder value.
+func CleanSMBIOSString(s string) string {
+	s = strings.TrimSpace(s)
+	if _, found := smbiosPlaceholders[s]; found {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func smbiosStructure(header []byte, strs ...string) []byte {
+	b := append([]byte{}, header...)
+	if len(strs) == 0 {
+		return append(b, 0, 0)
+	}
+	for _, s := range strs {
+		b = append(b, s...)
+		b = append(b, 0)
+	}
+	return append(b, 0)
+}
+
+func TestParseSMBIOS(t *testing.T) {
+	var table []byte
+	table = append(table, smbiosStructure(
+		[]byte{0, 0x18, 0x00, 0x00, 1, 2, 0x00, 0xf0, 3, 0x00, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 17, 0xff, 0xff},
+		"Dell Inc.", "2.17.1", "11/29/2022")...)
+	table = append(table, smbiosStructure(
+		[]byte{
+			1, 0x1b, 0x00, 0x01, 1, 2, 3, 4,
+			0x44, 0x45, 0x4c, 0x4c, 0x42, 0x00, 0x10, 0x35, 0x80, 0x53, 0xb7, 0xc0, 0x4f, 0x4e, 0x37, 0x32,
+			6, 5, 6,
+		},
+		"Dell Inc.", "PowerEdge R640", "Not Specified", "7B5SNK2", "SKU=0716;ModelName=PowerEdge R640", "PowerEdge")...)
+	table = append(table, smbiosStructure([]byte{127, 4, 0xff, 0xfe})...)
+	table = append(table, 0xde, 0xad)
+
+	structures, err := ParseSMBIOS(table)
+	require.NoError(t, err)
+	require.Len(t, structures, 3)
+
+	bios, found := FindSMBIOS(structures, SMBIOSTypeBIOS)
+	require.True(t, found)
+	assert.Equal(t, "Dell Inc.", bios.String(4))
+	assert.Equal(t, "2.17.1", bios.String(5))
+	assert.Equal(t, "11/29/2022", bios.String(8))
+
+	system, found := FindSMBIOS(structures, SMBIOSTypeSystem)
+	require.True(t, found)
+	assert.EqualValues(t, 0x0100, system.Handle)
+	assert.Equal(t, "Dell Inc.", system.String(4))
+	assert.Equal(t, "PowerEdge R640", system.String(5))
+	assert.Equal(t, "", system.String(6), "placeholder values are removed")
+	assert.Equal(t, "7B5SNK2", system.String(7))
+	assert.Equal(t, "PowerEdge", system.String(0x1a))
+	assert.Equal(t, "", system.String(100))
+	assert.Equal(t, "4C4C4544-0042-3510-8053-B7C04F4E3732", SMBIOSUUID(system, 8, 3, 2))
+	assert.Equal(t, "44454C4C-4200-1035-8053-B7C04F4E3732", SMBIOSUUID(system, 8, 2, 4))
+
+	_, found = FindSMBIOS(structures, SMBIOSTypeChassis)
+	assert.False(t, found)
+}
+
+func TestParseSMBIOSInvalid(t *testing.T) {
+	_, err := ParseSMBIOS([]byte{1, 2, 0, 0})
+	assert.Error(t, err)
+
+	_, err = ParseSMBIOS([]byte{1, 4, 0, 0, 'a'})
+	assert.Error(t, err)
+}
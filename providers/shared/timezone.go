@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"os"
+	"time"
+)
+
+// Timezone returns the name and the offset in seconds east of UTC of the
+// system time zone at t. Go loads time.Local only once per process, so it
+// does not reflect changes of the time zone made by an administrator. Timezone
+// instead reads the zone from localtime (e.g. /etc/localtime) on each call.
+// The TZ environment variable and errors reading localtime fall back to
+// time.Local.
+func Timezone(localtime string, t time.Time) (name string, offset int) {
+	loc := time.Local
+	if _, ok := os.LookupEnv("TZ"); !ok {
+		if data, err := os.ReadFile(localtime); err == nil {
+			if l, err := time.LoadLocationFromTZData("Local", data); err == nil {
+				loc = l
+			}
+		}
+	}
+	return t.In(loc).Zone()
+}
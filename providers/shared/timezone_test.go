@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimezone(t *testing.T) {
+	if _, ok := os.LookupEnv("TZ"); ok {
+		t.Skip("TZ is set")
+	}
+
+	// The DST transitions of Europe/Berlin in 2023 are at 01:00 UTC.
+	for _, tc := range []struct {
+		t      time.Time
+		name   string
+		offset int
+	}{
+		{time.Date(2023, 3, 26, 0, 59, 59, 0, time.UTC), "CET", 3600},
+		{time.Date(2023, 3, 26, 1, 0, 0, 0, time.UTC), "CEST", 7200},
+		{time.Date(2023, 10, 29, 0, 59, 59, 0, time.UTC), "CEST", 7200},
+		{time.Date(2023, 10, 29, 1, 0, 0, 0, time.UTC), "CET", 3600},
+	} {
+		name, offset := Timezone("testdata/Europe_Berlin", tc.t)
+		assert.Equal(t, tc.name, name, tc.t)
+		assert.Equal(t, tc.offset, offset, tc.t)
+	}
+
+	// A missing localtime falls back to time.Local.
+	now := time.Now()
+	name, offset := Timezone("testdata/missing", now)
+	wantName, wantOffset := now.Zone()
+	assert.Equal(t, wantName, name)
+	assert.Equal(t, wantOffset, offset)
+}
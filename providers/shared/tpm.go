@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// tpmVendors maps the TCG vendor IDs to the names of the vendors.
+var tpmVendors = map[string]string{
+	"AMD":  "AMD",
+	"ATML": "Atmel",
+	"BRCM": "Broadcom",
+	"CSCO": "Cisco",
+	"FLYS": "Flyslice Technologies",
+	"GOOG": "Google",
+	"HPE":  "HPE",
+	"HISI": "Huawei",
+	"IBM":  "IBM",
+	"IFX":  "Infineon",
+	"INTC": "Intel",
+	"LEN":  "Lenovo",
+	"MSFT": "Microsoft",
+	"NSM":  "National Semiconductor",
+	"NTC":  "Nuvoton Technology",
+	"NTZ":  "Nationz",
+	"QCOM": "Qualcomm",
+	"ROCC": "Fuzhou Rockchip",
+	"SMSC": "SMSC",
+	"SMSN": "Samsung",
+	"SNS":  "Sinosun",
+	"STM":  "STMicroelectronics",
+	"TXN":  "Texas Instruments",
+	"WEC":  "Winbond",
+}
+
+// TPMVendorName returns the name of the vendor with the given TCG vendor ID.
+// An empty string is returned if the vendor is unknown.
+func TPMVendorName(id string) string {
+	return tpmVendors[id]
+}
+
+// TPMVendorID decodes a vendor ID encoded as a 32-bit big-endian ASCII
+// value (e.g. 0x49465800 is IFX).
+func TPMVendorID(v uint32) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	return strings.TrimRight(string(b[:]), "\x00 ")
+}
+
+// TPM 2.0 command constants.
+const (
+	tpm2TagNoSessions     = 0x8001
+	tpm2CCGetCapability   = 0x0000017a
+	tpm2CapTPMProperties  = 0x00000006
+	tpm2PTManufacturer    = 0x00000105
+	tpm2ResponseHeaderLen = 10
+)
+
+// TPM2GetManufacturerCommand returns a TPM2_GetCapability command that
+// requests the TPM_PT_MANUFACTURER property.
+func TPM2GetManufacturerCommand() []byte {
+	cmd := make([]byte, 22)
+	binary.BigEndian.PutUint16(cmd[0:], tpm2TagNoSessions)
+	binary.BigEndian.PutUint32(cmd[2:], uint32(len(cmd)))
+	binary.BigEndian.PutUint32(cmd[6:], tpm2CCGetCapability)
+	binary.BigEndian.PutUint32(cmd[10:], tpm2CapTPMProperties)
+	binary.BigEndian.PutUint32(cmd[14:], tpm2PTManufacturer)
+	binary.BigEndian.PutUint32(cmd[18:], 1) // Property count.
+	return cmd
+}
+
+// ParseTPM2GetManufacturerResponse returns the vendor ID from the response
+// to the command returned by TPM2GetManufacturerCommand.
+func ParseTPM2GetManufacturerResponse(resp []byte) (string, error) {
+	if len(resp) < tpm2ResponseHeaderLen {
+		return "", errors.New("TPM response is too short")
+	}
+	if rc := binary.BigEndian.Uint32(resp[6:10]); rc != 0 {
+		return "", fmt.Errorf("TPM2_GetCapability failed with response code 0x%x", rc)
+	}
+
+	// moreData (1), capability (4), count (4), property (4), value (4).
+	body := resp[tpm2ResponseHeaderLen:]
+	if len(body) < 17 {
+		return "", errors.New("TPM2_GetCapability response is too short")
+	}
+	if binary.BigEndian.Uint32(body[5:9]) == 0 || binary.BigEndian.Uint32(body[9:13]) != tpm2PTManufacturer {
+		return "", errors.New("TPM_PT_MANUFACTURER not found in TPM2_GetCapability response")
+	}
+	return TPMVendorID(binary.BigEndian.Uint32(body[13:17])), nil
+}
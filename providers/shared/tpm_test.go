@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTPM2GetManufacturer(t *testing.T) {
+	assert.Equal(t,
+		"8001000000160000017a000000060000010500000001",
+		hex.EncodeToString(TPM2GetManufacturerCommand()))
+
+	resp, err := hex.DecodeString("80010000001b00000000" + "00" + "00000006" + "00000001" + "00000105" + "4e544300")
+	require.NoError(t, err)
+
+	id, err := ParseTPM2GetManufacturerResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "NTC", id)
+	assert.Equal(t, "Nuvoton Technology", TPMVendorName(id))
+
+	// TPM_RC_INITIALIZE
+	_, err = ParseTPM2GetManufacturerResponse([]byte{0x80, 0x01, 0, 0, 0, 0x0a, 0, 0, 0x01, 0x00})
+	assert.Error(t, err)
+}
+
+func TestTPMVendorID(t *testing.T) {
+	assert.Equal(t, "IFX", TPMVendorID(0x49465800))
+	assert.Equal(t, "INTC", TPMVendorID(0x494e5443))
+}
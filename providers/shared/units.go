@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// Unit conversions used by the providers. All sizes reported in the types
+// package are in bytes and all times are time.Durations, so raw counters
+// must be converted with the helpers below. The conversions saturate instead
+// of wrapping around because a wrapped value (e.g. 16 EiB of used memory) is
+// far more misleading to consumers than a clamped one.
+const (
+	// KiB is the unit of the kB suffixed values in /proc (e.g. /proc/meminfo
+	// and /proc/<pid>/status). Despite the suffix they are kibibytes.
+	KiB uint64 = 1024
+
+	// filetimeUnit is the resolution of Windows FILETIME values and of the
+	// interrupt time counters.
+	filetimeUnit = 100 * time.Nanosecond
+)
+
+// MulSaturating returns a*b, or math.MaxUint64 if the product overflows. It
+// is used to convert counts of units (e.g. pages or KiB) to bytes.
+func MulSaturating(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+	return lo
+}
+
+// SubSaturating returns a-b, or 0 if b is greater than a. It is used for
+// derived values like used = total - free where both operands are read
+// separately and can be momentarily inconsistent.
+func SubSaturating(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+// TicksToDuration converts a number of ticks of a clock running at hz ticks
+// per second (e.g. USER_HZ or the Mach host clock rate) to a duration. The
+// conversion is exact to the nanosecond and saturates at the maximum
+// duration. Zero is returned if hz is zero.
+func TicksToDuration(ticks, hz uint64) time.Duration {
+	if hz == 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(ticks, uint64(time.Second))
+	if hi >= hz {
+		// The quotient does not fit in 64 bits.
+		return math.MaxInt64
+	}
+	ns, _ := bits.Div64(hi, lo, hz)
+	if ns > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(ns)
+}
+
+// FiletimeToDuration converts a number of 100 nanosecond intervals, the unit
+// of Windows FILETIME values, to a duration. The conversion saturates at the
+// maximum duration.
+func FiletimeToDuration(intervals uint64) time.Duration {
+	ns := MulSaturating(intervals, uint64(filetimeUnit))
+	if ns > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(ns)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package shared
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMulSaturating(t *testing.T) {
+	assert.EqualValues(t, 2048, MulSaturating(2, KiB))
+	assert.EqualValues(t, 0, MulSaturating(0, math.MaxUint64))
+	assert.EqualValues(t, uint64(math.MaxUint64), MulSaturating(math.MaxUint64/1000, KiB))
+}
+
+func TestSubSaturating(t *testing.T) {
+	assert.EqualValues(t, 1, SubSaturating(3, 2))
+	assert.EqualValues(t, 0, SubSaturating(2, 3))
+}
+
+func TestTicksToDuration(t *testing.T) {
+	tests := []struct {
+		ticks, hz uint64
+		expected  time.Duration
+	}{
+		{0, 100, 0},
+		{1, 100, 10 * time.Millisecond},
+		{150, 100, 1500 * time.Millisecond},
+		{1, 3, 333333333},
+		{7, 0, 0},
+		// Overflows an int64 when multiplied by time.Second first.
+		{20_000_000_000, 100, 200_000_000 * time.Second},
+		{math.MaxUint64, 100, math.MaxInt64},
+		{math.MaxUint64, 1, math.MaxInt64},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, TicksToDuration(tc.ticks, tc.hz), "ticks=%d hz=%d", tc.ticks, tc.hz)
+	}
+}
+
+func TestFiletimeToDuration(t *testing.T) {
+	assert.Equal(t, time.Second, FiletimeToDuration(10_000_000))
+	assert.Equal(t, time.Duration(math.MaxInt64), FiletimeToDuration(math.MaxUint64/10))
+}
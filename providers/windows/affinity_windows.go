@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"syscall"
+
+	syswin "golang.org/x/sys/windows"
+)
+
+// Affinity reports the processors of the processor group of the process
+// that it is allowed to run on.
+func (p *process) Affinity() ([]int, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var processMask, systemMask uintptr
+	if err := _GetProcessAffinityMask(syswin.Handle(handle), &processMask, &systemMask); err != nil {
+		return nil, fmt.Errorf("GetProcessAffinityMask failed: %w", err)
+	}
+	return affinityMaskCPUs(processMask), nil
+}
+
+// SetAffinity restricts the process to the given processors of its
+// processor group.
+func (p *process) SetAffinity(cpus []int) error {
+	mask, err := cpusAffinityMask(cpus)
+	if err != nil {
+		return err
+	}
+
+	handle := syswin.CurrentProcess()
+	if p.pid != selfPID {
+		handle, err = syswin.OpenProcess(syswin.PROCESS_SET_INFORMATION|syswin.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(p.pid))
+		if err != nil {
+			return fmt.Errorf("OpenProcess failed: %w", err)
+		}
+		defer syswin.CloseHandle(handle)
+	}
+
+	if err := _SetProcessAffinityMask(handle, mask); err != nil {
+		return fmt.Errorf("SetProcessAffinityMask failed: %w", err)
+	}
+	return nil
+}
+
+func affinityMaskCPUs(mask uintptr) []int {
+	cpus := make([]int, 0, bits.OnesCount64(uint64(mask)))
+	for cpu := 0; cpu < bits.UintSize; cpu++ {
+		if mask&(1<<cpu) != 0 {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
+
+func cpusAffinityMask(cpus []int) (uintptr, error) {
+	if len(cpus) == 0 {
+		return 0, errors.New("no CPUs given")
+	}
+
+	var mask uintptr
+	for _, cpu := range cpus {
+		if cpu < 0 || cpu >= bits.UintSize {
+			return 0, fmt.Errorf("invalid CPU number %d", cpu)
+		}
+		mask |= 1 << cpu
+	}
+	return mask, nil
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Affinity = (*process)(nil)
+
+func TestCPUsAffinityMask(t *testing.T) {
+	mask, err := cpusAffinityMask([]int{0, 3, 5})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0x29, mask)
+	assert.Equal(t, []int{0, 3, 5}, affinityMaskCPUs(mask))
+
+	_, err = cpusAffinityMask(nil)
+	assert.Error(t, err)
+	_, err = cpusAffinityMask([]int{bits.UintSize})
+	assert.Error(t, err)
+}
+
+func TestSelfAffinity(t *testing.T) {
+	self, err := newProcess(selfPID)
+	require.NoError(t, err)
+
+	cpus, err := self.Affinity()
+	require.NoError(t, err)
+	require.NotEmpty(t, cpus)
+
+	// Setting the current affinity does not change anything.
+	require.NoError(t, self.SetAffinity(cpus))
+}
@@ -18,10 +18,25 @@
 package windows
 
 import (
+	"debug/pe"
+	"syscall"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+
 	windows "github.com/elastic/go-windows"
 )
 
 func Architecture() (string, error) {
+	// GetNativeSystemInfo reports x86_64 to processes that run under x64
+	// emulation on ARM64, but IsWow64Process2 returns the real machine.
+	var processMachine, nativeMachine uint16
+	if err := syswin.IsWow64Process2(syswin.CurrentProcess(), &processMachine, &nativeMachine); err == nil {
+		if arch := machineArchitecture(nativeMachine); arch != "" {
+			return arch, nil
+		}
+	}
+
 	systemInfo, err := windows.GetNativeSystemInfo()
 	if err != nil {
 		return "", err
@@ -29,3 +44,51 @@ func Architecture() (string, error) {
 
 	return systemInfo.ProcessorArchitecture.String(), nil
 }
+
+// machineArchitecture returns the architecture name of an
+// IMAGE_FILE_MACHINE value, or an empty string if it is unknown.
+func machineArchitecture(machine uint16) string {
+	switch machine {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "x86_64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "x86"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_ARMNT:
+		return "arm"
+	default:
+		return ""
+	}
+}
+
+// processArchitecture returns the architecture that the process runs as,
+// the native architecture of the machine, and whether the process runs
+// under x86 or x64 emulation on ARM64.
+func processArchitecture(handle syscall.Handle) (arch, native string, emulated *bool) {
+	var wowMachine, nativeMachine uint16
+	if err := syswin.IsWow64Process2(syswin.Handle(handle), &wowMachine, &nativeMachine); err != nil {
+		return "", "", nil
+	}
+	native = machineArchitecture(nativeMachine)
+
+	processMachine := nativeMachine
+	if wowMachine != pe.IMAGE_FILE_MACHINE_UNKNOWN {
+		// A WOW64 process, i.e. x86 or ARM32 code on a 64-bit system.
+		processMachine = wowMachine
+	} else {
+		// x64 emulation on ARM64 is not WOW64 and only reported by
+		// GetProcessInformation since Windows 11.
+		var info processMachineInformation
+		if err := _GetProcessInformation(syswin.Handle(handle), processMachineTypeInfo, unsafe.Pointer(&info), uint32(unsafe.Sizeof(info))); err == nil {
+			processMachine = info.ProcessMachine
+		}
+	}
+	arch = machineArchitecture(processMachine)
+
+	if nativeMachine == pe.IMAGE_FILE_MACHINE_ARM64 {
+		e := processMachine == pe.IMAGE_FILE_MACHINE_AMD64 || processMachine == pe.IMAGE_FILE_MACHINE_I386
+		emulated = &e
+	}
+	return arch, native, emulated
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"debug/pe"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineArchitecture(t *testing.T) {
+	assert.Equal(t, "x86_64", machineArchitecture(pe.IMAGE_FILE_MACHINE_AMD64))
+	assert.Equal(t, "arm64", machineArchitecture(pe.IMAGE_FILE_MACHINE_ARM64))
+	assert.Equal(t, "", machineArchitecture(pe.IMAGE_FILE_MACHINE_UNKNOWN))
+}
+
+func TestProcessArchitecture(t *testing.T) {
+	p, err := newProcess(selfPID)
+	require.NoError(t, err)
+	info, err := p.Info()
+	require.NoError(t, err)
+
+	native, err := Architecture()
+	require.NoError(t, err)
+	assert.Equal(t, native, info.NativeArchitecture)
+
+	goarch := map[string]string{"386": "x86", "amd64": "x86_64", "arm64": "arm64", "arm": "arm"}
+	assert.Equal(t, goarch[runtime.GOARCH], info.Architecture)
+	if native == "arm64" {
+		require.NotNil(t, info.Translated)
+		assert.Equal(t, runtime.GOARCH == "amd64" || runtime.GOARCH == "386", *info.Translated)
+	} else {
+		assert.Nil(t, info.Translated)
+	}
+}
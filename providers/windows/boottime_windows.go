@@ -31,6 +31,6 @@ func BootTime() (time.Time, error) {
 	// 10 milliseconds to 16 milliseconds. So this will round the value to the
 	// nearest second to not mislead anyone about the precision of the value
 	// and to provide a stable value.
-	bootTime = bootTime.Round(time.Second)
+	bootTime = bootTime.Round(time.Second).UTC()
 	return bootTime, nil
 }
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// CanCollect reports whether the process has the privileges needed to
+// collect the data. The privileges held by the process token are inspected.
+func (s windowsSystem) CanCollect(capability types.Capability) (bool, error) {
+	switch capability {
+	case types.CapabilityProcessEnvironment, types.CapabilityProcessCommandLine:
+		// Reading the memory of processes owned by other users requires
+		// SeDebugPrivilege. It is enabled when needed if the token holds it.
+		return hasPrivilege(windows.GetCurrentProcessToken(), "SeDebugPrivilege")
+	case types.CapabilityHardwareSerial:
+		// GetSystemFirmwareTable does not require any privileges.
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown capability %q: %w", capability, types.ErrNotImplemented)
+	}
+}
+
+// hasPrivilege returns whether the token holds the privilege, enabled or not.
+func hasPrivilege(token windows.Token, name string) (bool, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return false, err
+	}
+	var luid windows.LUID
+	if err := windows.LookupPrivilegeValue(nil, namePtr, &luid); err != nil {
+		return false, fmt.Errorf("LookupPrivilegeValue failed: %w", err)
+	}
+
+	buf, err := tokenInformation(token, windows.TokenPrivileges)
+	if err != nil {
+		return false, err
+	}
+
+	privileges := (*windows.Tokenprivileges)(unsafe.Pointer(&buf[0]))
+	for _, p := range privileges.AllPrivileges() {
+		if p.Luid == luid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// ContextSwitches reports the context switches of the threads of the
+// process. Windows does not distinguish voluntary and involuntary switches.
+// The switches of threads that have exited are not included.
+func (p *process) ContextSwitches() (*types.ContextSwitchInfo, error) {
+	buf, err := querySystemProcessInformation()
+	if err != nil {
+		return nil, err
+	}
+
+	var info *types.ContextSwitchInfo
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, threads []systemThreadInformation) bool {
+		if int(proc.UniqueProcessID) != p.pid {
+			return true
+		}
+		info = &types.ContextSwitchInfo{Total: threadContextSwitches(threads)}
+		return false
+	})
+	if info == nil {
+		return nil, fmt.Errorf("process %d not found", p.pid)
+	}
+	return info, nil
+}
+
+// threadContextSwitches sums the context switches of the threads.
+func threadContextSwitches(threads []systemThreadInformation) uint64 {
+	var total uint64
+	for _, t := range threads {
+		total += uint64(t.ContextSwitches)
+	}
+	return total
+}
@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Delays reports the number of waiting threads of the process by wait
+// reason (e.g. UserRequest, WrQueue, or PageIn). Windows does not account
+// for the cumulative time spent waiting so only the current state of the
+// threads is reported.
+func (p *process) Delays() (*types.DelayInfo, error) {
+	buf, err := querySystemProcessInformation()
+	if err != nil {
+		return nil, err
+	}
+
+	var info *types.DelayInfo
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, threads []systemThreadInformation) bool {
+		if int(proc.UniqueProcessID) != p.pid {
+			return true
+		}
+		info = &types.DelayInfo{WaitReasons: threadWaitReasons(threads)}
+		return false
+	})
+	if info == nil {
+		return nil, fmt.Errorf("process %d not found", p.pid)
+	}
+	return info, nil
+}
+
+// threadWaitReasons counts the waiting threads by wait reason.
+func threadWaitReasons(threads []systemThreadInformation) map[string]int {
+	reasons := map[string]int{}
+	for _, t := range threads {
+		if t.ThreadState == threadStateWaiting {
+			reasons[waitReasonName(t.WaitReason)]++
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Delays          = (*process)(nil)
+	_ types.ContextSwitches = (*process)(nil)
+)
+
+// systemProcessInformation builds a snapshot in the format returned by
+// NtQuerySystemInformation.
+func systemProcessInformation(procs map[uintptr][]systemThreadInformation, order ...uintptr) []byte {
+	const (
+		processSize = int(unsafe.Sizeof(syswin.SYSTEM_PROCESS_INFORMATION{}))
+		threadSize  = int(unsafe.Sizeof(systemThreadInformation{}))
+	)
+
+	var size int
+	for _, threads := range procs {
+		size += processSize + len(threads)*threadSize
+	}
+	buf := make([]byte, size)
+
+	offset := 0
+	for i, pid := range order {
+		threads := procs[pid]
+		entry := (*syswin.SYSTEM_PROCESS_INFORMATION)(unsafe.Pointer(&buf[offset]))
+		entry.UniqueProcessID = pid
+		entry.NumberOfThreads = uint32(len(threads))
+		for j, t := range threads {
+			*(*systemThreadInformation)(unsafe.Pointer(&buf[offset+processSize+j*threadSize])) = t
+		}
+		if i < len(order)-1 {
+			entry.NextEntryOffset = uint32(processSize + len(threads)*threadSize)
+		}
+		offset += processSize + len(threads)*threadSize
+	}
+	return buf
+}
+
+func TestWalkSystemProcessInformation(t *testing.T) {
+	buf := systemProcessInformation(map[uintptr][]systemThreadInformation{
+		4: {{ThreadState: threadStateWaiting, WaitReason: 15}},
+		1200: {
+			{ThreadState: threadStateWaiting, WaitReason: 6, ContextSwitches: 10},
+			{ThreadState: threadStateWaiting, WaitReason: 6, ContextSwitches: 20},
+			{ThreadState: threadStateWaiting, WaitReason: 2},
+			{ThreadState: 2, ContextSwitches: 5}, // Running
+			{ThreadState: threadStateWaiting, WaitReason: 99},
+		},
+	}, 4, 1200)
+
+	var pids []uintptr
+	var reasons map[string]int
+	var switches uint64
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, threads []systemThreadInformation) bool {
+		pids = append(pids, proc.UniqueProcessID)
+		if proc.UniqueProcessID == 1200 {
+			reasons = threadWaitReasons(threads)
+			switches = threadContextSwitches(threads)
+		}
+		return true
+	})
+
+	assert.Equal(t, []uintptr{4, 1200}, pids)
+	assert.Equal(t, map[string]int{"UserRequest": 2, "PageIn": 1, "Unknown(99)": 1}, reasons)
+	assert.EqualValues(t, 35, switches)
+}
+
+func TestWalkSystemProcessInformationTruncated(t *testing.T) {
+	buf := systemProcessInformation(map[uintptr][]systemThreadInformation{
+		8: {{}, {}},
+	}, 8)
+
+	var threads []systemThreadInformation
+	walkSystemProcessInformation(buf[:len(buf)-1], func(_ *syswin.SYSTEM_PROCESS_INFORMATION, t []systemThreadInformation) bool {
+		threads = t
+		return true
+	})
+	assert.Nil(t, threads)
+}
@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// GetAdaptersAddresses flags.
+const (
+	gaaFlagSkipUnicast   = 0x1
+	gaaFlagSkipAnycast   = 0x2
+	gaaFlagSkipMulticast = 0x4
+	gaaFlagSkipDNSServer = 0x8
+)
+
+const tcpipParametersKey = `SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
+
+// DNSConfig reports the DNS servers and connection-specific suffixes of the
+// network adapters that are up from GetAdaptersAddresses, which is what
+// "ipconfig /all" shows. The search domains are the configured suffix search
+// list or, without one, the primary DNS suffix followed by the
+// connection-specific suffixes.
+func (h *host) DNSConfig() (*types.DNSConfigInfo, error) {
+	interfaces, err := adapterDNS()
+	if err != nil {
+		return nil, err
+	}
+
+	var searchList []string
+	var domain string
+	if k, err := openLocalMachineKey(tcpipParametersKey); err == nil {
+		if list, err := regString(k, "SearchList"); err == nil {
+			searchList = splitSearchList(list)
+		}
+		domain, _ = regString(k, "Domain")
+		k.Close()
+	}
+	return dnsConfig(interfaces, searchList, domain), nil
+}
+
+// adapterAddresses returns the list of network adapters from
+// GetAdaptersAddresses.
+func adapterAddresses(flags uint32) (*windows.IpAdapterAddresses, error) {
+	size := uint32(15 * 1024)
+	for {
+		buf := make([]byte, size)
+		aa := (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0]))
+		err := windows.GetAdaptersAddresses(windows.AF_UNSPEC, flags, 0, aa, &size)
+		if err == nil {
+			return aa, nil
+		}
+		if !errors.Is(err, windows.ERROR_BUFFER_OVERFLOW) {
+			return nil, err
+		}
+	}
+}
+
+// adapterDNS returns the DNS settings of the network adapters that are up.
+func adapterDNS() ([]types.InterfaceDNS, error) {
+	aa, err := adapterAddresses(gaaFlagSkipUnicast | gaaFlagSkipAnycast | gaaFlagSkipMulticast)
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []types.InterfaceDNS
+	for ; aa != nil; aa = aa.Next {
+		if aa.OperStatus != windows.IfOperStatusUp {
+			continue
+		}
+		dns := types.InterfaceDNS{Interface: windows.UTF16PtrToString(aa.FriendlyName)}
+		for s := aa.FirstDnsServerAddress; s != nil; s = s.Next {
+			if ip := s.Address.IP(); ip != nil {
+				dns.Nameservers = append(dns.Nameservers, ip.String())
+			}
+		}
+		if suffix := windows.UTF16PtrToString(aa.DnsSuffix); suffix != "" {
+			dns.Search = []string{suffix}
+		}
+		if len(dns.Nameservers) > 0 {
+			interfaces = append(interfaces, dns)
+		}
+	}
+	return interfaces, nil
+}
+
+// dnsConfig combines the DNS settings of the adapters. Windows sends queries
+// to the servers of all adapters, so the nameservers are their union.
+func dnsConfig(interfaces []types.InterfaceDNS, searchList []string, domain string) *types.DNSConfigInfo {
+	info := &types.DNSConfigInfo{Nameservers: []string{}, Interfaces: interfaces}
+	for _, ifc := range interfaces {
+		info.Nameservers = appendUnique(info.Nameservers, ifc.Nameservers...)
+	}
+
+	if len(searchList) > 0 {
+		info.Search = appendUnique(info.Search, searchList...)
+		return info
+	}
+	if domain != "" {
+		info.Search = append(info.Search, domain)
+	}
+	for _, ifc := range interfaces {
+		info.Search = appendUnique(info.Search, ifc.Search...)
+	}
+	return info
+}
+
+// appendUnique appends the values that are not in list yet.
+func appendUnique(list []string, values ...string) []string {
+outer:
+	for _, v := range values {
+		for _, l := range list {
+			if l == v {
+				continue outer
+			}
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+// splitSearchList splits the comma separated SearchList registry value.
+func splitSearchList(s string) []string {
+	var list []string
+	for _, d := range strings.Split(s, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			list = append(list, d)
+		}
+	}
+	return list
+}
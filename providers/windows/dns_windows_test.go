@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.DNSConfig = (*host)(nil)
+
+func TestDNSConfig(t *testing.T) {
+	interfaces := []types.InterfaceDNS{
+		{Interface: "Ethernet", Nameservers: []string{"192.168.1.1", "fec0:0:0:ffff::1"}, Search: []string{"lan"}},
+		{Interface: "VPN", Nameservers: []string{"10.8.0.1", "192.168.1.1"}, Search: []string{"corp.example.com"}},
+	}
+
+	assert.Equal(t, &types.DNSConfigInfo{
+		Nameservers: []string{"192.168.1.1", "fec0:0:0:ffff::1", "10.8.0.1"},
+		Search:      []string{"ad.example.com", "lan", "corp.example.com"},
+		Interfaces:  interfaces,
+	}, dnsConfig(interfaces, nil, "ad.example.com"))
+
+	assert.Equal(t, []string{"example.com", "example.org"},
+		dnsConfig(interfaces, splitSearchList("example.com, example.org,"), "ad.example.com").Search)
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// domain returns the domain or workgroup membership of the host and its
+// Azure AD join.
+func domain() (*types.DomainInfo, error) {
+	var name *uint16
+	var status uint32
+	if err := syswin.NetGetJoinInformation(nil, &name, &status); err != nil {
+		return nil, fmt.Errorf("NetGetJoinInformation failed: %w", err)
+	}
+	defer syswin.NetApiBufferFree((*byte)(unsafe.Pointer(name)))
+
+	info := &types.DomainInfo{JoinType: types.DomainJoinNone}
+	switch status {
+	case syswin.NetSetupDomainName:
+		info.JoinType = types.DomainJoinDomain
+		info.Name = syswin.UTF16PtrToString(name)
+	case syswin.NetSetupWorkgroupName:
+		info.JoinType = types.DomainJoinWorkgroup
+		info.Name = syswin.UTF16PtrToString(name)
+	}
+
+	aad, err := azureADJoin()
+	if err != nil {
+		return info, err
+	}
+	if aad != nil {
+		info.AzureAD = aad
+		// Devices that are only joined to Azure AD are members of a
+		// workgroup for NetGetJoinInformation.
+		if info.JoinType != types.DomainJoinDomain {
+			info.JoinType = types.DomainJoinAzureAD
+			info.Name = aad.TenantName
+		}
+	}
+	return info, nil
+}
+
+// azureADJoin returns the Azure AD join of the device, or nil if the device
+// is not joined. Workplace joins of user accounts are not device joins.
+func azureADJoin() (*types.AzureADInfo, error) {
+	var join *dsregJoinInfo
+	if err := _NetGetAadJoinInformation(&join); err != nil {
+		// NetGetAadJoinInformation requires Windows 10.
+		var dllErr *syswin.DLLError
+		if errors.As(err, &dllErr) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("NetGetAadJoinInformation failed: %w", err)
+	}
+	if join == nil {
+		return nil, nil
+	}
+	defer _NetFreeAadJoinInformation(join)
+
+	if join.JoinType != dsregDeviceJoin {
+		return nil, nil
+	}
+	return &types.AzureADInfo{
+		TenantID:   syswin.UTF16PtrToString(join.TenantID),
+		TenantName: syswin.UTF16PtrToString(join.TenantDisplayName),
+		DeviceID:   syswin.UTF16PtrToString(join.DeviceID),
+	}, nil
+}
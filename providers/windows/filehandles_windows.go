@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// FileHandles reports the number of handles held by all processes. Windows
+// does not track file handles separately from other object handles and has
+// no fixed system-wide limit, so Maximum is always nil.
+func (h *host) FileHandles() (*types.FileHandlesInfo, error) {
+	buf, err := querySystemProcessInformation()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.FileHandlesInfo{}
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, _ []systemThreadInformation) bool {
+		info.Allocated += uint64(proc.HandleCount)
+		return true
+	})
+	return info, nil
+}
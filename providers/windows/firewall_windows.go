@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	firewallPolicyKey      = `SYSTEM\CurrentControlSet\Services\SharedAccess\Parameters\FirewallPolicy`
+	firewallGroupPolicyKey = `SOFTWARE\Policies\Microsoft\WindowsFirewall`
+)
+
+// firewallProfiles are the network profiles of Windows Defender Firewall and
+// the names of their keys in the local and Group Policy settings.
+var firewallProfiles = []struct {
+	name, localKey, policyKey string
+}{
+	{"domain", "DomainProfile", "DomainProfile"},
+	{"private", "StandardProfile", "PrivateProfile"},
+	{"public", "PublicProfile", "PublicProfile"},
+}
+
+// Firewall reports whether Windows Defender Firewall is enabled for each
+// network profile. The EnableFirewall setting of Group Policy overrides the
+// local one, and no profile is enabled when the firewall service (mpssvc) is
+// not running.
+func (h *host) Firewall() (*types.FirewallInfo, error) {
+	running, err := serviceRunning("mpssvc")
+	if err != nil {
+		// The firewall service is not installed.
+		return &types.FirewallInfo{Firewalls: []types.FirewallStatus{}}, nil
+	}
+
+	info := &types.FirewallInfo{}
+	for _, p := range firewallProfiles {
+		enabled := registryBool(firewallGroupPolicyKey+`\`+p.policyKey, "EnableFirewall")
+		if enabled == nil {
+			enabled = registryBool(firewallPolicyKey+`\`+p.localKey, "EnableFirewall")
+		}
+
+		status := types.FirewallStatus{
+			Name:    types.FirewallWindows,
+			Profile: p.name,
+			Enabled: running && enabled != nil && *enabled,
+		}
+		info.Firewalls = append(info.Firewalls, status)
+		info.Enabled = info.Enabled || status.Enabled
+	}
+	return info, nil
+}
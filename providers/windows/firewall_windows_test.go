@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Firewall = (*host)(nil)
+
+func TestFirewall(t *testing.T) {
+	info, err := (&host{}).Firewall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fw := range info.Firewalls {
+		assert.Equal(t, types.FirewallWindows, fw.Name)
+		assert.Contains(t, []string{"domain", "private", "public"}, fw.Profile)
+	}
+	t.Logf("%+v", info)
+}
@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const secureBootStateKey = `SYSTEM\CurrentControlSet\Control\SecureBoot\State`
+
+// FirmwareInfo returns the BIOS information from SMBIOS, the boot mode, and
+// the Secure Boot state.
+func FirmwareInfo() (*types.FirmwareInfo, error) {
+	table, err := readSMBIOS()
+	if err != nil {
+		return nil, err
+	}
+
+	info := firmwareInfo(table)
+	info.BootMode = bootMode()
+	if info.BootMode == types.BootModeUEFI {
+		info.SecureBoot = secureBootEnabled()
+	}
+	return info, nil
+}
+
+// bootMode returns the boot mode reported by GetFirmwareType, which requires
+// Windows 8 / Server 2012. An empty string is returned if it is unknown.
+func bootMode() string {
+	var firmwareType uint32
+	if err := _GetFirmwareType(&firmwareType); err != nil {
+		return ""
+	}
+
+	switch firmwareType {
+	case firmwareTypeBios:
+		return types.BootModeLegacy
+	case firmwareTypeUefi:
+		return types.BootModeUEFI
+	default:
+		return ""
+	}
+}
+
+func firmwareInfo(table *smbiosTable) *types.FirmwareInfo {
+	info := &types.FirmwareInfo{}
+	if bios, found := shared.FindSMBIOS(table.Structures, shared.SMBIOSTypeBIOS); found {
+		info.Vendor = bios.String(0x04)
+		info.Version = bios.String(0x05)
+		info.ReleaseDate = bios.String(0x08)
+	}
+	return info
+}
+
+func secureBootEnabled() *bool {
+	return registryBool(secureBootStateKey, "UEFISecureBootEnabled")
+}
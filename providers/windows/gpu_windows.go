@@ -0,0 +1,320 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// displayClassKey is the device setup class of display adapters.
+const displayClassKey = `SYSTEM\CurrentControlSet\Control\Class\{4d36e968-e325-11ce-bfc1-08002be10318}`
+
+// gpuSampleInterval is the time between the two samples of the GPU Engine
+// counters. Their utilization is computed from the difference.
+const gpuSampleInterval = 250 * time.Millisecond
+
+var (
+	// adapterKeyRegexp matches the numbered driver keys (e.g. 0000) of the
+	// display class.
+	adapterKeyRegexp = regexp.MustCompile(`^[0-9]{4}$`)
+
+	// pciHardwareIDRegexp parses hardware IDs like pci\ven_10de&dev_2504.
+	pciHardwareIDRegexp = regexp.MustCompile(`(?i)^pci\\ven_([0-9a-f]{4})&dev_([0-9a-f]{4})`)
+
+	// gpuInstanceRegexp parses the instance names of the GPU Adapter Memory
+	// (e.g. luid_0x00000000_0x0000d1c5_phys_0) and GPU Engine (e.g.
+	// pid_1234_luid_0x00000000_0x0000d1c5_phys_0_eng_3_engtype_3D) counters.
+	gpuInstanceRegexp = regexp.MustCompile(`(?i)luid_(0x[0-9a-f]{8}_0x[0-9a-f]{8})_phys_([0-9]+)(?:_eng_([0-9]+))?`)
+)
+
+// GPUs reports the display adapters installed in the host based on the
+// driver information stored in the registry. MemoryUsed and Utilization are
+// read from the GPU Adapter Memory and GPU Engine performance counters (see
+// addGPUUsage), which takes gpuSampleInterval.
+func (h *host) GPUs() ([]types.GPUInfo, error) {
+	k, err := openLocalMachineKey(displayClassKey)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	names, err := k.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to list subkeys of HKLM\%v: %w`, displayClassKey, err)
+	}
+
+	var gpus []types.GPUInfo
+	for _, name := range names {
+		if !adapterKeyRegexp.MatchString(name) {
+			continue
+		}
+
+		gpu, err := readDisplayAdapter(k, name)
+		if err != nil {
+			// Keys can be inaccessible for adapters that were removed.
+			continue
+		}
+		gpus = append(gpus, *gpu)
+	}
+
+	// The metrics are optional, so the GPUs are reported without them if
+	// they cannot be read.
+	_ = addGPUUsage(gpus)
+	return gpus, nil
+}
+
+func readDisplayAdapter(class registry.Key, name string) (*types.GPUInfo, error) {
+	k, err := registry.OpenKey(class, name, registry.READ)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	var gpu types.GPUInfo
+	if gpu.Model, err = regString(k, "DriverDesc"); err != nil {
+		return nil, err
+	}
+	gpu.Driver, _ = regString(k, "ProviderName")
+
+	if id, err := regString(k, "MatchingDeviceId"); err == nil {
+		if m := pciHardwareIDRegexp.FindStringSubmatch(id); m != nil {
+			vendor, _ := shared.ParsePCIID(m[1])
+			device, _ := shared.ParsePCIID(m[2])
+			gpu.VendorID = shared.FormatPCIID(vendor)
+			gpu.DeviceID = shared.FormatPCIID(device)
+			gpu.Vendor = shared.PCIVendorName(vendor)
+		}
+	}
+
+	// The 64-bit value was introduced because the 32-bit one cannot
+	// represent more than 4 GiB.
+	if size, _, err := k.GetIntegerValue("HardwareInformation.qwMemorySize"); err == nil {
+		gpu.MemoryTotal = size
+	} else if size, _, err := k.GetIntegerValue("HardwareInformation.MemorySize"); err == nil {
+		gpu.MemoryTotal = size
+	} else if data, _, err := k.GetBinaryValue("HardwareInformation.MemorySize"); err == nil && len(data) >= 4 {
+		gpu.MemoryTotal = uint64(binary.LittleEndian.Uint32(data))
+	}
+
+	return &gpu, nil
+}
+
+// addGPUUsage sets MemoryUsed and Utilization of gpus. The performance
+// counters identify an adapter by its LUID, which is assigned at boot and is
+// not recorded in the registry. It is looked up with DXGI and matched to
+// gpus by the PCI vendor and device IDs. The metrics of GPUs whose IDs are
+// shared by several adapters are left nil because the LUIDs cannot be told
+// apart.
+func addGPUUsage(gpus []types.GPUInfo) error {
+	if len(gpus) == 0 {
+		return nil
+	}
+
+	adapters, err := dxgiAdapters()
+	if err != nil {
+		return err
+	}
+	luids := map[string][]string{} // LUIDs by PCI vendor and device ID.
+	for _, a := range adapters {
+		id := shared.FormatPCIID(uint16(a.VendorID)) + ":" + shared.FormatPCIID(uint16(a.DeviceID))
+		luids[id] = append(luids[id], fmt.Sprintf("0x%08x_0x%08x", uint32(a.AdapterLUID.HighPart), a.AdapterLUID.LowPart))
+	}
+
+	memory, engines, err := sampleGPUCounters()
+	if err != nil {
+		return err
+	}
+	memoryUsed, utilization := gpuUsage(memory, engines)
+
+	ids := map[string]int{}
+	for _, gpu := range gpus {
+		ids[gpu.VendorID+":"+gpu.DeviceID]++
+	}
+	for i := range gpus {
+		id := gpus[i].VendorID + ":" + gpus[i].DeviceID
+		if ids[id] != 1 || len(luids[id]) != 1 {
+			continue
+		}
+		luid := luids[id][0]
+		if used, found := memoryUsed[luid]; found {
+			used := used
+			gpus[i].MemoryUsed = &used
+		}
+		if pct, found := utilization[luid]; found {
+			pct := pct
+			gpus[i].Utilization = &pct
+		}
+	}
+	return nil
+}
+
+// gpuUsage returns the dedicated memory in use and the utilization of the
+// adapters keyed by their LUID (e.g. 0x00000000_0x0000d1c5) from the values
+// of the GPU Adapter Memory and GPU Engine counter instances. Like in the
+// Task Manager, the utilization of an adapter is that of its busiest engine.
+// The engine instances are per process and adapters without any report an
+// utilization of 0 if their memory usage is known.
+func gpuUsage(memory, engines map[string]float64) (map[string]uint64, map[string]float64) {
+	memoryUsed := map[string]uint64{}
+	utilization := map[string]float64{}
+	for name, v := range memory {
+		if m := gpuInstanceRegexp.FindStringSubmatch(name); m != nil {
+			luid := strings.ToLower(m[1])
+			memoryUsed[luid] += uint64(v)
+			if _, found := utilization[luid]; !found {
+				utilization[luid] = 0
+			}
+		}
+	}
+
+	busy := map[string]float64{} // Utilization by engine.
+	for name, v := range engines {
+		if m := gpuInstanceRegexp.FindStringSubmatch(name); m != nil && m[3] != "" {
+			busy[strings.ToLower(m[1])+"_"+m[2]+"_"+m[3]] += v
+		}
+	}
+	for engine, v := range busy {
+		luid := engine[:len("0x00000000_0x00000000")]
+		utilization[luid] = math.Max(utilization[luid], math.Min(v, 100))
+	}
+	return memoryUsed, utilization
+}
+
+// dxgiAdapters returns the descriptions of the hardware adapters enumerated
+// by DXGI.
+func dxgiAdapters() ([]dxgiAdapterDesc1, error) {
+	var factory unsafe.Pointer
+	if err := _CreateDXGIFactory1(&iidIDXGIFactory1, &factory); err != nil {
+		return nil, err
+	}
+	// IDXGIFactory1 methods: QueryInterface, AddRef, Release, SetPrivateData,
+	// SetPrivateDataInterface, GetPrivateData, GetParent, EnumAdapters,
+	// MakeWindowAssociation, GetWindowAssociation, CreateSwapChain,
+	// CreateSoftwareAdapter, EnumAdapters1, IsCurrent.
+	vtbl := *(*[14]uintptr)(*(*unsafe.Pointer)(factory))
+	defer syscall.SyscallN(vtbl[2], uintptr(factory))
+
+	var descs []dxgiAdapterDesc1
+	for i := 0; ; i++ {
+		var adapter unsafe.Pointer
+		r0, _, _ := syscall.SyscallN(vtbl[12], uintptr(factory), uintptr(i), uintptr(unsafe.Pointer(&adapter)))
+		if uint32(r0) == dxgiErrorNotFound {
+			return descs, nil
+		}
+		if err := hresultError(r0); err != nil {
+			return nil, err
+		}
+
+		desc, err := dxgiAdapterDesc(adapter)
+		if err != nil {
+			return nil, err
+		}
+		// The Microsoft Basic Render Driver has no counters.
+		if desc.Flags&dxgiAdapterFlagSoftware == 0 {
+			descs = append(descs, desc)
+		}
+	}
+}
+
+func dxgiAdapterDesc(adapter unsafe.Pointer) (dxgiAdapterDesc1, error) {
+	// IDXGIAdapter1 methods: QueryInterface, AddRef, Release, SetPrivateData,
+	// SetPrivateDataInterface, GetPrivateData, GetParent, EnumOutputs,
+	// GetDesc, CheckInterfaceSupport, GetDesc1.
+	vtbl := *(*[11]uintptr)(*(*unsafe.Pointer)(adapter))
+	defer syscall.SyscallN(vtbl[2], uintptr(adapter))
+
+	var desc dxgiAdapterDesc1
+	r0, _, _ := syscall.SyscallN(vtbl[10], uintptr(adapter), uintptr(unsafe.Pointer(&desc)))
+	return desc, hresultError(r0)
+}
+
+// sampleGPUCounters returns the values of the instances of the dedicated
+// memory usage and engine utilization counters.
+func sampleGPUCounters() (memory, engines map[string]float64, err error) {
+	var query windows.Handle
+	if err := _PdhOpenQuery(&query); err != nil {
+		return nil, nil, err
+	}
+	defer _PdhCloseQuery(query)
+
+	var memoryCounter, engineCounter windows.Handle
+	if err := _PdhAddEnglishCounter(query, `\GPU Adapter Memory(*)\Dedicated Usage`, &memoryCounter); err != nil {
+		return nil, nil, err
+	}
+	if err := _PdhAddEnglishCounter(query, `\GPU Engine(*)\Utilization Percentage`, &engineCounter); err != nil {
+		return nil, nil, err
+	}
+
+	if err := _PdhCollectQueryData(query); err != nil {
+		return nil, nil, err
+	}
+	time.Sleep(gpuSampleInterval)
+	if err := _PdhCollectQueryData(query); err != nil {
+		return nil, nil, err
+	}
+
+	if memory, err = pdhCounterValues(memoryCounter); err != nil {
+		return nil, nil, err
+	}
+	if engines, err = pdhCounterValues(engineCounter); err != nil {
+		return nil, nil, err
+	}
+	return memory, engines, nil
+}
+
+// pdhCounterValues returns the values of the instances of a counter with a
+// wildcard instance. Instances whose value is not valid are omitted.
+func pdhCounterValues(counter windows.Handle) (map[string]float64, error) {
+	var buf []byte
+	for {
+		size, count := uint32(len(buf)), uint32(0)
+		err := _PdhGetFormattedCounterArray(counter, pdhFmtDouble, &size, &count, buf)
+		if errors.Is(err, windows.Errno(pdhMoreData)) {
+			buf = make([]byte, size)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]float64, count)
+		if count == 0 {
+			return values, nil
+		}
+		for _, item := range unsafe.Slice((*pdhFmtCounterValueItem)(unsafe.Pointer(&buf[0])), count) {
+			if item.Status == pdhCStatusValid || item.Status == pdhCStatusNewData {
+				values[windows.UTF16PtrToString(item.Name)] = item.Value
+			}
+		}
+		return values, nil
+	}
+}
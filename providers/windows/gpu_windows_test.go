@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.GPU = (*host)(nil)
+
+func TestGPUStructSizes(t *testing.T) {
+	size := map[uintptr]uintptr{4: 296, 8: 312}[unsafe.Sizeof(uintptr(0))]
+	assert.EqualValues(t, size, unsafe.Sizeof(dxgiAdapterDesc1{}))
+	assert.EqualValues(t, 24, unsafe.Sizeof(pdhFmtCounterValueItem{}))
+}
+
+func TestGPUUsage(t *testing.T) {
+	memory := map[string]float64{
+		"luid_0x00000000_0x0000D1C5_phys_0": 512 << 20,
+		"luid_0x00000000_0x0000E2F0_phys_0": 64 << 20,
+		"_Total":                            576 << 20,
+	}
+	engines := map[string]float64{
+		"pid_1234_luid_0x00000000_0x0000D1C5_phys_0_eng_0_engtype_3D":          30,
+		"pid_5678_luid_0x00000000_0x0000D1C5_phys_0_eng_0_engtype_3D":          25,
+		"pid_5678_luid_0x00000000_0x0000D1C5_phys_0_eng_3_engtype_VideoDecode": 70,
+		"pid_9012_luid_0x00000000_0x0000F000_phys_0_eng_0_engtype_3D":          10,
+	}
+
+	memoryUsed, utilization := gpuUsage(memory, engines)
+	assert.Equal(t, map[string]uint64{
+		"0x00000000_0x0000d1c5": 512 << 20,
+		"0x00000000_0x0000e2f0": 64 << 20,
+	}, memoryUsed)
+	assert.Equal(t, map[string]float64{
+		"0x00000000_0x0000d1c5": 70,
+		"0x00000000_0x0000e2f0": 0,
+		"0x00000000_0x0000f000": 10,
+	}, utilization)
+}
+
+func TestGPUs(t *testing.T) {
+	gpus, err := (&host{}).GPUs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, gpu := range gpus {
+		if gpu.Utilization != nil {
+			assert.LessOrEqual(t, *gpu.Utilization, 100.0, gpu.Model)
+		}
+	}
+	t.Logf("%+v", gpus)
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"syscall"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// GUIResources reports the GDI and USER objects of the process. The peak
+// counts are not reported before Windows 7, where they are zero.
+func (p *process) GUIResources() (*types.GUIResourceInfo, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, fmt.Errorf("OpenProcess failed: %w", err)
+	}
+	defer syscall.CloseHandle(handle)
+
+	var info types.GUIResourceInfo
+	for _, r := range []struct {
+		flags uint32
+		count *uint32
+	}{
+		{grGDIObjects, &info.GDIObjects},
+		{grGDIObjectsPeak, &info.GDIObjectsPeak},
+		{grUserObjects, &info.USERObjects},
+		{grUserObjectsPeak, &info.USERObjectsPeak},
+	} {
+		if *r.count, err = _GetGuiResources(syswin.Handle(handle), r.flags); err != nil {
+			return nil, fmt.Errorf("GetGuiResources failed: %w", err)
+		}
+	}
+	return &info, nil
+}
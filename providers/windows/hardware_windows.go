@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// HardwareInfo reports the system identity from the SMBIOS System
+// Information (type 1) structure.
+func (h *host) HardwareInfo() (*types.HardwareInfo, error) {
+	table, err := readSMBIOS()
+	if err != nil {
+		return nil, err
+	}
+	return hardwareInfo(table), nil
+}
+
+func hardwareInfo(table *smbiosTable) *types.HardwareInfo {
+	info := &types.HardwareInfo{}
+	system, found := shared.FindSMBIOS(table.Structures, shared.SMBIOSTypeSystem)
+	if !found {
+		return info
+	}
+
+	info.Manufacturer = system.String(0x04)
+	info.ProductName = system.String(0x05)
+	info.Version = system.String(0x06)
+	info.SerialNumber = system.String(0x07)
+	info.UUID = shared.SMBIOSUUID(system, 0x08, table.Major, table.Minor)
+	info.SKU = system.String(0x19)
+	info.Family = system.String(0x1a)
+
+	if chassis, found := shared.FindSMBIOS(table.Structures, shared.SMBIOSTypeChassis); found {
+		info.AssetTag = chassis.String(0x08)
+	}
+	return info
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestHardwareInfoFromSMBIOS(t *testing.T) {
+	data := []byte{
+		// RawSMBIOSData header (version 3.3).
+		0, 3, 3, 0, 0, 0, 0, 0,
+		// System Information.
+		1, 0x1b, 0x01, 0x00, 1, 2, 0, 3,
+		0x44, 0x45, 0x4c, 0x4c, 0x42, 0x00, 0x10, 0x35, 0x80, 0x53, 0xb7, 0xc0, 0x4f, 0x4e, 0x37, 0x32,
+		6, 0, 4,
+		'L', 'E', 'N', 'O', 'V', 'O', 0,
+		'2', '0', 'X', 'W', 0,
+		'P', 'F', '2', 'A', 'B', 'C', 'D', 0,
+		'T', 'h', 'i', 'n', 'k', 'P', 'a', 'd', ' ', 'T', '1', '4', 0,
+		0,
+		// System Enclosure or Chassis.
+		3, 0x09, 0x02, 0x00, 1, 0x0a, 0, 0, 2,
+		'L', 'E', 'N', 'O', 'V', 'O', 0,
+		'I', 'T', '-', '0', '0', '4', '2', '1', '7', 0,
+		0,
+		// End-of-Table.
+		127, 4, 0x03, 0x00, 0, 0,
+	}
+	data[4] = byte(len(data) - 8)
+
+	table, err := parseRawSMBIOSData(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, table.Major)
+	assert.EqualValues(t, 3, table.Minor)
+
+	assert.Equal(t, &types.HardwareInfo{
+		Manufacturer: "LENOVO",
+		ProductName:  "20XW",
+		SerialNumber: "PF2ABCD",
+		Family:       "ThinkPad T14",
+		UUID:         "4C4C4544-0042-3510-8053-B7C04F4E3732",
+		AssetTag:     "IT-004217",
+	}, hardwareInfo(table))
+
+	_, err = parseRawSMBIOSData(data[:4])
+	assert.Error(t, err)
+}
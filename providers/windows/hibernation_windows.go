@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	powerKey               = `SYSTEM\CurrentControlSet\Control\Power`
+	sessionManagerPowerKey = `SYSTEM\CurrentControlSet\Control\Session Manager\Power`
+
+	// kernelBootQuery selects the event that the kernel logs on every start
+	// with the type of the boot.
+	kernelBootQuery = "*[System[Provider[@Name='Microsoft-Windows-Kernel-Boot'] and EventID=27]]"
+)
+
+// Boot types of the Microsoft-Windows-Kernel-Boot event 27.
+var kernelBootTypes = map[int]string{
+	0: types.BootTypeCold,
+	1: types.BootTypeFastStartup,
+	2: types.BootTypeHibernateResume,
+}
+
+// kernelBootEvent is the XML rendering of an event.
+type kernelBootEvent struct {
+	System struct {
+		TimeCreated struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		}
+	}
+	EventData struct {
+		Data []struct {
+			Name  string `xml:"Name,attr"`
+			Value string `xml:",chardata"`
+		}
+	}
+}
+
+// lastBoot returns the type and time of the last start of the host from the
+// System event log. With Fast Startup enabled the kernel session is resumed
+// from the hibernation file, so the tick count based BootTime refers to the
+// last cold boot.
+func lastBoot() (bootType string, startTime time.Time, err error) {
+	query, err := _EvtQuery("System", kernelBootQuery, evtQueryChannelPath|evtQueryReverseDirection)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("EvtQuery failed: %w", err)
+	}
+	defer _EvtClose(query)
+
+	events := make([]windows.Handle, 1)
+	var returned uint32
+	if err = _EvtNext(query, events, &returned); err != nil || returned == 0 {
+		return "", time.Time{}, fmt.Errorf("no kernel boot event found: %w", err)
+	}
+	defer _EvtClose(events[0])
+
+	var used uint32
+	err = _EvtRender(events[0], evtRenderEventXML, nil, &used)
+	if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+		return "", time.Time{}, fmt.Errorf("EvtRender failed: %w", err)
+	}
+	buf := make([]uint16, used/2+1)
+	if err = _EvtRender(events[0], evtRenderEventXML, buf, &used); err != nil {
+		return "", time.Time{}, fmt.Errorf("EvtRender failed: %w", err)
+	}
+
+	return parseKernelBootEvent(windows.UTF16ToString(buf))
+}
+
+func parseKernelBootEvent(data string) (string, time.Time, error) {
+	var event kernelBootEvent
+	if err := xml.Unmarshal([]byte(data), &event); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse kernel boot event: %w", err)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, event.System.TimeCreated.SystemTime)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse kernel boot event time: %w", err)
+	}
+
+	for _, d := range event.EventData.Data {
+		if d.Name != "BootType" {
+			continue
+		}
+		v, err := strconv.ParseUint(d.Value, 0, 32)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to parse boot type %q: %w", d.Value, err)
+		}
+		if bootType, found := kernelBootTypes[int(v)]; found {
+			return bootType, t, nil
+		}
+		return "", t, fmt.Errorf("unknown boot type %d", v)
+	}
+	return "", time.Time{}, errors.New("boot type not found in kernel boot event")
+}
+
+// hibernationEnabled reports whether hibernation is enabled.
+func hibernationEnabled() *bool {
+	return registryBool(powerKey, "HibernateEnabled")
+}
+
+// fastStartupEnabled reports whether Fast Startup is enabled. It requires
+// hibernation to be enabled.
+func fastStartupEnabled() *bool {
+	enabled := registryBool(sessionManagerPowerKey, "HiberbootEnabled")
+	if hibernate := hibernationEnabled(); enabled != nil && hibernate != nil && !*hibernate {
+		*enabled = false
+	}
+	return enabled
+}
+
+func registryBool(path, name string) *bool {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return nil
+	}
+	defer k.Close()
+
+	v, _, err := k.GetIntegerValue(name)
+	if err != nil {
+		return nil
+	}
+	b := v != 0
+	return &b
+}
@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const kernelBootEventXML = `<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'>
+  <System>
+    <Provider Name='Microsoft-Windows-Kernel-Boot' Guid='{15ca44ff-4d7a-4baa-bba5-0998955e531e}'/>
+    <EventID>27</EventID>
+    <Version>0</Version>
+    <Level>4</Level>
+    <Task>33</Task>
+    <TimeCreated SystemTime='2024-03-18T07:42:13.5016270Z'/>
+    <Channel>System</Channel>
+    <Computer>DESKTOP-1234</Computer>
+  </System>
+  <EventData>
+    <Data Name='BootType'>1</Data>
+    <Data Name='LoadOptions'></Data>
+  </EventData>
+</Event>`
+
+func TestParseKernelBootEvent(t *testing.T) {
+	bootType, startTime, err := parseKernelBootEvent(kernelBootEventXML)
+	require.NoError(t, err)
+	assert.Equal(t, types.BootTypeFastStartup, bootType)
+	assert.Equal(t, time.Date(2024, 3, 18, 7, 42, 13, 501627000, time.UTC), startTime)
+
+	_, _, err = parseKernelBootEvent("<Event></Event>")
+	assert.Error(t, err)
+}
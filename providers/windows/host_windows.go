@@ -18,9 +18,11 @@
 package windows
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -31,7 +33,10 @@ import (
 
 	windows "github.com/elastic/go-windows"
 
+	"github.com/elastic/go-sysinfo/internal/deadline"
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/providers/shared"
 	"github.com/elastic/go-sysinfo/types"
 )
@@ -43,18 +48,30 @@ func init() {
 type windowsSystem struct{}
 
 func (s windowsSystem) Host() (types.Host, error) {
-	return newHost()
+	return newHost(registry.HostOptions{})
+}
+
+// HostWithOptions returns the host information without the fields excluded
+// by opts.
+func (s windowsSystem) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	return newHost(opts)
 }
 
 type host struct {
 	info types.HostInfo
+
+	// opts are the options the host was created with. They are used to
+	// look up the FQDN again on refresh.
+	opts registry.HostOptions
 }
 
 func (h *host) Info() types.HostInfo {
 	return h.info
 }
 
-func (h *host) CPUTime() (types.CPUTimes, error) {
+func (h *host) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("host cpu time", &err)
+
 	idle, kernel, user, err := windows.GetSystemTimes()
 	if err != nil {
 		return types.CPUTimes{}, err
@@ -67,7 +84,9 @@ func (h *host) CPUTime() (types.CPUTimes, error) {
 	}, nil
 }
 
-func (h *host) Memory() (*types.HostMemoryInfo, error) {
+func (h *host) Memory() (_ *types.HostMemoryInfo, err error) {
+	defer safe.Recover("host memory", &err)
+
 	mem, err := windows.GlobalMemoryStatusEx()
 	if err != nil {
 		return nil, err
@@ -75,28 +94,78 @@ func (h *host) Memory() (*types.HostMemoryInfo, error) {
 
 	return &types.HostMemoryInfo{
 		Total:        mem.TotalPhys,
-		Used:         mem.TotalPhys - mem.AvailPhys,
+		Used:         shared.SubSaturating(mem.TotalPhys, mem.AvailPhys),
 		Free:         mem.AvailPhys,
 		Available:    mem.AvailPhys,
 		VirtualTotal: mem.TotalPageFile,
-		VirtualUsed:  mem.TotalPageFile - mem.AvailPageFile,
+		VirtualUsed:  shared.SubSaturating(mem.TotalPageFile, mem.AvailPageFile),
 		VirtualFree:  mem.AvailPageFile,
 	}, nil
 }
 
-func newHost() (*host, error) {
-	h := &host{}
+// Refresh updates the hostname, the network addresses, and the timezone. The
+// FQDN is looked up again when the hostname changed.
+func (h *host) Refresh() error {
+	hostname := h.info.Hostname
 	r := &reader{}
-	r.architecture(h)
-	r.bootTime(h)
 	r.hostname(h)
-	r.fqdn(h)
 	r.network(h)
-	r.kernelVersion(h)
-	r.os(h)
 	r.time(h)
-	r.uniqueID(h)
-	return h, r.Err()
+	if h.info.Hostname != hostname && !h.opts.SkipFQDN {
+		r.fqdn(h, h.opts)
+	}
+	return r.Err()
+}
+
+// RefreshFields updates the given fields of the host information.
+func (h *host) RefreshFields(fields ...string) error {
+	r := &reader{}
+	for _, field := range fields {
+		switch field {
+		case types.HostFieldHostname:
+			r.hostname(h)
+		case types.HostFieldFQDN:
+			r.fqdn(h, h.opts)
+		case types.HostFieldNetwork:
+			r.network(h)
+		case types.HostFieldTimezone:
+			r.time(h)
+		default:
+			r.addErr(fmt.Errorf("unknown host field %q", field))
+		}
+	}
+	return r.Err()
+}
+
+func newHost(opts registry.HostOptions) (*host, error) {
+	h := &host{opts: opts}
+	r := &reader{}
+	probes := []hostProbe{
+		{"static host info", func(r *reader, h *host) { r.staticInfo(h, opts) }},
+		{"boot time", (*reader).bootTime},
+		{"boot type", (*reader).bootType},
+		{"hostname", (*reader).hostname},
+	}
+	if !opts.SkipFQDN {
+		probes = append(probes, hostProbe{"fqdn", func(r *reader, h *host) { r.fqdn(h, opts) }})
+	}
+	probes = append(probes,
+		hostProbe{"network", (*reader).network},
+		hostProbe{"time", (*reader).time},
+		hostProbe{"domain", (*reader).domain},
+	)
+
+	b := deadline.New(opts.Deadline, len(probes))
+	for _, p := range probes {
+		r.probe(b, h, p.name, p.fn)
+	}
+	return h, b.Err(r.Err())
+}
+
+// hostProbe is a probe that collects some of the host fields.
+type hostProbe struct {
+	name string
+	fn   func(*reader, *host)
 }
 
 type reader struct {
@@ -113,6 +182,44 @@ func (r *reader) addErr(err error) bool {
 	return false
 }
 
+// staticInfo collects the host fields that do not change while the host is
+// running. The fields are cached for opts.CacheTTL.
+func (r *reader) staticInfo(h *host, opts registry.HostOptions) {
+	key := fmt.Sprint(opts.SkipMachineID, opts.MachineIDSources, opts.MachineIDPath)
+	v, err := ratelimit.Default.DoInterval(ratelimit.StaticHostInfo, key, opts.CacheTTL, func() (interface{}, error) {
+		sh := &host{}
+		sr := &reader{}
+		sb := deadline.New(time.Time{}, 0)
+		sr.probe(sb, sh, "architecture", (*reader).architecture)
+		sr.probe(sb, sh, "firmware", (*reader).firmware)
+		sr.probe(sb, sh, "hardware", (*reader).hardware)
+		sr.probe(sb, sh, "kernel version", (*reader).kernelVersion)
+		sr.probe(sb, sh, "os", (*reader).os)
+		if !opts.SkipMachineID {
+			sr.probe(sb, sh, "machine id", func(r *reader, h *host) { r.uniqueID(h, opts) })
+		}
+		return sh.info, sr.Err()
+	})
+	if info, ok := v.(types.HostInfo); ok {
+		shared.CopyStaticHostInfo(&h.info, info)
+	}
+	r.addErr(err)
+}
+
+// probe runs fn with its share of the budget b. fn modifies a copy of h that
+// is kept only if fn finishes in time, so a probe that is still running after
+// its deadline cannot race with the caller. A panic in fn is reported as an
+// error instead of crashing the application.
+func (r *reader) probe(b *deadline.Budget, h *host, name string, fn func(*reader, *host)) {
+	ph, pr := *h, &reader{}
+	if err := b.Run(name, func() { fn(pr, &ph) }); err != nil {
+		r.addErr(err)
+		return
+	}
+	h.info = ph.info
+	r.errs = append(r.errs, pr.errs...)
+}
+
 func (r *reader) Err() error {
 	if len(r.errs) > 0 {
 		return &multierror.MultiError{Errors: r.errs}
@@ -136,6 +243,19 @@ func (r *reader) bootTime(h *host) {
 	h.info.BootTime = v
 }
 
+// bootType annotates the boot time with the type of the last start. Errors
+// are ignored because the System event log is not always readable.
+func (r *reader) bootType(h *host) {
+	bootType, startTime, err := lastBoot()
+	if err != nil {
+		return
+	}
+	h.info.BootType = bootType
+	if bootType != types.BootTypeCold {
+		h.info.ResumeTime = &startTime
+	}
+}
+
 func (r *reader) hostname(h *host) {
 	v, err := os.Hostname()
 	if r.addErr(err) {
@@ -144,9 +264,11 @@ func (r *reader) hostname(h *host) {
 	h.info.Hostname = v
 }
 
-func (r *reader) fqdn(h *host) {
-	fqdn, err := getComputerNameEx(
-		stdwindows.ComputerNamePhysicalDnsFullyQualified)
+func (r *reader) fqdn(h *host, opts registry.HostOptions) {
+	hostsFile := filepath.Join(os.Getenv("SystemRoot"), `System32\drivers\etc\hosts`)
+	fqdn, err := shared.FQDNWithStrategy(opts.FQDNStrategy, hostsFile, opts.FQDNTimeout, func(context.Context, string) (string, error) {
+		return getComputerNameEx(stdwindows.ComputerNamePhysicalDnsFullyQualified)
+	})
 	if err != nil {
 		r.addErr(fmt.Errorf("could not get windows FQDN: %s", err))
 		return
@@ -186,6 +308,24 @@ func getComputerNameEx(name uint32) (string, error) {
 	}
 }
 
+func (r *reader) firmware(h *host) {
+	v, err := FirmwareInfo()
+	if r.addErr(err) {
+		return
+	}
+	h.info.Firmware = v
+}
+
+func (r *reader) hardware(h *host) {
+	table, err := readSMBIOS()
+	if r.addErr(err) {
+		return
+	}
+	v := hardwareInfo(table)
+	h.info.SerialNumber = v.SerialNumber
+	h.info.AssetTag = v.AssetTag
+}
+
 func (r *reader) network(h *host) {
 	ips, macs, err := shared.Network()
 	if r.addErr(err) {
@@ -212,13 +352,29 @@ func (r *reader) os(h *host) {
 }
 
 func (r *reader) time(h *host) {
-	h.info.Timezone, h.info.TimezoneOffsetSec = time.Now().Zone()
+	h.info.Timezone, h.info.TimezoneOffsetSec = timezone(time.Now())
 }
 
-func (r *reader) uniqueID(h *host) {
-	v, err := MachineID()
+func (r *reader) domain(h *host) {
+	v, err := domain()
+	r.addErr(err)
+	h.info.Domain = v
+}
+
+func (r *reader) uniqueID(h *host, opts registry.HostOptions) {
+	v, source, err := shared.MachineID(opts, []string{registry.MachineIDMachineGUID}, map[string]func() (string, error){
+		registry.MachineIDMachineGUID: MachineID,
+		registry.MachineIDSMBIOS: func() (string, error) {
+			table, err := readSMBIOS()
+			if err != nil {
+				return "", err
+			}
+			return hardwareInfo(table).UUID, nil
+		},
+	})
 	if r.addErr(err) {
 		return
 	}
 	h.info.UniqueID = v
+	h.info.UniqueIDSource = source
 }
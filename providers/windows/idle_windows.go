@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// noConsoleSession is returned by WTSGetActiveConsoleSessionId when no
+// session is attached to the console.
+const noConsoleSession = 0xFFFFFFFF
+
+// IdleState reports the lock state of the session attached to the console.
+// The idle time is only reported when the calling process runs in that
+// session because GetLastInputInfo is scoped to the session of the caller.
+// Services run in session 0 and therefore cannot observe user input.
+func (h *host) IdleState() (*types.IdleInfo, error) {
+	console := windows.WTSGetActiveConsoleSessionId()
+	if console == noConsoleSession {
+		return &types.IdleInfo{}, nil
+	}
+
+	info := &types.IdleInfo{SessionID: fmt.Sprint(console)}
+
+	locked, err := sessionLocked(console)
+	if err != nil {
+		return nil, err
+	}
+	info.Locked = locked
+
+	var session uint32
+	if err := windows.ProcessIdToSessionId(windows.GetCurrentProcessId(), &session); err == nil && session == console {
+		var lii lastInputInfo
+		if err := _GetLastInputInfo(&lii); err != nil {
+			return nil, fmt.Errorf("GetLastInputInfo failed: %w", err)
+		}
+		// The tick counts wrap around after 49.7 days so the unsigned
+		// difference is used.
+		idle := time.Duration(_GetTickCount()-lii.Time) * time.Millisecond
+		info.Idle = &idle
+	}
+	return info, nil
+}
+
+// sessionLocked returns whether the session is locked or nil if the state is
+// unknown.
+func sessionLocked(id uint32) (*bool, error) {
+	var buf *byte
+	var size uint32
+	if err := _WTSQuerySessionInformation(id, wtsSessionInfoEx, &buf, &size); err != nil {
+		return nil, fmt.Errorf("WTSQuerySessionInformation failed: %w", err)
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	if uintptr(size) < unsafe.Sizeof(wtsInfoEx{}) {
+		return nil, fmt.Errorf("WTSINFOEX size %d is too small", size)
+	}
+	info := (*wtsInfoEx)(unsafe.Pointer(buf))
+
+	var locked bool
+	switch info.SessionFlags {
+	case wtsSessionStateLock:
+		locked = true
+	case wtsSessionStateUnlock:
+		locked = false
+	default:
+		return nil, nil
+	}
+
+	// The meaning of the flags is inverted on Windows 7 and Server 2008 R2.
+	if v := windows.RtlGetVersion(); v.MajorVersion == 6 && v.MinorVersion == 1 {
+		locked = !locked
+	}
+	return &locked, nil
+}
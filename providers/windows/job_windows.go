@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"syscall"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// jobLimitFlags maps the JOB_OBJECT_LIMIT flags to their names.
+var jobLimitFlags = map[uint32]string{
+	syswin.JOB_OBJECT_LIMIT_WORKINGSET:                 "working_set",
+	syswin.JOB_OBJECT_LIMIT_PROCESS_TIME:               "process_time",
+	syswin.JOB_OBJECT_LIMIT_JOB_TIME:                   "job_time",
+	syswin.JOB_OBJECT_LIMIT_ACTIVE_PROCESS:             "active_process",
+	syswin.JOB_OBJECT_LIMIT_AFFINITY:                   "affinity",
+	syswin.JOB_OBJECT_LIMIT_PRIORITY_CLASS:             "priority_class",
+	syswin.JOB_OBJECT_LIMIT_PRESERVE_JOB_TIME:          "preserve_job_time",
+	syswin.JOB_OBJECT_LIMIT_SCHEDULING_CLASS:           "scheduling_class",
+	syswin.JOB_OBJECT_LIMIT_PROCESS_MEMORY:             "process_memory",
+	syswin.JOB_OBJECT_LIMIT_JOB_MEMORY:                 "job_memory",
+	syswin.JOB_OBJECT_LIMIT_DIE_ON_UNHANDLED_EXCEPTION: "die_on_unhandled_exception",
+	syswin.JOB_OBJECT_LIMIT_BREAKAWAY_OK:               "breakaway_ok",
+	syswin.JOB_OBJECT_LIMIT_SILENT_BREAKAWAY_OK:        "silent_breakaway_ok",
+	syswin.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE:          "kill_on_job_close",
+	syswin.JOB_OBJECT_LIMIT_SUBSET_AFFINITY:            "subset_affinity",
+}
+
+// JobObject reports whether the process is in a job. The limits and
+// accounting are reported when the process is in the job of the calling
+// process, which is the only job that can be queried without a handle.
+func (p *process) JobObject() (*types.JobObjectInfo, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	info := &types.JobObjectInfo{}
+	if err := _IsProcessInJob(syswin.Handle(handle), &info.InJob); err != nil {
+		return nil, fmt.Errorf("IsProcessInJob failed: %w", err)
+	}
+	if !info.InJob {
+		return info, nil
+	}
+
+	if p.pid != selfPID {
+		var selfInJob bool
+		if err := _IsProcessInJob(syswin.CurrentProcess(), &selfInJob); err != nil {
+			return nil, fmt.Errorf("IsProcessInJob failed: %w", err)
+		}
+		if !selfInJob {
+			return info, nil
+		}
+
+		pids, err := jobProcessIDs()
+		if err != nil {
+			return nil, err
+		}
+		if !containsPID(pids, p.pid) {
+			return info, nil
+		}
+	}
+
+	var ext syswin.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	if err := syswin.QueryInformationJobObject(0, syswin.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&ext)), uint32(unsafe.Sizeof(ext)), nil); err != nil {
+		return nil, fmt.Errorf("QueryInformationJobObject failed: %w", err)
+	}
+	info.Limits = extendedLimits(&ext)
+
+	var rate jobObjectCPURateControl
+	if err := syswin.QueryInformationJobObject(0, syswin.JobObjectCpuRateControlInformation,
+		uintptr(unsafe.Pointer(&rate)), uint32(unsafe.Sizeof(rate)), nil); err == nil {
+		info.Limits.CPURate = cpuRateLimit(rate)
+	}
+
+	if info.Accounting, err = jobAccounting(&ext); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// jobProcessIDs returns the IDs of the processes in the job of the calling
+// process.
+func jobProcessIDs() ([]int, error) {
+	for n := 256; ; n *= 2 {
+		// The buffer is allocated as uintptrs for the alignment of the IDs.
+		buf := make([]uintptr, n)
+		list := (*jobObjectBasicProcessIDList)(unsafe.Pointer(&buf[0]))
+		err := syswin.QueryInformationJobObject(0, jobObjectBasicProcessIDListInfo,
+			uintptr(unsafe.Pointer(list)), uint32(uintptr(n)*unsafe.Sizeof(buf[0])), nil)
+		if errors.Is(err, syswin.ERROR_MORE_DATA) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("QueryInformationJobObject failed: %w", err)
+		}
+
+		var pids []int
+		if list.NumberOfProcessIdsInList > 0 {
+			for _, id := range unsafe.Slice(&list.ProcessIDList[0], list.NumberOfProcessIdsInList) {
+				pids = append(pids, int(id))
+			}
+		}
+		return pids, nil
+	}
+}
+
+func containsPID(pids []int, pid int) bool {
+	for _, p := range pids {
+		if p == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// extendedLimits returns the limits that are set in the extended limit
+// information of a job.
+func extendedLimits(ext *syswin.JOBOBJECT_EXTENDED_LIMIT_INFORMATION) *types.JobObjectLimits {
+	basic := &ext.BasicLimitInformation
+	limits := &types.JobObjectLimits{}
+	for flag, name := range jobLimitFlags {
+		if basic.LimitFlags&flag != 0 {
+			limits.Flags = append(limits.Flags, name)
+		}
+	}
+	sort.Strings(limits.Flags)
+
+	if basic.LimitFlags&syswin.JOB_OBJECT_LIMIT_ACTIVE_PROCESS != 0 {
+		n := basic.ActiveProcessLimit
+		limits.ActiveProcesses = &n
+	}
+	if basic.LimitFlags&syswin.JOB_OBJECT_LIMIT_PROCESS_MEMORY != 0 {
+		n := uint64(ext.ProcessMemoryLimit)
+		limits.ProcessMemory = &n
+	}
+	if basic.LimitFlags&syswin.JOB_OBJECT_LIMIT_JOB_MEMORY != 0 {
+		n := uint64(ext.JobMemoryLimit)
+		limits.JobMemory = &n
+	}
+	// The time limits are in 100-nanosecond intervals.
+	if basic.LimitFlags&syswin.JOB_OBJECT_LIMIT_PROCESS_TIME != 0 {
+		d := shared.FiletimeToDuration(uint64(basic.PerProcessUserTimeLimit))
+		limits.ProcessUserTime = &d
+	}
+	if basic.LimitFlags&syswin.JOB_OBJECT_LIMIT_JOB_TIME != 0 {
+		d := shared.FiletimeToDuration(uint64(basic.PerJobUserTimeLimit))
+		limits.JobUserTime = &d
+	}
+	return limits
+}
+
+// cpuRateLimit returns the hard CPU rate limit in percent. Weight based
+// limits are relative to other jobs and are not reported.
+func cpuRateLimit(rate jobObjectCPURateControl) *float64 {
+	if rate.ControlFlags&jobObjectCPURateControlEnable == 0 ||
+		rate.ControlFlags&jobObjectCPURateControlWeightBased != 0 {
+		return nil
+	}
+	// The rate is in hundredths of a percent. With a minimum and maximum
+	// rate the maximum is in the high word.
+	cpuRate := rate.Rate
+	if rate.ControlFlags&jobObjectCPURateControlMinMaxRate != 0 {
+		cpuRate >>= 16
+	}
+	percent := float64(cpuRate) / 100
+	return &percent
+}
+
+// jobAccounting returns the accounting of the job of the calling process.
+// The peak memory usage is taken from its extended limit information.
+func jobAccounting(ext *syswin.JOBOBJECT_EXTENDED_LIMIT_INFORMATION) (*types.JobObjectAccounting, error) {
+	var acct jobObjectBasicAndIOAccounting
+	if err := syswin.QueryInformationJobObject(0, jobObjectBasicAndIOAccountingInfo,
+		uintptr(unsafe.Pointer(&acct)), uint32(unsafe.Sizeof(acct)), nil); err != nil {
+		return nil, fmt.Errorf("QueryInformationJobObject failed: %w", err)
+	}
+
+	return &types.JobObjectAccounting{
+		UserTime:            shared.FiletimeToDuration(uint64(acct.TotalUserTime)),
+		KernelTime:          shared.FiletimeToDuration(uint64(acct.TotalKernelTime)),
+		PageFaults:          acct.TotalPageFaultCount,
+		TotalProcesses:      acct.TotalProcesses,
+		ActiveProcesses:     acct.ActiveProcesses,
+		TerminatedProcesses: acct.TotalTerminatedProcesses,
+		ReadBytes:           acct.IoInfo.ReadTransferCount,
+		WriteBytes:          acct.IoInfo.WriteTransferCount,
+		PeakProcessMemory:   uint64(ext.PeakProcessMemoryUsed),
+		PeakJobMemory:       uint64(ext.PeakJobMemoryUsed),
+	}, nil
+}
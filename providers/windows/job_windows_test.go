@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.JobObject = (*process)(nil)
+
+func TestExtendedLimits(t *testing.T) {
+	var ext syswin.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+	ext.BasicLimitInformation.LimitFlags = syswin.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE |
+		syswin.JOB_OBJECT_LIMIT_ACTIVE_PROCESS | syswin.JOB_OBJECT_LIMIT_JOB_MEMORY |
+		syswin.JOB_OBJECT_LIMIT_JOB_TIME
+	ext.BasicLimitInformation.ActiveProcessLimit = 4
+	ext.BasicLimitInformation.PerJobUserTimeLimit = 10_000_000
+	ext.JobMemoryLimit = 1 << 30
+	ext.ProcessMemoryLimit = 1 << 20 // Not used without its flag.
+
+	limits := extendedLimits(&ext)
+	assert.Equal(t, []string{"active_process", "job_memory", "job_time", "kill_on_job_close"}, limits.Flags)
+	require.NotNil(t, limits.ActiveProcesses)
+	assert.EqualValues(t, 4, *limits.ActiveProcesses)
+	require.NotNil(t, limits.JobMemory)
+	assert.EqualValues(t, 1<<30, *limits.JobMemory)
+	require.NotNil(t, limits.JobUserTime)
+	assert.Equal(t, time.Second, *limits.JobUserTime)
+	assert.Nil(t, limits.ProcessMemory)
+	assert.Nil(t, limits.ProcessUserTime)
+}
+
+func TestCPURateLimit(t *testing.T) {
+	rate := cpuRateLimit(jobObjectCPURateControl{ControlFlags: jobObjectCPURateControlEnable | 0x4, Rate: 2500})
+	require.NotNil(t, rate)
+	assert.Equal(t, 25.0, *rate)
+
+	rate = cpuRateLimit(jobObjectCPURateControl{ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlMinMaxRate, Rate: 5000<<16 | 1000})
+	require.NotNil(t, rate)
+	assert.Equal(t, 50.0, *rate)
+
+	assert.Nil(t, cpuRateLimit(jobObjectCPURateControl{ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlWeightBased, Rate: 5}))
+	assert.Nil(t, cpuRateLimit(jobObjectCPURateControl{Rate: 2500}))
+}
+
+func TestSelfJobObject(t *testing.T) {
+	self, err := newProcess(selfPID)
+	require.NoError(t, err)
+
+	info, err := self.JobObject()
+	require.NoError(t, err)
+	if info.InJob {
+		assert.NotNil(t, info.Limits)
+		require.NotNil(t, info.Accounting)
+		assert.NotZero(t, info.Accounting.ActiveProcesses)
+	}
+}
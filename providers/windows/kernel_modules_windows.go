@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// KernelModules reports the kernel drivers that are loaded. The driver load
+// addresses are hidden from processes without administrative privileges on
+// recent Windows versions, in which case no names can be resolved. Sizes and
+// signature states are not reported.
+func (h *host) KernelModules() ([]types.KernelModuleInfo, error) {
+	v, err := ratelimit.Do(ratelimit.KernelModules, "", func() (interface{}, error) {
+		return kernelModules()
+	})
+	modules, _ := v.([]types.KernelModuleInfo)
+	return append([]types.KernelModuleInfo(nil), modules...), err
+}
+
+func kernelModules() ([]types.KernelModuleInfo, error) {
+	bases := make([]uintptr, 512)
+	for {
+		var needed uint32
+		if err := _EnumDeviceDrivers(bases, &needed); err != nil {
+			return nil, fmt.Errorf("EnumDeviceDrivers failed: %w", err)
+		}
+		n := int(uintptr(needed) / unsafe.Sizeof(bases[0]))
+		if n <= len(bases) {
+			bases = bases[:n]
+			break
+		}
+		bases = make([]uintptr, n)
+	}
+
+	var modules []types.KernelModuleInfo
+	buf := make([]uint16, windows.MAX_PATH)
+	for _, base := range bases {
+		n, err := _GetDeviceDriverBaseName(base, buf)
+		if err != nil {
+			continue
+		}
+		m := types.KernelModuleInfo{Name: windows.UTF16ToString(buf[:n])}
+		if n, err := _GetDeviceDriverFileName(base, buf); err == nil {
+			m.Path = driverPath(windows.UTF16ToString(buf[:n]))
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// driverPath converts the NT paths returned by GetDeviceDriverFileName (e.g.
+// \SystemRoot\system32\ntoskrnl.exe) to Win32 paths.
+func driverPath(path string) string {
+	switch {
+	case strings.HasPrefix(path, `\??\`):
+		return path[len(`\??\`):]
+	case strings.HasPrefix(strings.ToLower(path), `\systemroot\`):
+		if root := os.Getenv("SystemRoot"); root != "" {
+			return root + path[len(`\SystemRoot`):]
+		}
+	case strings.HasPrefix(strings.ToLower(path), `\windows\`):
+		if drive := os.Getenv("SystemDrive"); drive != "" {
+			return drive + path
+		}
+	}
+	return path
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// ListeningPorts reports the listening TCP and bound UDP sockets and their
+// owning processes, which is what "netstat -ano" shows for the LISTENING
+// sockets. The TCP table is requested with the TCP_TABLE_OWNER_PID_LISTENER
+// class so that the connections are not returned at all.
+func (h *host) ListeningPorts() ([]types.ListeningPort, error) {
+	var ports []types.ListeningPort
+	for _, family := range []uint32{windows.AF_INET, windows.AF_INET6} {
+		tcp, err := extendedTable(_GetExtendedTcpTable, family, tcpTableOwnerPIDListener)
+		if err != nil {
+			if family == windows.AF_INET6 && errors.Is(err, windows.ERROR_NOT_SUPPORTED) {
+				// IPv6 is not installed.
+				continue
+			}
+			return nil, fmt.Errorf("GetExtendedTcpTable failed: %w", err)
+		}
+		ports = append(ports, tcpListeningPorts(tcp, family)...)
+
+		udp, err := extendedTable(_GetExtendedUdpTable, family, udpTableOwnerPID)
+		if err != nil {
+			return nil, fmt.Errorf("GetExtendedUdpTable failed: %w", err)
+		}
+		ports = append(ports, udpListeningPorts(udp, family)...)
+	}
+
+	names := processNames()
+	for i := range ports {
+		ports[i].ProcessName = names[ports[i].PID]
+	}
+	return ports, nil
+}
+
+// extendedTable returns a table from GetExtendedTcpTable or
+// GetExtendedUdpTable.
+func extendedTable(get func(*byte, *uint32, uint32, uint32) error, family, class uint32) ([]byte, error) {
+	size := uint32(16 * 1024)
+	for {
+		buf := make([]byte, size)
+		err := get(&buf[0], &size, family, class)
+		if err == nil {
+			return buf[:size], nil
+		}
+		if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+			return nil, err
+		}
+	}
+}
+
+// tableRows returns a pointer to the rows of a MIB table and their number.
+// The rows follow the DWORD dwNumEntries. It returns 0 rows if the table is
+// truncated.
+func tableRows(table []byte, rowSize uintptr) (unsafe.Pointer, int) {
+	if len(table) < 4 {
+		return nil, 0
+	}
+	n := int(*(*uint32)(unsafe.Pointer(&table[0])))
+	if uintptr(len(table)-4) < uintptr(n)*rowSize {
+		return nil, 0
+	}
+	return unsafe.Pointer(&table[4]), n
+}
+
+// tcpListeningPorts converts a MIB_TCPTABLE_OWNER_PID or
+// MIB_TCP6TABLE_OWNER_PID table.
+func tcpListeningPorts(table []byte, family uint32) []types.ListeningPort {
+	var ports []types.ListeningPort
+	if family == windows.AF_INET6 {
+		p, n := tableRows(table, unsafe.Sizeof(mibTCP6RowOwnerPID{}))
+		for _, r := range unsafe.Slice((*mibTCP6RowOwnerPID)(p), n) {
+			ports = append(ports, listeningPort(types.ProtocolTCP, r.LocalAddr[:], r.LocalPort, r.OwningPID))
+		}
+		return ports
+	}
+	p, n := tableRows(table, unsafe.Sizeof(mibTCPRowOwnerPID{}))
+	for _, r := range unsafe.Slice((*mibTCPRowOwnerPID)(p), n) {
+		ports = append(ports, listeningPort(types.ProtocolTCP, r.LocalAddr[:], r.LocalPort, r.OwningPID))
+	}
+	return ports
+}
+
+// udpListeningPorts converts a MIB_UDPTABLE_OWNER_PID or
+// MIB_UDP6TABLE_OWNER_PID table.
+func udpListeningPorts(table []byte, family uint32) []types.ListeningPort {
+	var ports []types.ListeningPort
+	if family == windows.AF_INET6 {
+		p, n := tableRows(table, unsafe.Sizeof(mibUDP6RowOwnerPID{}))
+		for _, r := range unsafe.Slice((*mibUDP6RowOwnerPID)(p), n) {
+			ports = append(ports, listeningPort(types.ProtocolUDP, r.LocalAddr[:], r.LocalPort, r.OwningPID))
+		}
+		return ports
+	}
+	p, n := tableRows(table, unsafe.Sizeof(mibUDPRowOwnerPID{}))
+	for _, r := range unsafe.Slice((*mibUDPRowOwnerPID)(p), n) {
+		ports = append(ports, listeningPort(types.ProtocolUDP, r.LocalAddr[:], r.LocalPort, r.OwningPID))
+	}
+	return ports
+}
+
+// listeningPort converts the local address and port of a row. The port is
+// in network byte order in the low-order bytes of the DWORD.
+func listeningPort(protocol string, addr []byte, port [4]byte, pid uint32) types.ListeningPort {
+	family := types.FamilyIPv4
+	if len(addr) == net.IPv6len {
+		family = types.FamilyIPv6
+	}
+	return types.ListeningPort{
+		Protocol: protocol,
+		Family:   family,
+		IP:       net.IP(addr).String(),
+		Port:     int(port[0])<<8 | int(port[1]),
+		PID:      int(pid),
+	}
+}
+
+// processNames returns the image names of the running processes by PID.
+func processNames() map[int]string {
+	buf, err := querySystemProcessInformation()
+	if err != nil {
+		return nil
+	}
+
+	names := map[int]string{}
+	walkSystemProcessInformation(buf, func(proc *windows.SYSTEM_PROCESS_INFORMATION, _ []systemThreadInformation) bool {
+		names[int(proc.UniqueProcessID)] = proc.ImageName.String()
+		return true
+	})
+	return names
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.ListeningPorts = (*host)(nil)
+
+func TestMibRowOwnerPIDSizes(t *testing.T) {
+	assert.EqualValues(t, 24, unsafe.Sizeof(mibTCPRowOwnerPID{}))
+	assert.EqualValues(t, 56, unsafe.Sizeof(mibTCP6RowOwnerPID{}))
+	assert.EqualValues(t, 12, unsafe.Sizeof(mibUDPRowOwnerPID{}))
+	assert.EqualValues(t, 28, unsafe.Sizeof(mibUDP6RowOwnerPID{}))
+}
+
+func TestTCPListeningPorts(t *testing.T) {
+	table := make([]byte, 4+2*unsafe.Sizeof(mibTCPRowOwnerPID{}))
+	*(*uint32)(unsafe.Pointer(&table[0])) = 2
+	rows := unsafe.Slice((*mibTCPRowOwnerPID)(unsafe.Pointer(&table[4])), 2)
+	rows[0] = mibTCPRowOwnerPID{LocalPort: [4]byte{0x01, 0xbb}, OwningPID: 4}
+	rows[1] = mibTCPRowOwnerPID{LocalAddr: [4]byte{127, 0, 0, 1}, LocalPort: [4]byte{0x0c, 0xea}, OwningPID: 1234}
+
+	assert.Equal(t, []types.ListeningPort{
+		{Protocol: types.ProtocolTCP, Family: types.FamilyIPv4, IP: "0.0.0.0", Port: 443, PID: 4},
+		{Protocol: types.ProtocolTCP, Family: types.FamilyIPv4, IP: "127.0.0.1", Port: 3306, PID: 1234},
+	}, tcpListeningPorts(table, windows.AF_INET))
+
+	// A truncated table has no rows.
+	assert.Empty(t, tcpListeningPorts(table[:30], windows.AF_INET))
+}
+
+func TestUDPListeningPorts(t *testing.T) {
+	table := make([]byte, 4+unsafe.Sizeof(mibUDP6RowOwnerPID{}))
+	*(*uint32)(unsafe.Pointer(&table[0])) = 1
+	row := (*mibUDP6RowOwnerPID)(unsafe.Pointer(&table[4]))
+	row.LocalAddr[15] = 1
+	row.LocalPort = [4]byte{0x14, 0xe9}
+	row.OwningPID = 2000
+
+	assert.Equal(t, []types.ListeningPort{
+		{Protocol: types.ProtocolUDP, Family: types.FamilyIPv6, IP: "::1", Port: 5353, PID: 2000},
+	}, udpListeningPorts(table, windows.AF_INET6))
+}
@@ -17,31 +17,10 @@
 
 package windows
 
-import (
-	"fmt"
-
-	"golang.org/x/sys/windows/registry"
-)
-
 func MachineID() (string, error) {
 	return getMachineGUID()
 }
 
 func getMachineGUID() (string, error) {
-	const key = registry.LOCAL_MACHINE
-	const path = `SOFTWARE\Microsoft\Cryptography`
-	const name = "MachineGuid"
-
-	k, err := registry.OpenKey(key, path, registry.READ|registry.WOW64_64KEY)
-	if err != nil {
-		return "", fmt.Errorf(`failed to open HKLM\%v: %w`, path, err)
-	}
-	defer k.Close()
-
-	guid, _, err := k.GetStringValue(name)
-	if err != nil {
-		return "", fmt.Errorf(`failed to get value of HKLM\%v\%v: %w`, path, name, err)
-	}
-
-	return guid, nil
+	return localMachineString(`SOFTWARE\Microsoft\Cryptography`, "MachineGuid")
 }
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// neighborStates maps the NL_NEIGHBOR_STATE values to the
+// types.NeighborState values.
+var neighborStates = map[uint32]string{
+	nlnsUnreachable: types.NeighborStateFailed,
+	nlnsIncomplete:  types.NeighborStateIncomplete,
+	nlnsProbe:       types.NeighborStateProbe,
+	nlnsDelay:       types.NeighborStateDelay,
+	nlnsStale:       types.NeighborStateStale,
+	nlnsReachable:   types.NeighborStateReachable,
+	nlnsPermanent:   types.NeighborStatePermanent,
+}
+
+// Neighbors reports the IPv4 and IPv6 neighbor cache returned by
+// GetIpNetTable2, which is what "arp -a" and "netsh interface ipv6 show
+// neighbors" show.
+func (h *host) Neighbors() ([]types.Neighbor, error) {
+	var table *mibIPNetTable2
+	if err := _GetIpNetTable2(windows.AF_UNSPEC, &table); err != nil {
+		return nil, err
+	}
+	defer _FreeMibTable(unsafe.Pointer(table))
+
+	rows := unsafe.Slice((*mibIPNetRow2)(unsafe.Add(unsafe.Pointer(table), unsafe.Sizeof(*table))), table.NumEntries)
+	neighbors := make([]types.Neighbor, 0, len(rows))
+	for i := range rows {
+		if n, ok := neighborFromRow(&rows[i], shared.InterfaceName); ok {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors, nil
+}
+
+// neighborFromRow converts a MIB_IPNET_ROW2. It returns false for entries of
+// other address families.
+func neighborFromRow(row *mibIPNetRow2, interfaceName func(int) string) (types.Neighbor, bool) {
+	family, ip := row.Address.ip()
+	if ip == nil {
+		return types.Neighbor{}, false
+	}
+
+	n := types.Neighbor{
+		Family:    family,
+		IP:        ip.String(),
+		Interface: interfaceName(int(row.InterfaceIndex)),
+		State:     neighborStates[row.State],
+	}
+	if l := row.PhysicalAddressLength; l > 0 && l <= uint32(len(row.PhysicalAddress)) {
+		n.MAC = net.HardwareAddr(row.PhysicalAddress[:l]).String()
+	}
+	return n, true
+}
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"net"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Neighbors = (*host)(nil)
+
+func TestMibIPNetRow2Size(t *testing.T) {
+	assert.EqualValues(t, 88, unsafe.Sizeof(mibIPNetRow2{}))
+	assert.EqualValues(t, 40, unsafe.Offsetof(mibIPNetRow2{}.PhysicalAddress))
+	assert.EqualValues(t, 76, unsafe.Offsetof(mibIPNetRow2{}.State))
+}
+
+func TestNeighborFromRow(t *testing.T) {
+	names := func(index int) string { return map[int]string{7: "Ethernet"}[index] }
+
+	row := mibIPNetRow2{
+		Address:               sockaddr(net.ParseIP("192.168.1.1")),
+		InterfaceIndex:        7,
+		PhysicalAddress:       [32]byte{0x52, 0x54, 0x00, 0x12, 0x34, 0x56},
+		PhysicalAddressLength: 6,
+		State:                 nlnsReachable,
+	}
+	n, ok := neighborFromRow(&row, names)
+	assert.True(t, ok)
+	assert.Equal(t, types.Neighbor{Family: types.FamilyIPv4, IP: "192.168.1.1", MAC: "52:54:00:12:34:56", Interface: "Ethernet", State: types.NeighborStateReachable}, n)
+
+	row = mibIPNetRow2{
+		Address:        sockaddr(net.ParseIP("fe80::1")),
+		InterfaceIndex: 7,
+		State:          nlnsIncomplete,
+	}
+	n, ok = neighborFromRow(&row, names)
+	assert.True(t, ok)
+	assert.Equal(t, types.Neighbor{Family: types.FamilyIPv6, IP: "fe80::1", Interface: "Ethernet", State: types.NeighborStateIncomplete}, n)
+}
@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+)
+
+// threadStateWaiting is the KTHREAD_STATE of threads that are waiting.
+const threadStateWaiting = 5
+
+// waitReasons are the names of the KWAIT_REASON values.
+var waitReasons = []string{
+	"Executive",
+	"FreePage",
+	"PageIn",
+	"PoolAllocation",
+	"DelayExecution",
+	"Suspended",
+	"UserRequest",
+	"WrExecutive",
+	"WrFreePage",
+	"WrPageIn",
+	"WrPoolAllocation",
+	"WrDelayExecution",
+	"WrSuspended",
+	"WrUserRequest",
+	"WrEventPair",
+	"WrQueue",
+	"WrLpcReceive",
+	"WrLpcReply",
+	"WrVirtualMemory",
+	"WrPageOut",
+	"WrRendezvous",
+	"WrKeyedEvent",
+	"WrTerminated",
+	"WrProcessInSwap",
+	"WrCpuRateControl",
+	"WrCalloutStack",
+	"WrKernel",
+	"WrResource",
+	"WrPushLock",
+	"WrMutex",
+	"WrQuantumEnd",
+	"WrDispatchInt",
+	"WrPreempted",
+	"WrYieldExecution",
+	"WrFastMutex",
+	"WrGuardedMutex",
+	"WrRundown",
+	"WrAlertByThreadId",
+	"WrDeferredPreempt",
+}
+
+// waitReasonName returns the name of a KWAIT_REASON value.
+func waitReasonName(reason uint32) string {
+	if int(reason) < len(waitReasons) {
+		return waitReasons[reason]
+	}
+	return fmt.Sprintf("Unknown(%d)", reason)
+}
+
+// systemThreadInformation is the SYSTEM_THREAD_INFORMATION structure. An
+// array of them follows each SYSTEM_PROCESS_INFORMATION entry.
+type systemThreadInformation struct {
+	KernelTime      int64
+	UserTime        int64
+	CreateTime      int64
+	WaitTime        uint32
+	StartAddress    uintptr
+	ClientID        [2]uintptr
+	Priority        int32
+	BasePriority    int32
+	ContextSwitches uint32
+	ThreadState     uint32
+	WaitReason      uint32
+}
+
+// querySystemProcessInformation returns a snapshot of all processes and
+// their threads as returned by NtQuerySystemInformation.
+func querySystemProcessInformation() ([]byte, error) {
+	return querySystemInformation(syswin.SystemProcessInformation, 256*1024)
+}
+
+// querySystemInformation returns the information of the given class as
+// returned by NtQuerySystemInformation. The buffer starts at size bytes and
+// grows until the information fits.
+func querySystemInformation(class int32, size uint32) ([]byte, error) {
+	for {
+		buf := make([]byte, size)
+		err := syswin.NtQuerySystemInformation(class, unsafe.Pointer(&buf[0]), size, &size)
+		switch {
+		case err == nil:
+			return buf[:size], nil
+		case errors.Is(err, syswin.STATUS_INFO_LENGTH_MISMATCH), errors.Is(err, syswin.STATUS_BUFFER_TOO_SMALL):
+			// Leave room for the entries added since the last call (e.g.
+			// processes that were started).
+			size += 64 * 1024
+		default:
+			return nil, fmt.Errorf("NtQuerySystemInformation(%d) failed: %w", class, err)
+		}
+	}
+}
+
+// walkSystemProcessInformation calls fn for each process in a snapshot
+// returned by querySystemProcessInformation until fn returns false.
+func walkSystemProcessInformation(buf []byte, fn func(*syswin.SYSTEM_PROCESS_INFORMATION, []systemThreadInformation) bool) {
+	const (
+		processSize = int(unsafe.Sizeof(syswin.SYSTEM_PROCESS_INFORMATION{}))
+		threadSize  = int(unsafe.Sizeof(systemThreadInformation{}))
+	)
+
+	for offset := 0; offset+processSize <= len(buf); {
+		proc := (*syswin.SYSTEM_PROCESS_INFORMATION)(unsafe.Pointer(&buf[offset]))
+
+		var threads []systemThreadInformation
+		n := int(proc.NumberOfThreads)
+		if start := offset + processSize; n > 0 && start+n*threadSize <= len(buf) {
+			threads = unsafe.Slice((*systemThreadInformation)(unsafe.Pointer(&buf[start])), n)
+		}
+
+		if !fn(proc, threads) || proc.NextEntryOffset == 0 {
+			return
+		}
+		offset += int(proc.NextEntryOffset)
+	}
+}
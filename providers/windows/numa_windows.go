@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"math/bits"
+	"unsafe"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// groupAffinity is the GROUP_AFFINITY structure.
+type groupAffinity struct {
+	Mask     uintptr
+	Group    uint16
+	Reserved [3]uint16
+}
+
+// cpus returns the logical CPU numbers of the affinity mask. CPUs are
+// numbered across processor groups, so CPU 0 of group 1 is CPU 64 on 64-bit
+// Windows.
+func (a groupAffinity) cpus() []int {
+	width := int(unsafe.Sizeof(a.Mask)) * 8
+	cpus := []int{}
+	for mask := uint64(a.Mask); mask != 0; mask &= mask - 1 {
+		cpus = append(cpus, int(a.Group)*width+bits.TrailingZeros64(mask))
+	}
+	return cpus
+}
+
+// NUMA reports the NUMA nodes of the host. Windows only reports the available
+// memory of the nodes, so MemoryTotal is not set.
+func (h *host) NUMA() (*types.NUMAInfo, error) {
+	var highest uint32
+	if err := _GetNumaHighestNodeNumber(&highest); err != nil {
+		return nil, fmt.Errorf("GetNumaHighestNodeNumber failed: %w", err)
+	}
+
+	info := &types.NUMAInfo{Nodes: []types.NUMANode{}}
+	for id := uint32(0); id <= highest; id++ {
+		var affinity groupAffinity
+		if err := _GetNumaNodeProcessorMaskEx(uint16(id), &affinity); err != nil {
+			return nil, fmt.Errorf("GetNumaNodeProcessorMaskEx failed for node %d: %w", id, err)
+		}
+		// Node numbers can be sparse. Nodes without processors are skipped.
+		if affinity.Mask == 0 {
+			continue
+		}
+
+		node := types.NUMANode{ID: int(id), CPUs: affinity.cpus()}
+		if err := _GetNumaAvailableMemoryNodeEx(uint16(id), &node.MemoryFree); err != nil {
+			return nil, fmt.Errorf("GetNumaAvailableMemoryNodeEx failed for node %d: %w", id, err)
+		}
+		info.Nodes = append(info.Nodes, node)
+	}
+	return info, nil
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.NUMA = (*host)(nil)
+
+func TestGroupAffinityCPUs(t *testing.T) {
+	width := int(unsafe.Sizeof(uintptr(0))) * 8
+
+	assert.Equal(t, []int{0, 1, 4}, groupAffinity{Mask: 0b10011}.cpus())
+	assert.Equal(t, []int{width, width + 1}, groupAffinity{Mask: 0b11, Group: 1}.cpus())
+	assert.Empty(t, groupAffinity{}.cpus())
+}
+
+func TestNUMA(t *testing.T) {
+	info, err := (&host{}).NUMA()
+	require.NoError(t, err)
+	require.NotEmpty(t, info.Nodes)
+
+	var cpus int
+	for _, node := range info.Nodes {
+		cpus += len(node.CPUs)
+	}
+	// The CPUs of the process are limited to its processor group on hosts
+	// with more than 64 CPUs.
+	assert.GreaterOrEqual(t, cpus, runtime.NumCPU())
+}
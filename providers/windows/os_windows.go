@@ -28,13 +28,11 @@ import (
 )
 
 func OperatingSystem() (*types.OSInfo, error) {
-	const key = registry.LOCAL_MACHINE
-	const path = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
-	const flags = registry.READ | registry.WOW64_64KEY
+	const path = currentVersionKey
 
-	k, err := registry.OpenKey(key, path, flags)
+	k, err := openLocalMachineKey(path)
 	if err != nil {
-		return nil, fmt.Errorf(`failed to open HKLM\%v: %w`, path, err)
+		return nil, err
 	}
 	defer k.Close()
 
@@ -44,7 +42,7 @@ func OperatingSystem() (*types.OSInfo, error) {
 		Platform: "windows",
 	}
 	name := "ProductName"
-	osInfo.Name, _, err = k.GetStringValue(name)
+	osInfo.Name, err = regString(k, name)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to get value of HKLM\%v\%v: %w`, path, name, err)
 	}
@@ -58,23 +56,18 @@ func OperatingSystem() (*types.OSInfo, error) {
 		osInfo.Version = fmt.Sprintf("%d.%d", major, minor)
 	} else {
 		name = "CurrentVersion"
-		osInfo.Version, _, err = k.GetStringValue(name)
+		osInfo.Version, err = regString(k, name)
 		if err != nil {
 			return nil, fmt.Errorf(`failed to get value of HKLM\%v\%v: %w`, path, name, err)
 		}
-		parts := strings.SplitN(osInfo.Version, ".", 3)
-		for i, p := range parts {
-			switch i {
-			case 0:
-				osInfo.Major, _ = strconv.Atoi(p)
-			case 1:
-				osInfo.Major, _ = strconv.Atoi(p)
-			}
+		if v, err := types.ParseVersion(osInfo.Version); err == nil {
+			osInfo.Major = v.Major
+			osInfo.Minor = v.Minor
 		}
 	}
 
 	name = "CurrentBuild"
-	currentBuild, _, err := k.GetStringValue(name)
+	currentBuild, err := regString(k, name)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to get value of HKLM\%v\%v: %w`, path, name, err)
 	}
@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !nosysinfo_packages
+// +build !nosysinfo_packages
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	sysinforegistry "github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func init() {
+	sysinforegistry.RegisterSubsystem(sysinforegistry.SubsystemPackages)
+}
+
+// uninstallKey contains the entries displayed by Programs and Features.
+const uninstallKey = `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`
+
+// uninstallViews are the registry views that contain uninstall entries. The
+// 32-bit view contains the programs installed by 32-bit installers on 64-bit
+// Windows.
+var uninstallViews = []struct {
+	root   registry.Key
+	access uint32
+}{
+	{registry.LOCAL_MACHINE, registry.WOW64_64KEY},
+	{registry.LOCAL_MACHINE, registry.WOW64_32KEY},
+	{registry.CURRENT_USER, 0},
+}
+
+// Packages reports the programs registered in the uninstall keys of the
+// registry and the products installed by Windows Installer that are not
+// registered there. System components and updates are skipped.
+func (h *host) Packages() ([]types.PackageInfo, error) {
+	v, err := ratelimit.Do(ratelimit.Packages, "", func() (interface{}, error) {
+		return packages()
+	})
+	pkgs, _ := v.([]types.PackageInfo)
+	return append([]types.PackageInfo(nil), pkgs...), err
+}
+
+func packages() ([]types.PackageInfo, error) {
+	seen := map[string]struct{}{}
+
+	var pkgs []types.PackageInfo
+	for _, view := range uninstallViews {
+		p, err := uninstallEntries(view.root, view.access, seen)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, p...)
+	}
+
+	p, err := msiProducts(seen)
+	if err != nil {
+		return nil, err
+	}
+	return append(pkgs, p...), nil
+}
+
+func uninstallEntries(root registry.Key, access uint32, seen map[string]struct{}) ([]types.PackageInfo, error) {
+	k, err := registry.OpenKey(root, uninstallKey, registry.READ|access)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(`failed to open %v: %w`, uninstallKey, err)
+	}
+	defer k.Close()
+
+	names, err := k.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to list subkeys of %v: %w`, uninstallKey, err)
+	}
+
+	var pkgs []types.PackageInfo
+	for _, name := range names {
+		// The 64-bit and 32-bit views are identical on 32-bit Windows.
+		if _, found := seen[strings.ToUpper(name)]; found {
+			continue
+		}
+
+		pkg, ok := readUninstallEntry(k, name)
+		if !ok {
+			continue
+		}
+		seen[strings.ToUpper(name)] = struct{}{}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func readUninstallEntry(uninstall registry.Key, name string) (types.PackageInfo, bool) {
+	k, err := registry.OpenKey(uninstall, name, registry.QUERY_VALUE)
+	if err != nil {
+		return types.PackageInfo{}, false
+	}
+	defer k.Close()
+
+	displayName, err := regString(k, "DisplayName")
+	if err != nil || displayName == "" {
+		return types.PackageInfo{}, false
+	}
+	if v, _, err := k.GetIntegerValue("SystemComponent"); err == nil && v != 0 {
+		return types.PackageInfo{}, false
+	}
+	// Updates reference the program that they apply to.
+	if _, err := regString(k, "ParentKeyName"); err == nil {
+		return types.PackageInfo{}, false
+	}
+
+	pkg := types.PackageInfo{Name: displayName, Source: "registry"}
+	pkg.Version, _ = regString(k, "DisplayVersion")
+	pkg.Publisher, _ = regString(k, "Publisher")
+	if date, err := regString(k, "InstallDate"); err == nil {
+		pkg.InstallTime = parseInstallDate(date)
+	}
+	return pkg, true
+}
+
+// msiProducts returns the products installed by Windows Installer whose
+// product code is not in seen.
+func msiProducts(seen map[string]struct{}) ([]types.PackageInfo, error) {
+	var pkgs []types.PackageInfo
+	for i := uint32(0); ; i++ {
+		var code [msiGUIDLength]uint16
+		if err := _MsiEnumProducts(i, &code); err != nil {
+			if errors.Is(err, windows.ERROR_NO_MORE_ITEMS) {
+				break
+			}
+			return nil, fmt.Errorf("MsiEnumProducts failed: %w", err)
+		}
+
+		if _, found := seen[strings.ToUpper(windows.UTF16ToString(code[:]))]; found {
+			continue
+		}
+
+		name := msiProductInfo(&code[0], "ProductName")
+		if name == "" {
+			continue
+		}
+		pkgs = append(pkgs, types.PackageInfo{
+			Name:        name,
+			Version:     msiProductInfo(&code[0], "VersionString"),
+			Publisher:   msiProductInfo(&code[0], "Publisher"),
+			InstallTime: parseInstallDate(msiProductInfo(&code[0], "InstallDate")),
+			Source:      "msi",
+		})
+	}
+	return pkgs, nil
+}
+
+func msiProductInfo(code *uint16, property string) string {
+	buf := make([]uint16, 128)
+	for {
+		size := uint32(len(buf))
+		err := _MsiGetProductInfo(code, property, buf, &size)
+		if errors.Is(err, windows.ERROR_MORE_DATA) {
+			// The returned size excludes the NUL terminator.
+			buf = make([]uint16, size+1)
+			continue
+		}
+		if err != nil {
+			return ""
+		}
+		return windows.UTF16ToString(buf[:size])
+	}
+}
+
+// parseInstallDate parses install dates in the YYYYMMDD format used by the
+// uninstall entries and Windows Installer. The zero time is returned for
+// invalid dates.
+func parseInstallDate(s string) time.Time {
+	t, err := time.ParseInLocation("20060102", strings.TrimSpace(s), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
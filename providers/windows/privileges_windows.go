@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// TOKEN_ELEVATION_TYPE values.
+const (
+	tokenElevationTypeDefault = 1
+	tokenElevationTypeFull    = 2
+	tokenElevationTypeLimited = 3
+)
+
+// Mandatory integrity level RIDs (SECURITY_MANDATORY_*_RID).
+const (
+	mandatoryUntrustedRID  = 0x0000
+	mandatoryLowRID        = 0x1000
+	mandatoryMediumRID     = 0x2000
+	mandatoryMediumPlusRID = 0x2100
+	mandatoryHighRID       = 0x3000
+	mandatorySystemRID     = 0x4000
+	mandatoryProtectedRID  = 0x5000
+)
+
+// Privileges reports the privileges, elevation, and integrity level of the
+// access token of the process.
+func (p *process) Privileges() (*types.PrivilegeInfo, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, fmt.Errorf("OpenProcess failed: %w", err)
+	}
+	defer syscall.CloseHandle(handle)
+
+	var token syswin.Token
+	if err := syswin.OpenProcessToken(syswin.Handle(handle), syswin.TOKEN_QUERY, &token); err != nil {
+		return nil, fmt.Errorf("OpenProcessToken failed: %w", err)
+	}
+	defer token.Close()
+
+	return tokenPrivileges(token)
+}
+
+func tokenPrivileges(token syswin.Token) (*types.PrivilegeInfo, error) {
+	buf, err := tokenInformation(token, syswin.TokenPrivileges)
+	if err != nil {
+		return nil, err
+	}
+	privileges := (*syswin.Tokenprivileges)(unsafe.Pointer(&buf[0]))
+
+	info := &types.PrivilegeInfo{Privileges: []types.Privilege{}}
+	for _, p := range privileges.AllPrivileges() {
+		name, err := lookupPrivilegeName(p.Luid)
+		if err != nil {
+			return nil, err
+		}
+		info.Privileges = append(info.Privileges, types.Privilege{
+			Name:    name,
+			Enabled: p.Attributes&syswin.SE_PRIVILEGE_ENABLED != 0,
+		})
+	}
+
+	info.Elevated = token.IsElevated()
+
+	var elevationType uint32
+	var size uint32
+	if err := syswin.GetTokenInformation(token, syswin.TokenElevationType, (*byte)(unsafe.Pointer(&elevationType)), uint32(unsafe.Sizeof(elevationType)), &size); err == nil {
+		info.ElevationType = elevationTypeName(elevationType)
+	}
+
+	if buf, err := tokenInformation(token, syswin.TokenIntegrityLevel); err == nil {
+		label := (*syswin.Tokenmandatorylabel)(unsafe.Pointer(&buf[0]))
+		if sid := label.Label.Sid; sid != nil && sid.SubAuthorityCount() > 0 {
+			info.IntegrityLevel = integrityLevelName(sid.SubAuthority(uint32(sid.SubAuthorityCount() - 1)))
+		}
+	}
+	return info, nil
+}
+
+// tokenInformation returns a variable sized class of token information.
+func tokenInformation(token syswin.Token, class uint32) ([]byte, error) {
+	var size uint32
+	err := syswin.GetTokenInformation(token, class, nil, 0, &size)
+	if size == 0 {
+		return nil, fmt.Errorf("GetTokenInformation failed: %w", err)
+	}
+	buf := make([]byte, size)
+	if err := syswin.GetTokenInformation(token, class, &buf[0], size, &size); err != nil {
+		return nil, fmt.Errorf("GetTokenInformation failed: %w", err)
+	}
+	return buf, nil
+}
+
+func lookupPrivilegeName(luid syswin.LUID) (string, error) {
+	name := make([]uint16, 64)
+	size := uint32(len(name))
+	err := _LookupPrivilegeName(&luid, name, &size)
+	if errors.Is(err, syswin.ERROR_INSUFFICIENT_BUFFER) {
+		name = make([]uint16, size+1)
+		size = uint32(len(name))
+		err = _LookupPrivilegeName(&luid, name, &size)
+	}
+	if err != nil {
+		return "", fmt.Errorf("LookupPrivilegeName failed: %w", err)
+	}
+	return syswin.UTF16ToString(name[:size]), nil
+}
+
+func elevationTypeName(t uint32) string {
+	switch t {
+	case tokenElevationTypeDefault:
+		return "default"
+	case tokenElevationTypeFull:
+		return "full"
+	case tokenElevationTypeLimited:
+		return "limited"
+	}
+	return ""
+}
+
+// integrityLevelName returns the name of the integrity level with the RID.
+// RIDs between the named levels are rounded down.
+func integrityLevelName(rid uint32) string {
+	switch {
+	case rid >= mandatoryProtectedRID:
+		return "protected"
+	case rid >= mandatorySystemRID:
+		return "system"
+	case rid >= mandatoryHighRID:
+		return "high"
+	case rid >= mandatoryMediumPlusRID:
+		return "medium_plus"
+	case rid >= mandatoryMediumRID:
+		return "medium"
+	case rid >= mandatoryLowRID:
+		return "low"
+	}
+	return "untrusted"
+}
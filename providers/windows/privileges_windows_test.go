@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Privileges = (*process)(nil)
+
+func TestIntegrityLevelName(t *testing.T) {
+	assert.Equal(t, "untrusted", integrityLevelName(mandatoryUntrustedRID))
+	assert.Equal(t, "low", integrityLevelName(mandatoryLowRID))
+	assert.Equal(t, "medium", integrityLevelName(mandatoryMediumRID))
+	assert.Equal(t, "medium", integrityLevelName(mandatoryMediumRID+0x10))
+	assert.Equal(t, "medium_plus", integrityLevelName(mandatoryMediumPlusRID))
+	assert.Equal(t, "high", integrityLevelName(mandatoryHighRID))
+	assert.Equal(t, "system", integrityLevelName(mandatorySystemRID))
+	assert.Equal(t, "protected", integrityLevelName(mandatoryProtectedRID))
+}
+
+func TestElevationTypeName(t *testing.T) {
+	assert.Equal(t, "default", elevationTypeName(tokenElevationTypeDefault))
+	assert.Equal(t, "full", elevationTypeName(tokenElevationTypeFull))
+	assert.Equal(t, "limited", elevationTypeName(tokenElevationTypeLimited))
+	assert.Empty(t, elevationTypeName(0))
+}
+
+func TestTokenPrivileges(t *testing.T) {
+	info, err := tokenPrivileges(syswin.GetCurrentProcessToken())
+	require.NoError(t, err)
+
+	// Every token holds SeChangeNotifyPrivilege, which is enabled by default.
+	assert.Contains(t, info.Privileges, types.Privilege{Name: "SeChangeNotifyPrivilege", Enabled: true})
+	assert.NotEmpty(t, info.IntegrityLevel)
+	assert.NotEmpty(t, info.ElevationType)
+}
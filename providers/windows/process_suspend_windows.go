@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+
+	syswin "golang.org/x/sys/windows"
+)
+
+// Suspend suspends all threads of the process with NtSuspendProcess.
+func (p *process) Suspend() error {
+	return p.suspendResume("NtSuspendProcess", _NtSuspendProcess)
+}
+
+// Resume resumes the threads of the process that were suspended by Suspend
+// with NtResumeProcess.
+func (p *process) Resume() error {
+	return p.suspendResume("NtResumeProcess", _NtResumeProcess)
+}
+
+func (p *process) suspendResume(name string, fn func(syswin.Handle) error) error {
+	handle, err := syswin.OpenProcess(syswin.PROCESS_SUSPEND_RESUME, false, uint32(p.pid))
+	if err != nil {
+		return fmt.Errorf("OpenProcess failed: %w", err)
+	}
+	defer syswin.CloseHandle(handle)
+
+	if err := fn(handle); err != nil {
+		return fmt.Errorf("%v failed: %w", name, err)
+	}
+	return nil
+}
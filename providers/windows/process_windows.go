@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -31,6 +32,8 @@ import (
 
 	windows "github.com/elastic/go-windows"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/types"
 )
 
@@ -39,30 +42,80 @@ var (
 	devMapper = newDeviceMapper()
 )
 
-func (s windowsSystem) Processes() (procs []types.Process, err error) {
-	pids, err := windows.EnumProcesses()
+// Processes returns the running processes. The list, including the names,
+// parent PIDs and start times of the processes, is taken from a single
+// SystemProcessInformation snapshot so that the processes do not need to be
+// opened. The executable path, arguments and working directory are read when
+// Info is first called, and the CPU times, memory usage and handle counts are
+// read from the process each time they are requested.
+//
+// Unlike Process, the list includes processes that cannot be opened by the
+// caller, such as protected processes. Info returns only the values from the
+// snapshot for them and the other methods return the OpenProcess error.
+func (s windowsSystem) Processes() ([]types.Process, error) {
+	return s.ProcessesMatching(types.ProcessFilter{})
+}
+
+// ProcessesMatching returns the processes selected by filter. The PIDs and
+// names are matched against the process snapshot, so only the processes
+// that pass them are opened to read their user when the filter has UIDs.
+// Process states are not supported.
+func (s windowsSystem) ProcessesMatching(filter types.ProcessFilter) ([]types.Process, error) {
+	if len(filter.States) > 0 {
+		return nil, fmt.Errorf("process state filter: %w", types.ErrNotImplemented)
+	}
+
+	buf, err := querySystemProcessInformation()
 	if err != nil {
-		return nil, fmt.Errorf("EnumProcesses: %w", err)
+		return nil, err
 	}
-	procs = make([]types.Process, 0, len(pids))
-	var proc types.Process
-	for _, pid := range pids {
-		if pid == 0 || pid == 4 {
+
+	var procs []types.Process
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, _ []systemThreadInformation) bool {
+		if footprint.Low() && len(procs) == footprint.MaxEntries {
+			return false
+		}
+		if pid := proc.UniqueProcessID; pid == 0 || pid == 4 {
 			// The Idle and System processes (PIDs 0 and 4) can never be
 			// opened by user-level code (see documentation for OpenProcess).
-			continue
+			return true
+		}
+		if !filter.MatchPID(int(proc.UniqueProcessID)) || !filter.MatchName(proc.ImageName.String()) {
+			return true
 		}
 
-		if proc, err = s.Process(int(pid)); err == nil {
-			procs = append(procs, proc)
+		p := newSnapshotProcess(proc)
+		if len(filter.UIDs) > 0 {
+			user, err := p.User()
+			if err != nil || !filter.MatchUID(user.UID) {
+				return true
+			}
 		}
-	}
-	if len(procs) == 0 {
-		return nil, err
-	}
+		procs = append(procs, p)
+		return true
+	})
 	return procs, nil
 }
 
+// ForEachProcess calls fn for each process of a SystemProcessInformation
+// snapshot until fn returns false. The processes are created as the snapshot
+// is walked.
+func (s windowsSystem) ForEachProcess(fn func(types.Process) bool) error {
+	buf, err := querySystemProcessInformation()
+	if err != nil {
+		return err
+	}
+
+	walkSystemProcessInformation(buf, func(proc *syswin.SYSTEM_PROCESS_INFORMATION, _ []systemThreadInformation) bool {
+		if pid := proc.UniqueProcessID; pid == 0 || pid == 4 {
+			// The Idle and System processes cannot be opened.
+			return true
+		}
+		return fn(newSnapshotProcess(proc))
+	})
+	return nil
+}
+
 func (s windowsSystem) Process(pid int) (types.Process, error) {
 	return newProcess(pid)
 }
@@ -74,13 +127,21 @@ func (s windowsSystem) Self() (types.Process, error) {
 type process struct {
 	pid  int
 	info types.ProcessInfo
+
+	// snapshot is set for the processes returned by Processes. Their
+	// executable path, arguments and working directory are read on the
+	// first call to Info because they require opening the process.
+	snapshot    bool
+	detailsOnce sync.Once
 }
 
 func (p *process) PID() int {
 	return p.pid
 }
 
-func (p *process) Parent() (types.Process, error) {
+func (p *process) Parent() (_ types.Process, err error) {
+	defer safe.Recover("process parent", &err)
+
 	info, err := p.Info()
 	if err != nil {
 		return nil, err
@@ -92,11 +153,40 @@ func (p *process) Parent() (types.Process, error) {
 func newProcess(pid int) (*process, error) {
 	p := &process{pid: pid}
 	if err := p.init(); err != nil {
+		// OpenProcess fails with ERROR_INVALID_PARAMETER when no process
+		// has the PID.
+		if errors.Is(err, syswin.ERROR_INVALID_PARAMETER) {
+			return nil, fmt.Errorf("process %d not found: %w", pid, os.ErrNotExist)
+		}
 		return nil, err
 	}
 	return p, nil
 }
 
+// newSnapshotProcess returns a process that is initialized from an entry of
+// a SystemProcessInformation snapshot.
+func newSnapshotProcess(proc *syswin.SYSTEM_PROCESS_INFORMATION) *process {
+	return &process{
+		pid: int(proc.UniqueProcessID),
+		info: types.ProcessInfo{
+			Name:      proc.ImageName.String(),
+			PID:       int(proc.UniqueProcessID),
+			PPID:      int(proc.InheritedFromUniqueProcessID),
+			StartTime: filetimeToTime(proc.CreateTime),
+		},
+		snapshot: true,
+	}
+}
+
+// filetimeToTime converts a FILETIME stored as a 64-bit integer to a time.
+func filetimeToTime(v int64) time.Time {
+	ft := syscall.Filetime{
+		LowDateTime:  uint32(v),
+		HighDateTime: uint32(v >> 32),
+	}
+	return time.Unix(0, ft.Nanoseconds()).UTC()
+}
+
 func (p *process) init() error {
 	handle, err := p.open()
 	if err != nil {
@@ -104,59 +194,70 @@ func (p *process) init() error {
 	}
 	defer syscall.CloseHandle(handle)
 
-	var path string
+	var creationTime, exitTime, kernelTime, userTime syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creationTime, &exitTime, &kernelTime, &userTime); err != nil {
+		return err
+	}
+
+	p.info = types.ProcessInfo{
+		PID:       p.pid,
+		StartTime: filetimeToTime(int64(creationTime.HighDateTime)<<32 | int64(creationTime.LowDateTime)),
+	}
+	p.readDetails(handle)
+	return nil
+}
+
+// loadDetails reads the details of a process returned by Processes. The
+// values from the snapshot are kept if the process cannot be opened.
+func (p *process) loadDetails() {
+	handle, err := p.open()
+	if err != nil {
+		return
+	}
+	defer syscall.CloseHandle(handle)
+
+	p.readDetails(handle)
+}
+
+// readDetails reads the executable path, parent PID, arguments and working
+// directory of the process.
+func (p *process) readDetails(handle syscall.Handle) {
 	if imgf, err := windows.GetProcessImageFileName(handle); err == nil {
-		path, err = devMapper.DevicePathToDrivePath(imgf)
+		path, err := devMapper.DevicePathToDrivePath(imgf)
 		if err != nil {
 			path = imgf
 		}
+		p.info.Exe = path
+		p.info.Name = filepath.Base(path)
 	}
-
-	var creationTime, exitTime, kernelTime, userTime syscall.Filetime
-	if err := syscall.GetProcessTimes(handle, &creationTime, &exitTime, &kernelTime, &userTime); err != nil {
-		return err
-	}
+	p.info.Architecture, p.info.NativeArchitecture, p.info.Translated = processArchitecture(handle)
 
 	// Try to read the RTL_USER_PROCESS_PARAMETERS struct from the target process
 	// memory. This can fail due to missing access rights or when we are running
 	// as a 32bit process in a 64bit system (WOW64).
 	// Don't make this a fatal error: If it fails, `args` and `cwd` fields will
 	// be missing.
-	var args []string
-	var cwd string
-	var ppid int
 	pbi, err := getProcessBasicInformation(syswin.Handle(handle))
-	if err == nil {
-		ppid = int(pbi.InheritedFromUniqueProcessID)
-		userProcParams, err := getUserProcessParams(syswin.Handle(handle), pbi)
-		if err == nil {
-			if argsW, err := readProcessUnicodeString(handle, &userProcParams.CommandLine); err == nil {
-				args, err = splitCommandline(argsW)
-				if err != nil {
-					args = nil
-				}
-			}
-			if cwdW, err := readProcessUnicodeString(handle, &userProcParams.CurrentDirectoryPath); err == nil {
-				cwd, _, err = windows.UTF16BytesToString(cwdW)
-				if err != nil {
-					cwd = ""
-				}
-				// Remove trailing separator
-				cwd = strings.TrimRight(cwd, "\\")
-			}
-		}
+	if err != nil {
+		return
 	}
+	p.info.PPID = int(pbi.InheritedFromUniqueProcessID)
 
-	p.info = types.ProcessInfo{
-		Name:      filepath.Base(path),
-		PID:       p.pid,
-		PPID:      ppid,
-		Exe:       path,
-		Args:      args,
-		CWD:       cwd,
-		StartTime: time.Unix(0, creationTime.Nanoseconds()),
+	userProcParams, err := getUserProcessParams(syswin.Handle(handle), pbi)
+	if err != nil {
+		return
+	}
+	if argsW, err := readProcessUnicodeString(handle, &userProcParams.CommandLine); err == nil {
+		if args, err := splitCommandline(argsW); err == nil {
+			p.info.Args = args
+		}
+	}
+	if cwdW, err := readProcessUnicodeString(handle, &userProcParams.CurrentDirectoryPath); err == nil {
+		if cwd, _, err := windows.UTF16BytesToString(cwdW); err == nil {
+			// Remove trailing separator
+			p.info.CWD = strings.TrimRight(cwd, "\\")
+		}
 	}
-	return nil
 }
 
 func getProcessBasicInformation(handle syswin.Handle) (pbi windows.ProcessBasicInformationStruct, err error) {
@@ -284,11 +385,18 @@ func (p *process) open() (handle syscall.Handle, err error) {
 	return handle, err
 }
 
-func (p *process) Info() (types.ProcessInfo, error) {
+func (p *process) Info() (_ types.ProcessInfo, err error) {
+	defer safe.Recover("process info", &err)
+
+	if p.snapshot {
+		p.detailsOnce.Do(p.loadDetails)
+	}
 	return p.info, nil
 }
 
-func (p *process) User() (types.UserInfo, error) {
+func (p *process) User() (_ types.UserInfo, err error) {
+	defer safe.Recover("process user", &err)
+
 	handle, err := p.open()
 	if err != nil {
 		return types.UserInfo{}, fmt.Errorf("OpenProcess failed: %w", err)
@@ -334,7 +442,9 @@ func (p *process) User() (types.UserInfo, error) {
 	}, nil
 }
 
-func (p *process) Memory() (types.MemoryInfo, error) {
+func (p *process) Memory() (_ types.MemoryInfo, err error) {
+	defer safe.Recover("process memory", &err)
+
 	handle, err := p.open()
 	if err != nil {
 		return types.MemoryInfo{}, err
@@ -352,7 +462,9 @@ func (p *process) Memory() (types.MemoryInfo, error) {
 	}, nil
 }
 
-func (p *process) CPUTime() (types.CPUTimes, error) {
+func (p *process) CPUTime() (_ types.CPUTimes, err error) {
+	defer safe.Recover("process cpu time", &err)
+
 	handle, err := p.open()
 	if err != nil {
 		return types.CPUTimes{}, err
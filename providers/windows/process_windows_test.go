@@ -18,10 +18,121 @@
 package windows
 
 import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	syswin "golang.org/x/sys/windows"
+
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/types"
 )
 
 var (
 	_ registry.HostProvider    = windowsSystem{}
 	_ registry.ProcessProvider = windowsSystem{}
+	_ registry.ProcessMatcher  = windowsSystem{}
+	_ registry.ProcessIterator = windowsSystem{}
 )
+
+func TestFiletimeToTime(t *testing.T) {
+	// 2023-03-26T01:00:00Z, when daylight saving time started in Europe.
+	const ft = 133242660000000000
+
+	start := filetimeToTime(ft)
+	assert.Equal(t, time.UTC, start.Location())
+	assert.Equal(t, "2023-03-26T01:00:00Z", start.Format(time.RFC3339))
+	assert.Equal(t, time.Second, start.Sub(filetimeToTime(ft-1e7)))
+}
+
+func TestNewSnapshotProcess(t *testing.T) {
+	name, err := syswin.NewNTUnicodeString("example.exe")
+	require.NoError(t, err)
+
+	// 2021-01-01T00:00:00Z in 100-nanosecond intervals since 1601-01-01.
+	const createTime = 132539328000000000
+
+	p := newSnapshotProcess(&syswin.SYSTEM_PROCESS_INFORMATION{
+		// PIDs are multiples of 4 so this one cannot be opened.
+		UniqueProcessID:              0x7ffffff1,
+		InheritedFromUniqueProcessID: 1234,
+		ImageName:                    *name,
+		CreateTime:                   createTime,
+		UserTime:                     15_000_000,
+		KernelTime:                   5_000_000,
+		WorkingSetSize:               4096,
+		PagefileUsage:                8192,
+		HandleCount:                  42,
+	})
+
+	info, err := p.Info()
+	require.NoError(t, err)
+	assert.Equal(t, "example.exe", info.Name)
+	assert.Equal(t, 0x7ffffff1, info.PID)
+	assert.Equal(t, 1234, info.PPID)
+	assert.Empty(t, info.Exe)
+	assert.True(t, info.StartTime.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)), info.StartTime)
+
+	// The counters are read from the process rather than the snapshot.
+	_, err = p.CPUTime()
+	assert.Error(t, err)
+	_, err = p.Memory()
+	assert.Error(t, err)
+	_, err = p.OpenHandleCount()
+	assert.Error(t, err)
+}
+
+func TestProcessesSnapshot(t *testing.T) {
+	procs, err := windowsSystem{}.Processes()
+	require.NoError(t, err)
+
+	var self types.Process
+	for _, p := range procs {
+		if p.PID() == os.Getpid() {
+			self = p
+		}
+	}
+	require.NotNil(t, self, "current process not found")
+
+	info, err := self.Info()
+	require.NoError(t, err)
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	assert.Equal(t, exe, info.Exe)
+	assert.Equal(t, os.Getppid(), info.PPID)
+
+	// The CPU time is read each time it is requested.
+	before, err := self.CPUTime()
+	require.NoError(t, err)
+	for start := time.Now(); time.Since(start) < 100*time.Millisecond; {
+	}
+	after, err := self.CPUTime()
+	require.NoError(t, err)
+	assert.Greater(t, after.Total(), before.Total())
+}
+
+func TestProcessesMatching(t *testing.T) {
+	self, err := newProcess(os.Getpid())
+	require.NoError(t, err)
+	info, err := self.Info()
+	require.NoError(t, err)
+	user, err := self.User()
+	require.NoError(t, err)
+
+	procs, err := windowsSystem{}.ProcessesMatching(types.ProcessFilter{
+		Names: []string{info.Name},
+		UIDs:  []string{user.UID},
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, p := range procs {
+		found = found || p.PID() == os.Getpid()
+	}
+	assert.True(t, found, "current process not found")
+
+	_, err = windowsSystem{}.ProcessesMatching(types.ProcessFilter{States: []string{types.ProcessStateRunning}})
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"syscall"
+
+	windows "github.com/elastic/go-windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+)
+
+// currentVersionKey contains the version information of the OS.
+const currentVersionKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+
+// Raw returns the values of the CurrentVersion registry key, which contains
+// the OS version information, and the fields of MEMORYSTATUSEX.
+func (h *host) Raw() (map[string]interface{}, error) {
+	k, err := openLocalMachineKey(currentVersionKey)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	currentVersion, err := regValues(k)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]interface{}{"current_version": currentVersion}
+
+	if mem, err := windows.GlobalMemoryStatusEx(); err == nil {
+		raw["memory_status"] = shared.RawStruct(mem)
+	}
+	return raw, nil
+}
+
+// Raw returns the fields of PROCESS_MEMORY_COUNTERS_EX.
+func (p *process) Raw() (map[string]interface{}, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	counters, err := windows.GetProcessMemoryInfo(handle)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"memory_counters": shared.RawStruct(counters)}, nil
+}
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Raw = (*host)(nil)
+	_ types.Raw = (*process)(nil)
+)
+
+func TestRaw(t *testing.T) {
+	raw, err := (&host{}).Raw()
+	require.NoError(t, err)
+	assert.Contains(t, raw["current_version"], "CurrentBuild")
+	assert.Contains(t, raw["memory_status"], "TotalPhys")
+
+	raw, err = (&process{pid: os.Getpid()}).Raw()
+	require.NoError(t, err)
+	assert.Contains(t, raw["memory_counters"], "WorkingSetSize")
+}
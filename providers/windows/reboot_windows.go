@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// rebootIndicators are the registry keys and values that Windows sets while
+// a reboot is pending, keyed by the reason that is reported.
+var rebootIndicators = []struct {
+	reason string
+	key    string
+	value  string // Empty if the existence of the key is the indicator.
+}{
+	{"component_based_servicing", `SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\RebootPending`, ""},
+	{"windows_update", `SOFTWARE\Microsoft\Windows\CurrentVersion\WindowsUpdate\Auto Update\RebootRequired`, ""},
+	{"pending_file_rename", `SYSTEM\CurrentControlSet\Control\Session Manager`, "PendingFileRenameOperations"},
+}
+
+// RebootRequired reports whether the host must be restarted. It checks the
+// RebootPending key of Component Based Servicing, the RebootRequired key of
+// Windows Update, and the file rename operations that are pending until the
+// next boot (e.g. of files that were in use during an installation).
+func (h *host) RebootRequired() (*types.RebootRequiredInfo, error) {
+	info := &types.RebootRequiredInfo{}
+	for _, ind := range rebootIndicators {
+		pending, err := rebootIndicatorSet(ind.key, ind.value)
+		if err != nil {
+			return nil, err
+		}
+		if pending {
+			info.Reasons = append(info.Reasons, ind.reason)
+		}
+	}
+	info.Required = len(info.Reasons) > 0
+	return info, nil
+}
+
+// rebootIndicatorSet reports whether the key exists or, if value is not
+// empty, whether the key has a non-empty value with that name.
+func rebootIndicatorSet(key, value string) (bool, error) {
+	k, err := openLocalMachineKey(key)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer k.Close()
+
+	if value == "" {
+		return true, nil
+	}
+	v, err := regStrings(k, value)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return strings.Join(v, "") != "", nil
+}
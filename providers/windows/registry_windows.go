@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// openLocalMachineKey opens a key below HKEY_LOCAL_MACHINE in the 64-bit
+// view of the registry, which is the view of the OS also for 32-bit
+// processes.
+func openLocalMachineKey(path string) (registry.Key, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.READ|registry.WOW64_64KEY)
+	if err != nil {
+		return 0, fmt.Errorf(`failed to open HKLM\%v: %w`, path, err)
+	}
+	return k, nil
+}
+
+// localMachineString reads a string value of a key below HKEY_LOCAL_MACHINE.
+// See regString.
+func localMachineString(path, name string) (string, error) {
+	k, err := openLocalMachineKey(path)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+
+	v, err := regString(k, name)
+	if err != nil {
+		return "", fmt.Errorf(`failed to get value of HKLM\%v\%v: %w`, path, name, err)
+	}
+	return v, nil
+}
+
+// regString reads a REG_SZ or REG_EXPAND_SZ value. The environment variables
+// referenced by REG_EXPAND_SZ values (e.g. %SystemRoot%) are expanded.
+func regString(k registry.Key, name string) (string, error) {
+	v, typ, err := k.GetStringValue(name)
+	if err != nil {
+		return "", err
+	}
+	if typ == registry.EXPAND_SZ {
+		return registry.ExpandString(v)
+	}
+	return v, nil
+}
+
+// regStrings reads a REG_MULTI_SZ value. A REG_SZ or REG_EXPAND_SZ value is
+// returned as a single string, because some values are written with either
+// type.
+func regStrings(k registry.Key, name string) ([]string, error) {
+	v, _, err := k.GetStringsValue(name)
+	if errors.Is(err, registry.ErrUnexpectedType) {
+		s, err := regString(k, name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	return v, err
+}
+
+// regValues reads all values of a key by name. Strings are read as described
+// by regString and regStrings, DWORD and QWORD values as uint64, and other
+// types as bytes.
+func regValues(k registry.Key) (map[string]interface{}, error) {
+	names, err := k.ReadValueNames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		_, typ, err := k.GetValue(name, nil)
+		if err != nil {
+			continue
+		}
+
+		var v interface{}
+		switch typ {
+		case registry.SZ, registry.EXPAND_SZ:
+			v, err = regString(k, name)
+		case registry.MULTI_SZ:
+			v, err = regStrings(k, name)
+		case registry.DWORD, registry.QWORD:
+			v, _, err = k.GetIntegerValue(name)
+		default:
+			v, _, err = k.GetBinaryValue(name)
+		}
+		if err == nil {
+			values[name] = v
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/registry"
+)
+
+func TestRegistryStrings(t *testing.T) {
+	const path = `Software\go-sysinfo-test`
+	k, _, err := registry.CreateKey(registry.CURRENT_USER, path, registry.ALL_ACCESS)
+	require.NoError(t, err)
+	defer func() {
+		k.Close()
+		registry.DeleteKey(registry.CURRENT_USER, path)
+	}()
+
+	require.NoError(t, k.SetStringValue("sz", "plain"))
+	require.NoError(t, k.SetExpandStringValue("expand_sz", `%SystemRoot%\System32`))
+	require.NoError(t, k.SetStringsValue("multi_sz", []string{"first", "second"}))
+	require.NoError(t, k.SetDWordValue("dword", 1))
+
+	v, err := regString(k, "sz")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", v)
+
+	v, err = regString(k, "expand_sz")
+	require.NoError(t, err)
+	assert.Equal(t, os.Getenv("SystemRoot")+`\System32`, v)
+
+	_, err = regString(k, "multi_sz")
+	assert.ErrorIs(t, err, registry.ErrUnexpectedType)
+
+	list, err := regStrings(k, "multi_sz")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, list)
+
+	list, err = regStrings(k, "expand_sz")
+	require.NoError(t, err)
+	assert.Equal(t, []string{os.Getenv("SystemRoot") + `\System32`}, list)
+
+	_, err = regStrings(k, "dword")
+	assert.ErrorIs(t, err, registry.ErrUnexpectedType)
+
+	values, err := regValues(k)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"sz":        "plain",
+		"expand_sz": os.Getenv("SystemRoot") + `\System32`,
+		"multi_sz":  []string{"first", "second"},
+		"dword":     uint64(1),
+	}, values)
+
+	_, err = regString(k, "missing")
+	assert.ErrorIs(t, err, registry.ErrNotExist)
+}
+
+func TestLocalMachineString(t *testing.T) {
+	v, err := localMachineString(`SOFTWARE\Microsoft\Windows NT\CurrentVersion`, "SystemRoot")
+	require.NoError(t, err)
+	assert.NotEmpty(t, v)
+}
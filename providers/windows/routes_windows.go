@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"net"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Routes reports the IPv4 and IPv6 routing table returned by
+// GetIpForwardTable2. Like the output of "route print", it includes the host
+// routes of the local addresses and the multicast and broadcast routes.
+func (h *host) Routes() ([]types.Route, error) {
+	var table *mibIPForwardTable2
+	if err := _GetIpForwardTable2(windows.AF_UNSPEC, &table); err != nil {
+		return nil, err
+	}
+	defer _FreeMibTable(unsafe.Pointer(table))
+
+	rows := unsafe.Slice((*mibIPForwardRow2)(unsafe.Add(unsafe.Pointer(table), unsafe.Sizeof(*table))), table.NumEntries)
+	routes := make([]types.Route, 0, len(rows))
+	for i := range rows {
+		if route, ok := routeFromRow(&rows[i], shared.InterfaceName); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// routeFromRow converts a MIB_IPFORWARD_ROW2. It returns false for routes of
+// other address families.
+func routeFromRow(row *mibIPForwardRow2, interfaceName func(int) string) (types.Route, bool) {
+	family, dst := row.DestinationPrefix.Prefix.ip()
+	if dst == nil {
+		return types.Route{}, false
+	}
+
+	bits := 8 * len(dst)
+	metric := row.Metric
+	route := types.Route{
+		Family:      family,
+		Destination: (&net.IPNet{IP: dst, Mask: net.CIDRMask(int(row.DestinationPrefix.PrefixLength), bits)}).String(),
+		Interface:   interfaceName(int(row.InterfaceIndex)),
+		Metric:      &metric,
+	}
+	if _, gw := row.NextHop.ip(); gw != nil && !gw.IsUnspecified() {
+		route.Gateway = gw.String()
+	}
+	return route, true
+}
+
+// ip returns the address family and the IP address of the SOCKADDR_INET.
+func (s *sockaddrInet) ip() (string, net.IP) {
+	b := (*[unsafe.Sizeof(*s)]byte)(unsafe.Pointer(s))
+	switch binary.LittleEndian.Uint16(b[0:]) {
+	case windows.AF_INET:
+		// SOCKADDR_IN: family, port, and address.
+		return types.FamilyIPv4, net.IP(append([]byte(nil), b[4:8]...))
+	case windows.AF_INET6:
+		// SOCKADDR_IN6: family, port, flow info, and address.
+		return types.FamilyIPv6, net.IP(append([]byte(nil), b[8:24]...))
+	}
+	return "", nil
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Routes = (*host)(nil)
+
+func TestMibIPForwardRow2Size(t *testing.T) {
+	assert.EqualValues(t, 104, unsafe.Sizeof(mibIPForwardRow2{}))
+	assert.EqualValues(t, 12, unsafe.Offsetof(mibIPForwardRow2{}.DestinationPrefix))
+	assert.EqualValues(t, 44, unsafe.Offsetof(mibIPForwardRow2{}.NextHop))
+	assert.EqualValues(t, 84, unsafe.Offsetof(mibIPForwardRow2{}.Metric))
+}
+
+// sockaddr returns the SOCKADDR_INET of ip.
+func sockaddr(ip net.IP) sockaddrInet {
+	var s sockaddrInet
+	b := (*[unsafe.Sizeof(s)]byte)(unsafe.Pointer(&s))
+	if ip4 := ip.To4(); ip4 != nil {
+		binary.LittleEndian.PutUint16(b[0:], syswin.AF_INET)
+		copy(b[4:], ip4)
+	} else {
+		binary.LittleEndian.PutUint16(b[0:], syswin.AF_INET6)
+		copy(b[8:], ip)
+	}
+	return s
+}
+
+func TestRouteFromRow(t *testing.T) {
+	names := func(index int) string { return map[int]string{7: "Ethernet"}[index] }
+	metric := func(v uint32) *uint32 { return &v }
+
+	row := mibIPForwardRow2{
+		InterfaceIndex:    7,
+		DestinationPrefix: ipAddressPrefix{Prefix: sockaddr(net.IPv4zero), PrefixLength: 0},
+		NextHop:           sockaddr(net.ParseIP("192.168.1.1")),
+		Metric:            25,
+	}
+	route, ok := routeFromRow(&row, names)
+	assert.True(t, ok)
+	assert.Equal(t, types.Route{Family: types.FamilyIPv4, Destination: "0.0.0.0/0", Gateway: "192.168.1.1", Interface: "Ethernet", Metric: metric(25)}, route)
+
+	row = mibIPForwardRow2{
+		InterfaceIndex:    7,
+		DestinationPrefix: ipAddressPrefix{Prefix: sockaddr(net.ParseIP("2001:db8::")), PrefixLength: 64},
+		NextHop:           sockaddr(net.IPv6unspecified),
+		Metric:            256,
+	}
+	route, ok = routeFromRow(&row, names)
+	assert.True(t, ok)
+	assert.Equal(t, types.Route{Family: types.FamilyIPv6, Destination: "2001:db8::/64", Interface: "Ethernet", Metric: metric(256)}, route)
+
+	_, ok = routeFromRow(&mibIPForwardRow2{}, names)
+	assert.False(t, ok)
+}
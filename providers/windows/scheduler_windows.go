@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"syscall"
+
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// priorityClasses maps the process priority classes to their names.
+var priorityClasses = map[uint32]string{
+	syswin.IDLE_PRIORITY_CLASS:         types.PriorityClassIdle,
+	syswin.BELOW_NORMAL_PRIORITY_CLASS: types.PriorityClassBelowNormal,
+	syswin.NORMAL_PRIORITY_CLASS:       types.PriorityClassNormal,
+	syswin.ABOVE_NORMAL_PRIORITY_CLASS: types.PriorityClassAboveNormal,
+	syswin.HIGH_PRIORITY_CLASS:         types.PriorityClassHigh,
+	syswin.REALTIME_PRIORITY_CLASS:     types.PriorityClassRealtime,
+}
+
+// priorityClassNice maps the process priority classes to nice values.
+var priorityClassNice = map[uint32]int{
+	syswin.IDLE_PRIORITY_CLASS:         19,
+	syswin.BELOW_NORMAL_PRIORITY_CLASS: 10,
+	syswin.NORMAL_PRIORITY_CLASS:       0,
+	syswin.ABOVE_NORMAL_PRIORITY_CLASS: -10,
+	syswin.HIGH_PRIORITY_CLASS:         -15,
+	syswin.REALTIME_PRIORITY_CLASS:     -20,
+}
+
+// Scheduler reports the priority class of the process and whether the
+// dynamic priority boost of its threads is enabled.
+func (p *process) Scheduler() (*types.SchedulerInfo, error) {
+	handle, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	class, err := syswin.GetPriorityClass(syswin.Handle(handle))
+	if err != nil {
+		return nil, fmt.Errorf("GetPriorityClass failed: %w", err)
+	}
+
+	info := &types.SchedulerInfo{PriorityClass: priorityClassName(class)}
+
+	var disabled int32
+	if err := _GetProcessPriorityBoost(syswin.Handle(handle), &disabled); err == nil {
+		enabled := disabled == 0
+		info.PriorityBoost = &enabled
+	}
+	return info, nil
+}
+
+func priorityClassName(class uint32) string {
+	if name, found := priorityClasses[class]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown(0x%x)", class)
+}
+
+// Nice reports the nice value that corresponds to the priority class of the
+// process.
+func (p *process) Nice() (int, error) {
+	handle, err := p.open()
+	if err != nil {
+		return 0, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	class, err := syswin.GetPriorityClass(syswin.Handle(handle))
+	if err != nil {
+		return 0, fmt.Errorf("GetPriorityClass failed: %w", err)
+	}
+	nice, found := priorityClassNice[class]
+	if !found {
+		return 0, fmt.Errorf("unknown priority class 0x%x", class)
+	}
+	return nice, nil
+}
+
+// SetNice changes the priority class of the process to the one that is
+// closest to the nice value. The realtime class is never selected because
+// its threads can starve the system.
+func (p *process) SetNice(nice int) error {
+	return p.setPriorityClass(nicePriorityClass(nice))
+}
+
+// SetPriorityClass changes the priority class of the process.
+func (p *process) SetPriorityClass(class string) error {
+	for value, name := range priorityClasses {
+		if name == class {
+			return p.setPriorityClass(value)
+		}
+	}
+	return fmt.Errorf("unknown priority class %q", class)
+}
+
+func (p *process) setPriorityClass(class uint32) error {
+	handle := syswin.CurrentProcess()
+	if p.pid != selfPID {
+		var err error
+		handle, err = syswin.OpenProcess(syswin.PROCESS_SET_INFORMATION, false, uint32(p.pid))
+		if err != nil {
+			return fmt.Errorf("OpenProcess failed: %w", err)
+		}
+		defer syswin.CloseHandle(handle)
+	}
+
+	if err := syswin.SetPriorityClass(handle, class); err != nil {
+		return fmt.Errorf("SetPriorityClass failed: %w", err)
+	}
+	return nil
+}
+
+// nicePriorityClass returns the priority class for a nice value.
+func nicePriorityClass(nice int) uint32 {
+	switch {
+	case nice <= -15:
+		return syswin.HIGH_PRIORITY_CLASS
+	case nice < 0:
+		return syswin.ABOVE_NORMAL_PRIORITY_CLASS
+	case nice == 0:
+		return syswin.NORMAL_PRIORITY_CLASS
+	case nice < 15:
+		return syswin.BELOW_NORMAL_PRIORITY_CLASS
+	default:
+		return syswin.IDLE_PRIORITY_CLASS
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Scheduler           = (*process)(nil)
+	_ types.Niceness            = (*process)(nil)
+	_ types.PriorityClassSetter = (*process)(nil)
+)
+
+func TestPriorityClassName(t *testing.T) {
+	assert.Equal(t, "normal", priorityClassName(syswin.NORMAL_PRIORITY_CLASS))
+	assert.Equal(t, "realtime", priorityClassName(syswin.REALTIME_PRIORITY_CLASS))
+	assert.Equal(t, "unknown(0x1)", priorityClassName(1))
+}
+
+func TestNicePriorityClass(t *testing.T) {
+	// Each nice value of a priority class maps back to the class.
+	for class, nice := range priorityClassNice {
+		if class == syswin.REALTIME_PRIORITY_CLASS {
+			assert.EqualValues(t, syswin.HIGH_PRIORITY_CLASS, nicePriorityClass(nice))
+			continue
+		}
+		assert.EqualValues(t, class, nicePriorityClass(nice), priorityClassName(class))
+	}
+	assert.EqualValues(t, syswin.BELOW_NORMAL_PRIORITY_CLASS, nicePriorityClass(5))
+	assert.EqualValues(t, syswin.ABOVE_NORMAL_PRIORITY_CLASS, nicePriorityClass(-1))
+}
+
+func TestSelfSetPriorityClass(t *testing.T) {
+	self, err := newProcess(selfPID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := self.Scheduler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Setting the current class does not require privileges.
+	if err := self.SetPriorityClass(info.PriorityClass); err != nil {
+		t.Fatal(err)
+	}
+
+	nice, err := self.Nice()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := self.SetNice(nice); err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, self.SetPriorityClass("bogus"))
+}
+
+func TestSelfScheduler(t *testing.T) {
+	self, err := newProcess(selfPID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := self.Scheduler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, info.PriorityClass)
+	assert.NotNil(t, info.PriorityBoost)
+}
@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+const (
+	defenderKey       = `SOFTWARE\Microsoft\Windows Defender`
+	defenderPolicyKey = `SOFTWARE\Policies\Microsoft\Windows Defender`
+)
+
+// securityHealth maps the WSC_SECURITY_PROVIDER_HEALTH values to the
+// types.SecurityHealth values.
+var securityHealth = map[uint32]string{
+	0: types.SecurityHealthGood,
+	1: types.SecurityHealthNotMonitored,
+	2: types.SecurityHealthPoor,
+	3: types.SecurityHealthSnoozed,
+}
+
+// bitLockerStatuses maps the values of the System.Volume.BitLockerProtection
+// shell property to the types.BitLocker values.
+var bitLockerStatuses = map[int32]string{
+	1: types.BitLockerOn,
+	2: types.BitLockerOff,
+	3: types.BitLockerEncrypting,
+	4: types.BitLockerDecrypting,
+	5: types.BitLockerSuspended,
+	6: types.BitLockerLocked,
+	8: types.BitLockerWaitingActivation,
+}
+
+// WindowsSecurity reports the antivirus and firewall health from Windows
+// Security Center, the state of Microsoft Defender Antivirus from its
+// service and registry settings, and the BitLocker status of the fixed
+// volumes from the shell property that Explorer shows. None of them require
+// administrator privileges.
+func (h *host) WindowsSecurity() (*types.WindowsSecurityInfo, error) {
+	info := &types.WindowsSecurityInfo{
+		Defender: defenderStatus(),
+	}
+
+	var health uint32
+	if err := _WscGetSecurityProviderHealth(wscSecurityProviderAntivirus, &health); err == nil {
+		info.AntivirusHealth = securityHealth[health]
+	}
+	if err := _WscGetSecurityProviderHealth(wscSecurityProviderFirewall, &health); err == nil {
+		info.FirewallHealth = securityHealth[health]
+	}
+
+	volumes, err := bitLockerVolumes()
+	if err != nil {
+		return nil, err
+	}
+	info.BitLocker = volumes
+	return info, nil
+}
+
+// defenderStatus returns the state of Microsoft Defender Antivirus or nil if
+// its service is not installed. Real-time monitoring can be disabled by
+// policy or in the settings of Defender.
+func defenderStatus() *types.DefenderStatus {
+	running, err := serviceRunning("WinDefend")
+	if err != nil {
+		return nil
+	}
+
+	status := &types.DefenderStatus{Running: running}
+	status.SignatureVersion, _ = localMachineString(defenderKey+`\Signature Updates`, "AVSignatureVersion")
+
+	disabled := registryBool(defenderPolicyKey+`\Real-Time Protection`, "DisableRealtimeMonitoring")
+	if disabled == nil {
+		disabled = registryBool(defenderKey+`\Real-Time Protection`, "DisableRealtimeMonitoring")
+	}
+	status.RealTimeProtection = running && (disabled == nil || !*disabled)
+	return status
+}
+
+// serviceRunning returns whether a service is running. It returns an error
+// if the service does not exist.
+func serviceRunning(name string) (bool, error) {
+	mgr, err := windows.OpenSCManager(nil, nil, windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return false, err
+	}
+	defer windows.CloseServiceHandle(mgr)
+
+	service, err := windows.OpenService(mgr, windows.StringToUTF16Ptr(name), windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		return false, err
+	}
+	defer windows.CloseServiceHandle(service)
+
+	var status windows.SERVICE_STATUS
+	if err := windows.QueryServiceStatus(service, &status); err != nil {
+		return false, err
+	}
+	return status.CurrentState == windows.SERVICE_RUNNING, nil
+}
+
+// bitLockerVolumes returns the BitLocker status of the fixed volumes with a
+// drive letter. Volumes that cannot be encrypted are omitted.
+func bitLockerVolumes() ([]types.BitLockerVolume, error) {
+	// COM is initialized per thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	switch err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); {
+	case err == nil, errors.Is(err, windows.Errno(windows.S_FALSE)):
+		defer windows.CoUninitialize()
+	case errors.Is(err, windows.Errno(windows.RPC_E_CHANGED_MODE)):
+		// COM is already initialized as single-threaded on this thread.
+	default:
+		return nil, err
+	}
+
+	var key propertyKey
+	if err := _PSGetPropertyKeyFromName(windows.StringToUTF16Ptr("System.Volume.BitLockerProtection"), &key); err != nil {
+		// The property is not known to this version of Windows.
+		return nil, nil
+	}
+
+	drives, err := fixedDrives()
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []types.BitLockerVolume
+	for _, drive := range drives {
+		value, ok := shellPropertyInt32(drive+`\`, &key)
+		if !ok || value == 0 {
+			continue
+		}
+		status, found := bitLockerStatuses[value]
+		if !found {
+			status = types.BitLockerUnknown
+		}
+		volumes = append(volumes, types.BitLockerVolume{Volume: drive, Status: status})
+	}
+	return volumes, nil
+}
+
+// fixedDrives returns the drive letters of the fixed volumes (e.g. C:).
+func fixedDrives() ([]string, error) {
+	buf := make([]uint16, 256)
+	n, err := windows.GetLogicalDriveStrings(uint32(len(buf)), &buf[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var drives []string
+	for _, root := range splitMultiString(buf[:n]) {
+		if windows.GetDriveType(windows.StringToUTF16Ptr(root)) == windows.DRIVE_FIXED {
+			drives = append(drives, root[:2])
+		}
+	}
+	return drives, nil
+}
+
+// splitMultiString splits a list of NUL terminated strings.
+func splitMultiString(buf []uint16) []string {
+	var strs []string
+	for start, i := 0, 0; i < len(buf); i++ {
+		if buf[i] == 0 {
+			if i > start {
+				strs = append(strs, windows.UTF16ToString(buf[start:i]))
+			}
+			start = i + 1
+		}
+	}
+	return strs
+}
+
+// shellPropertyInt32 reads a VT_I4 or VT_UI4 property of a shell item through
+// its IPropertyStore.
+func shellPropertyInt32(path string, key *propertyKey) (int32, bool) {
+	const (
+		vtI4  = 3
+		vtUI4 = 19
+	)
+
+	var store unsafe.Pointer
+	if err := _SHGetPropertyStoreFromParsingName(windows.StringToUTF16Ptr(path), &iidIPropertyStore, &store); err != nil {
+		return 0, false
+	}
+	// IPropertyStore methods: QueryInterface, AddRef, Release, GetCount,
+	// GetAt, GetValue, SetValue, Commit.
+	vtbl := *(*[8]uintptr)(*(*unsafe.Pointer)(store))
+	defer syscall.SyscallN(vtbl[2], uintptr(store))
+
+	var v propVariant
+	if r0, _, _ := syscall.SyscallN(vtbl[5], uintptr(store), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(&v))); hresultError(r0) != nil {
+		return 0, false
+	}
+	defer _PropVariantClear(&v)
+
+	if v.VT != vtI4 && v.VT != vtUI4 {
+		return 0, false
+	}
+	return int32(v.Val[0]), true
+}
@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.WindowsSecurity = (*host)(nil)
+
+func TestPropVariantSize(t *testing.T) {
+	assert.EqualValues(t, 20, unsafe.Sizeof(propertyKey{}))
+	assert.GreaterOrEqual(t, unsafe.Sizeof(propVariant{}), uintptr(16+unsafe.Sizeof(uintptr(0))))
+}
+
+func TestSplitMultiString(t *testing.T) {
+	buf := windows.StringToUTF16("C:\\\x00D:\\\x00")
+	assert.Equal(t, []string{`C:\`, `D:\`}, splitMultiString(buf))
+	assert.Empty(t, splitMultiString([]uint16{0}))
+}
+
+func TestWindowsSecurity(t *testing.T) {
+	info, err := (&host{}).WindowsSecurity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range info.BitLocker {
+		assert.NotEmpty(t, v.Status, v.Volume)
+	}
+	t.Logf("%+v", info)
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Sessions reports the Remote Desktop Services sessions that have a user
+// logged in, including the console session.
+func (h *host) Sessions() ([]types.SessionInfo, error) {
+	var infos *windows.WTS_SESSION_INFO
+	var count uint32
+	if err := windows.WTSEnumerateSessions(0, 0, 1, &infos, &count); err != nil {
+		return nil, fmt.Errorf("WTSEnumerateSessions failed: %w", err)
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(infos)))
+
+	var sessions []types.SessionInfo
+	for _, info := range unsafe.Slice(infos, count) {
+		s, err := querySession(info.SessionID)
+		if err != nil || s.User == "" {
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+	return sessions, nil
+}
+
+// Users reports the distinct names of the users with a login session.
+func (h *host) Users() ([]string, error) {
+	s, err := h.Sessions()
+	if err != nil {
+		return nil, err
+	}
+	return shared.SessionUsers(s), nil
+}
+
+func querySession(id uint32) (*types.SessionInfo, error) {
+	var buf *byte
+	var size uint32
+	if err := _WTSQuerySessionInformation(id, wtsSessionInfo, &buf, &size); err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	if uintptr(size) < unsafe.Sizeof(wtsInfo{}) {
+		return nil, fmt.Errorf("WTSINFO size %d is too small", size)
+	}
+	info := (*wtsInfo)(unsafe.Pointer(buf))
+
+	s := &types.SessionInfo{
+		ID:       fmt.Sprint(id),
+		User:     windows.UTF16ToString(info.UserName[:]),
+		Terminal: windows.UTF16ToString(info.WinStationName[:]),
+	}
+	if domain := windows.UTF16ToString(info.Domain[:]); domain != "" && s.User != "" {
+		s.User = domain + `\` + s.User
+	}
+	if info.LogonTime > 0 {
+		ft := windows.Filetime{
+			LowDateTime:  uint32(info.LogonTime),
+			HighDateTime: uint32(info.LogonTime >> 32),
+		}
+		s.LoginTime = time.Unix(0, ft.Nanoseconds()).UTC()
+	}
+
+	// The client name is only set for remote sessions.
+	if err := _WTSQuerySessionInformation(id, wtsClientName, &buf, &size); err == nil {
+		if size >= 2 {
+			s.RemoteHost = windows.UTF16ToString(unsafe.Slice((*uint16)(unsafe.Pointer(buf)), size/2))
+		}
+		windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	}
+	return s, nil
+}
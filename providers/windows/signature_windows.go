@@ -0,0 +1,220 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// SignatureInfo verifies the Authenticode signature of the executable of
+// the process. Executables without an embedded signature, like most of those
+// that ship with Windows, are looked up in the security catalogs. Revocation
+// is only checked against cached revocation lists to avoid network access.
+func (p *process) SignatureInfo() (*types.SignatureInfo, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	return verifySignature(info.Exe)
+}
+
+func verifySignature(path string) (*types.SignatureInfo, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &types.SignatureInfo{Path: path}
+	trustErr := verifyTrust(windows.WTD_CHOICE_FILE, unsafe.Pointer(&windows.WinTrustFileInfo{
+		Size:     uint32(unsafe.Sizeof(windows.WinTrustFileInfo{})),
+		FilePath: pathPtr,
+	}), sig)
+
+	if isUnsigned(trustErr) {
+		var found bool
+		found, trustErr, err = verifyCatalogSignature(pathPtr, sig)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			sig.Status = types.SignatureStatusUnsigned
+			return sig, nil
+		}
+		sig.Catalog = true
+	}
+
+	sig.Signed = true
+	sig.Status = signatureStatus(trustErr)
+	return sig, nil
+}
+
+// verifyCatalogSignature verifies the signature of the security catalog that
+// contains the hash of the file. It reports whether a catalog was found.
+func verifyCatalogSignature(path *uint16, sig *types.SignatureInfo) (found bool, trustErr, err error) {
+	file, err := windows.CreateFile(path, windows.GENERIC_READ, windows.FILE_SHARE_READ|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return false, nil, fmt.Errorf("CreateFile failed: %w", err)
+	}
+	defer windows.CloseHandle(file)
+
+	// Catalogs contain SHA-256 hashes since Windows 8 and SHA-1 hashes
+	// before.
+	for _, alg := range []string{"SHA256", "SHA1"} {
+		found, trustErr, err = verifyCatalogHash(file, path, alg, sig)
+		if err != nil || found {
+			return found, trustErr, err
+		}
+	}
+	return false, nil, nil
+}
+
+func verifyCatalogHash(file windows.Handle, path *uint16, alg string, sig *types.SignatureInfo) (found bool, trustErr, err error) {
+	var catAdmin windows.Handle
+	if err := _CryptCATAdminAcquireContext2(&catAdmin, alg); err != nil {
+		return false, nil, fmt.Errorf("CryptCATAdminAcquireContext2 failed: %w", err)
+	}
+	defer _CryptCATAdminReleaseContext(catAdmin)
+
+	if _, err := windows.Seek(file, 0, 0); err != nil {
+		return false, nil, err
+	}
+	hash, err := _CryptCATAdminCalcHashFromFileHandle2(catAdmin, file, make([]byte, 64))
+	if err != nil {
+		// Files that are not in a supported format cannot be hashed.
+		return false, nil, nil
+	}
+
+	catInfo := _CryptCATAdminEnumCatalogFromHash(catAdmin, hash)
+	if catInfo == 0 {
+		return false, nil, nil
+	}
+	defer _CryptCATAdminReleaseCatalogContext(catAdmin, catInfo)
+
+	info := catalogInfo{Size: uint32(unsafe.Sizeof(catalogInfo{}))}
+	if err := _CryptCATCatalogInfoFromContext(catInfo, &info); err != nil {
+		return false, nil, fmt.Errorf("CryptCATCatalogInfoFromContext failed: %w", err)
+	}
+
+	tag, err := windows.UTF16PtrFromString(strings.ToUpper(fmt.Sprintf("%x", hash)))
+	if err != nil {
+		return false, nil, err
+	}
+	trustErr = verifyTrust(windows.WTD_CHOICE_CATALOG, unsafe.Pointer(&wintrustCatalogInfo{
+		Size:                   uint32(unsafe.Sizeof(wintrustCatalogInfo{})),
+		CatalogFilePath:        &info.CatalogFile[0],
+		MemberTag:              tag,
+		MemberFilePath:         path,
+		MemberFile:             file,
+		CalculatedFileHash:     &hash[0],
+		CalculatedFileHashSize: uint32(len(hash)),
+		CatAdmin:               catAdmin,
+	}), sig)
+	return true, trustErr, nil
+}
+
+// verifyTrust calls WinVerifyTrust with the WINTRUST_ACTION_GENERIC_VERIFY_V2
+// policy and sets the subject and issuer of sig from the signing
+// certificate. It returns the trust error.
+func verifyTrust(choice uint32, object unsafe.Pointer, sig *types.SignatureInfo) error {
+	data := &windows.WinTrustData{
+		Size:                            uint32(unsafe.Sizeof(windows.WinTrustData{})),
+		UIChoice:                        windows.WTD_UI_NONE,
+		RevocationChecks:                windows.WTD_REVOKE_WHOLECHAIN,
+		UnionChoice:                     choice,
+		FileOrCatalogOrBlobOrSgnrOrCert: object,
+		StateAction:                     windows.WTD_STATEACTION_VERIFY,
+		ProvFlags:                       windows.WTD_CACHE_ONLY_URL_RETRIEVAL,
+	}
+	trustErr := windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+
+	if data.StateData != 0 {
+		if provData := _WTHelperProvDataFromStateData(data.StateData); provData != 0 {
+			if signer := _WTHelperGetProvSignerFromChain(provData); signer != nil && signer.CertChainCount > 0 {
+				cert := signer.CertChain.Cert
+				sig.Subject = certName(cert, 0)
+				sig.Issuer = certName(cert, windows.CERT_NAME_ISSUER_FLAG)
+			}
+		}
+	}
+
+	data.StateAction = windows.WTD_STATEACTION_CLOSE
+	windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+	return trustErr
+}
+
+// certName returns the display name of the subject or issuer of a
+// certificate.
+func certName(cert *windows.CertContext, flags uint32) string {
+	if cert == nil {
+		return ""
+	}
+	n := windows.CertGetNameString(cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, flags, nil, nil, 0)
+	if n <= 1 {
+		return ""
+	}
+	buf := make([]uint16, n)
+	windows.CertGetNameString(cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, flags, nil, &buf[0], n)
+	return windows.UTF16ToString(buf)
+}
+
+// isUnsigned reports whether a trust error means that the file has no
+// embedded signature.
+func isUnsigned(trustErr error) bool {
+	var errno windows.Errno
+	if !errors.As(trustErr, &errno) {
+		return false
+	}
+	switch windows.Handle(errno) {
+	case windows.TRUST_E_NOSIGNATURE, windows.TRUST_E_SUBJECT_FORM_UNKNOWN, windows.TRUST_E_PROVIDER_UNKNOWN:
+		return true
+	}
+	return false
+}
+
+// signatureStatus returns the SignatureStatus for the result of
+// WinVerifyTrust.
+func signatureStatus(trustErr error) string {
+	if trustErr == nil {
+		return types.SignatureStatusValid
+	}
+	var errno windows.Errno
+	if !errors.As(trustErr, &errno) {
+		return types.SignatureStatusUnknown
+	}
+	switch windows.Handle(errno) {
+	case windows.TRUST_E_BAD_DIGEST, windows.TRUST_E_NOSIGNATURE, windows.CRYPT_E_SECURITY_SETTINGS:
+		return types.SignatureStatusInvalid
+	case windows.CERT_E_UNTRUSTEDROOT, windows.CERT_E_CHAINING, windows.TRUST_E_EXPLICIT_DISTRUST,
+		windows.CERT_E_UNTRUSTEDTESTROOT, windows.TRUST_E_SUBJECT_NOT_TRUSTED:
+		return types.SignatureStatusUntrusted
+	case windows.CERT_E_EXPIRED:
+		return types.SignatureStatusExpired
+	case windows.CERT_E_REVOKED:
+		return types.SignatureStatusRevoked
+	default:
+		return types.SignatureStatusUnknown
+	}
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.CodeSignature = (*process)(nil)
+
+func TestSignatureStatus(t *testing.T) {
+	assert.Equal(t, types.SignatureStatusValid, signatureStatus(nil))
+	assert.Equal(t, types.SignatureStatusInvalid, signatureStatus(syswin.Errno(syswin.TRUST_E_BAD_DIGEST)))
+	assert.Equal(t, types.SignatureStatusUntrusted, signatureStatus(syswin.Errno(syswin.CERT_E_UNTRUSTEDROOT)))
+	assert.Equal(t, types.SignatureStatusExpired, signatureStatus(syswin.Errno(syswin.CERT_E_EXPIRED)))
+	assert.Equal(t, types.SignatureStatusRevoked, signatureStatus(syswin.Errno(syswin.CERT_E_REVOKED)))
+	assert.Equal(t, types.SignatureStatusUnknown, signatureStatus(syswin.Errno(1)))
+
+	assert.True(t, isUnsigned(syswin.Errno(syswin.TRUST_E_NOSIGNATURE)))
+	assert.False(t, isUnsigned(syswin.Errno(syswin.TRUST_E_BAD_DIGEST)))
+	assert.False(t, isUnsigned(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	// The executables of Windows are signed by Microsoft, either embedded
+	// or through a catalog.
+	sig, err := verifySignature(filepath.Join(os.Getenv("SystemRoot"), "System32", "notepad.exe"))
+	require.NoError(t, err)
+	assert.True(t, sig.Signed)
+	assert.Equal(t, types.SignatureStatusValid, sig.Status)
+	assert.Contains(t, sig.Subject, "Microsoft")
+	assert.NotEmpty(t, sig.Issuer)
+
+	unsigned := filepath.Join(t.TempDir(), "unsigned.exe")
+	require.NoError(t, ioutil.WriteFile(unsigned, []byte("MZ"), 0o644))
+	sig, err = verifySignature(unsigned)
+	require.NoError(t, err)
+	assert.False(t, sig.Signed)
+	assert.Equal(t, types.SignatureStatusUnsigned, sig.Status)
+}
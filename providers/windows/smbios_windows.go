@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+)
+
+// rsmbProvider is the 'RSMB' firmware table provider signature.
+const rsmbProvider = 'R'<<24 | 'S'<<16 | 'M'<<8 | 'B'
+
+// smbiosTable holds the parsed SMBIOS structures and the SMBIOS version.
+type smbiosTable struct {
+	Major, Minor uint8
+	Structures   []shared.SMBIOSStructure
+}
+
+// readSMBIOS reads the raw SMBIOS table using GetSystemFirmwareTable.
+func readSMBIOS() (*smbiosTable, error) {
+	size, err := _GetSystemFirmwareTable(rsmbProvider, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetSystemFirmwareTable failed: %w", err)
+	}
+
+	buf := make([]byte, size)
+	if _, err = _GetSystemFirmwareTable(rsmbProvider, 0, buf); err != nil {
+		return nil, fmt.Errorf("GetSystemFirmwareTable failed: %w", err)
+	}
+	return parseRawSMBIOSData(buf)
+}
+
+// parseRawSMBIOSData parses the RawSMBIOSData structure returned by the
+// RSMB firmware table provider.
+func parseRawSMBIOSData(buf []byte) (*smbiosTable, error) {
+	// Used20CallingMethod, SMBIOSMajorVersion, SMBIOSMinorVersion,
+	// DmiRevision, Length.
+	const headerSize = 8
+	if len(buf) < headerSize {
+		return nil, errors.New("RawSMBIOSData is too short")
+	}
+	length := binary.LittleEndian.Uint32(buf[4:8])
+	if uint64(length) > uint64(len(buf)-headerSize) {
+		return nil, fmt.Errorf("RawSMBIOSData length %d exceeds buffer size", length)
+	}
+
+	structures, err := shared.ParseSMBIOS(buf[headerSize : headerSize+int(length)])
+	if err != nil {
+		return nil, err
+	}
+	return &smbiosTable{Major: buf[1], Minor: buf[2], Structures: structures}, nil
+}
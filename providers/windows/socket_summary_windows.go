@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// tcpStates maps the MIB_TCP_STATE values to the types.TCPState values.
+var tcpStates = map[uint32]string{
+	1:  types.TCPStateClose,
+	2:  types.TCPStateListen,
+	3:  types.TCPStateSynSent,
+	4:  types.TCPStateSynRecv,
+	5:  types.TCPStateEstablished,
+	6:  types.TCPStateFinWait1,
+	7:  types.TCPStateFinWait2,
+	8:  types.TCPStateCloseWait,
+	9:  types.TCPStateClosing,
+	10: types.TCPStateLastAck,
+	11: types.TCPStateTimeWait,
+}
+
+// SocketSummary reports the number of TCP connections from
+// GetTcpStatisticsEx and the number of UDP sockets from GetUdpStatisticsEx.
+func (h *host) SocketSummary() (*types.SocketSummaryInfo, error) {
+	info := &types.SocketSummaryInfo{}
+	for _, family := range []uint32{windows.AF_INET, windows.AF_INET6} {
+		var tcp mibTCPStats
+		if err := _GetTcpStatisticsEx(&tcp, family); err != nil {
+			if family == windows.AF_INET6 && errors.Is(err, windows.ERROR_NOT_SUPPORTED) {
+				// IPv6 is not installed.
+				continue
+			}
+			return nil, fmt.Errorf("GetTcpStatisticsEx failed: %w", err)
+		}
+		info.TCPInUse += int(tcp.NumConns)
+		info.TCPEstablished += int(tcp.CurrEstab)
+
+		var udp mibUDPStats
+		if err := _GetUdpStatisticsEx(&udp, family); err != nil {
+			return nil, fmt.Errorf("GetUdpStatisticsEx failed: %w", err)
+		}
+		info.UDPInUse += int(udp.NumAddrs)
+	}
+	return info, nil
+}
+
+// TCPStates counts the TCP sockets by state from the TCP tables of
+// GetExtendedTcpTable.
+func (h *host) TCPStates() (map[string]int, error) {
+	states := map[string]int{}
+	for _, family := range []uint32{windows.AF_INET, windows.AF_INET6} {
+		table, err := extendedTable(_GetExtendedTcpTable, family, tcpTableOwnerPIDAll)
+		if err != nil {
+			if family == windows.AF_INET6 && errors.Is(err, windows.ERROR_NOT_SUPPORTED) {
+				// IPv6 is not installed.
+				continue
+			}
+			return nil, fmt.Errorf("GetExtendedTcpTable failed: %w", err)
+		}
+		countTCPStates(states, table, family)
+	}
+	return states, nil
+}
+
+// countTCPStates adds the number of sockets by state of a
+// MIB_TCPTABLE_OWNER_PID or MIB_TCP6TABLE_OWNER_PID table to states.
+func countTCPStates(states map[string]int, table []byte, family uint32) {
+	count := func(state uint32) {
+		if name, found := tcpStates[state]; found {
+			states[name]++
+		}
+	}
+
+	if family == windows.AF_INET6 {
+		p, n := tableRows(table, unsafe.Sizeof(mibTCP6RowOwnerPID{}))
+		for _, r := range unsafe.Slice((*mibTCP6RowOwnerPID)(p), n) {
+			count(r.State)
+		}
+		return
+	}
+	p, n := tableRows(table, unsafe.Sizeof(mibTCPRowOwnerPID{}))
+	for _, r := range unsafe.Slice((*mibTCPRowOwnerPID)(p), n) {
+		count(r.State)
+	}
+}
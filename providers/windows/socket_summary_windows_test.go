@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.SocketSummary   = (*host)(nil)
+	_ types.TCPStateCounter = (*host)(nil)
+)
+
+func TestCountTCPStates(t *testing.T) {
+	table := make([]byte, 4+3*unsafe.Sizeof(mibTCP6RowOwnerPID{}))
+	*(*uint32)(unsafe.Pointer(&table[0])) = 3
+	rows := unsafe.Slice((*mibTCP6RowOwnerPID)(unsafe.Pointer(&table[4])), 3)
+	rows[0].State = 2
+	rows[1].State = 5
+	rows[2].State = 5
+
+	states := map[string]int{types.TCPStateEstablished: 1}
+	countTCPStates(states, table, windows.AF_INET6)
+	assert.Equal(t, map[string]int{types.TCPStateListen: 1, types.TCPStateEstablished: 3}, states)
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// resumeQuery selects the events that the kernel logs when the system
+// resumes from sleep. It must be formatted with the boot time.
+const resumeQuery = "*[System[Provider[@Name='Microsoft-Windows-Kernel-Power'] and EventID=107 and TimeCreated[@SystemTime>='%s']]]"
+
+// SuspendInfo reports the time spent in sleep or hibernation since boot as
+// the difference between the tick count and the unbiased interrupt time,
+// which does not advance while the system is suspended. The number of
+// suspends is counted from the resume events in the System event log.
+func (h *host) SuspendInfo() (*types.SuspendInfo, error) {
+	var unbiased uint64
+	if err := _QueryUnbiasedInterruptTime(&unbiased); err != nil {
+		return nil, fmt.Errorf("QueryUnbiasedInterruptTime failed: %w", err)
+	}
+	sinceBoot := windows.DurationSinceBoot()
+
+	// The interrupt time is in 100 nanosecond intervals.
+	info := &types.SuspendInfo{Active: shared.FiletimeToDuration(unbiased)}
+	if sinceBoot > info.Active {
+		info.Suspended = sinceBoot - info.Active
+	}
+
+	bootTime := time.Now().Add(-sinceBoot).UTC()
+	if count, err := countEvents("System", fmt.Sprintf(resumeQuery, bootTime.Format(time.RFC3339Nano))); err == nil {
+		info.SuspendCount = &count
+	}
+	return info, nil
+}
+
+// countEvents returns the number of events in the channel that match the
+// query.
+func countEvents(channel, query string) (uint64, error) {
+	result, err := _EvtQuery(channel, query, evtQueryChannelPath)
+	if err != nil {
+		return 0, fmt.Errorf("EvtQuery failed: %w", err)
+	}
+	defer _EvtClose(result)
+
+	var count uint64
+	events := make([]windows.Handle, 64)
+	for {
+		var returned uint32
+		if err := _EvtNext(result, events, &returned); err != nil {
+			if errors.Is(err, windows.ERROR_NO_MORE_ITEMS) {
+				return count, nil
+			}
+			return 0, fmt.Errorf("EvtNext failed: %w", err)
+		}
+		for _, e := range events[:returned] {
+			_EvtClose(e)
+		}
+		count += uint64(returned)
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	syswin "golang.org/x/sys/windows"
+
+	windows "github.com/elastic/go-windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Offsets of the paging counters in SYSTEM_PERFORMANCE_INFORMATION. These are
+// the counters behind the Pages Input/sec and Pages Output/sec performance
+// counters, whose sum is Pages/sec.
+const (
+	perfPageReadCountOffset         = 80
+	perfDirtyPagesWriteCountOffset  = 96
+	perfMappedPagesWriteCountOffset = 104
+)
+
+// systemPagefileInformation is the SYSTEM_PAGEFILE_INFORMATION structure.
+// The sizes are in pages.
+type systemPagefileInformation struct {
+	NextEntryOffset uint32
+	TotalSize       uint32
+	TotalInUse      uint32
+	PeakUsage       uint32
+	PageFileName    syswin.NTUnicodeString
+}
+
+// SwapInfo reports the page files and the number of pages read from and
+// written to disk to resolve page faults. The counters are 32-bit and wrap
+// around.
+func (h *host) SwapInfo() (*types.SwapInfo, error) {
+	sysInfo, err := windows.GetNativeSystemInfo()
+	if err != nil {
+		return nil, fmt.Errorf("GetNativeSystemInfo failed: %w", err)
+	}
+	pageSize := uint64(sysInfo.PageSize)
+
+	buf, err := querySystemInformation(syswin.SystemPageFileInformation, 4096)
+	if err != nil {
+		return nil, err
+	}
+	info := &types.SwapInfo{
+		Devices:  pagefiles(buf, pageSize),
+		PageSize: pageSize,
+	}
+
+	perf, err := querySystemInformation(syswin.SystemPerformanceInformation, 512)
+	if err != nil {
+		return nil, err
+	}
+	info.PagesIn, info.PagesOut, err = pagingCounters(perf)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// pagefiles returns the page files in a SystemPageFileInformation buffer.
+func pagefiles(buf []byte, pageSize uint64) []types.SwapDevice {
+	const entrySize = int(unsafe.Sizeof(systemPagefileInformation{}))
+
+	devices := []types.SwapDevice{}
+	for offset := 0; offset+entrySize <= len(buf); {
+		pf := (*systemPagefileInformation)(unsafe.Pointer(&buf[offset]))
+		devices = append(devices, types.SwapDevice{
+			// The names are NT paths (e.g. \??\C:\pagefile.sys).
+			Name:  strings.TrimPrefix(pf.PageFileName.String(), `\??\`),
+			Type:  "file",
+			Total: uint64(pf.TotalSize) * pageSize,
+			Used:  uint64(pf.TotalInUse) * pageSize,
+		})
+
+		if pf.NextEntryOffset == 0 {
+			break
+		}
+		offset += int(pf.NextEntryOffset)
+	}
+	return devices
+}
+
+// pagingCounters returns the pages read and written for paging from a
+// SYSTEM_PERFORMANCE_INFORMATION buffer.
+func pagingCounters(perf []byte) (in, out uint64, err error) {
+	if len(perf) < perfMappedPagesWriteCountOffset+4 {
+		return 0, 0, fmt.Errorf("SYSTEM_PERFORMANCE_INFORMATION is too short (%d bytes)", len(perf))
+	}
+	in = uint64(binary.LittleEndian.Uint32(perf[perfPageReadCountOffset:]))
+	out = uint64(binary.LittleEndian.Uint32(perf[perfDirtyPagesWriteCountOffset:])) +
+		uint64(binary.LittleEndian.Uint32(perf[perfMappedPagesWriteCountOffset:]))
+	return in, out, nil
+}
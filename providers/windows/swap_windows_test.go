@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	syswin "golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.Swap = (*host)(nil)
+
+func TestPagefiles(t *testing.T) {
+	const entrySize = int(unsafe.Sizeof(systemPagefileInformation{}))
+
+	names := []string{`\??\C:\pagefile.sys`, `\??\D:\pagefile.sys`}
+	buf := make([]byte, len(names)*entrySize)
+	for i, name := range names {
+		s, err := syswin.NewNTUnicodeString(name)
+		require.NoError(t, err)
+
+		pf := (*systemPagefileInformation)(unsafe.Pointer(&buf[i*entrySize]))
+		pf.TotalSize = uint32(1024 * (i + 1))
+		pf.TotalInUse = uint32(10 * (i + 1))
+		pf.PageFileName = *s
+		if i < len(names)-1 {
+			pf.NextEntryOffset = uint32(entrySize)
+		}
+	}
+
+	assert.Equal(t, []types.SwapDevice{
+		{Name: `C:\pagefile.sys`, Type: "file", Total: 4 << 20, Used: 40960},
+		{Name: `D:\pagefile.sys`, Type: "file", Total: 8 << 20, Used: 81920},
+	}, pagefiles(buf, 4096))
+
+	assert.Empty(t, pagefiles(nil, 4096))
+}
+
+func TestPagingCounters(t *testing.T) {
+	perf := make([]byte, 312)
+	binary.LittleEndian.PutUint32(perf[perfPageReadCountOffset:], 100)
+	binary.LittleEndian.PutUint32(perf[perfDirtyPagesWriteCountOffset:], 20)
+	binary.LittleEndian.PutUint32(perf[perfMappedPagesWriteCountOffset:], 3)
+
+	in, out, err := pagingCounters(perf)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, in)
+	assert.EqualValues(t, 23, out)
+
+	_, _, err = pagingCounters(perf[:64])
+	assert.Error(t, err)
+}
@@ -0,0 +1,1198 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modadvapi32 = windows.NewLazySystemDLL("advapi32.dll")
+	moddxgi     = windows.NewLazySystemDLL("dxgi.dll")
+	modiphlpapi = windows.NewLazySystemDLL("iphlpapi.dll")
+	modkernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	modmsi      = windows.NewLazySystemDLL("msi.dll")
+	modnetapi32 = windows.NewLazySystemDLL("netapi32.dll")
+	modntdll    = windows.NewLazySystemDLL("ntdll.dll")
+	modole32    = windows.NewLazySystemDLL("ole32.dll")
+	modpdh      = windows.NewLazySystemDLL("pdh.dll")
+	modpropsys  = windows.NewLazySystemDLL("propsys.dll")
+	modpsapi    = windows.NewLazySystemDLL("psapi.dll")
+	modshell32  = windows.NewLazySystemDLL("shell32.dll")
+	modtbs      = windows.NewLazySystemDLL("tbs.dll")
+	moduser32   = windows.NewLazySystemDLL("user32.dll")
+	modw32time  = windows.NewLazySystemDLL("w32time.dll")
+	modwevtapi  = windows.NewLazySystemDLL("wevtapi.dll")
+	modwintrust = windows.NewLazySystemDLL("wintrust.dll")
+	modwlanapi  = windows.NewLazySystemDLL("wlanapi.dll")
+	modwscapi   = windows.NewLazySystemDLL("wscapi.dll")
+	modwtsapi32 = windows.NewLazySystemDLL("wtsapi32.dll")
+
+	procLookupPrivilegeName    = modadvapi32.NewProc("LookupPrivilegeNameW")
+	procFreeMibTable           = modiphlpapi.NewProc("FreeMibTable")
+	procGetExtendedTcpTable    = modiphlpapi.NewProc("GetExtendedTcpTable")
+	procGetExtendedUdpTable    = modiphlpapi.NewProc("GetExtendedUdpTable")
+	procGetIpForwardTable2     = modiphlpapi.NewProc("GetIpForwardTable2")
+	procGetIpNetTable2         = modiphlpapi.NewProc("GetIpNetTable2")
+	procGetTcpStatisticsEx     = modiphlpapi.NewProc("GetTcpStatisticsEx")
+	procGetUdpStatisticsEx     = modiphlpapi.NewProc("GetUdpStatisticsEx")
+	procGetFirmwareType        = modkernel32.NewProc("GetFirmwareType")
+	procGetNumaHighestNode     = modkernel32.NewProc("GetNumaHighestNodeNumber")
+	procGetNumaNodeProcMaskEx  = modkernel32.NewProc("GetNumaNodeProcessorMaskEx")
+	procGetNumaAvailMemNodeEx  = modkernel32.NewProc("GetNumaAvailableMemoryNodeEx")
+	procGetSystemFirmwareTable = modkernel32.NewProc("GetSystemFirmwareTable")
+	procGetProcessAffinityMask = modkernel32.NewProc("GetProcessAffinityMask")
+	procGetProcessPrioBoost    = modkernel32.NewProc("GetProcessPriorityBoost")
+	procSetProcessAffinityMask = modkernel32.NewProc("SetProcessAffinityMask")
+	procGetTickCount           = modkernel32.NewProc("GetTickCount")
+	procQueryUnbiasedIntTime   = modkernel32.NewProc("QueryUnbiasedInterruptTime")
+	procTbsiContextCreate      = modtbs.NewProc("Tbsi_Context_Create")
+	procTbsiGetDeviceInfo      = modtbs.NewProc("Tbsi_GetDeviceInfo")
+	procTbsipContextClose      = modtbs.NewProc("Tbsip_Context_Close")
+	procTbsipSubmitCommand     = modtbs.NewProc("Tbsip_Submit_Command")
+	procMsiEnumProducts        = modmsi.NewProc("MsiEnumProductsW")
+	procMsiGetProductInfo      = modmsi.NewProc("MsiGetProductInfoW")
+	procEnumDeviceDrivers      = modpsapi.NewProc("EnumDeviceDrivers")
+	procGetDeviceDriverBase    = modpsapi.NewProc("GetDeviceDriverBaseNameW")
+	procGetDeviceDriverFile    = modpsapi.NewProc("GetDeviceDriverFileNameW")
+	procGetGuiResources        = moduser32.NewProc("GetGuiResources")
+	procGetLastInputInfo       = moduser32.NewProc("GetLastInputInfo")
+	procEvtClose               = modwevtapi.NewProc("EvtClose")
+	procEvtNext                = modwevtapi.NewProc("EvtNext")
+	procEvtQuery               = modwevtapi.NewProc("EvtQuery")
+	procEvtRender              = modwevtapi.NewProc("EvtRender")
+	procWlanCloseHandle        = modwlanapi.NewProc("WlanCloseHandle")
+	procWlanEnumInterfaces     = modwlanapi.NewProc("WlanEnumInterfaces")
+	procWlanFreeMemory         = modwlanapi.NewProc("WlanFreeMemory")
+	procWlanOpenHandle         = modwlanapi.NewProc("WlanOpenHandle")
+	procWlanQueryInterface     = modwlanapi.NewProc("WlanQueryInterface")
+	procWTSQuerySessionInfo    = modwtsapi32.NewProc("WTSQuerySessionInformationW")
+)
+
+// FIRMWARE_TYPE values.
+const (
+	firmwareTypeBios = 1
+	firmwareTypeUefi = 2
+)
+
+func _GetFirmwareType(firmwareType *uint32) error {
+	if err := procGetFirmwareType.Find(); err != nil {
+		return err
+	}
+	r0, _, e1 := procGetFirmwareType.Call(uintptr(unsafe.Pointer(firmwareType)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _LookupPrivilegeName(luid *windows.LUID, name []uint16, size *uint32) error {
+	r0, _, e1 := procLookupPrivilegeName.Call(0, uintptr(unsafe.Pointer(luid)), uintptr(unsafe.Pointer(&name[0])), uintptr(unsafe.Pointer(size)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetNumaHighestNodeNumber(node *uint32) error {
+	r0, _, e1 := procGetNumaHighestNode.Call(uintptr(unsafe.Pointer(node)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetNumaNodeProcessorMaskEx(node uint16, affinity *groupAffinity) error {
+	r0, _, e1 := procGetNumaNodeProcMaskEx.Call(uintptr(node), uintptr(unsafe.Pointer(affinity)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetNumaAvailableMemoryNodeEx(node uint16, available *uint64) error {
+	r0, _, e1 := procGetNumaAvailMemNodeEx.Call(uintptr(node), uintptr(unsafe.Pointer(available)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _QueryUnbiasedInterruptTime(t *uint64) error {
+	r0, _, e1 := procQueryUnbiasedIntTime.Call(uintptr(unsafe.Pointer(t)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetSystemFirmwareTable(provider uint32, id uint32, buf []byte) (uint32, error) {
+	var p *byte
+	if len(buf) > 0 {
+		p = &buf[0]
+	}
+	r0, _, e1 := procGetSystemFirmwareTable.Call(uintptr(provider), uintptr(id), uintptr(unsafe.Pointer(p)), uintptr(len(buf)))
+	if r0 == 0 {
+		return 0, e1
+	}
+	return uint32(r0), nil
+}
+
+// TBS constants.
+const (
+	tbsSuccess        = 0
+	tbsETPMNotFound   = 0x8028400f
+	tpmVersion12      = 1
+	tpmVersion20      = 2
+	tbsContextVersion = 2
+	tbsIncludeTPM20   = 1 << 2
+	tbsPriorityNormal = 200
+)
+
+// tpmDeviceInfo is the TPM_DEVICE_INFO structure.
+type tpmDeviceInfo struct {
+	StructVersion    uint32
+	TPMVersion       uint32
+	TPMInterfaceType uint32
+	TPMImpRevision   uint32
+}
+
+// tbsContextParams2 is the TBS_CONTEXT_PARAMS2 structure.
+type tbsContextParams2 struct {
+	Version uint32
+	Flags   uint32
+}
+
+func _TbsiGetDeviceInfo(info *tpmDeviceInfo) uint32 {
+	if err := procTbsiGetDeviceInfo.Find(); err != nil {
+		return tbsETPMNotFound
+	}
+	r0, _, _ := procTbsiGetDeviceInfo.Call(unsafe.Sizeof(*info), uintptr(unsafe.Pointer(info)))
+	return uint32(r0)
+}
+
+func _TbsiContextCreate(params *tbsContextParams2, context *uintptr) uint32 {
+	r0, _, _ := procTbsiContextCreate.Call(uintptr(unsafe.Pointer(params)), uintptr(unsafe.Pointer(context)))
+	return uint32(r0)
+}
+
+func _TbsipContextClose(context uintptr) uint32 {
+	r0, _, _ := procTbsipContextClose.Call(context)
+	return uint32(r0)
+}
+
+func _TbsipSubmitCommand(context uintptr, command []byte, result []byte, resultLen *uint32) uint32 {
+	r0, _, _ := procTbsipSubmitCommand.Call(
+		context,
+		0, // TBS_COMMAND_LOCALITY_ZERO
+		tbsPriorityNormal,
+		uintptr(unsafe.Pointer(&command[0])),
+		uintptr(len(command)),
+		uintptr(unsafe.Pointer(&result[0])),
+		uintptr(unsafe.Pointer(resultLen)))
+	return uint32(r0)
+}
+
+// WTS_INFO_CLASS values.
+const (
+	wtsClientName    = 10
+	wtsSessionInfo   = 24
+	wtsSessionInfoEx = 25
+)
+
+// WTSINFOEX_LEVEL1 session flags.
+const (
+	wtsSessionStateLock   = 0
+	wtsSessionStateUnlock = 1
+)
+
+// wtsInfo is the WTSINFOW structure.
+type wtsInfo struct {
+	State                   uint32
+	SessionID               uint32
+	IncomingBytes           uint32
+	OutgoingBytes           uint32
+	IncomingFrames          uint32
+	OutgoingFrames          uint32
+	IncomingCompressedBytes uint32
+	OutgoingCompressedBytes uint32
+	WinStationName          [32]uint16
+	Domain                  [17]uint16
+	UserName                [21]uint16
+	_                       [2]uint16 // Alignment of the LARGE_INTEGER fields.
+	ConnectTime             int64
+	DisconnectTime          int64
+	LastInputTime           int64
+	LogonTime               int64
+	CurrentTime             int64
+}
+
+// wtsInfoEx is the beginning of the WTSINFOEXW structure with a level 1
+// WTSINFOEX_LEVEL1_W member.
+type wtsInfoEx struct {
+	Level        uint32
+	_            uint32 // Alignment of the union.
+	SessionID    uint32
+	SessionState uint32
+	SessionFlags int32
+}
+
+func _WTSQuerySessionInformation(session uint32, infoClass uint32, buf **byte, size *uint32) error {
+	r0, _, e1 := procWTSQuerySessionInfo.Call(
+		0, // WTS_CURRENT_SERVER_HANDLE
+		uintptr(session),
+		uintptr(infoClass),
+		uintptr(unsafe.Pointer(buf)),
+		uintptr(unsafe.Pointer(size)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+// Event log API constants.
+const (
+	evtQueryChannelPath      = 0x1
+	evtQueryReverseDirection = 0x200
+	evtRenderEventXML        = 1
+	evtInfiniteTimeout       = 0xffffffff
+)
+
+func _EvtQuery(path, query string, flags uint32) (windows.Handle, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	queryPtr, err := windows.UTF16PtrFromString(query)
+	if err != nil {
+		return 0, err
+	}
+	r0, _, e1 := procEvtQuery.Call(0, uintptr(unsafe.Pointer(pathPtr)), uintptr(unsafe.Pointer(queryPtr)), uintptr(flags))
+	if r0 == 0 {
+		return 0, e1
+	}
+	return windows.Handle(r0), nil
+}
+
+func _EvtNext(resultSet windows.Handle, events []windows.Handle, returned *uint32) error {
+	r0, _, e1 := procEvtNext.Call(
+		uintptr(resultSet),
+		uintptr(len(events)),
+		uintptr(unsafe.Pointer(&events[0])),
+		evtInfiniteTimeout,
+		0,
+		uintptr(unsafe.Pointer(returned)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _EvtRender(event windows.Handle, flags uint32, buf []uint16, bufferUsed *uint32) error {
+	var p *uint16
+	if len(buf) > 0 {
+		p = &buf[0]
+	}
+	var propertyCount uint32
+	r0, _, e1 := procEvtRender.Call(
+		0,
+		uintptr(event),
+		uintptr(flags),
+		uintptr(len(buf)*2),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(bufferUsed)),
+		uintptr(unsafe.Pointer(&propertyCount)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _EvtClose(h windows.Handle) {
+	procEvtClose.Call(uintptr(h))
+}
+
+// msiGUIDLength is the length of a product code including the NUL terminator.
+const msiGUIDLength = 39
+
+func _MsiEnumProducts(index uint32, productCode *[msiGUIDLength]uint16) error {
+	if err := procMsiEnumProducts.Find(); err != nil {
+		return err
+	}
+	r0, _, _ := procMsiEnumProducts.Call(uintptr(index), uintptr(unsafe.Pointer(&productCode[0])))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _MsiGetProductInfo(productCode *uint16, property string, buf []uint16, size *uint32) error {
+	propertyPtr, err := windows.UTF16PtrFromString(property)
+	if err != nil {
+		return err
+	}
+	r0, _, _ := procMsiGetProductInfo.Call(
+		uintptr(unsafe.Pointer(productCode)),
+		uintptr(unsafe.Pointer(propertyPtr)),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(size)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+// lastInputInfo is the LASTINPUTINFO structure.
+type lastInputInfo struct {
+	Size uint32
+	Time uint32
+}
+
+// GetGuiResources flags.
+const (
+	grGDIObjects      = 0
+	grUserObjects     = 1
+	grGDIObjectsPeak  = 2
+	grUserObjectsPeak = 4
+)
+
+func _GetGuiResources(process windows.Handle, flags uint32) (uint32, error) {
+	r0, _, e1 := procGetGuiResources.Call(uintptr(process), uintptr(flags))
+	// Zero is also returned for processes without GUI objects, in which case
+	// the last error is not set.
+	if r0 == 0 && e1 != windows.ERROR_SUCCESS {
+		return 0, e1
+	}
+	return uint32(r0), nil
+}
+
+func _GetLastInputInfo(info *lastInputInfo) error {
+	info.Size = uint32(unsafe.Sizeof(*info))
+	r0, _, e1 := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(info)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetProcessPriorityBoost(process windows.Handle, disabled *int32) error {
+	r0, _, e1 := procGetProcessPrioBoost.Call(uintptr(process), uintptr(unsafe.Pointer(disabled)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetProcessAffinityMask(process windows.Handle, processMask, systemMask *uintptr) error {
+	r0, _, e1 := procGetProcessAffinityMask.Call(uintptr(process), uintptr(unsafe.Pointer(processMask)), uintptr(unsafe.Pointer(systemMask)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _SetProcessAffinityMask(process windows.Handle, mask uintptr) error {
+	r0, _, e1 := procSetProcessAffinityMask.Call(uintptr(process), mask)
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetTickCount() uint32 {
+	r0, _, _ := procGetTickCount.Call()
+	return uint32(r0)
+}
+
+func _EnumDeviceDrivers(bases []uintptr, needed *uint32) error {
+	r0, _, e1 := procEnumDeviceDrivers.Call(
+		uintptr(unsafe.Pointer(&bases[0])),
+		uintptr(len(bases))*unsafe.Sizeof(bases[0]),
+		uintptr(unsafe.Pointer(needed)))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _GetDeviceDriverBaseName(base uintptr, name []uint16) (uint32, error) {
+	r0, _, e1 := procGetDeviceDriverBase.Call(base, uintptr(unsafe.Pointer(&name[0])), uintptr(len(name)))
+	if r0 == 0 {
+		return 0, e1
+	}
+	return uint32(r0), nil
+}
+
+func _GetDeviceDriverFileName(base uintptr, name []uint16) (uint32, error) {
+	r0, _, e1 := procGetDeviceDriverFile.Call(base, uintptr(unsafe.Pointer(&name[0])), uintptr(len(name)))
+	if r0 == 0 {
+		return 0, e1
+	}
+	return uint32(r0), nil
+}
+
+// sockaddrInet is the SOCKADDR_INET union of a SOCKADDR_IN and a
+// SOCKADDR_IN6. The first field is the address family.
+type sockaddrInet [7]uint32
+
+// ipAddressPrefix is the IP_ADDRESS_PREFIX structure.
+type ipAddressPrefix struct {
+	Prefix       sockaddrInet
+	PrefixLength uint8
+}
+
+// mibIPForwardRow2 is the MIB_IPFORWARD_ROW2 structure.
+type mibIPForwardRow2 struct {
+	InterfaceLUID        uint64
+	InterfaceIndex       uint32
+	DestinationPrefix    ipAddressPrefix
+	NextHop              sockaddrInet
+	SitePrefixLength     uint8
+	ValidLifetime        uint32
+	PreferredLifetime    uint32
+	Metric               uint32
+	Protocol             uint32
+	Loopback             uint8
+	AutoconfigureAddress uint8
+	Publish              uint8
+	Immortal             uint8
+	Age                  uint32
+	Origin               uint32
+}
+
+// mibIPForwardTable2 is the MIB_IPFORWARD_TABLE2 structure. Its rows follow
+// the header.
+type mibIPForwardTable2 struct {
+	NumEntries uint32
+	_          uint32 // Alignment of the rows.
+}
+
+func _GetIpForwardTable2(family uint16, table **mibIPForwardTable2) error {
+	r0, _, _ := procGetIpForwardTable2.Call(uintptr(family), uintptr(unsafe.Pointer(table)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+// NL_NEIGHBOR_STATE values.
+const (
+	nlnsUnreachable = 0
+	nlnsIncomplete  = 1
+	nlnsProbe       = 2
+	nlnsDelay       = 3
+	nlnsStale       = 4
+	nlnsReachable   = 5
+	nlnsPermanent   = 6
+)
+
+// mibIPNetRow2 is the MIB_IPNET_ROW2 structure.
+type mibIPNetRow2 struct {
+	Address               sockaddrInet
+	InterfaceIndex        uint32
+	InterfaceLUID         uint64
+	PhysicalAddress       [32]byte
+	PhysicalAddressLength uint32
+	State                 uint32
+	Flags                 uint8
+	ReachabilityTime      uint32
+}
+
+// mibIPNetTable2 is the MIB_IPNET_TABLE2 structure. Its rows follow the
+// header.
+type mibIPNetTable2 struct {
+	NumEntries uint32
+	_          uint32 // Alignment of the rows.
+}
+
+func _GetIpNetTable2(family uint16, table **mibIPNetTable2) error {
+	r0, _, _ := procGetIpNetTable2.Call(uintptr(family), uintptr(unsafe.Pointer(table)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _FreeMibTable(table unsafe.Pointer) {
+	procFreeMibTable.Call(uintptr(table))
+}
+
+// WLAN API constants.
+const (
+	wlanClientVersion2              = 2
+	wlanInterfaceStateConnected     = 1
+	wlanIntfOpcodeCurrentConnection = 7
+	wlanIntfOpcodeChannelNumber     = 8
+	wlanIntfOpcodeRSSI              = 0x10000102
+)
+
+// wlanInterfaceInfo is the WLAN_INTERFACE_INFO structure.
+type wlanInterfaceInfo struct {
+	InterfaceGUID windows.GUID
+	Description   [256]uint16
+	State         uint32
+}
+
+// wlanInterfaceInfoList is the WLAN_INTERFACE_INFO_LIST structure. Its
+// items follow the header.
+type wlanInterfaceInfoList struct {
+	NumberOfItems uint32
+	Index         uint32
+}
+
+// dot11SSID is the DOT11_SSID structure.
+type dot11SSID struct {
+	Length uint32
+	SSID   [32]byte
+}
+
+// wlanAssociationAttributes is the WLAN_ASSOCIATION_ATTRIBUTES structure.
+type wlanAssociationAttributes struct {
+	SSID          dot11SSID
+	BSSType       uint32
+	BSSID         [6]byte
+	PHYType       uint32
+	PHYIndex      uint32
+	SignalQuality uint32 // 0 (-100 dBm) to 100 (-50 dBm).
+	RxRate        uint32 // kbit/s
+	TxRate        uint32 // kbit/s
+}
+
+// wlanSecurityAttributes is the WLAN_SECURITY_ATTRIBUTES structure.
+type wlanSecurityAttributes struct {
+	SecurityEnabled int32
+	OneXEnabled     int32
+	AuthAlgorithm   uint32
+	CipherAlgorithm uint32
+}
+
+// wlanConnectionAttributes is the WLAN_CONNECTION_ATTRIBUTES structure.
+type wlanConnectionAttributes struct {
+	State          uint32
+	ConnectionMode uint32
+	ProfileName    [256]uint16
+	Association    wlanAssociationAttributes
+	Security       wlanSecurityAttributes
+}
+
+func _WlanOpenHandle(handle *windows.Handle) error {
+	if err := procWlanOpenHandle.Find(); err != nil {
+		return err
+	}
+	var negotiatedVersion uint32
+	r0, _, _ := procWlanOpenHandle.Call(wlanClientVersion2, 0, uintptr(unsafe.Pointer(&negotiatedVersion)), uintptr(unsafe.Pointer(handle)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _WlanCloseHandle(handle windows.Handle) {
+	procWlanCloseHandle.Call(uintptr(handle), 0)
+}
+
+func _WlanEnumInterfaces(handle windows.Handle, list **wlanInterfaceInfoList) error {
+	r0, _, _ := procWlanEnumInterfaces.Call(uintptr(handle), 0, uintptr(unsafe.Pointer(list)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _WlanQueryInterface(handle windows.Handle, guid *windows.GUID, opcode uint32, size *uint32, data *unsafe.Pointer) error {
+	r0, _, _ := procWlanQueryInterface.Call(
+		uintptr(handle),
+		uintptr(unsafe.Pointer(guid)),
+		uintptr(opcode),
+		0,
+		uintptr(unsafe.Pointer(size)),
+		uintptr(unsafe.Pointer(data)),
+		0)
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _WlanFreeMemory(p unsafe.Pointer) {
+	procWlanFreeMemory.Call(uintptr(p))
+}
+
+// TCP_TABLE_CLASS and UDP_TABLE_CLASS values.
+const (
+	tcpTableOwnerPIDListener = 3
+	tcpTableOwnerPIDAll      = 5
+	udpTableOwnerPID         = 1
+)
+
+// mibTCPRowOwnerPID is the MIB_TCPROW_OWNER_PID structure. The addresses and
+// ports are in network byte order.
+type mibTCPRowOwnerPID struct {
+	State      uint32
+	LocalAddr  [4]byte
+	LocalPort  [4]byte
+	RemoteAddr [4]byte
+	RemotePort [4]byte
+	OwningPID  uint32
+}
+
+// mibTCP6RowOwnerPID is the MIB_TCP6ROW_OWNER_PID structure.
+type mibTCP6RowOwnerPID struct {
+	LocalAddr     [16]byte
+	LocalScopeID  uint32
+	LocalPort     [4]byte
+	RemoteAddr    [16]byte
+	RemoteScopeID uint32
+	RemotePort    [4]byte
+	State         uint32
+	OwningPID     uint32
+}
+
+// mibUDPRowOwnerPID is the MIB_UDPROW_OWNER_PID structure.
+type mibUDPRowOwnerPID struct {
+	LocalAddr [4]byte
+	LocalPort [4]byte
+	OwningPID uint32
+}
+
+// mibUDP6RowOwnerPID is the MIB_UDP6ROW_OWNER_PID structure.
+type mibUDP6RowOwnerPID struct {
+	LocalAddr    [16]byte
+	LocalScopeID uint32
+	LocalPort    [4]byte
+	OwningPID    uint32
+}
+
+// mibTCPStats is the MIB_TCPSTATS structure.
+type mibTCPStats struct {
+	RtoAlgorithm uint32
+	RtoMin       uint32
+	RtoMax       uint32
+	MaxConn      uint32
+	ActiveOpens  uint32
+	PassiveOpens uint32
+	AttemptFails uint32
+	EstabResets  uint32
+	CurrEstab    uint32
+	InSegs       uint32
+	OutSegs      uint32
+	RetransSegs  uint32
+	InErrs       uint32
+	OutRsts      uint32
+	NumConns     uint32
+}
+
+// mibUDPStats is the MIB_UDPSTATS structure.
+type mibUDPStats struct {
+	InDatagrams  uint32
+	NoPorts      uint32
+	InErrors     uint32
+	OutDatagrams uint32
+	NumAddrs     uint32
+}
+
+func _GetExtendedTcpTable(table *byte, size *uint32, family uint32, class uint32) error {
+	r0, _, _ := procGetExtendedTcpTable.Call(uintptr(unsafe.Pointer(table)), uintptr(unsafe.Pointer(size)), 0, uintptr(family), uintptr(class), 0)
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _GetExtendedUdpTable(table *byte, size *uint32, family uint32, class uint32) error {
+	r0, _, _ := procGetExtendedUdpTable.Call(uintptr(unsafe.Pointer(table)), uintptr(unsafe.Pointer(size)), 0, uintptr(family), uintptr(class), 0)
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _GetTcpStatisticsEx(stats *mibTCPStats, family uint32) error {
+	r0, _, _ := procGetTcpStatisticsEx.Call(uintptr(unsafe.Pointer(stats)), uintptr(family))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _GetUdpStatisticsEx(stats *mibUDPStats, family uint32) error {
+	r0, _, _ := procGetUdpStatisticsEx.Call(uintptr(unsafe.Pointer(stats)), uintptr(family))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+var (
+	procPropVariantClear                  = modole32.NewProc("PropVariantClear")
+	procPSGetPropertyKeyFromName          = modpropsys.NewProc("PSGetPropertyKeyFromName")
+	procSHGetPropertyStoreFromParsingName = modshell32.NewProc("SHGetPropertyStoreFromParsingName")
+	procWscGetSecurityProviderHealth      = modwscapi.NewProc("WscGetSecurityProviderHealth")
+)
+
+// WSC_SECURITY_PROVIDER values.
+const (
+	wscSecurityProviderFirewall  = 0x1
+	wscSecurityProviderAntivirus = 0x4
+)
+
+// propertyKey is the PROPERTYKEY structure.
+type propertyKey struct {
+	FmtID windows.GUID
+	PID   uint32
+}
+
+// propVariant is the PROPVARIANT structure. The size of its union is that of
+// the largest member on 64-bit platforms.
+type propVariant struct {
+	VT  uint16
+	_   [3]uint16
+	Val [2]uint64
+}
+
+// iidIPropertyStore is the interface ID of IPropertyStore.
+var iidIPropertyStore = windows.GUID{Data1: 0x886d8eeb, Data2: 0x8cf2, Data3: 0x4446, Data4: [8]byte{0x8d, 0x02, 0xcd, 0xba, 0x1d, 0xbd, 0xcf, 0x99}}
+
+// hresultError returns the error of a failed HRESULT.
+func hresultError(r0 uintptr) error {
+	if int32(r0) < 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _WscGetSecurityProviderHealth(providers uint32, health *uint32) error {
+	if err := procWscGetSecurityProviderHealth.Find(); err != nil {
+		return err
+	}
+	r0, _, _ := procWscGetSecurityProviderHealth.Call(uintptr(providers), uintptr(unsafe.Pointer(health)))
+	return hresultError(r0)
+}
+
+func _PSGetPropertyKeyFromName(name *uint16, key *propertyKey) error {
+	r0, _, _ := procPSGetPropertyKeyFromName.Call(uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(key)))
+	return hresultError(r0)
+}
+
+func _SHGetPropertyStoreFromParsingName(path *uint16, iid *windows.GUID, store *unsafe.Pointer) error {
+	r0, _, _ := procSHGetPropertyStoreFromParsingName.Call(uintptr(unsafe.Pointer(path)), 0, 0, uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(store)))
+	return hresultError(r0)
+}
+
+func _PropVariantClear(v *propVariant) {
+	procPropVariantClear.Call(uintptr(unsafe.Pointer(v)))
+}
+
+var (
+	procW32TimeBufferFree             = modw32time.NewProc("W32TimeBufferFree")
+	procW32TimeQueryNTPProviderStatus = modw32time.NewProc("W32TimeQueryNTPProviderStatus")
+)
+
+// w32timeNTPProviderData is the W32TIME_NTP_PROVIDER_DATA structure.
+type w32timeNTPProviderData struct {
+	Size       uint32
+	Error      uint32
+	ErrorMsgID uint32
+	PeerCount  uint32
+	Peers      *w32timeNTPPeerInfo
+}
+
+// w32timeNTPPeerInfo is the W32TIME_NTP_PEER_INFO structure.
+type w32timeNTPPeerInfo struct {
+	Size               uint32
+	ResolveAttempts    uint32
+	TimeRemaining      uint64
+	LastSuccessfulSync uint64 // FILETIME of the last successful synchronization.
+	LastSyncError      uint32
+	LastSyncErrorMsgID uint32
+	ValidDataCounter   uint32
+	AuthTypeMsgID      uint32
+	PeerName           *uint16
+	Mode               uint8
+	Stratum            uint8
+	Reachability       uint8
+	PeerPoll           uint8
+	HostPoll           uint8
+}
+
+func _W32TimeQueryNTPProviderStatus(provider string, data **w32timeNTPProviderData) error {
+	if err := procW32TimeQueryNTPProviderStatus.Find(); err != nil {
+		return err
+	}
+	providerPtr, err := windows.UTF16PtrFromString(provider)
+	if err != nil {
+		return err
+	}
+	r0, _, _ := procW32TimeQueryNTPProviderStatus.Call(0, 0, uintptr(unsafe.Pointer(providerPtr)), uintptr(unsafe.Pointer(data)))
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _W32TimeBufferFree(buf unsafe.Pointer) {
+	procW32TimeBufferFree.Call(uintptr(buf))
+}
+
+var (
+	procNtResumeProcess  = modntdll.NewProc("NtResumeProcess")
+	procNtSuspendProcess = modntdll.NewProc("NtSuspendProcess")
+)
+
+func _NtSuspendProcess(process windows.Handle) error {
+	r0, _, _ := procNtSuspendProcess.Call(uintptr(process))
+	if r0 != 0 {
+		return windows.NTStatus(r0)
+	}
+	return nil
+}
+
+func _NtResumeProcess(process windows.Handle) error {
+	r0, _, _ := procNtResumeProcess.Call(uintptr(process))
+	if r0 != 0 {
+		return windows.NTStatus(r0)
+	}
+	return nil
+}
+
+var procIsProcessInJob = modkernel32.NewProc("IsProcessInJob")
+
+// JOBOBJECTINFOCLASS values that are not defined by x/sys/windows.
+const (
+	jobObjectBasicProcessIDListInfo   = 3
+	jobObjectBasicAndIOAccountingInfo = 8
+)
+
+// JOB_OBJECT_CPU_RATE_CONTROL values.
+const (
+	jobObjectCPURateControlEnable      = 0x1
+	jobObjectCPURateControlWeightBased = 0x2
+	jobObjectCPURateControlMinMaxRate  = 0x10
+)
+
+// jobObjectBasicProcessIDList is the JOBOBJECT_BASIC_PROCESS_ID_LIST
+// structure. The IDs are followed by NumberOfProcessIdsInList-1 more.
+type jobObjectBasicProcessIDList struct {
+	NumberOfAssignedProcesses uint32
+	NumberOfProcessIdsInList  uint32
+	ProcessIDList             [1]uintptr
+}
+
+// jobObjectBasicAndIOAccounting is the
+// JOBOBJECT_BASIC_AND_IO_ACCOUNTING_INFORMATION structure.
+type jobObjectBasicAndIOAccounting struct {
+	TotalUserTime             int64
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+	IoInfo                    windows.IO_COUNTERS
+}
+
+// jobObjectCPURateControl is the JOBOBJECT_CPU_RATE_CONTROL_INFORMATION
+// structure. Rate is the CpuRate, Weight, or MinRate and MaxRate member of
+// the union depending on ControlFlags.
+type jobObjectCPURateControl struct {
+	ControlFlags uint32
+	Rate         uint32
+}
+
+func _IsProcessInJob(process windows.Handle, inJob *bool) error {
+	var result int32
+	r0, _, e1 := procIsProcessInJob.Call(uintptr(process), 0, uintptr(unsafe.Pointer(&result)))
+	if r0 == 0 {
+		return e1
+	}
+	*inJob = result != 0
+	return nil
+}
+
+var (
+	procCryptCATAdminAcquireContext2         = modwintrust.NewProc("CryptCATAdminAcquireContext2")
+	procCryptCATAdminCalcHashFromFileHandle2 = modwintrust.NewProc("CryptCATAdminCalcHashFromFileHandle2")
+	procCryptCATAdminEnumCatalogFromHash     = modwintrust.NewProc("CryptCATAdminEnumCatalogFromHash")
+	procCryptCATAdminReleaseCatalogContext   = modwintrust.NewProc("CryptCATAdminReleaseCatalogContext")
+	procCryptCATAdminReleaseContext          = modwintrust.NewProc("CryptCATAdminReleaseContext")
+	procCryptCATCatalogInfoFromContext       = modwintrust.NewProc("CryptCATCatalogInfoFromContext")
+	procWTHelperGetProvSignerFromChain       = modwintrust.NewProc("WTHelperGetProvSignerFromChain")
+	procWTHelperProvDataFromStateData        = modwintrust.NewProc("WTHelperProvDataFromStateData")
+)
+
+// catalogInfo is the CATALOG_INFO structure.
+type catalogInfo struct {
+	Size        uint32
+	CatalogFile [windows.MAX_PATH]uint16
+}
+
+// wintrustCatalogInfo is the WINTRUST_CATALOG_INFO structure.
+type wintrustCatalogInfo struct {
+	Size                   uint32
+	CatalogVersion         uint32
+	CatalogFilePath        *uint16
+	MemberTag              *uint16
+	MemberFilePath         *uint16
+	MemberFile             windows.Handle
+	CalculatedFileHash     *byte
+	CalculatedFileHashSize uint32
+	CatalogContext         uintptr
+	CatAdmin               windows.Handle
+}
+
+// cryptProviderSgnr is the beginning of the CRYPT_PROVIDER_SGNR structure.
+type cryptProviderSgnr struct {
+	Size           uint32
+	VerifyAsOf     windows.Filetime
+	CertChainCount uint32
+	CertChain      *cryptProviderCert
+}
+
+// cryptProviderCert is the beginning of the CRYPT_PROVIDER_CERT structure.
+type cryptProviderCert struct {
+	Size uint32
+	Cert *windows.CertContext
+}
+
+func _CryptCATAdminAcquireContext2(catAdmin *windows.Handle, hashAlgorithm string) error {
+	alg, err := windows.UTF16PtrFromString(hashAlgorithm)
+	if err != nil {
+		return err
+	}
+	r0, _, e1 := procCryptCATAdminAcquireContext2.Call(uintptr(unsafe.Pointer(catAdmin)), 0, uintptr(unsafe.Pointer(alg)), 0, 0)
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _CryptCATAdminCalcHashFromFileHandle2(catAdmin, file windows.Handle, hash []byte) ([]byte, error) {
+	size := uint32(len(hash))
+	r0, _, e1 := procCryptCATAdminCalcHashFromFileHandle2.Call(uintptr(catAdmin), uintptr(file), uintptr(unsafe.Pointer(&size)), uintptr(unsafe.Pointer(&hash[0])), 0)
+	if r0 == 0 {
+		return nil, e1
+	}
+	return hash[:size], nil
+}
+
+func _CryptCATAdminEnumCatalogFromHash(catAdmin windows.Handle, hash []byte) windows.Handle {
+	r0, _, _ := procCryptCATAdminEnumCatalogFromHash.Call(uintptr(catAdmin), uintptr(unsafe.Pointer(&hash[0])), uintptr(len(hash)), 0, 0)
+	return windows.Handle(r0)
+}
+
+func _CryptCATCatalogInfoFromContext(catInfo windows.Handle, info *catalogInfo) error {
+	r0, _, e1 := procCryptCATCatalogInfoFromContext.Call(uintptr(catInfo), uintptr(unsafe.Pointer(info)), 0)
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+func _CryptCATAdminReleaseCatalogContext(catAdmin, catInfo windows.Handle) {
+	procCryptCATAdminReleaseCatalogContext.Call(uintptr(catAdmin), uintptr(catInfo), 0)
+}
+
+func _CryptCATAdminReleaseContext(catAdmin windows.Handle) {
+	procCryptCATAdminReleaseContext.Call(uintptr(catAdmin), 0)
+}
+
+func _WTHelperProvDataFromStateData(stateData windows.Handle) uintptr {
+	r0, _, _ := procWTHelperProvDataFromStateData.Call(uintptr(stateData))
+	return r0
+}
+
+func _WTHelperGetProvSignerFromChain(provData uintptr) *cryptProviderSgnr {
+	r0, _, _ := procWTHelperGetProvSignerFromChain.Call(provData, 0, 0, 0)
+	// The signer is owned by the WinVerifyTrust state, not by Go.
+	return *(**cryptProviderSgnr)(unsafe.Pointer(&r0))
+}
+
+var procGetProcessInformation = modkernel32.NewProc("GetProcessInformation")
+
+// processMachineTypeInfo is the ProcessMachineTypeInfo value of
+// PROCESS_INFORMATION_CLASS.
+const processMachineTypeInfo = 9
+
+// processMachineInformation is the PROCESS_MACHINE_INFORMATION structure.
+type processMachineInformation struct {
+	ProcessMachine    uint16
+	Res0              uint16
+	MachineAttributes uint32
+}
+
+func _GetProcessInformation(process windows.Handle, class uint32, info unsafe.Pointer, size uint32) error {
+	if err := procGetProcessInformation.Find(); err != nil {
+		return err
+	}
+	r0, _, e1 := procGetProcessInformation.Call(uintptr(process), uintptr(class), uintptr(info), uintptr(size))
+	if r0 == 0 {
+		return e1
+	}
+	return nil
+}
+
+var (
+	procNetFreeAadJoinInformation = modnetapi32.NewProc("NetFreeAadJoinInformation")
+	procNetGetAadJoinInformation  = modnetapi32.NewProc("NetGetAadJoinInformation")
+)
+
+// DSREG_JOIN_TYPE values.
+const (
+	dsregUnknownJoin   = 0
+	dsregDeviceJoin    = 1
+	dsregWorkplaceJoin = 2
+)
+
+// dsregJoinInfo is the DSREG_JOIN_INFO structure.
+type dsregJoinInfo struct {
+	JoinType           uint32
+	JoinCertificate    uintptr
+	DeviceID           *uint16
+	IdpDomain          *uint16
+	TenantID           *uint16
+	JoinUserEmail      *uint16
+	TenantDisplayName  *uint16
+	MdmEnrollmentURL   *uint16
+	MdmTermsOfUseURL   *uint16
+	MdmComplianceURL   *uint16
+	UserSettingSyncURL *uint16
+	UserInfo           uintptr
+}
+
+// _NetGetAadJoinInformation returns the Azure AD join of the device. info is
+// nil if the device is not joined.
+func _NetGetAadJoinInformation(info **dsregJoinInfo) error {
+	if err := procNetGetAadJoinInformation.Find(); err != nil {
+		return err
+	}
+	r0, _, _ := procNetGetAadJoinInformation.Call(0, uintptr(unsafe.Pointer(info)))
+	return hresultError(r0)
+}
+
+func _NetFreeAadJoinInformation(info *dsregJoinInfo) {
+	procNetFreeAadJoinInformation.Call(uintptr(unsafe.Pointer(info)))
+}
+
+var (
+	procCreateDXGIFactory1          = moddxgi.NewProc("CreateDXGIFactory1")
+	procPdhAddEnglishCounter        = modpdh.NewProc("PdhAddEnglishCounterW")
+	procPdhCloseQuery               = modpdh.NewProc("PdhCloseQuery")
+	procPdhCollectQueryData         = modpdh.NewProc("PdhCollectQueryData")
+	procPdhGetFormattedCounterArray = modpdh.NewProc("PdhGetFormattedCounterArrayW")
+	procPdhOpenQuery                = modpdh.NewProc("PdhOpenQueryW")
+)
+
+// iidIDXGIFactory1 is the interface ID of IDXGIFactory1.
+var iidIDXGIFactory1 = windows.GUID{Data1: 0x770aae78, Data2: 0xf26f, Data3: 0x4dba, Data4: [8]byte{0xa8, 0x29, 0x25, 0x3c, 0x83, 0xd1, 0xb3, 0x87}}
+
+// DXGI constants.
+const (
+	dxgiErrorNotFound       = 0x887a0002
+	dxgiAdapterFlagSoftware = 0x2
+)
+
+// dxgiAdapterDesc1 is the DXGI_ADAPTER_DESC1 structure.
+type dxgiAdapterDesc1 struct {
+	Description           [128]uint16
+	VendorID              uint32
+	DeviceID              uint32
+	SubSysID              uint32
+	Revision              uint32
+	DedicatedVideoMemory  uintptr
+	DedicatedSystemMemory uintptr
+	SharedSystemMemory    uintptr
+	AdapterLUID           windows.LUID
+	Flags                 uint32
+}
+
+func _CreateDXGIFactory1(iid *windows.GUID, factory *unsafe.Pointer) error {
+	if err := procCreateDXGIFactory1.Find(); err != nil {
+		return err
+	}
+	r0, _, _ := procCreateDXGIFactory1.Call(uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(factory)))
+	return hresultError(r0)
+}
+
+// PDH constants.
+const (
+	pdhMoreData       = 0x800007d2
+	pdhFmtDouble      = 0x200
+	pdhCStatusValid   = 0x0
+	pdhCStatusNewData = 0x1
+)
+
+// pdhFmtCounterValueItem is the PDH_FMT_COUNTERVALUE_ITEM_W structure
+// formatted as PDH_FMT_DOUBLE. The union of its PDH_FMT_COUNTERVALUE member
+// is 8-byte aligned on all platforms.
+type pdhFmtCounterValueItem struct {
+	Name   *uint16
+	_      [8 - unsafe.Sizeof(uintptr(0))]byte
+	Status uint32
+	_      uint32
+	Value  float64
+}
+
+func pdhError(r0 uintptr) error {
+	if r0 != 0 {
+		return windows.Errno(r0)
+	}
+	return nil
+}
+
+func _PdhOpenQuery(query *windows.Handle) error {
+	if err := procPdhOpenQuery.Find(); err != nil {
+		return err
+	}
+	r0, _, _ := procPdhOpenQuery.Call(0, 0, uintptr(unsafe.Pointer(query)))
+	return pdhError(r0)
+}
+
+func _PdhAddEnglishCounter(query windows.Handle, path string, counter *windows.Handle) error {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	r0, _, _ := procPdhAddEnglishCounter.Call(uintptr(query), uintptr(unsafe.Pointer(pathPtr)), 0, uintptr(unsafe.Pointer(counter)))
+	return pdhError(r0)
+}
+
+func _PdhCollectQueryData(query windows.Handle) error {
+	r0, _, _ := procPdhCollectQueryData.Call(uintptr(query))
+	return pdhError(r0)
+}
+
+func _PdhGetFormattedCounterArray(counter windows.Handle, format uint32, size *uint32, count *uint32, buf []byte) error {
+	var p *byte
+	if len(buf) > 0 {
+		p = &buf[0]
+	}
+	r0, _, _ := procPdhGetFormattedCounterArray.Call(
+		uintptr(counter),
+		uintptr(format),
+		uintptr(unsafe.Pointer(size)),
+		uintptr(unsafe.Pointer(count)),
+		uintptr(unsafe.Pointer(p)))
+	return pdhError(r0)
+}
+
+func _PdhCloseQuery(query windows.Handle) {
+	procPdhCloseQuery.Call(uintptr(query))
+}
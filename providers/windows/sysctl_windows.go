@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// currentControlSetKey is the root of the names of the kernel parameters.
+const currentControlSetKey = `SYSTEM\CurrentControlSet`
+
+// kernelParameterKeys are the keys below HKLM\SYSTEM\CurrentControlSet whose
+// values are listed by KernelParameters. They hold the tunables of the
+// memory manager, the kernel, the scheduler, the file systems and the
+// network stack.
+var kernelParameterKeys = []string{
+	`Control\FileSystem`,
+	`Control\PriorityControl`,
+	`Control\Session Manager\Kernel`,
+	`Control\Session Manager\Memory Management`,
+	`Services\AFD\Parameters`,
+	`Services\LanmanServer\Parameters`,
+	`Services\Tcpip\Parameters`,
+	`Services\Tcpip6\Parameters`,
+}
+
+// Sysctl reads a registry value below HKLM\SYSTEM\CurrentControlSet. The
+// name is the path of the key followed by the name of the value.
+func (h *host) Sysctl(name string) (string, bool, error) {
+	i := strings.LastIndex(name, `\`)
+	if i < 0 {
+		return "", false, nil
+	}
+
+	k, err := openLocalMachineKey(currentControlSetKey + `\` + name[:i])
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer k.Close()
+
+	values, err := regValues(k)
+	if err != nil {
+		return "", false, err
+	}
+	for n, v := range values {
+		// Value names are case-insensitive.
+		if strings.EqualFold(n, name[i+1:]) {
+			return formatRegValue(v), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// KernelParameters reads the values of well-known keys that hold kernel and
+// network stack tunables. Only the values that are set are returned because
+// Windows uses built-in defaults for the others. The prefix is matched
+// without regard to case.
+func (h *host) KernelParameters(prefix string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, key := range kernelParameterKeys {
+		k, err := openLocalMachineKey(currentControlSetKey + `\` + key)
+		if err != nil {
+			continue
+		}
+		values, err := regValues(k)
+		k.Close()
+		if err != nil {
+			continue
+		}
+
+		for n, v := range values {
+			name := key + `\` + n
+			if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+				params[name] = formatRegValue(v)
+			}
+		}
+	}
+	return params, nil
+}
+
+// formatRegValue formats a value returned by regValues. The strings of a
+// REG_MULTI_SZ value are separated by newlines and binary values are
+// hex-encoded.
+func formatRegValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, "\n")
+	case []byte:
+		return hex.EncodeToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
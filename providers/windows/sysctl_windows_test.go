@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.KernelParameters = (*host)(nil)
+
+func TestFormatRegValue(t *testing.T) {
+	assert.Equal(t, "30", formatRegValue(uint64(30)))
+	assert.Equal(t, "example.com", formatRegValue("example.com"))
+	assert.Equal(t, "a\nb", formatRegValue([]string{"a", "b"}))
+	assert.Equal(t, "01ff", formatRegValue([]byte{0x01, 0xff}))
+}
+
+func TestSysctl(t *testing.T) {
+	h := &host{}
+
+	// Every installation has a host name in the TCP/IP parameters.
+	v, found, err := h.Sysctl(`Services\Tcpip\Parameters\Hostname`)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.NotEmpty(t, v)
+
+	_, found, err = h.Sysctl(`Services\Tcpip\Parameters\NoSuchValue`)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	params, err := h.KernelParameters(`services\tcpip\`)
+	require.NoError(t, err)
+	assert.Contains(t, params, `Services\Tcpip\Parameters\Hostname`)
+}
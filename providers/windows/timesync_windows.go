@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// TimeSync reports the synchronization status of the NtpClient provider of
+// the Windows Time service. The clock offset is not exposed by the service.
+func (h *host) TimeSync() (*types.TimeSyncInfo, error) {
+	running, err := serviceRunning("W32Time")
+	if err != nil || !running {
+		// The service is not installed or stopped, so the clock is not
+		// being synchronized.
+		return &types.TimeSyncInfo{}, nil
+	}
+
+	var data *w32timeNTPProviderData
+	if err := _W32TimeQueryNTPProviderStatus("NtpClient", &data); err != nil {
+		return nil, err
+	}
+	defer _W32TimeBufferFree(unsafe.Pointer(data))
+
+	var peers []w32timeNTPPeerInfo
+	if data.Peers != nil && data.PeerCount > 0 {
+		peers = unsafe.Slice(data.Peers, data.PeerCount)
+	}
+	info := ntpPeersTimeSync(peers)
+	if data.Error != 0 {
+		info.Synchronized = false
+	}
+	return info, nil
+}
+
+// ntpPeersTimeSync returns the synchronization status of the peer that was
+// synchronized with most recently.
+func ntpPeersTimeSync(peers []w32timeNTPPeerInfo) *types.TimeSyncInfo {
+	var last *w32timeNTPPeerInfo
+	for i := range peers {
+		if peers[i].LastSuccessfulSync == 0 {
+			continue
+		}
+		if last == nil || peers[i].LastSuccessfulSync > last.LastSuccessfulSync {
+			last = &peers[i]
+		}
+	}
+	if last == nil {
+		return &types.TimeSyncInfo{}
+	}
+
+	ft := windows.Filetime{
+		LowDateTime:  uint32(last.LastSuccessfulSync),
+		HighDateTime: uint32(last.LastSuccessfulSync >> 32),
+	}
+	lastSync := time.Unix(0, ft.Nanoseconds()).UTC()
+
+	// Peer names carry the NtpServer flags, e.g. "time.windows.com,0x9".
+	server := windows.UTF16PtrToString(last.PeerName)
+	if i := strings.IndexByte(server, ','); i >= 0 {
+		server = server[:i]
+	}
+
+	return &types.TimeSyncInfo{
+		Synchronized: last.LastSyncError == 0,
+		Server:       server,
+		LastSync:     &lastSync,
+	}
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.TimeSync = (*host)(nil)
+
+func TestNTPPeersTimeSync(t *testing.T) {
+	synced := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	ft := windows.NsecToFiletime(synced.UnixNano())
+
+	name, err := windows.UTF16PtrFromString("time.windows.com,0x9")
+	require.NoError(t, err)
+
+	info := ntpPeersTimeSync([]w32timeNTPPeerInfo{
+		{LastSuccessfulSync: 0, LastSyncError: 1},
+		{LastSuccessfulSync: uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime), PeerName: name},
+	})
+	assert.True(t, info.Synchronized)
+	assert.Equal(t, "time.windows.com", info.Server)
+	require.NotNil(t, info.LastSync)
+	assert.True(t, synced.Equal(*info.LastSync))
+	assert.Equal(t, time.UTC, info.LastSync.Location())
+
+	assert.Equal(t, &types.TimeSyncInfo{}, ntpPeersTimeSync(nil))
+}
+
+func TestTimeSync(t *testing.T) {
+	_, err := (&host{}).TimeSync()
+	require.NoError(t, err)
+}
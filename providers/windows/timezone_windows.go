@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// timeZoneIDDaylight is returned by GetTimeZoneInformation when daylight
+// saving time is in effect.
+const timeZoneIDDaylight = 2
+
+// timezone returns the name and the offset in seconds east of UTC of the
+// system time zone at t. Go loads time.Local only once per process, so the
+// offset is read with GetTimeZoneInformation to reflect changes of the time
+// zone. The name reported by Go is kept while the offsets agree because Go
+// abbreviates the names (e.g. CET instead of W. Europe Standard Time).
+func timezone(t time.Time) (string, int) {
+	name, offset := t.Zone()
+
+	var tzi windows.Timezoneinformation
+	id, err := windows.GetTimeZoneInformation(&tzi)
+	if err != nil {
+		return name, offset
+	}
+	if sysName, sysOffset := zone(&tzi, id); sysOffset != offset {
+		return sysName, sysOffset
+	}
+	return name, offset
+}
+
+// zone returns the name and offset of the standard or daylight saving time
+// of tzi depending on the time zone ID returned by GetTimeZoneInformation.
+func zone(tzi *windows.Timezoneinformation, id uint32) (string, int) {
+	if id == timeZoneIDDaylight {
+		return windows.UTF16ToString(tzi.DaylightName[:]), -int(tzi.Bias+tzi.DaylightBias) * 60
+	}
+	return windows.UTF16ToString(tzi.StandardName[:]), -int(tzi.Bias+tzi.StandardBias) * 60
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ types.Refresher      = (*host)(nil)
+	_ types.FieldRefresher = (*host)(nil)
+)
+
+func TestZone(t *testing.T) {
+	tzi := windows.Timezoneinformation{Bias: -60, DaylightBias: -60}
+	copy(tzi.StandardName[:], windows.StringToUTF16("W. Europe Standard Time"))
+	copy(tzi.DaylightName[:], windows.StringToUTF16("W. Europe Daylight Time"))
+
+	name, offset := zone(&tzi, 1)
+	assert.Equal(t, "W. Europe Standard Time", name)
+	assert.Equal(t, 3600, offset)
+
+	name, offset = zone(&tzi, timeZoneIDDaylight)
+	assert.Equal(t, "W. Europe Daylight Time", name)
+	assert.Equal(t, 7200, offset)
+}
+
+func TestTimezone(t *testing.T) {
+	now := time.Now()
+	_, offset := timezone(now)
+	_, want := now.Zone()
+	assert.Equal(t, want, offset)
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+
+	"github.com/elastic/go-sysinfo/providers/shared"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// TPM reports the Trusted Platform Module using the TPM Base Services (TBS).
+func (h *host) TPM() (*types.TPMInfo, error) {
+	var device tpmDeviceInfo
+	switch rc := _TbsiGetDeviceInfo(&device); rc {
+	case tbsSuccess:
+	case tbsETPMNotFound:
+		return &types.TPMInfo{Present: false}, nil
+	default:
+		return nil, fmt.Errorf("Tbsi_GetDeviceInfo failed with 0x%x", rc)
+	}
+
+	info := &types.TPMInfo{Present: true}
+	switch device.TPMVersion {
+	case tpmVersion12:
+		info.Version = "1.2"
+	case tpmVersion20:
+		info.Version = "2.0"
+		info.ManufacturerID, _ = tpm2Manufacturer()
+		info.ManufacturerName = shared.TPMVendorName(info.ManufacturerID)
+	}
+	return info, nil
+}
+
+// tpm2Manufacturer submits a TPM2_GetCapability command through TBS.
+func tpm2Manufacturer() (string, error) {
+	params := tbsContextParams2{Version: tbsContextVersion, Flags: tbsIncludeTPM20}
+	var context uintptr
+	if rc := _TbsiContextCreate(&params, &context); rc != tbsSuccess {
+		return "", fmt.Errorf("Tbsi_Context_Create failed with 0x%x", rc)
+	}
+	defer _TbsipContextClose(context)
+
+	resp := make([]byte, 4096)
+	n := uint32(len(resp))
+	if rc := _TbsipSubmitCommand(context, shared.TPM2GetManufacturerCommand(), resp, &n); rc != tbsSuccess {
+		return "", fmt.Errorf("Tbsip_Submit_Command failed with 0x%x", rc)
+	}
+	return shared.ParseTPM2GetManufacturerResponse(resp[:n])
+}
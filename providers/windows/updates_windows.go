@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// cbsPackagesKey lists the packages known to Component Based Servicing,
+// which installs Windows updates.
+const cbsPackagesKey = `SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\Packages`
+
+// cbsStateInstalled is the CurrentState of an installed package.
+const cbsStateInstalled = 0x70
+
+// kbRegexp matches knowledge base article numbers, which identify hotfixes.
+var kbRegexp = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])KB([0-9]{6,8})(?:[^0-9]|$)`)
+
+// InstalledUpdates reports the installed hotfixes recorded by Component
+// Based Servicing. Each hotfix is reported once with the time at which its
+// first package was installed. Cumulative updates are identified from the
+// name of the cabinet they were installed from.
+func (h *host) InstalledUpdates() ([]types.UpdateInfo, error) {
+	v, err := ratelimit.Do(ratelimit.InstalledUpdates, "", func() (interface{}, error) {
+		return installedUpdates()
+	})
+	updates, _ := v.([]types.UpdateInfo)
+	return append([]types.UpdateInfo(nil), updates...), err
+}
+
+// LastUpdate reports the latest install time of the hotfixes recorded by
+// Component Based Servicing.
+func (h *host) LastUpdate() (*types.LastUpdateInfo, error) {
+	updates, err := h.InstalledUpdates()
+	if err != nil {
+		return nil, err
+	}
+
+	var last time.Time
+	for _, u := range updates {
+		if u.InstallTime.After(last) {
+			last = u.InstallTime
+		}
+	}
+	if last.IsZero() {
+		return nil, types.ErrNotImplemented
+	}
+	return &types.LastUpdateInfo{Time: last, Source: "cbs"}, nil
+}
+
+func installedUpdates() ([]types.UpdateInfo, error) {
+	k, err := openLocalMachineKey(cbsPackagesKey)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	names, err := k.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to list subkeys of HKLM\%v: %w`, cbsPackagesKey, err)
+	}
+
+	updates := map[string]time.Time{}
+	for _, name := range names {
+		kb, installed := readCBSPackage(k, name)
+		if kb == "" || installed.IsZero() {
+			continue
+		}
+		if t, found := updates[kb]; !found || installed.Before(t) {
+			updates[kb] = installed
+		}
+	}
+
+	list := make([]types.UpdateInfo, 0, len(updates))
+	for kb, t := range updates {
+		list = append(list, types.UpdateInfo{ID: kb, InstallTime: t})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+// readCBSPackage returns the hotfix ID and install time of an installed
+// package. An empty ID is returned if the package is not a hotfix or is not
+// installed.
+func readCBSPackage(packages registry.Key, name string) (kb string, installed time.Time) {
+	if !strings.HasPrefix(name, "Package_") {
+		return "", time.Time{}
+	}
+
+	k, err := registry.OpenKey(packages, name, registry.QUERY_VALUE)
+	if err != nil {
+		return "", time.Time{}
+	}
+	defer k.Close()
+
+	if state, _, err := k.GetIntegerValue("CurrentState"); err != nil || state != cbsStateInstalled {
+		return "", time.Time{}
+	}
+
+	location, _ := regString(k, "InstallLocation")
+	kb = hotfixID(name, location)
+	if kb == "" {
+		return "", time.Time{}
+	}
+
+	high, _, errHigh := k.GetIntegerValue("InstallTimeHigh")
+	low, _, errLow := k.GetIntegerValue("InstallTimeLow")
+	if errHigh != nil || errLow != nil {
+		return kb, time.Time{}
+	}
+	ft := windows.Filetime{HighDateTime: uint32(high), LowDateTime: uint32(low)}
+	return kb, time.Unix(0, ft.Nanoseconds()).UTC()
+}
+
+// hotfixID returns the KB identifier from the name of a servicing package
+// (e.g. Package_for_KB5031356~31bf3856ad364e35~amd64~~22621.2428.1.1) or
+// from the path of the cabinet it was installed from.
+func hotfixID(name, installLocation string) string {
+	for _, s := range []string{name, installLocation} {
+		if m := kbRegexp.FindStringSubmatch(s); m != nil {
+			return "KB" + m[1]
+		}
+	}
+	return ""
+}
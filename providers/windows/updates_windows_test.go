@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.LastUpdate = (*host)(nil)
+
+func TestHotfixID(t *testing.T) {
+	tests := []struct {
+		name, location, want string
+	}{
+		{"Package_for_KB5031356~31bf3856ad364e35~amd64~~22621.2428.1.1", "", "KB5031356"},
+		{"Package_1_for_KB5012170~31bf3856ad364e35~amd64~~22000.850.1.1", "", "KB5012170"},
+		{
+			"Package_for_RollupFix~31bf3856ad364e35~amd64~~22621.2428.1.10",
+			`\\?\C:\Windows\SoftwareDistribution\Download\0ab1\Windows11.0-KB5031354-x64.cab`,
+			"KB5031354",
+		},
+		{"Package_for_ServicingStack_2420~31bf3856ad364e35~amd64~~22621.2420.1.1", "", ""},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, hotfixID(tc.name, tc.location), tc.name)
+	}
+}
@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// dot11PHYModes maps the DOT11_PHY_TYPE values to the IEEE 802.11 standards.
+var dot11PHYModes = map[uint32]string{
+	4:  "802.11a",
+	5:  "802.11b",
+	6:  "802.11g",
+	7:  "802.11n",
+	8:  "802.11ac",
+	9:  "802.11ad",
+	10: "802.11ax",
+	11: "802.11be",
+}
+
+// WiFi reports the wireless interfaces from the WLAN AutoConfig service,
+// which is what "netsh wlan show interfaces" shows. It returns
+// types.ErrNotImplemented when the service is not available, as on Windows
+// Server without the Wireless LAN Service feature.
+func (h *host) WiFi() ([]types.WiFiInfo, error) {
+	var handle windows.Handle
+	if err := _WlanOpenHandle(&handle); err != nil {
+		var dllErr *windows.DLLError
+		if errors.As(err, &dllErr) || errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+			return nil, fmt.Errorf("WLAN service is not available: %v: %w", err, types.ErrNotImplemented)
+		}
+		return nil, fmt.Errorf("WlanOpenHandle failed: %w", err)
+	}
+	defer _WlanCloseHandle(handle)
+
+	var list *wlanInterfaceInfoList
+	if err := _WlanEnumInterfaces(handle, &list); err != nil {
+		return nil, fmt.Errorf("WlanEnumInterfaces failed: %w", err)
+	}
+	defer _WlanFreeMemory(unsafe.Pointer(list))
+
+	names := adapterNames()
+	items := unsafe.Slice((*wlanInterfaceInfo)(unsafe.Add(unsafe.Pointer(list), unsafe.Sizeof(*list))), list.NumberOfItems)
+	infos := make([]types.WiFiInfo, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		info := types.WiFiInfo{Interface: names[strings.ToLower(item.InterfaceGUID.String())]}
+		if info.Interface == "" {
+			info.Interface = windows.UTF16ToString(item.Description[:])
+		}
+		if item.State == wlanInterfaceStateConnected {
+			wlanLink(handle, &item.InterfaceGUID, &info)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// wlanLink adds the information of the current connection of an interface.
+func wlanLink(handle windows.Handle, guid *windows.GUID, info *types.WiFiInfo) {
+	var size uint32
+	var data unsafe.Pointer
+	if err := _WlanQueryInterface(handle, guid, wlanIntfOpcodeCurrentConnection, &size, &data); err != nil {
+		return
+	}
+	if uintptr(size) >= unsafe.Sizeof(wlanConnectionAttributes{}) {
+		wifiAssociation(&(*wlanConnectionAttributes)(data).Association, info)
+	}
+	_WlanFreeMemory(data)
+
+	if err := _WlanQueryInterface(handle, guid, wlanIntfOpcodeRSSI, &size, &data); err == nil {
+		if size >= 4 {
+			signal := int(*(*int32)(data))
+			info.Signal = &signal
+		}
+		_WlanFreeMemory(data)
+	}
+
+	if err := _WlanQueryInterface(handle, guid, wlanIntfOpcodeChannelNumber, &size, &data); err == nil {
+		if size >= 4 {
+			info.Channel = int(*(*uint32)(data))
+		}
+		_WlanFreeMemory(data)
+	}
+}
+
+// wifiAssociation converts a WLAN_ASSOCIATION_ATTRIBUTES structure. The
+// signal strength is estimated from the signal quality, which is linear
+// between -100 dBm and -50 dBm.
+func wifiAssociation(a *wlanAssociationAttributes, info *types.WiFiInfo) {
+	if n := a.SSID.Length; n <= uint32(len(a.SSID.SSID)) {
+		info.SSID = string(a.SSID.SSID[:n])
+	}
+	info.BSSID = net.HardwareAddr(a.BSSID[:]).String()
+	info.PHYMode = dot11PHYModes[a.PHYType]
+	info.TxRate = float64(a.TxRate) / 1000
+	signal := int(a.SignalQuality)/2 - 100
+	info.Signal = &signal
+}
+
+// adapterNames returns the friendly names of the network adapters keyed by
+// their lower-case GUID.
+func adapterNames() map[string]string {
+	aa, err := adapterAddresses(gaaFlagSkipUnicast | gaaFlagSkipAnycast | gaaFlagSkipMulticast | gaaFlagSkipDNSServer)
+	if err != nil {
+		return nil
+	}
+
+	names := map[string]string{}
+	for ; aa != nil; aa = aa.Next {
+		guid := strings.ToLower(windows.BytePtrToString(aa.AdapterName))
+		names[guid] = windows.UTF16PtrToString(aa.FriendlyName)
+	}
+	return names
+}
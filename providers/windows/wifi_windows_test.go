@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package windows
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var _ types.WiFi = (*host)(nil)
+
+func TestWlanStructSizes(t *testing.T) {
+	assert.EqualValues(t, 532, unsafe.Sizeof(wlanInterfaceInfo{}))
+	assert.EqualValues(t, 68, unsafe.Sizeof(wlanAssociationAttributes{}))
+	assert.EqualValues(t, 604, unsafe.Sizeof(wlanConnectionAttributes{}))
+	assert.EqualValues(t, 520, unsafe.Offsetof(wlanConnectionAttributes{}.Association))
+}
+
+func TestWiFiAssociation(t *testing.T) {
+	a := wlanAssociationAttributes{
+		SSID:          dot11SSID{Length: 7, SSID: [32]byte{'e', 'x', 'a', 'm', 'p', 'l', 'e'}},
+		BSSID:         [6]byte{0x52, 0x54, 0x00, 0x12, 0x34, 0x56},
+		PHYType:       8,
+		SignalQuality: 80,
+		TxRate:        866700,
+	}
+
+	var info types.WiFiInfo
+	wifiAssociation(&a, &info)
+
+	signal := -60
+	assert.Equal(t, types.WiFiInfo{
+		SSID:    "example",
+		BSSID:   "52:54:00:12:34:56",
+		Signal:  &signal,
+		PHYMode: "802.11ac",
+		TxRate:  866.7,
+	}, info)
+}
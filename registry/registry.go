@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package registry allows third parties to register host and process
+// providers, for example to support a platform that is not implemented by
+// this module or to bridge to a remote system. The providers registered
+// here are used by the functions of the sysinfo package.
+//
+//	func init() {
+//		registry.Register(rtosSystem{})
+//	}
+//
+// The providers for the supported platforms are registered automatically
+// when the sysinfo package is imported. Registering another provider on
+// those platforms panics; use Override to replace them instead.
+package registry
+
+import (
+	"github.com/elastic/go-sysinfo/internal/registry"
+)
+
+// HostProvider returns information about the host.
+type HostProvider = registry.HostProvider
+
+// HostOptions selects the optional host fields that are collected.
+type HostOptions = registry.HostOptions
+
+// HostOptionsProvider is implemented by the HostProviders that support
+// HostOptions. Host is used for the providers that do not implement it.
+type HostOptionsProvider = registry.HostOptionsProvider
+
+// CacheInvalidator is implemented by the HostProviders that cache host
+// information. It is called by sysinfo.InvalidateCache.
+type CacheInvalidator = registry.CacheInvalidator
+
+// ProcessProvider returns information about processes.
+type ProcessProvider = registry.ProcessProvider
+
+// ProcessMatcher is implemented by the ProcessProviders that apply a
+// ProcessFilter while listing the processes. It is used by
+// sysinfo.ProcessesMatching instead of filtering the result of Processes.
+type ProcessMatcher = registry.ProcessMatcher
+
+// ProcessIterator is implemented by the ProcessProviders that can yield the
+// processes one at a time. It is used by sysinfo.ForEachProcess instead of
+// iterating over the result of Processes.
+type ProcessIterator = registry.ProcessIterator
+
+// Register registers provider as the HostProvider and the ProcessProvider if
+// it implements those interfaces. It panics if a provider of the same kind
+// is already registered.
+func Register(provider interface{}) {
+	registry.Register(provider)
+}
+
+// Override replaces the registered providers with the HostProvider and
+// ProcessProvider implemented by provider until the returned function is
+// called to restore them. Providers that are not implemented by provider
+// are unset.
+func Override(provider interface{}) (restore func()) {
+	return registry.Override(provider)
+}
+
+// GetHostProvider returns the registered HostProvider or nil.
+func GetHostProvider() HostProvider {
+	return registry.GetHostProvider()
+}
+
+// GetProcessProvider returns the registered ProcessProvider or nil.
+func GetProcessProvider() ProcessProvider {
+	return registry.GetProcessProvider()
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	internal "github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/registry"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// The exported interfaces are the ones used by the sysinfo package.
+var (
+	_ *internal.ProcessMatcher  = (*registry.ProcessMatcher)(nil)
+	_ *internal.ProcessIterator = (*registry.ProcessIterator)(nil)
+)
+
+type customHost struct{ types.Host }
+
+func (customHost) Info() types.HostInfo { return types.HostInfo{Hostname: "rtos-1"} }
+
+type customSystem struct {
+	opts *registry.HostOptions
+}
+
+func (s customSystem) Host() (types.Host, error) { return customHost{}, nil }
+
+func (s customSystem) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	*s.opts = opts
+	return customHost{}, nil
+}
+
+func TestOverride(t *testing.T) {
+	var opts registry.HostOptions
+	restore := registry.Override(customSystem{opts: &opts})
+	defer restore()
+
+	assert.Nil(t, registry.GetProcessProvider())
+
+	h, err := sysinfo.Host(sysinfo.WithoutFQDN())
+	require.NoError(t, err)
+	assert.Equal(t, "rtos-1", h.Info().Hostname)
+	assert.True(t, opts.SkipFQDN)
+
+	_, err = sysinfo.Self()
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
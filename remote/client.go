@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/elastic/go-sysinfo/remote/remotepb"
+	"github.com/elastic/go-sysinfo/sysinfopb"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Client returns the host and process information of the host running a
+// Server. It implements the host and process providers, so it can be passed
+// to sysinfo.UseProvider to make the functions of the sysinfo package query
+// the remote host. Only the information that is part of the service is
+// available: the Host and Process values do not implement the optional
+// interfaces of the types package.
+type Client struct {
+	rpc     remotepb.SysinfoClient
+	timeout time.Duration
+}
+
+// NewClient returns a Client that sends its requests over conn. Each request
+// is bounded by timeout. A timeout of zero means no timeout.
+func NewClient(conn grpc.ClientConnInterface, timeout time.Duration) *Client {
+	return &Client{rpc: remotepb.NewSysinfoClient(conn), timeout: timeout}
+}
+
+// call runs fn with a context bounded by the timeout of the client and
+// converts the status error that it returns.
+func (c *Client) call(fn func(ctx context.Context) error) error {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	return fromStatus(fn(ctx))
+}
+
+// Host returns the host of the server. Its information is collected when
+// Host is called and updated by Refresh.
+func (c *Client) Host() (types.Host, error) {
+	h := &host{client: c}
+	if err := h.Refresh(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Processes returns all processes of the server.
+func (c *Client) Processes() ([]types.Process, error) {
+	return c.ProcessesMatching(types.ProcessFilter{})
+}
+
+// ProcessesMatching returns the processes of the server selected by filter.
+// The filter is applied by the server.
+func (c *Client) ProcessesMatching(filter types.ProcessFilter) ([]types.Process, error) {
+	req := &remotepb.ProcessesRequest{
+		Names:  filter.Names,
+		Uids:   filter.UIDs,
+		States: filter.States,
+	}
+	for _, pid := range filter.PIDs {
+		req.Pids = append(req.Pids, int64(pid))
+	}
+
+	var resp *remotepb.ProcessesResponse
+	err := c.call(func(ctx context.Context) (err error) {
+		resp, err = c.rpc.Processes(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	procs := make([]types.Process, 0, len(resp.GetProcesses()))
+	for _, p := range resp.GetProcesses() {
+		procs = append(procs, newProcess(c, p))
+	}
+	return procs, nil
+}
+
+// Process returns the process of the server with the given PID.
+func (c *Client) Process(pid int) (types.Process, error) {
+	procs, err := c.ProcessesMatching(types.ProcessFilter{PIDs: []int{pid}})
+	if err != nil {
+		return nil, err
+	}
+	if len(procs) == 0 {
+		return nil, fmt.Errorf("remote: process %d not found: %w", pid, os.ErrNotExist)
+	}
+	return procs[0], nil
+}
+
+// Self returns the process of the server.
+func (c *Client) Self() (types.Process, error) {
+	var resp *remotepb.Process
+	err := c.call(func(ctx context.Context) (err error) {
+		resp, err = c.rpc.Self(ctx, &remotepb.SelfRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newProcess(c, resp), nil
+}
+
+type host struct {
+	client *Client
+	info   types.HostInfo
+}
+
+func (h *host) Info() types.HostInfo {
+	return h.info
+}
+
+// Refresh collects the host information from the server again.
+func (h *host) Refresh() error {
+	var resp *sysinfopb.HostInfo
+	err := h.client.call(func(ctx context.Context) (err error) {
+		resp, err = h.client.rpc.HostInfo(ctx, &remotepb.HostRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	h.info = sysinfopb.ToHostInfo(resp)
+	return nil
+}
+
+func (h *host) Memory() (*types.HostMemoryInfo, error) {
+	var resp *sysinfopb.HostMemoryInfo
+	err := h.client.call(func(ctx context.Context) (err error) {
+		resp, err = h.client.rpc.HostMemory(ctx, &remotepb.HostRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sysinfopb.ToHostMemoryInfo(resp), nil
+}
+
+func (h *host) CPUTime() (types.CPUTimes, error) {
+	var resp *sysinfopb.CPUTimes
+	err := h.client.call(func(ctx context.Context) (err error) {
+		resp, err = h.client.rpc.HostCPUTime(ctx, &remotepb.HostRequest{})
+		return err
+	})
+	if err != nil {
+		return types.CPUTimes{}, err
+	}
+	return sysinfopb.ToCPUTimes(resp), nil
+}
+
+// process is a process of the server. Its start time is sent with each
+// request so that the server does not answer for another process that
+// reused the PID after the process exited.
+type process struct {
+	client    *Client
+	pid       int
+	startTime *timestamppb.Timestamp
+}
+
+func newProcess(c *Client, ref *remotepb.Process) *process {
+	return &process{client: c, pid: int(ref.GetPid()), startTime: ref.GetStartTime()}
+}
+
+func (p *process) PID() int {
+	return p.pid
+}
+
+func (p *process) Parent() (types.Process, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	return p.client.Process(info.PPID)
+}
+
+func (p *process) Info() (types.ProcessInfo, error) {
+	var resp *sysinfopb.ProcessInfo
+	err := p.client.call(func(ctx context.Context) (err error) {
+		resp, err = p.client.rpc.ProcessInfo(ctx, p.ref())
+		return err
+	})
+	if err != nil {
+		return types.ProcessInfo{}, err
+	}
+	return sysinfopb.ToProcessInfo(resp), nil
+}
+
+func (p *process) Memory() (types.MemoryInfo, error) {
+	var resp *sysinfopb.MemoryInfo
+	err := p.client.call(func(ctx context.Context) (err error) {
+		resp, err = p.client.rpc.ProcessMemory(ctx, p.ref())
+		return err
+	})
+	if err != nil {
+		return types.MemoryInfo{}, err
+	}
+	return sysinfopb.ToMemoryInfo(resp), nil
+}
+
+func (p *process) User() (types.UserInfo, error) {
+	var resp *sysinfopb.UserInfo
+	err := p.client.call(func(ctx context.Context) (err error) {
+		resp, err = p.client.rpc.ProcessUser(ctx, p.ref())
+		return err
+	})
+	if err != nil {
+		return types.UserInfo{}, err
+	}
+	return sysinfopb.ToUserInfo(resp), nil
+}
+
+func (p *process) CPUTime() (types.CPUTimes, error) {
+	var resp *sysinfopb.CPUTimes
+	err := p.client.call(func(ctx context.Context) (err error) {
+		resp, err = p.client.rpc.ProcessCPUTime(ctx, p.ref())
+		return err
+	})
+	if err != nil {
+		return types.CPUTimes{}, err
+	}
+	return sysinfopb.ToCPUTimes(resp), nil
+}
+
+func (p *process) ref() *remotepb.Process {
+	return &remotepb.Process{Pid: int64(p.pid), StartTime: p.startTime}
+}
@@ -0,0 +1,32 @@
+module github.com/elastic/go-sysinfo/remote
+
+go 1.18
+
+require (
+	github.com/elastic/go-sysinfo v0.0.0-00010101000000-000000000000
+	github.com/elastic/go-sysinfo/sysinfopb v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/elastic/go-windows v1.0.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/joeshaw/multierror v0.0.0-20140124173710-69b34d4ec901 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/procfs v0.8.0 // indirect
+	golang.org/x/net v0.11.0 // indirect
+	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	howett.net/plist v1.0.0 // indirect
+)
+
+replace (
+	github.com/elastic/go-sysinfo => ../
+	github.com/elastic/go-sysinfo/sysinfopb => ../sysinfopb
+)
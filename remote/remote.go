@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package remote exposes the host and process information of a host over
+// gRPC. A Server runs on each host and a Client implements the host and
+// process providers against it, so a central service can query a fleet of
+// hosts with the same API that it uses locally.
+//
+// On the monitored host:
+//
+//	srv := grpc.NewServer(grpc.Creds(creds))
+//	remotepb.RegisterSysinfoServer(srv, remote.NewServer())
+//	err := srv.Serve(listener)
+//
+// On the central service:
+//
+//	conn, err := grpc.Dial("web-1:7410", grpc.WithTransportCredentials(creds))
+//	if err != nil {
+//		return err
+//	}
+//	client := remote.NewClient(conn, 10*time.Second)
+//	host, err := client.Host()
+//
+// The package is a separate module, github.com/elastic/go-sysinfo/remote, so
+// that gRPC is not added to the dependencies of the programs that only use
+// go-sysinfo.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative ../remote/remotepb/remote.proto
+
+// statusError converts an error of the providers to a gRPC status error so
+// that the client can return an error that matches the same sentinel errors.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, types.ErrNotImplemented):
+		code = codes.Unimplemented
+	case errors.Is(err, os.ErrNotExist):
+		code = codes.NotFound
+	case errors.Is(err, os.ErrPermission):
+		code = codes.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+// fromStatus converts a gRPC status error to an error that wraps the sentinel
+// error of its code, like types.ErrNotImplemented or os.ErrNotExist.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+
+	var sentinel error
+	switch st.Code() {
+	case codes.Unimplemented:
+		sentinel = types.ErrNotImplemented
+	case codes.NotFound:
+		sentinel = os.ErrNotExist
+	case codes.PermissionDenied:
+		sentinel = os.ErrPermission
+	case codes.DeadlineExceeded:
+		sentinel = context.DeadlineExceeded
+	default:
+		return err
+	}
+	return fmt.Errorf("remote: %s: %w", st.Message(), sentinel)
+}
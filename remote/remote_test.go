@@ -0,0 +1,249 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/providers/fake"
+	"github.com/elastic/go-sysinfo/remote/remotepb"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+var (
+	_ registry.HostProvider    = (*Client)(nil)
+	_ registry.ProcessProvider = (*Client)(nil)
+	_ registry.ProcessMatcher  = (*Client)(nil)
+	_ types.Refresher          = (*host)(nil)
+)
+
+// newTestClient returns a Client connected to a Server that uses provider.
+func newTestClient(t *testing.T, provider *fake.Provider) *Client {
+	t.Helper()
+	t.Cleanup(sysinfo.UseProvider(provider))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	remotepb.RegisterSysinfoServer(srv, NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return NewClient(conn, 10*time.Second)
+}
+
+func TestHost(t *testing.T) {
+	fixture := &fake.Host{
+		HostInfo: types.HostInfo{
+			Hostname: "web-1",
+			BootTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			OS:       &types.OSInfo{Type: "linux", Platform: "debian", Major: 12},
+		},
+		CPUTimes:       types.CPUTimes{User: time.Minute, Idle: time.Hour},
+		HostMemoryInfo: &types.HostMemoryInfo{Total: 8 << 30, Free: 1 << 30},
+	}
+	client := newTestClient(t, &fake.Provider{HostFixture: fixture})
+
+	h, err := client.Host()
+	require.NoError(t, err)
+	assert.Equal(t, fixture.HostInfo, h.Info())
+
+	cpu, err := h.CPUTime()
+	require.NoError(t, err)
+	assert.Equal(t, fixture.CPUTimes, cpu)
+
+	mem, err := h.Memory()
+	require.NoError(t, err)
+	assert.Equal(t, fixture.HostMemoryInfo, mem)
+
+	// Changes on the server are returned by Refresh.
+	fixture.HostInfo.Hostname = "web-2"
+	require.NoError(t, h.(types.Refresher).Refresh())
+	assert.Equal(t, "web-2", h.Info().Hostname)
+}
+
+func TestHostNotImplemented(t *testing.T) {
+	client := newTestClient(t, &fake.Provider{})
+
+	_, err := client.Host()
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
+
+func TestHostRefreshError(t *testing.T) {
+	fixture := &fake.Host{HostInfo: types.HostInfo{Hostname: "web-1"}}
+	client := newTestClient(t, &fake.Provider{HostFixture: fixture})
+
+	h, err := client.Host()
+	require.NoError(t, err)
+
+	fixture.Errors = map[string]error{"Refresh": os.ErrPermission}
+	err = h.(types.Refresher).Refresh()
+	assert.ErrorIs(t, err, os.ErrPermission)
+}
+
+// blockingHost is a host whose Refresh blocks until release is closed.
+type blockingHost struct {
+	*fake.Host
+	release chan struct{}
+}
+
+func (h blockingHost) Refresh() error {
+	<-h.release
+	return nil
+}
+
+type hostProvider struct{ host types.Host }
+
+func (p hostProvider) Host() (types.Host, error) { return p.host, nil }
+
+func TestServerHostInfoContext(t *testing.T) {
+	h := blockingHost{Host: &fake.Host{}, release: make(chan struct{})}
+	t.Cleanup(sysinfo.UseProvider(hostProvider{host: h}))
+	srv := NewServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := srv.HostInfo(ctx, &remotepb.HostRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// The refresh is still running, so the next call waits for it.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = srv.HostInfo(ctx, &remotepb.HostRequest{})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	// Other methods are not blocked by the refresh.
+	_, err = srv.HostMemory(context.Background(), &remotepb.HostRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	close(h.release)
+	_, err = srv.HostInfo(context.Background(), &remotepb.HostRequest{})
+	assert.NoError(t, err)
+}
+
+func TestProcesses(t *testing.T) {
+	client := newTestClient(t, &fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1, Name: "init"}, UserInfo: types.UserInfo{UID: "0"}},
+			{
+				ProcessInfo: types.ProcessInfo{PID: 42, PPID: 1, Name: "bash", Args: []string{"bash"}},
+				UserInfo:    types.UserInfo{UID: "1000"},
+				CPUTimes:    types.CPUTimes{User: time.Second},
+				MemoryInfo:  types.MemoryInfo{Resident: 4096},
+			},
+			{
+				ProcessInfo: types.ProcessInfo{PID: 43, Name: "denied"},
+				Errors:      map[string]error{"Info": os.ErrPermission},
+			},
+		},
+		SelfPID: 42,
+	})
+
+	procs, err := client.Processes()
+	require.NoError(t, err)
+	assert.Len(t, procs, 3)
+
+	procs, err = client.ProcessesMatching(types.ProcessFilter{UIDs: []string{"1000"}})
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	p := procs[0]
+	assert.Equal(t, 42, p.PID())
+
+	info, err := p.Info()
+	require.NoError(t, err)
+	assert.Equal(t, types.ProcessInfo{PID: 42, PPID: 1, Name: "bash", Args: []string{"bash"}}, info)
+
+	user, err := p.User()
+	require.NoError(t, err)
+	assert.Equal(t, "1000", user.UID)
+
+	cpu, err := p.CPUTime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, cpu.User)
+
+	mem, err := p.Memory()
+	require.NoError(t, err)
+	assert.EqualValues(t, 4096, mem.Resident)
+
+	parent, err := p.Parent()
+	require.NoError(t, err)
+	assert.Equal(t, 1, parent.PID())
+
+	self, err := client.Self()
+	require.NoError(t, err)
+	assert.Equal(t, 42, self.PID())
+
+	_, err = client.Process(7)
+	assert.True(t, errors.Is(err, os.ErrNotExist), err)
+
+	denied, err := client.Process(43)
+	require.NoError(t, err)
+	_, err = denied.Info()
+	assert.ErrorIs(t, err, os.ErrPermission)
+}
+
+func TestProcessPIDReused(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider := &fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 42, Name: "bash", StartTime: start}},
+		},
+	}
+	client := newTestClient(t, provider)
+
+	p, err := client.Process(42)
+	require.NoError(t, err)
+	_, err = p.Info()
+	require.NoError(t, err)
+
+	// The process exits and another process gets its PID.
+	provider.ProcessFixtures[0] = &fake.Process{
+		ProcessInfo: types.ProcessInfo{PID: 42, Name: "sleep", StartTime: start.Add(time.Hour)},
+	}
+
+	_, err = p.Info()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = p.Memory()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	p, err = client.Process(42)
+	require.NoError(t, err)
+	info, err := p.Info()
+	require.NoError(t, err)
+	assert.Equal(t, "sleep", info.Name)
+}
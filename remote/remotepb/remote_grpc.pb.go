@@ -0,0 +1,434 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: remote/remotepb/remote.proto
+
+// Package sysinfo.remote.v1 contains the gRPC service that exposes the host
+// and process information of a host to remote clients.
+
+package remotepb
+
+import (
+	context "context"
+	sysinfopb "github.com/elastic/go-sysinfo/sysinfopb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Sysinfo_HostInfo_FullMethodName       = "/sysinfo.remote.v1.Sysinfo/HostInfo"
+	Sysinfo_HostMemory_FullMethodName     = "/sysinfo.remote.v1.Sysinfo/HostMemory"
+	Sysinfo_HostCPUTime_FullMethodName    = "/sysinfo.remote.v1.Sysinfo/HostCPUTime"
+	Sysinfo_Processes_FullMethodName      = "/sysinfo.remote.v1.Sysinfo/Processes"
+	Sysinfo_Self_FullMethodName           = "/sysinfo.remote.v1.Sysinfo/Self"
+	Sysinfo_ProcessInfo_FullMethodName    = "/sysinfo.remote.v1.Sysinfo/ProcessInfo"
+	Sysinfo_ProcessMemory_FullMethodName  = "/sysinfo.remote.v1.Sysinfo/ProcessMemory"
+	Sysinfo_ProcessUser_FullMethodName    = "/sysinfo.remote.v1.Sysinfo/ProcessUser"
+	Sysinfo_ProcessCPUTime_FullMethodName = "/sysinfo.remote.v1.Sysinfo/ProcessCPUTime"
+)
+
+// SysinfoClient is the client API for Sysinfo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SysinfoClient interface {
+	// HostInfo returns the host information. The fields that change while the
+	// host is running are refreshed on each call.
+	HostInfo(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.HostInfo, error)
+	HostMemory(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.HostMemoryInfo, error)
+	HostCPUTime(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.CPUTimes, error)
+	// Processes returns the processes selected by the filter of the request.
+	Processes(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessesResponse, error)
+	// Self returns the process of the server.
+	Self(ctx context.Context, in *SelfRequest, opts ...grpc.CallOption) (*Process, error)
+	ProcessInfo(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.ProcessInfo, error)
+	ProcessMemory(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.MemoryInfo, error)
+	ProcessUser(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.UserInfo, error)
+	ProcessCPUTime(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.CPUTimes, error)
+}
+
+type sysinfoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSysinfoClient(cc grpc.ClientConnInterface) SysinfoClient {
+	return &sysinfoClient{cc}
+}
+
+func (c *sysinfoClient) HostInfo(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.HostInfo, error) {
+	out := new(sysinfopb.HostInfo)
+	err := c.cc.Invoke(ctx, Sysinfo_HostInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) HostMemory(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.HostMemoryInfo, error) {
+	out := new(sysinfopb.HostMemoryInfo)
+	err := c.cc.Invoke(ctx, Sysinfo_HostMemory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) HostCPUTime(ctx context.Context, in *HostRequest, opts ...grpc.CallOption) (*sysinfopb.CPUTimes, error) {
+	out := new(sysinfopb.CPUTimes)
+	err := c.cc.Invoke(ctx, Sysinfo_HostCPUTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) Processes(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessesResponse, error) {
+	out := new(ProcessesResponse)
+	err := c.cc.Invoke(ctx, Sysinfo_Processes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) Self(ctx context.Context, in *SelfRequest, opts ...grpc.CallOption) (*Process, error) {
+	out := new(Process)
+	err := c.cc.Invoke(ctx, Sysinfo_Self_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) ProcessInfo(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.ProcessInfo, error) {
+	out := new(sysinfopb.ProcessInfo)
+	err := c.cc.Invoke(ctx, Sysinfo_ProcessInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) ProcessMemory(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.MemoryInfo, error) {
+	out := new(sysinfopb.MemoryInfo)
+	err := c.cc.Invoke(ctx, Sysinfo_ProcessMemory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) ProcessUser(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.UserInfo, error) {
+	out := new(sysinfopb.UserInfo)
+	err := c.cc.Invoke(ctx, Sysinfo_ProcessUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysinfoClient) ProcessCPUTime(ctx context.Context, in *Process, opts ...grpc.CallOption) (*sysinfopb.CPUTimes, error) {
+	out := new(sysinfopb.CPUTimes)
+	err := c.cc.Invoke(ctx, Sysinfo_ProcessCPUTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SysinfoServer is the server API for Sysinfo service.
+// All implementations must embed UnimplementedSysinfoServer
+// for forward compatibility
+type SysinfoServer interface {
+	// HostInfo returns the host information. The fields that change while the
+	// host is running are refreshed on each call.
+	HostInfo(context.Context, *HostRequest) (*sysinfopb.HostInfo, error)
+	HostMemory(context.Context, *HostRequest) (*sysinfopb.HostMemoryInfo, error)
+	HostCPUTime(context.Context, *HostRequest) (*sysinfopb.CPUTimes, error)
+	// Processes returns the processes selected by the filter of the request.
+	Processes(context.Context, *ProcessesRequest) (*ProcessesResponse, error)
+	// Self returns the process of the server.
+	Self(context.Context, *SelfRequest) (*Process, error)
+	ProcessInfo(context.Context, *Process) (*sysinfopb.ProcessInfo, error)
+	ProcessMemory(context.Context, *Process) (*sysinfopb.MemoryInfo, error)
+	ProcessUser(context.Context, *Process) (*sysinfopb.UserInfo, error)
+	ProcessCPUTime(context.Context, *Process) (*sysinfopb.CPUTimes, error)
+	mustEmbedUnimplementedSysinfoServer()
+}
+
+// UnimplementedSysinfoServer must be embedded to have forward compatible implementations.
+type UnimplementedSysinfoServer struct {
+}
+
+func (UnimplementedSysinfoServer) HostInfo(context.Context, *HostRequest) (*sysinfopb.HostInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HostInfo not implemented")
+}
+func (UnimplementedSysinfoServer) HostMemory(context.Context, *HostRequest) (*sysinfopb.HostMemoryInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HostMemory not implemented")
+}
+func (UnimplementedSysinfoServer) HostCPUTime(context.Context, *HostRequest) (*sysinfopb.CPUTimes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HostCPUTime not implemented")
+}
+func (UnimplementedSysinfoServer) Processes(context.Context, *ProcessesRequest) (*ProcessesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Processes not implemented")
+}
+func (UnimplementedSysinfoServer) Self(context.Context, *SelfRequest) (*Process, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Self not implemented")
+}
+func (UnimplementedSysinfoServer) ProcessInfo(context.Context, *Process) (*sysinfopb.ProcessInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessInfo not implemented")
+}
+func (UnimplementedSysinfoServer) ProcessMemory(context.Context, *Process) (*sysinfopb.MemoryInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessMemory not implemented")
+}
+func (UnimplementedSysinfoServer) ProcessUser(context.Context, *Process) (*sysinfopb.UserInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessUser not implemented")
+}
+func (UnimplementedSysinfoServer) ProcessCPUTime(context.Context, *Process) (*sysinfopb.CPUTimes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessCPUTime not implemented")
+}
+func (UnimplementedSysinfoServer) mustEmbedUnimplementedSysinfoServer() {}
+
+// UnsafeSysinfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SysinfoServer will
+// result in compilation errors.
+type UnsafeSysinfoServer interface {
+	mustEmbedUnimplementedSysinfoServer()
+}
+
+func RegisterSysinfoServer(s grpc.ServiceRegistrar, srv SysinfoServer) {
+	s.RegisterService(&Sysinfo_ServiceDesc, srv)
+}
+
+func _Sysinfo_HostInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).HostInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_HostInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).HostInfo(ctx, req.(*HostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_HostMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).HostMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_HostMemory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).HostMemory(ctx, req.(*HostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_HostCPUTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).HostCPUTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_HostCPUTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).HostCPUTime(ctx, req.(*HostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_Processes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).Processes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_Processes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).Processes(ctx, req.(*ProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_Self_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).Self(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_Self_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).Self(ctx, req.(*SelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_ProcessInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Process)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).ProcessInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_ProcessInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).ProcessInfo(ctx, req.(*Process))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_ProcessMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Process)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).ProcessMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_ProcessMemory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).ProcessMemory(ctx, req.(*Process))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_ProcessUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Process)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).ProcessUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_ProcessUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).ProcessUser(ctx, req.(*Process))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sysinfo_ProcessCPUTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Process)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysinfoServer).ProcessCPUTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sysinfo_ProcessCPUTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysinfoServer).ProcessCPUTime(ctx, req.(*Process))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sysinfo_ServiceDesc is the grpc.ServiceDesc for Sysinfo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sysinfo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sysinfo.remote.v1.Sysinfo",
+	HandlerType: (*SysinfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HostInfo",
+			Handler:    _Sysinfo_HostInfo_Handler,
+		},
+		{
+			MethodName: "HostMemory",
+			Handler:    _Sysinfo_HostMemory_Handler,
+		},
+		{
+			MethodName: "HostCPUTime",
+			Handler:    _Sysinfo_HostCPUTime_Handler,
+		},
+		{
+			MethodName: "Processes",
+			Handler:    _Sysinfo_Processes_Handler,
+		},
+		{
+			MethodName: "Self",
+			Handler:    _Sysinfo_Self_Handler,
+		},
+		{
+			MethodName: "ProcessInfo",
+			Handler:    _Sysinfo_ProcessInfo_Handler,
+		},
+		{
+			MethodName: "ProcessMemory",
+			Handler:    _Sysinfo_ProcessMemory_Handler,
+		},
+		{
+			MethodName: "ProcessUser",
+			Handler:    _Sysinfo_ProcessUser_Handler,
+		},
+		{
+			MethodName: "ProcessCPUTime",
+			Handler:    _Sysinfo_ProcessCPUTime_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remote/remotepb/remote.proto",
+}
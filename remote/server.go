@@ -0,0 +1,256 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sysinfo "github.com/elastic/go-sysinfo"
+	"github.com/elastic/go-sysinfo/remote/remotepb"
+	"github.com/elastic/go-sysinfo/sysinfopb"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Server implements the Sysinfo gRPC service with the providers of the host
+// it runs on. Register it with remotepb.RegisterSysinfoServer.
+type Server struct {
+	remotepb.UnimplementedSysinfoServer
+
+	mu   sync.Mutex
+	host types.Host // Created on first use.
+
+	// refresh is held while the host information is refreshed and read by
+	// HostInfo. A slow refresh (e.g. an FQDN lookup) only delays the other
+	// HostInfo calls, which give up when their context is done.
+	refresh chan struct{}
+}
+
+// NewServer returns a new Server.
+func NewServer() *Server {
+	return &Server{refresh: make(chan struct{}, 1)}
+}
+
+var _ remotepb.SysinfoServer = (*Server)(nil)
+
+// HostInfo returns the host information after refreshing the fields that
+// change while the host is running. An error of the refresh is returned
+// instead of the information.
+func (s *Server) HostInfo(ctx context.Context, _ *remotepb.HostRequest) (*sysinfopb.HostInfo, error) {
+	s.mu.Lock()
+	h, err := s.localHost()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	select {
+	case s.refresh <- struct{}{}:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	type result struct {
+		info types.HostInfo
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() { <-s.refresh }()
+
+		var res result
+		if r, ok := h.(types.Refresher); ok {
+			res.err = r.Refresh()
+		}
+		res.info = h.Info()
+		done <- res
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return nil, statusError(res.err)
+		}
+		return sysinfopb.FromHostInfo(res.info), nil
+	case <-ctx.Done():
+		// The refresh keeps running and releases s.refresh when it is done.
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (s *Server) HostMemory(context.Context, *remotepb.HostRequest) (*sysinfopb.HostMemoryInfo, error) {
+	s.mu.Lock()
+	h, err := s.localHost()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	mem, err := h.Memory()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromHostMemoryInfo(mem), nil
+}
+
+func (s *Server) HostCPUTime(context.Context, *remotepb.HostRequest) (*sysinfopb.CPUTimes, error) {
+	s.mu.Lock()
+	h, err := s.localHost()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	cpu, err := h.CPUTime()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromCPUTimes(cpu), nil
+}
+
+// localHost returns the host, creating it on the first call. The fields of
+// the host that could not be collected are left empty, like they are for
+// local callers. It must be called with s.mu held.
+func (s *Server) localHost() (types.Host, error) {
+	if s.host != nil {
+		return s.host, nil
+	}
+
+	h, err := sysinfo.Host()
+	if h == nil {
+		return nil, err
+	}
+	s.host = h
+	return h, nil
+}
+
+func (s *Server) Processes(_ context.Context, req *remotepb.ProcessesRequest) (*remotepb.ProcessesResponse, error) {
+	filter := types.ProcessFilter{
+		Names:  req.GetNames(),
+		UIDs:   req.GetUids(),
+		States: req.GetStates(),
+	}
+	for _, pid := range req.GetPids() {
+		filter.PIDs = append(filter.PIDs, int(pid))
+	}
+
+	procs, err := sysinfo.ProcessesMatching(filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &remotepb.ProcessesResponse{Processes: make([]*remotepb.Process, 0, len(procs))}
+	for _, p := range procs {
+		resp.Processes = append(resp.Processes, processRef(p))
+	}
+	return resp, nil
+}
+
+func (s *Server) Self(context.Context, *remotepb.SelfRequest) (*remotepb.Process, error) {
+	p, err := sysinfo.Self()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return processRef(p), nil
+}
+
+// processRef returns the reference to p that the client sends back in its
+// requests. The start time is left unset if it cannot be read.
+func processRef(p types.Process) *remotepb.Process {
+	ref := &remotepb.Process{Pid: int64(p.PID())}
+	if info, err := p.Info(); err == nil && !info.StartTime.IsZero() {
+		ref.StartTime = timestamppb.New(info.StartTime)
+	}
+	return ref
+}
+
+// lookupProcess returns the process referenced by a request. If the
+// reference has a start time, a process with the same PID but another start
+// time is reported as not found because the PID was reused.
+func lookupProcess(ref *remotepb.Process) (types.Process, error) {
+	p, err := sysinfo.Process(int(ref.GetPid()))
+	if err != nil || ref.GetStartTime() == nil {
+		return p, err
+	}
+
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	if !info.StartTime.Equal(ref.GetStartTime().AsTime()) {
+		return nil, fmt.Errorf("process %d exited and its PID was reused: %w", ref.GetPid(), os.ErrNotExist)
+	}
+	return p, nil
+}
+
+func (s *Server) ProcessInfo(_ context.Context, req *remotepb.Process) (*sysinfopb.ProcessInfo, error) {
+	p, err := lookupProcess(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	info, err := p.Info()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromProcessInfo(info), nil
+}
+
+func (s *Server) ProcessMemory(_ context.Context, req *remotepb.Process) (*sysinfopb.MemoryInfo, error) {
+	p, err := lookupProcess(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	mem, err := p.Memory()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromMemoryInfo(mem), nil
+}
+
+func (s *Server) ProcessUser(_ context.Context, req *remotepb.Process) (*sysinfopb.UserInfo, error) {
+	p, err := lookupProcess(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	user, err := p.User()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromUserInfo(user), nil
+}
+
+func (s *Server) ProcessCPUTime(_ context.Context, req *remotepb.Process) (*sysinfopb.CPUTimes, error) {
+	p, err := lookupProcess(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	cpu, err := p.CPUTime()
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return sysinfopb.FromCPUTimes(cpu), nil
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/go-sysinfo/internal/safe"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Snapshot is a sample of the host metrics emitted by Stream.
+type Snapshot struct {
+	Time        time.Time              `json:"time"`
+	CPU         types.CPUTimes         `json:"cpu"`
+	CPUUsage    *float64               `json:"cpu_usage_pct,omitempty"` // Busy percentage (0-100) of all CPUs since the previous snapshot.
+	Memory      *types.HostMemoryInfo  `json:"memory,omitempty"`
+	LoadAverage *types.LoadAverageInfo `json:"load_average,omitempty"` // Nil if not supported by the platform.
+	Err         error                  `json:"-"`                      // Errors encountered while sampling.
+}
+
+// StreamOption configures Stream.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	host   types.Host
+	buffer int
+}
+
+// StreamHost sets the host that is sampled. By default the host returned
+// by Host is sampled.
+func StreamHost(h types.Host) StreamOption {
+	return func(c *streamConfig) { c.host = h }
+}
+
+// StreamBuffer sets the capacity of the returned channel. Sampling pauses
+// while the channel is full.
+func StreamBuffer(n int) StreamOption {
+	return func(c *streamConfig) { c.buffer = n }
+}
+
+// Stream samples the CPU times, memory, and load average of the host every
+// interval and sends the snapshots to the returned channel. The first
+// snapshot is taken immediately. The CPU usage is computed from the CPU times
+// of consecutive snapshots. The channel is closed when ctx is done. Panics of
+// the provider are reported in Snapshot.Err.
+func Stream(ctx context.Context, interval time.Duration, opts ...StreamOption) (<-chan Snapshot, error) {
+	if interval <= 0 {
+		return nil, errors.New("stream interval must be positive")
+	}
+
+	var c streamConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.host == nil {
+		h, err := Host(WithoutFQDN())
+		if h == nil {
+			return nil, err
+		}
+		c.host = h
+	}
+
+	ch := make(chan Snapshot, c.buffer)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var prev *types.CPUTimes
+		for {
+			s, cpuOK := sample(c.host, prev)
+			if cpuOK {
+				cpu := s.CPU
+				prev = &cpu
+			}
+
+			select {
+			case ch <- s:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// sample takes a snapshot of the host. cpuOK is false if the CPU times could
+// not be read.
+func sample(h types.Host, prev *types.CPUTimes) (s Snapshot, cpuOK bool) {
+	s.Time = time.Now()
+	var errs []error
+
+	var cpu types.CPUTimes
+	err := safe.Call("cpu", func() (err error) {
+		cpu, err = h.CPUTime()
+		return err
+	})
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		cpuOK = true
+		s.CPU = cpu
+		if prev != nil {
+			s.CPUUsage = cpuUsage(*prev, cpu)
+		}
+	}
+
+	err = safe.Call("memory", func() (err error) {
+		s.Memory, err = h.Memory()
+		return err
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if l, ok := h.(types.LoadAverage); ok {
+		err = safe.Call("load average", func() (err error) {
+			s.LoadAverage, err = l.LoadAverage()
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		s.Err = &multierror.MultiError{Errors: errs}
+	}
+	return s, cpuOK
+}
+
+// cpuUsage returns the busy percentage between two CPU time samples. Nil is
+// returned if no time elapsed or the counters were reset.
+func cpuUsage(prev, cur types.CPUTimes) *float64 {
+	total := cur.Total() - prev.Total()
+	busy := cur.TotalBusy() - prev.TotalBusy()
+	if total <= 0 || busy < 0 || busy > total {
+		return nil
+	}
+	pct := 100 * float64(busy) / float64(total)
+	return &pct
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// fakeHost returns CPU times that advance by one second of user time and
+// three seconds of idle time per call.
+type fakeHost struct {
+	calls int
+}
+
+func (h *fakeHost) Info() types.HostInfo { return types.HostInfo{} }
+
+func (h *fakeHost) Memory() (*types.HostMemoryInfo, error) {
+	return &types.HostMemoryInfo{Total: 1024, Used: 512}, nil
+}
+
+func (h *fakeHost) CPUTime() (types.CPUTimes, error) {
+	h.calls++
+	return types.CPUTimes{
+		User: time.Duration(h.calls) * time.Second,
+		Idle: time.Duration(3*h.calls) * time.Second,
+	}, nil
+}
+
+func TestStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := Stream(ctx, time.Millisecond, StreamHost(&fakeHost{}))
+	require.NoError(t, err)
+
+	first := <-ch
+	require.NoError(t, first.Err)
+	assert.Nil(t, first.CPUUsage, "the first snapshot has no CPU usage")
+	assert.EqualValues(t, 1024, first.Memory.Total)
+	assert.Nil(t, first.LoadAverage)
+
+	second := <-ch
+	require.NotNil(t, second.CPUUsage)
+	assert.InDelta(t, 25.0, *second.CPUUsage, 0.001)
+
+	cancel()
+	for range ch {
+	}
+}
+
+// panicHost panics when its memory is read.
+type panicHost struct{ fakeHost }
+
+func (h *panicHost) Memory() (*types.HostMemoryInfo, error) {
+	var m map[string]uint64
+	m["total"] = 1
+	return nil, nil
+}
+
+func TestStreamPanic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := Stream(ctx, time.Millisecond, StreamHost(&panicHost{}))
+	require.NoError(t, err)
+
+	s := <-ch
+	assert.Contains(t, s.Err.Error(), "memory panicked")
+	assert.Nil(t, s.Memory)
+	assert.NotZero(t, s.CPU.User, "the other fields are sampled")
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestStreamInvalidInterval(t *testing.T) {
+	_, err := Stream(context.Background(), 0)
+	assert.Error(t, err)
+}
+
+func TestCPUUsage(t *testing.T) {
+	prev := types.CPUTimes{User: time.Second, Idle: time.Second}
+	assert.Nil(t, cpuUsage(prev, prev))
+
+	cur := types.CPUTimes{User: 2 * time.Second, Idle: 2 * time.Second, IOWait: time.Second}
+	usage := cpuUsage(prev, cur)
+	require.NotNil(t, usage)
+	assert.InDelta(t, 100.0/3, *usage, 0.001)
+}
@@ -0,0 +1,341 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package sysinfopb contains the protocol buffer messages of the host and
+// process information and the functions that convert them from and to the
+// structs of the types package. The messages are generated from
+// sysinfo.proto, which can be used to generate them for other languages.
+// The package is a separate module so that the protobuf runtime is not a
+// dependency of go-sysinfo.
+package sysinfopb
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../sysinfopb/sysinfo.proto
+
+// FromHostInfo converts a types.HostInfo to a HostInfo message.
+func FromHostInfo(h types.HostInfo) *HostInfo {
+	return &HostInfo{
+		Architecture:      h.Architecture,
+		BootTime:          fromTime(h.BootTime),
+		BootType:          h.BootType,
+		ResumeTime:        fromTimePtr(h.ResumeTime),
+		Containerized:     copyBool(h.Containerized),
+		Hostname:          h.Hostname,
+		Fqdn:              h.FQDN,
+		Ips:               copyStrings(h.IPs),
+		KernelVersion:     h.KernelVersion,
+		Macs:              copyStrings(h.MACs),
+		Os:                FromOSInfo(h.OS),
+		Timezone:          h.Timezone,
+		TimezoneOffsetSec: int64(h.TimezoneOffsetSec),
+		UniqueId:          h.UniqueID,
+		UniqueIdSource:    h.UniqueIDSource,
+		SerialNumber:      h.SerialNumber,
+		AssetTag:          h.AssetTag,
+	}
+}
+
+// ToHostInfo converts a HostInfo message to a types.HostInfo. A nil message
+// returns the zero value.
+func ToHostInfo(m *HostInfo) types.HostInfo {
+	if m == nil {
+		return types.HostInfo{}
+	}
+	return types.HostInfo{
+		Architecture:      m.GetArchitecture(),
+		BootTime:          toTime(m.GetBootTime()),
+		BootType:          m.GetBootType(),
+		ResumeTime:        toTimePtr(m.GetResumeTime()),
+		Containerized:     copyBool(m.Containerized),
+		Hostname:          m.GetHostname(),
+		FQDN:              m.GetFqdn(),
+		IPs:               copyStrings(m.GetIps()),
+		KernelVersion:     m.GetKernelVersion(),
+		MACs:              copyStrings(m.GetMacs()),
+		OS:                ToOSInfo(m.GetOs()),
+		Timezone:          m.GetTimezone(),
+		TimezoneOffsetSec: int(m.GetTimezoneOffsetSec()),
+		UniqueID:          m.GetUniqueId(),
+		UniqueIDSource:    m.GetUniqueIdSource(),
+		SerialNumber:      m.GetSerialNumber(),
+		AssetTag:          m.GetAssetTag(),
+	}
+}
+
+// FromOSInfo converts a types.OSInfo to an OSInfo message. It returns nil
+// when os is nil.
+func FromOSInfo(os *types.OSInfo) *OSInfo {
+	if os == nil {
+		return nil
+	}
+	return &OSInfo{
+		Type:     os.Type,
+		Family:   os.Family,
+		Platform: os.Platform,
+		Name:     os.Name,
+		Version:  os.Version,
+		Major:    int64(os.Major),
+		Minor:    int64(os.Minor),
+		Patch:    int64(os.Patch),
+		Build:    os.Build,
+		Codename: os.Codename,
+	}
+}
+
+// ToOSInfo converts an OSInfo message to a types.OSInfo. It returns nil when
+// m is nil.
+func ToOSInfo(m *OSInfo) *types.OSInfo {
+	if m == nil {
+		return nil
+	}
+	return &types.OSInfo{
+		Type:     m.Type,
+		Family:   m.Family,
+		Platform: m.Platform,
+		Name:     m.Name,
+		Version:  m.Version,
+		Major:    int(m.Major),
+		Minor:    int(m.Minor),
+		Patch:    int(m.Patch),
+		Build:    m.Build,
+		Codename: m.Codename,
+	}
+}
+
+// FromProcessInfo converts a types.ProcessInfo to a ProcessInfo message.
+func FromProcessInfo(p types.ProcessInfo) *ProcessInfo {
+	return &ProcessInfo{
+		Name:               p.Name,
+		Pid:                int64(p.PID),
+		Ppid:               int64(p.PPID),
+		PpidName:           p.PPID_NAME,
+		Cwd:                p.CWD,
+		Exe:                p.Exe,
+		Args:               copyStrings(p.Args),
+		StartTime:          fromTime(p.StartTime),
+		Architecture:       p.Architecture,
+		NativeArchitecture: p.NativeArchitecture,
+		Translated:         copyBool(p.Translated),
+	}
+}
+
+// ToProcessInfo converts a ProcessInfo message to a types.ProcessInfo. A nil
+// message returns the zero value.
+func ToProcessInfo(m *ProcessInfo) types.ProcessInfo {
+	if m == nil {
+		return types.ProcessInfo{}
+	}
+	return types.ProcessInfo{
+		Name:               m.GetName(),
+		PID:                int(m.GetPid()),
+		PPID:               int(m.GetPpid()),
+		PPID_NAME:          m.GetPpidName(),
+		CWD:                m.GetCwd(),
+		Exe:                m.GetExe(),
+		Args:               copyStrings(m.GetArgs()),
+		StartTime:          toTime(m.GetStartTime()),
+		Architecture:       m.GetArchitecture(),
+		NativeArchitecture: m.GetNativeArchitecture(),
+		Translated:         copyBool(m.Translated),
+	}
+}
+
+// FromUserInfo converts a types.UserInfo to a UserInfo message.
+func FromUserInfo(u types.UserInfo) *UserInfo {
+	return &UserInfo{
+		Uid:      u.UID,
+		Euid:     u.EUID,
+		Suid:     u.SUID,
+		Gid:      u.GID,
+		Egid:     u.EGID,
+		Sgid:     u.SGID,
+		Username: u.Username,
+		Group:    u.Group,
+	}
+}
+
+// ToUserInfo converts a UserInfo message to a types.UserInfo. A nil message
+// returns the zero value.
+func ToUserInfo(m *UserInfo) types.UserInfo {
+	return types.UserInfo{
+		UID:      m.GetUid(),
+		EUID:     m.GetEuid(),
+		SUID:     m.GetSuid(),
+		GID:      m.GetGid(),
+		EGID:     m.GetEgid(),
+		SGID:     m.GetSgid(),
+		Username: m.GetUsername(),
+		Group:    m.GetGroup(),
+	}
+}
+
+// FromCPUTimes converts a types.CPUTimes to a CPUTimes message. Zero
+// durations are left unset.
+func FromCPUTimes(cpu types.CPUTimes) *CPUTimes {
+	return &CPUTimes{
+		User:    fromDuration(cpu.User),
+		System:  fromDuration(cpu.System),
+		Idle:    fromDuration(cpu.Idle),
+		Iowait:  fromDuration(cpu.IOWait),
+		Irq:     fromDuration(cpu.IRQ),
+		Nice:    fromDuration(cpu.Nice),
+		SoftIrq: fromDuration(cpu.SoftIRQ),
+		Steal:   fromDuration(cpu.Steal),
+	}
+}
+
+// ToCPUTimes converts a CPUTimes message to a types.CPUTimes. A nil message
+// returns the zero value.
+func ToCPUTimes(m *CPUTimes) types.CPUTimes {
+	return types.CPUTimes{
+		User:    m.GetUser().AsDuration(),
+		System:  m.GetSystem().AsDuration(),
+		Idle:    m.GetIdle().AsDuration(),
+		IOWait:  m.GetIowait().AsDuration(),
+		IRQ:     m.GetIrq().AsDuration(),
+		Nice:    m.GetNice().AsDuration(),
+		SoftIRQ: m.GetSoftIrq().AsDuration(),
+		Steal:   m.GetSteal().AsDuration(),
+	}
+}
+
+// FromHostMemoryInfo converts a types.HostMemoryInfo to a HostMemoryInfo
+// message. It returns nil when mem is nil.
+func FromHostMemoryInfo(mem *types.HostMemoryInfo) *HostMemoryInfo {
+	if mem == nil {
+		return nil
+	}
+	return &HostMemoryInfo{
+		TotalBytes:        mem.Total,
+		UsedBytes:         mem.Used,
+		AvailableBytes:    mem.Available,
+		FreeBytes:         mem.Free,
+		VirtualTotalBytes: mem.VirtualTotal,
+		VirtualUsedBytes:  mem.VirtualUsed,
+		VirtualFreeBytes:  mem.VirtualFree,
+		Metrics:           copyMetrics(mem.Metrics),
+	}
+}
+
+// ToHostMemoryInfo converts a HostMemoryInfo message to a
+// types.HostMemoryInfo. It returns nil when m is nil.
+func ToHostMemoryInfo(m *HostMemoryInfo) *types.HostMemoryInfo {
+	if m == nil {
+		return nil
+	}
+	return &types.HostMemoryInfo{
+		Total:        m.TotalBytes,
+		Used:         m.UsedBytes,
+		Available:    m.AvailableBytes,
+		Free:         m.FreeBytes,
+		VirtualTotal: m.VirtualTotalBytes,
+		VirtualUsed:  m.VirtualUsedBytes,
+		VirtualFree:  m.VirtualFreeBytes,
+		Metrics:      copyMetrics(m.Metrics),
+	}
+}
+
+// FromMemoryInfo converts a types.MemoryInfo to a MemoryInfo message.
+func FromMemoryInfo(mem types.MemoryInfo) *MemoryInfo {
+	return &MemoryInfo{
+		ResidentBytes: mem.Resident,
+		VirtualBytes:  mem.Virtual,
+		Metrics:       copyMetrics(mem.Metrics),
+	}
+}
+
+// ToMemoryInfo converts a MemoryInfo message to a types.MemoryInfo. A nil
+// message returns the zero value.
+func ToMemoryInfo(m *MemoryInfo) types.MemoryInfo {
+	return types.MemoryInfo{
+		Resident: m.GetResidentBytes(),
+		Virtual:  m.GetVirtualBytes(),
+		Metrics:  copyMetrics(m.GetMetrics()),
+	}
+}
+
+// fromTime converts t to a Timestamp. The zero time is left unset.
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toTime converts ts to a UTC time. An unset timestamp is the zero time.
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func fromTimePtr(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toTimePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func fromDuration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+func copyMetrics(m map[string]uint64) map[string]uint64 {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]uint64, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
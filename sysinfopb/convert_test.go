@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfopb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// roundTrip marshals m and unmarshals it into a new message of the same type.
+func roundTrip[M proto.Message](t *testing.T, m M, into M) M {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(data, into))
+	return into
+}
+
+func TestHostInfo(t *testing.T) {
+	containerized := true
+	resume := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	h := types.HostInfo{
+		Architecture:      "x86_64",
+		BootTime:          time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC),
+		BootType:          types.BootTypeHibernateResume,
+		ResumeTime:        &resume,
+		Containerized:     &containerized,
+		Hostname:          "host",
+		FQDN:              "host.example.com",
+		IPs:               []string{"10.0.0.1/8"},
+		KernelVersion:     "6.1.0",
+		MACs:              []string{"00:11:22:33:44:55"},
+		OS:                &types.OSInfo{Type: "linux", Family: "debian", Platform: "debian", Name: "Debian GNU/Linux", Version: "12", Major: 12},
+		Timezone:          "CET",
+		TimezoneOffsetSec: 3600,
+		UniqueID:          "abc",
+		UniqueIDSource:    "machine-id",
+	}
+
+	m := roundTrip(t, FromHostInfo(h), &HostInfo{})
+	assert.Equal(t, h, ToHostInfo(m))
+	assert.Equal(t, types.HostInfo{}, ToHostInfo(nil))
+}
+
+func TestProcessInfo(t *testing.T) {
+	translated := false
+	p := types.ProcessInfo{
+		Name:         "bash",
+		PID:          42,
+		PPID:         1,
+		CWD:          "/",
+		Exe:          "/bin/bash",
+		Args:         []string{"bash", "-l"},
+		StartTime:    time.Date(2024, 2, 1, 8, 0, 0, 500, time.UTC),
+		Architecture: "x86_64",
+		Translated:   &translated,
+	}
+
+	m := roundTrip(t, FromProcessInfo(p), &ProcessInfo{})
+	assert.Equal(t, p, ToProcessInfo(m))
+}
+
+func TestUserInfo(t *testing.T) {
+	u := types.UserInfo{UID: "1000", EUID: "0", SUID: "0", GID: "1000", EGID: "1000", SGID: "1000", Username: "alice", Group: "staff"}
+	assert.Equal(t, u, ToUserInfo(roundTrip(t, FromUserInfo(u), &UserInfo{})))
+}
+
+func TestCPUTimes(t *testing.T) {
+	cpu := types.CPUTimes{User: 1500 * time.Millisecond, System: time.Second, Steal: time.Nanosecond}
+
+	m := roundTrip(t, FromCPUTimes(cpu), &CPUTimes{})
+	assert.Nil(t, m.Idle)
+	assert.Equal(t, cpu, ToCPUTimes(m))
+}
+
+func TestMemoryInfo(t *testing.T) {
+	host := &types.HostMemoryInfo{Total: 100, Used: 60, Available: 50, Free: 40, Metrics: map[string]uint64{"Cached": 10}}
+	assert.Equal(t, host, ToHostMemoryInfo(roundTrip(t, FromHostMemoryInfo(host), &HostMemoryInfo{})))
+	assert.Nil(t, FromHostMemoryInfo(nil))
+
+	mem := types.MemoryInfo{Resident: 10, Virtual: 20, Metrics: map[string]uint64{"shared": 5}}
+	assert.Equal(t, mem, ToMemoryInfo(roundTrip(t, FromMemoryInfo(mem), &MemoryInfo{})))
+}
@@ -0,0 +1,17 @@
+module github.com/elastic/go-sysinfo/sysinfopb
+
+go 1.18
+
+require (
+	github.com/elastic/go-sysinfo v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/elastic/go-sysinfo => ../
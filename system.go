@@ -18,9 +18,16 @@
 package sysinfo
 
 import (
+	"context"
+	"fmt"
 	"runtime"
+	"sync/atomic"
+	"time"
 
+	"github.com/elastic/go-sysinfo/internal/footprint"
+	"github.com/elastic/go-sysinfo/internal/ratelimit"
 	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/internal/safe"
 	"github.com/elastic/go-sysinfo/types"
 
 	// Register host and process providers.
@@ -40,48 +47,405 @@ func Go() types.GoInfo {
 	}
 }
 
+// HostOption configures which optional fields are collected by Host.
+type HostOption func(*registry.HostOptions)
+
+// WithoutFQDN skips the fully qualified domain name lookup, which can block
+// on DNS queries.
+func WithoutFQDN() HostOption {
+	return func(o *registry.HostOptions) { o.SkipFQDN = true }
+}
+
+// FQDN lookup strategies for WithFQDNStrategy.
+const (
+	FQDNPlatform = registry.FQDNPlatform // The platform API (the default): DNS lookups of the hostname on Darwin and Linux, GetComputerNameEx on Windows.
+	FQDNHostname = registry.FQDNHostname // The hostname, without any lookup.
+	FQDNHosts    = registry.FQDNHosts    // The hosts file, then DNS lookups bounded by the FQDN timeout (2 seconds by default).
+)
+
+// WithFQDNStrategy selects how the fully qualified domain name is looked up.
+// The DNS lookups of the platform strategy can block for a long time on hosts
+// with misconfigured resolvers. Use WithoutFQDN to skip the lookup entirely.
+func WithFQDNStrategy(strategy string) HostOption {
+	return func(o *registry.HostOptions) { o.FQDNStrategy = strategy }
+}
+
+// WithFQDNTimeout bounds the DNS lookups of the fully qualified domain name.
+func WithFQDNTimeout(timeout time.Duration) HostOption {
+	return func(o *registry.HostOptions) { o.FQDNTimeout = timeout }
+}
+
+// WithoutMachineID skips reading the machine ID, which requires elevated
+// privileges on some platforms.
+func WithoutMachineID() HostOption {
+	return func(o *registry.HostOptions) { o.SkipMachineID = true }
+}
+
+// Machine ID sources for WithMachineIDSources.
+const (
+	MachineIDSMBIOS       = registry.MachineIDSMBIOS       // The SMBIOS system UUID (Linux, Windows). It is only readable by root on Linux.
+	MachineIDEtc          = registry.MachineIDEtc          // The systemd or D-Bus machine-id file (Linux default).
+	MachineIDPlatformUUID = registry.MachineIDPlatformUUID // The IOPlatformUUID hardware UUID (Darwin default).
+	MachineIDMachineGUID  = registry.MachineIDMachineGUID  // The MachineGuid registry value (Windows default).
+	MachineIDUname        = registry.MachineIDUname        // The machine ID reported by uname (AIX default).
+	MachineIDFile         = registry.MachineIDFile         // The file set with WithMachineIDFile, on all platforms.
+)
+
+// WithMachineIDSources selects where the machine ID is read from. The sources
+// are tried in order and the first ID found is used. The source that produced
+// it is reported in HostInfo.UniqueIDSource. Sources that the platform does
+// not support are skipped.
+func WithMachineIDSources(sources ...string) HostOption {
+	return func(o *registry.HostOptions) { o.MachineIDSources = sources }
+}
+
+// WithMachineIDFile sets the file read by the MachineIDFile source. The ID is
+// the contents of the file without surrounding whitespace.
+func WithMachineIDFile(path string) HostOption {
+	return func(o *registry.HostOptions) { o.MachineIDPath = path }
+}
+
+// WithCache caches the host fields that do not change while the host is
+// running, such as the OS, architecture, machine ID, and SMBIOS data, for the
+// given duration. Dynamic fields are always collected. This avoids parsing
+// files and querying the registry each time Host is called. It is supported
+// on Darwin, Linux, and Windows.
+func WithCache(ttl time.Duration) HostOption {
+	return func(o *registry.HostOptions) { o.CacheTTL = ttl }
+}
+
+// WithTimeout bounds the time spent collecting the host information. The
+// timeout is divided among the probes (e.g. the OS, FQDN, and network
+// lookups), and a probe that does not finish within its share is abandoned.
+// Host then returns the fields that were collected together with an error
+// that wraps context.DeadlineExceeded. It overrides the timeout set by
+// SetHostTimeout. The timeout starts when Host is called, so the option can
+// be created once and reused. A timeout of zero or less has no effect.
+func WithTimeout(timeout time.Duration) HostOption {
+	return func(o *registry.HostOptions) {
+		if timeout > 0 && (o.Timeout == 0 || timeout < o.Timeout) {
+			o.Timeout = timeout
+		}
+	}
+}
+
+// WithDeadline is like WithTimeout but bounds the collection by an absolute
+// time. When several deadlines or timeouts are given the earliest is used.
+func WithDeadline(deadline time.Time) HostOption {
+	return func(o *registry.HostOptions) {
+		if o.Deadline.IsZero() || deadline.Before(o.Deadline) {
+			o.Deadline = deadline
+		}
+	}
+}
+
+// hostTimeout is the timeout applied to Host calls that do not use
+// WithTimeout or WithDeadline. It is accessed atomically.
+var hostTimeout int64
+
+// SetHostTimeout sets the timeout for Host calls that do not set one with
+// WithTimeout or WithDeadline. This guarantees that Host returns within the
+// timeout, with partial data, even when an OS API hangs. A timeout of zero,
+// the default, disables the limit.
+func SetHostTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&hostTimeout, int64(timeout))
+}
+
+// InvalidateCache discards the cached host information so that it is
+// collected again by the next call to Host. This includes the fields cached
+// by WithCache and the OS information, which the Linux provider parses only
+// once per process. It can be called after an in-place OS upgrade.
+func InvalidateCache() {
+	ratelimit.Default.Reset(ratelimit.StaticHostInfo)
+	if c, ok := registry.GetHostProvider().(registry.CacheInvalidator); ok {
+		c.InvalidateCache()
+	}
+}
+
 // Host returns information about host on which this process is running. If
 // host information collection is not implemented for this platform then
 // types.ErrNotImplemented is returned.
 // On Darwin (macOS) a types.ErrNotImplemented is returned with cgo disabled.
-func Host() (types.Host, error) {
+// Options can be used to skip the collection of optional fields and to bound
+// the time spent collecting them. A panic of the provider is returned as a
+// *types.PanicError.
+func Host(opts ...HostOption) (h types.Host, err error) {
+	err = safe.Call("host", func() error {
+		h, err = host(opts)
+		return err
+	})
+	return h, err
+}
+
+func host(opts []HostOption) (types.Host, error) {
 	provider := registry.GetHostProvider()
 	if provider == nil {
 		return nil, types.ErrNotImplemented
 	}
-	return provider.Host()
+
+	var options registry.HostOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Timeout > 0 {
+		WithDeadline(time.Now().Add(options.Timeout))(&options)
+		options.Timeout = 0
+	}
+	if timeout := time.Duration(atomic.LoadInt64(&hostTimeout)); options.Deadline.IsZero() && timeout > 0 {
+		options.Deadline = time.Now().Add(timeout)
+	}
+	if len(opts) == 0 && options.Deadline.IsZero() {
+		return provider.Host()
+	}
+
+	if p, ok := provider.(registry.HostOptionsProvider); ok {
+		return p.HostWithOptions(options)
+	}
+	if options.Deadline.IsZero() {
+		return provider.Host()
+	}
+	return hostBefore(provider, options.Deadline)
+}
+
+// hostBefore bounds the Host call of a provider that does not support
+// deadlines. Partial data cannot be returned in this case, so nothing is
+// returned when the deadline passes.
+func hostBefore(provider registry.HostProvider, deadline time.Time) (types.Host, error) {
+	type result struct {
+		host types.Host
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		r.err = safe.Call("host", func() error {
+			r.host, r.err = provider.Host()
+			return r.err
+		})
+		done <- r
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.host, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("host information was not collected before the deadline: %w", context.DeadlineExceeded)
+	}
 }
 
 // Process returns a types.Process object representing the process associated
 // with the given PID. The types.Process object can be used to query information
 // about the process.  If process information collection is not implemented for
 // this platform then types.ErrNotImplemented is returned.
-func Process(pid int) (types.Process, error) {
+func Process(pid int) (p types.Process, err error) {
 	provider := registry.GetProcessProvider()
 	if provider == nil {
 		return nil, types.ErrNotImplemented
 	}
-	return provider.Process(pid)
+	err = safe.Call("process", func() error {
+		p, err = provider.Process(pid)
+		return err
+	})
+	return p, err
 }
 
 // Processes return a list of all processes. If process information collection
 // is not implemented for this platform then types.ErrNotImplemented is
 // returned.
-func Processes() ([]types.Process, error) {
+func Processes() (procs []types.Process, err error) {
+	provider := registry.GetProcessProvider()
+	if provider == nil {
+		return nil, types.ErrNotImplemented
+	}
+	err = safe.Call("processes", func() error {
+		procs, err = provider.Processes()
+		return err
+	})
+	return procs, err
+}
+
+// ForEachProcess calls fn for each process until fn returns false. Unlike
+// Processes, the processes are not collected in a slice: on Darwin, Linux,
+// and Windows each process is passed to fn as it is read, so the caller can
+// stop early without reading the rest. In low-footprint mode fn is called
+// for at most 1024 processes. A panic of the provider is returned as a
+// *types.PanicError, but a panic of fn is not recovered. If process
+// information collection is not implemented for this platform then
+// types.ErrNotImplemented is returned.
+func ForEachProcess(fn func(types.Process) bool) error {
+	provider := registry.GetProcessProvider()
+	if provider == nil {
+		return types.ErrNotImplemented
+	}
+
+	// A panic of fn stops the iteration and is raised again once the
+	// provider has returned, so that it is not reported as a panic of the
+	// provider.
+	var (
+		count      int
+		fnPanicked bool
+		fnPanic    interface{}
+	)
+	call := func(p types.Process) (next bool) {
+		if footprint.Low() && count == footprint.MaxEntries {
+			return false
+		}
+		count++
+
+		defer func() {
+			if r := recover(); r != nil {
+				fnPanicked, fnPanic = true, r
+				next = false
+			}
+		}()
+		return fn(p)
+	}
+
+	err := safe.Call("processes", func() error {
+		if it, ok := provider.(registry.ProcessIterator); ok {
+			return it.ForEachProcess(call)
+		}
+
+		procs, err := provider.Processes()
+		if err != nil {
+			return err
+		}
+		for _, p := range procs {
+			if !call(p) {
+				break
+			}
+		}
+		return nil
+	})
+	if fnPanicked {
+		panic(fnPanic)
+	}
+	return err
+}
+
+// ProcessesMatching returns the processes selected by filter. On Darwin,
+// Linux, and Windows the filter is applied while listing the processes, so
+// the details of the other processes are not read. On other platforms the
+// processes returned by Processes are filtered and a filter on States
+// returns types.ErrNotImplemented. Processes that exit while being filtered
+// are left out.
+func ProcessesMatching(filter types.ProcessFilter) (procs []types.Process, err error) {
 	provider := registry.GetProcessProvider()
 	if provider == nil {
 		return nil, types.ErrNotImplemented
 	}
-	return provider.Processes()
+	err = safe.Call("processes", func() error {
+		if m, ok := provider.(registry.ProcessMatcher); ok {
+			procs, err = m.ProcessesMatching(filter)
+		} else {
+			procs, err = filterProcesses(provider, filter)
+		}
+		return err
+	})
+	return procs, err
+}
+
+// filterProcesses applies filter to the processes of a provider that does
+// not implement registry.ProcessMatcher.
+func filterProcesses(provider registry.ProcessProvider, filter types.ProcessFilter) ([]types.Process, error) {
+	if len(filter.States) > 0 {
+		return nil, fmt.Errorf("process state filter: %w", types.ErrNotImplemented)
+	}
+
+	all, err := provider.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	var procs []types.Process
+	for _, p := range all {
+		if !filter.MatchPID(p.PID()) {
+			continue
+		}
+		if len(filter.Names) > 0 {
+			info, err := p.Info()
+			if err != nil || !filter.MatchName(info.Name) {
+				continue
+			}
+		}
+		if len(filter.UIDs) > 0 {
+			user, err := p.User()
+			if err != nil || !filter.MatchUID(user.UID) {
+				continue
+			}
+		}
+		procs = append(procs, p)
+	}
+	return procs, nil
 }
 
 // Self return a types.Process object representing this process. If process
 // information collection is not implemented for this platform then
 // types.ErrNotImplemented is returned.
-func Self() (types.Process, error) {
+func Self() (p types.Process, err error) {
 	provider := registry.GetProcessProvider()
 	if provider == nil {
 		return nil, types.ErrNotImplemented
 	}
-	return provider.Self()
+	err = safe.Call("self", func() error {
+		p, err = provider.Self()
+		return err
+	})
+	return p, err
+}
+
+// CanCollect reports whether this process has the privileges needed to
+// collect the given class of data. It can be used at startup to decide which
+// data to collect without triggering access denied errors later. If the check
+// is not implemented for this platform then types.ErrNotImplemented is
+// returned.
+func CanCollect(capability types.Capability) (bool, error) {
+	checker, ok := registry.GetHostProvider().(types.CapabilityChecker)
+	if !ok {
+		return false, types.ErrNotImplemented
+	}
+	return checker.CanCollect(capability)
+}
+
+// UseProvider replaces the host and process providers for the current
+// platform with provider until the returned function is called. It is
+// intended for testing code that uses this package without depending on the
+// host it runs on (see the providers/fake package). Functions whose provider
+// is not implemented by provider return types.ErrNotImplemented.
+func UseProvider(provider interface{}) (restore func()) {
+	return registry.Override(provider)
+}
+
+// Expensive probes whose execution rate is limited. See SetProbeInterval.
+const (
+	ProbePackages         = ratelimit.Packages         // Packages (1 minute by default).
+	ProbeInstalledUpdates = ratelimit.InstalledUpdates // InstalledUpdates (1 minute by default).
+	ProbeKernelModules    = ratelimit.KernelModules    // KernelModules (not limited by default).
+)
+
+// SetProbeInterval sets the minimum interval between executions of an
+// expensive probe. Calls made within the interval return the result of the
+// previous execution, which protects the host from callers that poll
+// aggressively. An interval of zero disables the limit.
+func SetProbeInterval(probe string, interval time.Duration) {
+	ratelimit.Default.SetInterval(probe, interval)
+}
+
+// SetLowFootprint enables or disables the low-footprint collection mode,
+// which is intended for memory constrained devices. In this mode the Metrics
+// maps of memory information are not populated, Processes and
+// ProcessesMatching return at most 1024 processes, and ForEachProcess stops
+// after 1024 processes.
+func SetLowFootprint(enabled bool) {
+	footprint.SetLow(enabled)
+}
+
+// Subsystems returns the names of the optional subsystems (e.g. packages)
+// that were compiled in. A subsystem can be excluded from the build with the
+// nosysinfo_<name> build tag (e.g. nosysinfo_packages) to reduce the binary
+// size.
+func Subsystems() []string {
+	return registry.Subsystems()
 }
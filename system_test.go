@@ -18,11 +18,14 @@
 package sysinfo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/fs"
+	"io/ioutil"
 	"os"
 	osUser "os/user"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -34,6 +37,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/go-sysinfo/internal/registry"
+	"github.com/elastic/go-sysinfo/providers/fake"
 	"github.com/elastic/go-sysinfo/types"
 )
 
@@ -273,6 +278,193 @@ func TestHost(t *testing.T) {
 	logAsJSON(t, output)
 }
 
+func TestHostWithOptions(t *testing.T) {
+	host, err := Host(WithoutFQDN(), WithoutMachineID())
+	if err == types.ErrNotImplemented {
+		t.Skip("host provider not implemented on", runtime.GOOS)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	info := host.Info()
+	assert.NotEmpty(t, info.Hostname)
+	assert.Empty(t, info.FQDN)
+	assert.Empty(t, info.UniqueID)
+}
+
+func TestTimesAreUTC(t *testing.T) {
+	// The times must not depend on the local time zone, including one that
+	// observes daylight saving time.
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available:", err)
+	}
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = loc
+
+	host, err := Host(WithoutFQDN(), WithoutMachineID())
+	if err == types.ErrNotImplemented {
+		t.Skip("host provider not implemented on", runtime.GOOS)
+	}
+	require.NotNil(t, host)
+	bootTime := host.Info().BootTime
+	assert.Equal(t, time.UTC, bootTime.Location())
+
+	self, err := Self()
+	require.NoError(t, err)
+	info, err := self.Info()
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, info.StartTime.Location())
+
+	// Boot times are rounded to seconds on some platforms.
+	assert.False(t, info.StartTime.Before(bootTime.Add(-time.Second)), "process started before boot: %v < %v", info.StartTime, bootTime)
+	assert.False(t, info.StartTime.After(time.Now()), "process started in the future: %v", info.StartTime)
+}
+
+func TestHostFQDNStrategy(t *testing.T) {
+	if runtime.GOOS == "aix" {
+		t.Skip("FQDN is not reported on aix")
+	}
+	host, err := Host(WithFQDNStrategy(FQDNHostname), WithoutMachineID())
+	if err == types.ErrNotImplemented {
+		t.Skip("host provider not implemented on", runtime.GOOS)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	assert.Equal(t, hostname, host.Info().FQDN)
+}
+
+func TestHostMachineIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "machine-id")
+	require.NoError(t, ioutil.WriteFile(path, []byte("b5b4c0e2f3d04c1a\n"), 0o600))
+
+	host, err := Host(WithMachineIDSources(MachineIDFile), WithMachineIDFile(path), WithoutFQDN())
+	if err == types.ErrNotImplemented {
+		t.Skip("host provider not implemented on", runtime.GOOS)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	info := host.Info()
+	assert.Equal(t, "b5b4c0e2f3d04c1a", info.UniqueID)
+	assert.Equal(t, MachineIDFile, info.UniqueIDSource)
+}
+
+func TestHostDeadline(t *testing.T) {
+	host, err := Host(WithDeadline(time.Now().Add(-time.Second)))
+	if err == types.ErrNotImplemented {
+		t.Skip("host provider not implemented on", runtime.GOOS)
+	}
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "err: %v", err)
+	assert.NotNil(t, host, "partial host information is returned")
+}
+
+type optionsProvider struct{ opts *registry.HostOptions }
+
+func (p optionsProvider) Host() (types.Host, error) { return &fake.Host{}, nil }
+
+func (p optionsProvider) HostWithOptions(opts registry.HostOptions) (types.Host, error) {
+	*p.opts = opts
+	return &fake.Host{}, nil
+}
+
+func TestHostTimeoutStartsWithCall(t *testing.T) {
+	var opts registry.HostOptions
+	defer UseProvider(optionsProvider{opts: &opts})()
+
+	// The option is created before the timeout would have passed.
+	timeout := WithTimeout(20 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := Host(timeout)
+	require.NoError(t, err)
+	assert.False(t, opts.Deadline.Before(start.Add(20*time.Millisecond)), "deadline: %v", opts.Deadline)
+	assert.Zero(t, opts.Timeout)
+
+	_, err = Host(timeout, WithDeadline(start.Add(-time.Second)))
+	require.NoError(t, err)
+	assert.True(t, opts.Deadline.Equal(start.Add(-time.Second)), "deadline: %v", opts.Deadline)
+}
+
+type hangingProvider struct{ block chan struct{} }
+
+func (p hangingProvider) Host() (types.Host, error) {
+	<-p.block
+	return &fake.Host{}, nil
+}
+
+func TestHostTimeoutWithoutProviderSupport(t *testing.T) {
+	p := hangingProvider{block: make(chan struct{})}
+	defer close(p.block)
+	defer UseProvider(p)()
+
+	SetHostTimeout(50 * time.Millisecond)
+	defer SetHostTimeout(0)
+
+	start := time.Now()
+	host, err := Host()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "err: %v", err)
+	assert.Nil(t, host)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+type panicProvider struct{}
+
+func (panicProvider) Host() (types.Host, error) { panic("bad host data") }
+
+func (panicProvider) Processes() ([]types.Process, error) { panic("bad process data") }
+
+func (panicProvider) Process(int) (types.Process, error) { panic("bad process data") }
+
+func (panicProvider) Self() (types.Process, error) { panic("bad process data") }
+
+func TestPanicContainment(t *testing.T) {
+	defer UseProvider(panicProvider{})()
+
+	var perr *types.PanicError
+	_, err := Host()
+	if assert.True(t, errors.As(err, &perr), "err: %v", err) {
+		assert.Equal(t, "host", perr.Probe)
+		assert.Equal(t, "bad host data", perr.Value)
+	}
+
+	_, err = Process(1)
+	assert.True(t, errors.As(err, &perr), "err: %v", err)
+	_, err = Processes()
+	assert.True(t, errors.As(err, &perr), "err: %v", err)
+	_, err = Self()
+	assert.True(t, errors.As(err, &perr), "err: %v", err)
+}
+
+func TestUseProvider(t *testing.T) {
+	restore := UseProvider(&fake.Provider{
+		HostFixture:     &fake.Host{HostInfo: types.HostInfo{Hostname: "fake-host"}},
+		ProcessFixtures: []*fake.Process{{ProcessInfo: types.ProcessInfo{PID: 7}}},
+		SelfPID:         7,
+	})
+
+	host, err := Host(WithoutFQDN())
+	require.NoError(t, err)
+	assert.Equal(t, "fake-host", host.Info().Hostname)
+
+	self, err := Self()
+	require.NoError(t, err)
+	assert.Equal(t, 7, self.PID())
+
+	restore()
+
+	self, err = Self()
+	if err == types.ErrNotImplemented {
+		return
+	}
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), self.PID())
+}
+
 func logAsJSON(t testing.TB, v interface{}) {
 	if !testing.Verbose() {
 		return
@@ -309,3 +501,98 @@ func TestProcesses(t *testing.T) {
 			info.StartTime)
 	}
 }
+
+func TestProcessesMatchingFallback(t *testing.T) {
+	restore := UseProvider(&fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1, Name: "init"}, UserInfo: types.UserInfo{UID: "0"}},
+			{ProcessInfo: types.ProcessInfo{PID: 42, Name: "bash"}, UserInfo: types.UserInfo{UID: "1000"}},
+			{ProcessInfo: types.ProcessInfo{PID: 43, Name: "bash"}, UserInfo: types.UserInfo{UID: "0"}},
+		},
+	})
+	defer restore()
+
+	procs, err := ProcessesMatching(types.ProcessFilter{Names: []string{"bash"}, UIDs: []string{"0"}})
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	assert.Equal(t, 43, procs[0].PID())
+
+	procs, err = ProcessesMatching(types.ProcessFilter{PIDs: []int{1, 42}})
+	require.NoError(t, err)
+	assert.Len(t, procs, 2)
+
+	_, err = ProcessesMatching(types.ProcessFilter{States: []string{types.ProcessStateRunning}})
+	assert.ErrorIs(t, err, types.ErrNotImplemented)
+}
+
+func TestProcessesMatchingSelf(t *testing.T) {
+	procs, err := ProcessesMatching(types.ProcessFilter{PIDs: []int{os.Getpid()}})
+	if errors.Is(err, types.ErrNotImplemented) {
+		t.Skip("process information not implemented on", runtime.GOOS)
+	}
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	assert.Equal(t, os.Getpid(), procs[0].PID())
+}
+
+func TestForEachProcess(t *testing.T) {
+	restore := UseProvider(&fake.Provider{
+		ProcessFixtures: []*fake.Process{
+			{ProcessInfo: types.ProcessInfo{PID: 1}},
+			{ProcessInfo: types.ProcessInfo{PID: 2}},
+			{ProcessInfo: types.ProcessInfo{PID: 3}},
+		},
+	})
+	defer restore()
+
+	var pids []int
+	err := ForEachProcess(func(p types.Process) bool {
+		pids = append(pids, p.PID())
+		return p.PID() < 2
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, pids)
+}
+
+func TestForEachProcessPanic(t *testing.T) {
+	restore := UseProvider(&fake.Provider{
+		ProcessFixtures: []*fake.Process{{ProcessInfo: types.ProcessInfo{PID: 1}}},
+	})
+	defer restore()
+
+	// The panic of the callback is not mistaken for a panic of the provider.
+	assert.PanicsWithValue(t, "callback bug", func() {
+		_ = ForEachProcess(func(types.Process) bool { panic("callback bug") })
+	})
+}
+
+func TestForEachProcessLowFootprint(t *testing.T) {
+	provider := &fake.Provider{}
+	for pid := 1; pid <= 2000; pid++ {
+		provider.ProcessFixtures = append(provider.ProcessFixtures, &fake.Process{ProcessInfo: types.ProcessInfo{PID: pid}})
+	}
+	defer UseProvider(provider)()
+
+	SetLowFootprint(true)
+	defer SetLowFootprint(false)
+
+	var count int
+	require.NoError(t, ForEachProcess(func(types.Process) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, 1024, count)
+}
+
+func TestForEachProcessSelf(t *testing.T) {
+	var found bool
+	err := ForEachProcess(func(p types.Process) bool {
+		found = p.PID() == os.Getpid()
+		return !found
+	})
+	if errors.Is(err, types.ErrNotImplemented) {
+		t.Skip("process information not implemented on", runtime.GOOS)
+	}
+	require.NoError(t, err)
+	assert.True(t, found, "current process not found")
+}
@@ -0,0 +1,34 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Boot is the interface that wraps the BootInfo method.
+// BootInfo returns information about how the running system was booted.
+type Boot interface {
+	BootInfo() (*BootInfo, error)
+}
+
+// BootInfo contains information about the boot process.
+type BootInfo struct {
+	Loader      string `json:"loader,omitempty"`       // Boot loader (e.g. GRUB, systemd-boot, Windows Boot Manager).
+	KernelImage string `json:"kernel_image,omitempty"` // Path of the booted kernel image.
+	BootMode    string `json:"boot_mode,omitempty"`    // Boot mode (uefi or legacy).
+
+	HibernationEnabled *bool `json:"hibernation_enabled,omitempty"`  // Is hibernation enabled.
+	FastStartupEnabled *bool `json:"fast_startup_enabled,omitempty"` // Is Windows Fast Startup (hybrid shutdown) enabled.
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Capability identifies a class of data whose collection requires elevated
+// privileges on some platforms.
+type Capability string
+
+// Capabilities that can be checked with CapabilityChecker.
+const (
+	CapabilityProcessEnvironment Capability = "process_environment"  // Environments of other users' processes.
+	CapabilityProcessCommandLine Capability = "process_command_line" // Command lines of other users' processes.
+	CapabilityHardwareSerial     Capability = "hardware_serial"      // System serial number and UUID.
+)
+
+// CapabilityChecker is the interface that wraps the CanCollect method.
+// CanCollect reports whether the calling process has the privileges needed
+// to collect the given class of data. The check inspects the credentials of
+// the process instead of attempting the access so that it does not generate
+// access denied audit events. An error wrapping ErrNotImplemented is
+// returned for capabilities that are unknown to the implementation.
+type CapabilityChecker interface {
+	CanCollect(capability Capability) (bool, error)
+}
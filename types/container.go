@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// Containers is the interface that wraps the Containers method.
+// Containers returns the containers running on the host with their resource
+// usage. The containers are discovered from the control groups and the
+// state directories of the container runtimes, so no runtime API (e.g. the
+// Docker socket) is needed.
+type Containers interface {
+	Containers() ([]ContainerInfo, error)
+}
+
+// ContainerInfo contains information about a running container.
+type ContainerInfo struct {
+	ID      string          `json:"id"`                // Container ID.
+	Runtime string          `json:"runtime,omitempty"` // Container runtime (e.g. docker, containerd, cri-o, podman). Empty if unknown.
+	Name    string          `json:"name,omitempty"`    // Container name when known to the runtime state.
+	Cgroup  string          `json:"cgroup"`            // Control group path relative to the cgroup root.
+	UID     *int            `json:"uid,omitempty"`     // Owner of a rootless container. Nil for containers run by root.
+	PIDs    []int           `json:"pids"`              // Processes in the container.
+	CPU     ContainerCPU    `json:"cpu"`
+	Memory  ContainerMemory `json:"memory"`
+}
+
+// ContainerCPU contains the CPU time consumed by a container.
+type ContainerCPU struct {
+	Usage     time.Duration  `json:"usage"`               // Total CPU time.
+	User      time.Duration  `json:"user"`                // CPU time spent in user mode.
+	System    time.Duration  `json:"system"`              // CPU time spent in kernel mode.
+	Throttled *time.Duration `json:"throttled,omitempty"` // Time the container was throttled by its CPU quota.
+}
+
+// ContainerMemory contains the memory usage of a container.
+type ContainerMemory struct {
+	Usage uint64  `json:"usage_bytes"`           // Memory in use, including the page cache.
+	Limit *uint64 `json:"limit_bytes,omitempty"` // Memory limit. Nil when unlimited.
+}
+
+// ContainerGuest is the interface that wraps the ContainerGuest method.
+// ContainerGuest reports whether the host is itself a container, such as a
+// systemd-nspawn machine or an LXC container, from the markers that the
+// container managers leave for the guest.
+type ContainerGuest interface {
+	ContainerGuest() (*ContainerGuestInfo, error)
+}
+
+// ContainerGuestInfo describes the container that the host is running in.
+type ContainerGuestInfo struct {
+	Containerized bool   `json:"containerized"`
+	Runtime       string `json:"runtime,omitempty"` // Container manager (e.g. systemd-nspawn, lxc, lxd, docker, podman).
+	ID            string `json:"id,omitempty"`      // Container ID or UUID when exposed to the guest.
+	Name          string `json:"name,omitempty"`    // Container name when exposed to the guest.
+}
+
+// ProcessContainer is the interface that wraps the Container method.
+// Container returns the container that the process runs in or nil if the
+// process does not run in a container. The IDs match those reported by
+// Containers, so processes can be grouped by container.
+type ProcessContainer interface {
+	Container() (*ProcessContainerInfo, error)
+}
+
+// ProcessContainerInfo identifies the container of a process.
+type ProcessContainerInfo struct {
+	ID      string `json:"id"`                // Container ID, or the machine name of system containers.
+	Runtime string `json:"runtime,omitempty"` // Container runtime. Empty if unknown.
+	Cgroup  string `json:"cgroup"`            // Control group of the process.
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// DisplaySession is the interface that wraps the DisplaySession method.
+// DisplaySession returns the type of the session that is displayed on the
+// local console.
+type DisplaySession interface {
+	DisplaySession() (*DisplaySessionInfo, error)
+}
+
+// Display session types.
+const (
+	DisplaySessionX11     = "x11"
+	DisplaySessionWayland = "wayland"
+	DisplaySessionMir     = "mir"
+	DisplaySessionTTY     = "tty"
+)
+
+// DisplaySessionInfo describes the session displayed on the local console.
+// Headless is true when no graphical session is running.
+type DisplaySessionInfo struct {
+	Type      string `json:"type,omitempty"`       // Session type (e.g. x11, wayland, tty).
+	Desktop   string `json:"desktop,omitempty"`    // Desktop environment (e.g. gnome).
+	SessionID string `json:"session_id,omitempty"` // Login session ID.
+	Headless  bool   `json:"headless"`
+}
+
+// Graphical returns true if the type is a graphical session type.
+func (d DisplaySessionInfo) Graphical() bool {
+	switch d.Type {
+	case DisplaySessionX11, DisplaySessionWayland, DisplaySessionMir:
+		return true
+	}
+	return false
+}
@@ -17,7 +17,28 @@
 
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNotImplemented represents an error for a function that is not implemented on a particular platform.
 var ErrNotImplemented = errors.New("unimplemented")
+
+// PanicError is returned in place of the data of a probe that panicked, for
+// example on data in an unexpected format. The panic is contained so that it
+// does not crash the application.
+//
+// Panics are contained in the functions of the sysinfo package, the host
+// probes, and the Memory and CPUTime methods of Host and the Info, Memory,
+// CPUTime, User, and Parent methods of Process. The optional interfaces
+// (e.g. VMStat or Environment) do not contain panics.
+type PanicError struct {
+	Probe string      // Name of the probe.
+	Value interface{} // Value passed to panic.
+	Stack []byte      // Stack trace of the goroutine at the time of the panic.
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %v", e.Probe, e.Value)
+}
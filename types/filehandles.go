@@ -0,0 +1,31 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// FileHandles is the interface that wraps the FileHandles method.
+// FileHandles returns the number of open file handles of the whole host.
+type FileHandles interface {
+	FileHandles() (*FileHandlesInfo, error)
+}
+
+// FileHandlesInfo contains the number of file handles that are allocated by
+// all processes and the system-wide limit.
+type FileHandlesInfo struct {
+	Allocated uint64  `json:"allocated"`         // Number of allocated file handles. On Windows this is the number of handles of all object types.
+	Maximum   *uint64 `json:"maximum,omitempty"` // Maximum number of file handles. Nil if there is no fixed limit, as on Windows.
+}
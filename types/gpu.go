@@ -0,0 +1,42 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// GPU is the interface that wraps the GPUs method.
+// GPUs returns the graphics processors installed in the host.
+type GPU interface {
+	GPUs() ([]GPUInfo, error)
+}
+
+// GPUInfo contains information about a graphics processor. The memory and
+// utilization metrics are optional and are only populated when the
+// platform driver exposes them. They are reported on Linux for the amdgpu
+// driver and, when nvidia-smi is installed, for the NVIDIA driver, on
+// Darwin, and on Windows from the GPU performance counters when the adapter
+// can be told apart from the others by its PCI IDs.
+type GPUInfo struct {
+	Vendor      string   `json:"vendor,omitempty"`             // Vendor name (e.g. NVIDIA Corporation).
+	VendorID    string   `json:"vendor_id,omitempty"`          // PCI vendor ID (e.g. 0x10de).
+	Model       string   `json:"model,omitempty"`              // Model name (e.g. GeForce RTX 3060).
+	DeviceID    string   `json:"device_id,omitempty"`          // PCI device ID (e.g. 0x2504).
+	Driver      string   `json:"driver,omitempty"`             // Kernel driver or driver provider.
+	BusID       string   `json:"bus_id,omitempty"`             // PCI bus address (e.g. 0000:01:00.0).
+	MemoryTotal uint64   `json:"memory_total_bytes,omitempty"` // Dedicated video memory.
+	MemoryUsed  *uint64  `json:"memory_used_bytes,omitempty"`  // Dedicated video memory in use.
+	Utilization *float64 `json:"utilization_pct,omitempty"`    // Busy percentage (0-100).
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Hardware is the interface that wraps the HardwareInfo method.
+// HardwareInfo returns the identity of the system as reported by the
+// firmware (SMBIOS/DMI).
+type Hardware interface {
+	HardwareInfo() (*HardwareInfo, error)
+}
+
+// HardwareInfo contains the system identity reported by the firmware. Fields
+// that require elevated privileges to read (e.g. the serial number on Linux)
+// are left empty when they are not accessible.
+type HardwareInfo struct {
+	Manufacturer string `json:"manufacturer,omitempty"` // System manufacturer (e.g. Dell Inc.).
+	ProductName  string `json:"product_name,omitempty"` // Product name (e.g. PowerEdge R640).
+	Version      string `json:"version,omitempty"`      // Product version.
+	Family       string `json:"family,omitempty"`       // Product family.
+	SKU          string `json:"sku,omitempty"`          // Product SKU number.
+	SerialNumber string `json:"serial_number,omitempty"`
+	UUID         string `json:"uuid,omitempty"`      // SMBIOS system UUID.
+	AssetTag     string `json:"asset_tag,omitempty"` // Chassis asset tag.
+}
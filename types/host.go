@@ -61,20 +61,54 @@ type VMStat interface {
 	VMStat() (*VMStatInfo, error)
 }
 
+// Refresher is the interface that wraps the Refresh method.
+// Refresh updates the fields of the host information that change while the
+// host is running: the hostname, the IP and MAC addresses, and the timezone.
+// This lets a long-lived Host reflect DHCP changes, DST transitions, and
+// changes of the system time zone without being created again. The FQDN is
+// looked up again when the hostname changed, unless it was skipped when the
+// Host was created. It must not be called concurrently with Info.
+type Refresher interface {
+	Refresh() error
+}
+
+// FieldRefresher is the interface that wraps the RefreshFields method.
+// RefreshFields updates only the given fields (HostField constants) of the
+// host information, which avoids the cost of the other lookups. It must not
+// be called concurrently with Info.
+type FieldRefresher interface {
+	RefreshFields(fields ...string) error
+}
+
+// Host information fields updated by FieldRefresher.
+const (
+	HostFieldHostname = "hostname" // Hostname.
+	HostFieldFQDN     = "fqdn"     // FQDN, looked up with the strategy used when the Host was created.
+	HostFieldNetwork  = "network"  // IPs and MACs.
+	HostFieldTimezone = "timezone" // Timezone and TimezoneOffsetSec.
+)
+
 // HostInfo contains basic host information.
 type HostInfo struct {
-	Architecture      string    `json:"architecture"`            // Hardware architecture (e.g. x86_64, arm, ppc, mips).
-	BootTime          time.Time `json:"boot_time"`               // Host boot time.
-	Containerized     *bool     `json:"containerized,omitempty"` // Is the process containerized.
-	Hostname          string    `json:"name"`                    // Hostname
-	FQDN              string    `json:"fqdn"`
-	IPs               []string  `json:"ip,omitempty"`        // List of all IPs.
-	KernelVersion     string    `json:"kernel_version"`      // Kernel version.
-	MACs              []string  `json:"mac"`                 // List of MAC addresses.
-	OS                *OSInfo   `json:"os"`                  // OS information.
-	Timezone          string    `json:"timezone"`            // System timezone.
-	TimezoneOffsetSec int       `json:"timezone_offset_sec"` // Timezone offset (seconds from UTC).
-	UniqueID          string    `json:"id,omitempty"`        // Unique ID of the host (optional).
+	Architecture      string        `json:"architecture"`            // Hardware architecture (e.g. x86_64, arm, ppc, mips).
+	BootTime          time.Time     `json:"boot_time"`               // Host boot time (UTC).
+	BootType          string        `json:"boot_type,omitempty"`     // How the host was last started (see BootType constants).
+	ResumeTime        *time.Time    `json:"resume_time,omitempty"`   // Time of the last resume when BootType is not cold.
+	Containerized     *bool         `json:"containerized,omitempty"` // Is the process containerized.
+	Hostname          string        `json:"name"`                    // Hostname
+	FQDN              string        `json:"fqdn"`
+	Firmware          *FirmwareInfo `json:"firmware,omitempty"`      // Firmware information.
+	IPs               []string      `json:"ip,omitempty"`            // List of all IPs.
+	KernelVersion     string        `json:"kernel_version"`          // Kernel version.
+	MACs              []string      `json:"mac"`                     // List of MAC addresses.
+	OS                *OSInfo       `json:"os"`                      // OS information.
+	Timezone          string        `json:"timezone"`                // System timezone.
+	TimezoneOffsetSec int           `json:"timezone_offset_sec"`     // Timezone offset (seconds from UTC).
+	UniqueID          string        `json:"id,omitempty"`            // Unique ID of the host (optional).
+	UniqueIDSource    string        `json:"id_source,omitempty"`     // Source of UniqueID (e.g. machine-id, smbios, machine-guid).
+	SerialNumber      string        `json:"serial_number,omitempty"` // System serial number (optional).
+	AssetTag          string        `json:"asset_tag,omitempty"`     // Chassis asset tag (optional).
+	Domain            *DomainInfo   `json:"domain,omitempty"`        // Directory domain membership.
 }
 
 // Uptime returns the system uptime
@@ -82,6 +116,61 @@ func (host HostInfo) Uptime() time.Duration {
 	return time.Since(host.BootTime)
 }
 
+// ParsedKernelVersion returns the kernel version split into its numeric
+// components so that it can be compared with Version.Compare.
+func (host HostInfo) ParsedKernelVersion() (Version, error) {
+	return ParseVersion(host.KernelVersion)
+}
+
+// Boot types reported in HostInfo. When the host was started by resuming a
+// hibernated kernel session, BootTime is the time of the last cold boot and
+// ResumeTime is the time the host was started.
+const (
+	BootTypeCold            = "cold"
+	BootTypeFastStartup     = "fast_startup" // Windows Fast Startup (hybrid boot).
+	BootTypeHibernateResume = "hibernate_resume"
+)
+
+// Boot modes reported in FirmwareInfo.
+const (
+	BootModeUEFI   = "uefi"
+	BootModeLegacy = "legacy"
+)
+
+// DomainInfo contains the membership of the host in a directory domain.
+type DomainInfo struct {
+	JoinType string       `json:"join_type"`          // One of the DomainJoin constants.
+	Name     string       `json:"name,omitempty"`     // Name of the domain, workgroup, or Azure AD tenant.
+	AzureAD  *AzureADInfo `json:"azure_ad,omitempty"` // Azure AD (Entra ID) join of the device.
+	Client   string       `json:"client,omitempty"`   // Software that manages the membership on Linux (sssd, winbind, or kerberos).
+}
+
+// Domain join types reported in DomainInfo. A host that is joined to both an
+// Active Directory domain and Azure AD (hybrid join) has the join type
+// DomainJoinDomain and an AzureAD value.
+const (
+	DomainJoinNone      = "none"      // Not joined to a domain.
+	DomainJoinWorkgroup = "workgroup" // Member of a Windows workgroup.
+	DomainJoinDomain    = "domain"    // Joined to an Active Directory domain or, on Linux, a Kerberos realm.
+	DomainJoinAzureAD   = "azure_ad"  // Only joined to Azure AD.
+)
+
+// AzureADInfo contains information about the Azure AD join of a device.
+type AzureADInfo struct {
+	TenantID   string `json:"tenant_id,omitempty"`
+	TenantName string `json:"tenant_name,omitempty"`
+	DeviceID   string `json:"device_id,omitempty"`
+}
+
+// FirmwareInfo contains information about the system firmware (BIOS/UEFI).
+type FirmwareInfo struct {
+	Vendor      string `json:"vendor,omitempty"`       // Firmware vendor (e.g. American Megatrends Inc.).
+	Version     string `json:"version,omitempty"`      // Firmware version.
+	ReleaseDate string `json:"release_date,omitempty"` // Release date as reported by the firmware (e.g. 11/29/2022).
+	BootMode    string `json:"boot_mode,omitempty"`    // Boot mode (uefi or legacy).
+	SecureBoot  *bool  `json:"secure_boot,omitempty"`  // Is Secure Boot enabled (only for UEFI).
+}
+
 // OSInfo contains basic OS information
 type OSInfo struct {
 	Type     string `json:"type"`               // OS Type (one of linux, macos, unix, windows).
@@ -96,6 +185,20 @@ type OSInfo struct {
 	Codename string `json:"codename,omitempty"` // OS codename (e.g. jessie).
 }
 
+// ParsedVersion returns the OS version as a Version built from the Major,
+// Minor, and Patch fields. On Windows the build number and update build
+// revision are used as the patch and revision (e.g. 10.0.22631.3296).
+func (os OSInfo) ParsedVersion() Version {
+	v := Version{Major: os.Major, Minor: os.Minor, Patch: os.Patch}
+	if os.Type == "windows" {
+		if build, err := ParseVersion(os.Build); err == nil {
+			v.Patch = build.Major
+			v.Revision = build.Minor
+		}
+	}
+	return v
+}
+
 // LoadAverage is the interface that wraps the LoadAverage method.
 // LoadAverage returns load info on the host
 type LoadAverage interface {
@@ -118,13 +221,83 @@ type HostMemoryInfo struct {
 	VirtualTotal uint64            `json:"virtual_total_bytes"` // Total virtual memory.
 	VirtualUsed  uint64            `json:"virtual_used_bytes"`  // VirtualTotal - VirtualFree
 	VirtualFree  uint64            `json:"virtual_free_bytes"`  // Virtual memory that is not used.
+	Hugepages    *HugepagesInfo    `json:"hugepages,omitempty"` // Huge pages (Linux only).
 	Metrics      map[string]uint64 `json:"raw,omitempty"`       // Other memory related metrics.
 }
 
+// HugepagesInfo contains the usage of the huge page pools and of the
+// transparent huge pages. The Total, Free, Reserved, and Surplus counts are
+// for the pool of the default huge page size.
+type HugepagesInfo struct {
+	Total       uint64                    `json:"total"`                 // Pages in the pool.
+	Free        uint64                    `json:"free"`                  // Pages that are not allocated.
+	Reserved    uint64                    `json:"reserved"`              // Pages committed to allocations that were not made yet.
+	Surplus     uint64                    `json:"surplus"`               // Pages above the pool size allocated by overcommit.
+	DefaultSize uint64                    `json:"default_size_bytes"`    // Default huge page size.
+	Pools       []HugepagePool            `json:"pools,omitempty"`       // Pools of each supported page size.
+	Transparent *TransparentHugepagesInfo `json:"transparent,omitempty"` // Transparent huge pages.
+}
+
+// HugepagePool contains the usage of the huge page pool of one page size.
+type HugepagePool struct {
+	Size     uint64 `json:"size_bytes"` // Page size.
+	Total    uint64 `json:"total"`      // Pages in the pool.
+	Free     uint64 `json:"free"`       // Pages that are not allocated.
+	Reserved uint64 `json:"reserved"`   // Pages committed to allocations that were not made yet.
+	Surplus  uint64 `json:"surplus"`    // Pages above the pool size allocated by overcommit.
+}
+
+// TransparentHugepagesInfo contains the configuration and usage of the
+// transparent huge pages (THP).
+type TransparentHugepagesInfo struct {
+	Enabled string `json:"enabled,omitempty"` // Mode (always, madvise, or never).
+	Defrag  string `json:"defrag,omitempty"`  // Defragmentation mode (e.g. madvise).
+	Anon    uint64 `json:"anon_bytes"`        // Anonymous memory backed by huge pages.
+	Shmem   uint64 `json:"shmem_bytes"`       // Shared memory and tmpfs backed by huge pages.
+	File    uint64 `json:"file_bytes"`        // Page cache backed by huge pages.
+}
+
+// UsedPercent returns the percentage (0-100) of physical memory that is in
+// use, computed as (Total - Available) / Total. Memory that can be reclaimed
+// without swapping (e.g. the page cache) is not counted as used. Zero is
+// returned if Total is unknown.
+func (m HostMemoryInfo) UsedPercent() float64 {
+	if m.Total == 0 || m.Available > m.Total {
+		return 0
+	}
+	return percent(m.Total-m.Available, m.Total)
+}
+
+// AvailablePercent returns the percentage (0-100) of physical memory that is
+// available without swapping, computed as Available / Total. It is equal to
+// 100 - UsedPercent. Zero is returned if Total is unknown.
+func (m HostMemoryInfo) AvailablePercent() float64 {
+	if m.Total == 0 || m.Available > m.Total {
+		return 0
+	}
+	return percent(m.Available, m.Total)
+}
+
+// SwapUsedPercent returns the percentage (0-100) of swap (VirtualTotal) that
+// is in use, computed as VirtualUsed / VirtualTotal. Zero is returned if the
+// host has no swap.
+func (m HostMemoryInfo) SwapUsedPercent() float64 {
+	if m.VirtualTotal == 0 || m.VirtualUsed > m.VirtualTotal {
+		return 0
+	}
+	return percent(m.VirtualUsed, m.VirtualTotal)
+}
+
+func percent(part, total uint64) float64 {
+	return 100 * float64(part) / float64(total)
+}
+
 // VMStatInfo contains parsed info from /proc/vmstat.
 // This procfs file has expanded much over the years
 // with different kernel versions. If we don't have a field in vmstat,
 // the field in the struct will just be blank. The comments represent kernel versions.
+// The values are reported as they appear in the file, so the nr_* fields are
+// counts of pages (not bytes) and the other fields are event counters.
 type VMStatInfo struct {
 	NrFreePages                uint64 `json:"nr_free_pages"`                 // (since Linux 2.6.31)
 	NrAllocBatch               uint64 `json:"nr_alloc_batch"`                // (since Linux 3.12)
@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostMemoryInfoPercentages(t *testing.T) {
+	m := HostMemoryInfo{
+		Total:        8 << 30,
+		Available:    2 << 30,
+		VirtualTotal: 4 << 30,
+		VirtualUsed:  1 << 30,
+	}
+	assert.InDelta(t, 75.0, m.UsedPercent(), 1e-9)
+	assert.InDelta(t, 25.0, m.AvailablePercent(), 1e-9)
+	assert.InDelta(t, 25.0, m.SwapUsedPercent(), 1e-9)
+
+	var empty HostMemoryInfo
+	assert.Zero(t, empty.UsedPercent())
+	assert.Zero(t, empty.AvailablePercent())
+	assert.Zero(t, empty.SwapUsedPercent())
+}
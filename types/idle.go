@@ -0,0 +1,35 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// IdleState is the interface that wraps the IdleState method.
+// IdleState returns the idle time and lock state of the session attached to
+// the local console (the seat on Linux).
+type IdleState interface {
+	IdleState() (*IdleInfo, error)
+}
+
+// IdleInfo contains the idle time and lock state of the console session. The
+// fields are nil when the platform does not expose them to the caller.
+type IdleInfo struct {
+	SessionID string         `json:"session_id,omitempty"` // Console session, empty if not applicable.
+	Idle      *time.Duration `json:"idle,omitempty"`       // Time since the last keyboard or mouse input.
+	Locked    *bool          `json:"locked,omitempty"`     // Whether the screen is locked.
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// KernelFeature identifies an optional kernel feature.
+type KernelFeature string
+
+// Kernel features that can be checked with KernelFeatureDetector.
+const (
+	KernelFeatureCgroupV2       KernelFeature = "cgroup_v2"       // Unified cgroup hierarchy (cgroup2 filesystem).
+	KernelFeatureIOUring        KernelFeature = "io_uring"        // io_uring asynchronous I/O interface.
+	KernelFeatureEBPF           KernelFeature = "ebpf"            // bpf() system call.
+	KernelFeatureTCPBBR         KernelFeature = "tcp_bbr"         // BBR TCP congestion control.
+	KernelFeatureUserNamespaces KernelFeature = "user_namespaces" // User namespaces.
+)
+
+// KernelFeatureDetector is the interface that wraps the KernelSupports method.
+// KernelSupports reports whether the running kernel supports the given
+// feature. An error wrapping ErrNotImplemented is returned for features
+// that are unknown to the implementation.
+type KernelFeatureDetector interface {
+	KernelSupports(feature KernelFeature) (bool, error)
+}
+
+// KernelConfig is the interface that wraps the KernelConfigValue method.
+// KernelConfigValue returns the value of a kernel build configuration option
+// (e.g. CONFIG_BPF_SYSCALL). The CONFIG_ prefix is optional. Options that
+// are not set are reported as not found.
+type KernelConfig interface {
+	KernelConfigValue(option string) (value string, found bool, err error)
+}
+
+// KernelModules is the interface that wraps the KernelModules method.
+// KernelModules returns the modules (drivers) loaded into the kernel.
+type KernelModules interface {
+	KernelModules() ([]KernelModuleInfo, error)
+}
+
+// KernelModuleInfo contains information about a loaded kernel module or
+// driver. Optional fields are left empty when the platform does not expose
+// them.
+type KernelModuleInfo struct {
+	Name   string   `json:"name"`
+	Path   string   `json:"path,omitempty"`    // Path of the module file.
+	Size   uint64   `json:"size,omitempty"`    // Memory used by the module in bytes.
+	State  string   `json:"state,omitempty"`   // Load state (e.g. Live, Loading, Unloading).
+	UsedBy []string `json:"used_by,omitempty"` // Modules that depend on this module.
+	Signed *bool    `json:"signed,omitempty"`  // Whether the module is signed.
+}
+
+// KernelParameters is the interface that wraps the Sysctl and
+// KernelParameters methods.
+//
+// Sysctl returns the value of a kernel tunable by name. On Linux and Darwin
+// the name is the sysctl name (e.g. vm.swappiness); on Linux a dot within a
+// component is written as a slash, as with sysctl(8). On Windows it is the
+// path of a registry value below HKLM\SYSTEM\CurrentControlSet (e.g.
+// Services\Tcpip\Parameters\TcpTimedWaitDelay). Tunables that do not exist
+// are reported as not found.
+//
+// KernelParameters returns the readable tunables whose names start with the
+// given prefix, or all of them if the prefix is empty.
+type KernelParameters interface {
+	Sysctl(name string) (value string, found bool, err error)
+	KernelParameters(prefix string) (map[string]string, error)
+}
+
+// Entropy is the interface that wraps the Entropy method.
+// Entropy returns the status of the kernel entropy pool.
+type Entropy interface {
+	Entropy() (*EntropyInfo, error)
+}
+
+// EntropyInfo contains the status of the kernel entropy pool.
+type EntropyInfo struct {
+	Available uint64  `json:"available"`           // Estimated entropy in bits.
+	PoolSize  *uint64 `json:"pool_size,omitempty"` // Size of the entropy pool in bits.
+	// Blocking reports whether reading random data would block because the
+	// pool has not been initialized yet. Nil when it cannot be determined.
+	Blocking *bool `json:"blocking,omitempty"`
+}
@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Address families reported in network information.
+const (
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+)
+
+// Routes is the interface that wraps the Routes method.
+// Routes returns the IPv4 and IPv6 routing table of the host.
+type Routes interface {
+	Routes() ([]Route, error)
+}
+
+// Route is an entry of the routing table.
+type Route struct {
+	Family      string  `json:"family"`              // Address family (see Family constants).
+	Destination string  `json:"destination"`         // Destination network in CIDR notation (e.g. 0.0.0.0/0 for the default route).
+	Gateway     string  `json:"gateway,omitempty"`   // Next hop. Empty for networks that are directly connected.
+	Interface   string  `json:"interface,omitempty"` // Name of the outgoing interface.
+	Metric      *uint32 `json:"metric,omitempty"`    // Route metric (Linux and Windows only).
+}
+
+// Neighbors is the interface that wraps the Neighbors method.
+// Neighbors returns the neighbor cache of the host, which maps the IP
+// addresses of the hosts on the local networks to their link-layer addresses
+// (ARP for IPv4 and NDP for IPv6).
+type Neighbors interface {
+	Neighbors() ([]Neighbor, error)
+}
+
+// Neighbor cache entry states.
+const (
+	NeighborStateIncomplete = "incomplete" // Address resolution is in progress.
+	NeighborStateReachable  = "reachable"  // The neighbor was recently reachable.
+	NeighborStateStale      = "stale"      // The neighbor is no longer known to be reachable.
+	NeighborStateDelay      = "delay"      // Waiting before probing a stale neighbor.
+	NeighborStateProbe      = "probe"      // The neighbor is being probed.
+	NeighborStateFailed     = "failed"     // Address resolution failed.
+	NeighborStatePermanent  = "permanent"  // The entry was added statically.
+)
+
+// Neighbor is an entry of the neighbor cache.
+type Neighbor struct {
+	Family    string `json:"family"`          // Address family (see Family constants).
+	IP        string `json:"ip"`              // IP address of the neighbor.
+	MAC       string `json:"mac,omitempty"`   // Link-layer address. Empty until the address is resolved.
+	Interface string `json:"interface"`       // Name of the interface the neighbor is reached through.
+	State     string `json:"state,omitempty"` // State of the entry (see NeighborState constants).
+}
+
+// DNSConfig is the interface that wraps the DNSConfig method.
+// DNSConfig returns the DNS resolver configuration of the host.
+type DNSConfig interface {
+	DNSConfig() (*DNSConfigInfo, error)
+}
+
+// DNSConfigInfo contains the DNS resolver configuration.
+type DNSConfigInfo struct {
+	Nameservers []string       `json:"nameservers"`          // Nameservers used for queries that are not specific to an interface.
+	Search      []string       `json:"search,omitempty"`     // Search domains appended to names that are not fully qualified.
+	Interfaces  []InterfaceDNS `json:"interfaces,omitempty"` // DNS settings of the network interfaces that have their own.
+}
+
+// InterfaceDNS contains the DNS settings of a network interface, such as
+// the nameservers that were assigned by DHCP or a VPN.
+type InterfaceDNS struct {
+	Interface   string   `json:"interface"`
+	Nameservers []string `json:"nameservers"`
+	Search      []string `json:"search,omitempty"`
+}
+
+// WiFi is the interface that wraps the WiFi method.
+// WiFi returns the link information of the wireless network interfaces.
+type WiFi interface {
+	WiFi() ([]WiFiInfo, error)
+}
+
+// WiFiInfo contains the link information of a wireless network interface.
+// The link fields are empty when the interface is not associated with an
+// access point.
+type WiFiInfo struct {
+	Interface string  `json:"interface"`
+	SSID      string  `json:"ssid,omitempty"`          // Name of the network.
+	BSSID     string  `json:"bssid,omitempty"`         // MAC address of the access point.
+	Signal    *int    `json:"signal_dbm,omitempty"`    // Received signal strength in dBm.
+	Noise     *int    `json:"noise_dbm,omitempty"`     // Noise level in dBm (Darwin only).
+	Channel   int     `json:"channel,omitempty"`       // Channel number.
+	Frequency int     `json:"frequency_mhz,omitempty"` // Center frequency of the channel in MHz (Linux only).
+	PHYMode   string  `json:"phy_mode,omitempty"`      // IEEE 802.11 standard of the link (e.g. 802.11ac).
+	TxRate    float64 `json:"tx_rate_mbps,omitempty"`  // Transmit rate in Mbit/s.
+}
+
+// Transport protocols reported in network information.
+const (
+	ProtocolTCP = "tcp"
+	ProtocolUDP = "udp"
+)
+
+// ListeningPorts is the interface that wraps the ListeningPorts method.
+// ListeningPorts returns the TCP sockets that are listening for connections
+// and the UDP sockets that are bound to a local port without being connected.
+type ListeningPorts interface {
+	ListeningPorts() ([]ListeningPort, error)
+}
+
+// ListeningPort is a socket that accepts connections or datagrams.
+type ListeningPort struct {
+	Protocol    string `json:"protocol"`               // Transport protocol (see Protocol constants).
+	Family      string `json:"family"`                 // Address family (see Family constants).
+	IP          string `json:"ip"`                     // Local address (0.0.0.0 or :: for all addresses).
+	Port        int    `json:"port"`                   // Local port.
+	PID         int    `json:"pid,omitempty"`          // ID of the owning process. 0 if it is unknown.
+	ProcessName string `json:"process_name,omitempty"` // Name of the owning process.
+}
+
+// SocketSummary is the interface that wraps the SocketSummary method.
+// SocketSummary returns aggregate socket statistics of the host without the
+// individual sockets. The totals are read from the counters of the kernel,
+// so the cost does not grow with the number of sockets.
+type SocketSummary interface {
+	SocketSummary() (*SocketSummaryInfo, error)
+}
+
+// TCPStateCounter is the interface that wraps the TCPStates method.
+// TCPStates returns the number of IPv4 and IPv6 TCP sockets by state (see
+// the TCPState constants). Unlike SocketSummary, it walks the socket table,
+// so it is more expensive on hosts with many connections.
+type TCPStateCounter interface {
+	TCPStates() (map[string]int, error)
+}
+
+// TCP connection states reported by TCPStates.
+const (
+	TCPStateEstablished = "established"
+	TCPStateSynSent     = "syn_sent"
+	TCPStateSynRecv     = "syn_recv"
+	TCPStateFinWait1    = "fin_wait1"
+	TCPStateFinWait2    = "fin_wait2"
+	TCPStateTimeWait    = "time_wait"
+	TCPStateClose       = "close"
+	TCPStateCloseWait   = "close_wait"
+	TCPStateLastAck     = "last_ack"
+	TCPStateListen      = "listen"
+	TCPStateClosing     = "closing"
+)
+
+// SocketSummaryInfo contains aggregate socket statistics for IPv4 and IPv6.
+type SocketSummaryInfo struct {
+	TCPInUse          int     `json:"tcp_in_use"`                    // Number of TCP sockets. On Linux it excludes the sockets in the TIME_WAIT state.
+	TCPEstablished    int     `json:"tcp_established"`               // Number of TCP connections in the ESTABLISHED or CLOSE_WAIT state.
+	TCPOrphaned       *int    `json:"tcp_orphaned,omitempty"`        // Number of TCP sockets that are no longer attached to a file descriptor (Linux only).
+	TCPMemory         *uint64 `json:"tcp_memory_bytes,omitempty"`    // Memory used by TCP socket buffers (Linux only).
+	TCPMemoryPressure *bool   `json:"tcp_memory_pressure,omitempty"` // Whether TCP is under memory pressure (Linux only).
+	UDPInUse          int     `json:"udp_in_use"`                    // Number of UDP sockets.
+	UDPMemory         *uint64 `json:"udp_memory_bytes,omitempty"`    // Memory used by UDP socket buffers (Linux only).
+}
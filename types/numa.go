@@ -0,0 +1,38 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// NUMA is the interface that wraps the NUMA method.
+// NUMA returns the non-uniform memory access (NUMA) topology of the host.
+type NUMA interface {
+	NUMA() (*NUMAInfo, error)
+}
+
+// NUMAInfo contains the NUMA nodes of the host. Hosts without NUMA support
+// report a single node that contains all CPUs and memory.
+type NUMAInfo struct {
+	Nodes []NUMANode `json:"nodes"`
+}
+
+// NUMANode contains the CPUs and memory of a NUMA node.
+type NUMANode struct {
+	ID          int    `json:"id"`                           // Node number.
+	CPUs        []int  `json:"cpus"`                         // Logical CPU numbers of the node.
+	MemoryTotal uint64 `json:"memory_total_bytes,omitempty"` // Memory of the node. Not reported on Windows.
+	MemoryFree  uint64 `json:"memory_free_bytes"`            // Memory of the node that is available.
+}
@@ -0,0 +1,36 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// Packages is the interface that wraps the Packages method.
+// Packages returns the software installed on the host.
+type Packages interface {
+	Packages() ([]PackageInfo, error)
+}
+
+// PackageInfo contains information about an installed software package.
+type PackageInfo struct {
+	Name         string    `json:"name"`
+	Version      string    `json:"version,omitempty"`
+	Architecture string    `json:"architecture,omitempty"`
+	InstallTime  time.Time `json:"install_time,omitempty"` // Zero if unknown.
+	Publisher    string    `json:"publisher,omitempty"`    // Vendor or maintainer.
+	Source       string    `json:"source"`                 // Package database (e.g. dpkg, rpm, apk, registry, msi, app, pkgutil).
+}
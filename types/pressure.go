@@ -0,0 +1,52 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// Pressure is the interface that wraps the Pressure method.
+// Pressure returns the pressure stall information (PSI) of the host, which
+// is the share of time that tasks were delayed waiting for a resource.
+type Pressure interface {
+	Pressure() (*PressureInfo, error)
+}
+
+// PressureInfo contains the pressure stall information of each resource.
+// Resources that are not reported by the kernel are nil.
+type PressureInfo struct {
+	CPU    *ResourcePressure `json:"cpu,omitempty"`
+	Memory *ResourcePressure `json:"memory,omitempty"`
+	IO     *ResourcePressure `json:"io,omitempty"`
+	IRQ    *ResourcePressure `json:"irq,omitempty"` // (since Linux 6.1)
+}
+
+// ResourcePressure contains the pressure of a resource. Some is the time in
+// which at least one task was stalled on the resource and Full the time in
+// which all non-idle tasks were stalled at the same time.
+type ResourcePressure struct {
+	Some *PressureStats `json:"some,omitempty"` // Not reported for IRQ.
+	Full *PressureStats `json:"full,omitempty"` // Reported for CPU since Linux 5.13.
+}
+
+// PressureStats contains the stall time averages and total.
+type PressureStats struct {
+	Avg10  float64       `json:"avg10"`  // Percentage (0-100) of the last 10 seconds that was stalled.
+	Avg60  float64       `json:"avg60"`  // Percentage (0-100) of the last 60 seconds that was stalled.
+	Avg300 float64       `json:"avg300"` // Percentage (0-100) of the last 300 seconds that was stalled.
+	Total  time.Duration `json:"total"`  // Total stall time since boot.
+}
@@ -42,6 +42,15 @@ type ProcessInfo struct {
 	Exe       string    `json:"exe"`
 	Args      []string  `json:"args"`
 	StartTime time.Time `json:"start_time"`
+
+	// Architecture is the architecture that the process runs as and
+	// NativeArchitecture is the architecture of the hardware. Translated is
+	// true for x86_64 processes that run under Rosetta on Apple Silicon and
+	// for x86 and x86_64 processes that are emulated on Windows on ARM64.
+	// Only reported on macOS and Windows.
+	Architecture       string `json:"architecture,omitempty"`
+	NativeArchitecture string `json:"native_architecture,omitempty"`
+	Translated         *bool  `json:"translated,omitempty"`
 }
 
 // UserInfo contains information about the UID and GID
@@ -74,6 +83,15 @@ type UserInfo struct {
 	// On Linux and Darwin (macOS) this is the saved group ID.
 	// On Windows, this is empty.
 	SGID string `json:"sgid"`
+
+	// Username is the name of the user with the UID. On Windows it is the
+	// account name in the DOMAIN\user form. It is empty unless the names
+	// are resolved with sysinfo.ResolveUser.
+	Username string `json:"username,omitempty"`
+
+	// Group is the name of the group with the GID. It is empty unless the
+	// names are resolved with sysinfo.ResolveUser.
+	Group string `json:"group,omitempty"`
 }
 
 // Environment is the interface that wraps the Environment method.
@@ -126,7 +144,141 @@ func (cpu CPUTimes) Total() time.Duration {
 		cpu.SoftIRQ + cpu.Steal
 }
 
-// MemoryInfo contains memory stats for a process
+// TotalBusy returns the CPU time that was not spent idle, which is Total
+// minus Idle and IOWait.
+func (cpu CPUTimes) TotalBusy() time.Duration {
+	return cpu.Total() - cpu.Idle - cpu.IOWait
+}
+
+// Delta returns the CPU time spent between the samples prev and cpu, which
+// must be taken in that order from the same source. Fields that decreased
+// are zero: the counters were reset, or they are not monotonic, like IOWait
+// on Linux.
+func (cpu CPUTimes) Delta(prev CPUTimes) CPUTimes {
+	return CPUTimes{
+		User:    durationDelta(prev.User, cpu.User),
+		System:  durationDelta(prev.System, cpu.System),
+		Idle:    durationDelta(prev.Idle, cpu.Idle),
+		IOWait:  durationDelta(prev.IOWait, cpu.IOWait),
+		IRQ:     durationDelta(prev.IRQ, cpu.IRQ),
+		Nice:    durationDelta(prev.Nice, cpu.Nice),
+		SoftIRQ: durationDelta(prev.SoftIRQ, cpu.SoftIRQ),
+		Steal:   durationDelta(prev.Steal, cpu.Steal),
+	}
+}
+
+func durationDelta(prev, cur time.Duration) time.Duration {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// Delays is the interface that wraps the Delays method.
+// Delays returns the time a process spent waiting instead of running.
+type Delays interface {
+	Delays() (*DelayInfo, error)
+}
+
+// DelayInfo contains the time a process spent waiting for resources. It
+// helps to tell whether a slow process is starved of CPU or blocked on I/O.
+// Fields that the platform does not account for are nil.
+type DelayInfo struct {
+	CPU         *time.Duration `json:"cpu,omitempty"`          // Time spent runnable while waiting for a CPU (Linux only).
+	BlockIO     *time.Duration `json:"block_io,omitempty"`     // Time spent waiting for synchronous block I/O (Linux only).
+	Timeslices  *uint64        `json:"timeslices,omitempty"`   // Number of times the process was scheduled on a CPU (Linux only).
+	WaitReasons map[string]int `json:"wait_reasons,omitempty"` // Number of waiting threads by wait reason (Windows only).
+}
+
+// ContextSwitches is the interface that wraps the ContextSwitches method.
+// ContextSwitches returns the number of times a process was switched off a
+// CPU.
+type ContextSwitches interface {
+	ContextSwitches() (*ContextSwitchInfo, error)
+}
+
+// ContextSwitchInfo contains the context switch counters of all threads of a
+// process. A high rate of voluntary switches indicates that the process
+// often blocks (e.g. on locks or I/O) while involuntary switches indicate
+// that it is preempted because of CPU contention.
+type ContextSwitchInfo struct {
+	Voluntary   *uint64 `json:"voluntary,omitempty"`   // Switches because the process blocked (Linux and AIX only).
+	Involuntary *uint64 `json:"involuntary,omitempty"` // Switches because the process was preempted (Linux and AIX only).
+	Total       uint64  `json:"total"`                 // All context switches.
+}
+
+// Scheduler is the interface that wraps the Scheduler method.
+// Scheduler returns the scheduling parameters of a process.
+type Scheduler interface {
+	Scheduler() (*SchedulerInfo, error)
+}
+
+// Linux scheduling policies reported in SchedulerInfo.
+const (
+	SchedulerPolicyOther    = "SCHED_OTHER"
+	SchedulerPolicyFIFO     = "SCHED_FIFO"
+	SchedulerPolicyRR       = "SCHED_RR"
+	SchedulerPolicyBatch    = "SCHED_BATCH"
+	SchedulerPolicyIdle     = "SCHED_IDLE"
+	SchedulerPolicyDeadline = "SCHED_DEADLINE"
+)
+
+// SchedulerInfo contains the scheduling parameters of a process.
+type SchedulerInfo struct {
+	Policy        string `json:"policy,omitempty"`         // Scheduling policy (Linux only).
+	RTPriority    *int   `json:"rt_priority,omitempty"`    // Real-time priority (1-99) of the SCHED_FIFO and SCHED_RR policies (Linux only).
+	PriorityClass string `json:"priority_class,omitempty"` // Priority class (e.g. normal, realtime) (Windows only).
+	PriorityBoost *bool  `json:"priority_boost,omitempty"` // Is dynamic priority boosting enabled (Windows only).
+}
+
+// Niceness is the interface that wraps the Nice and SetNice methods.
+// Nice returns the nice value of a process, from -20 (highest priority) to
+// 19 (lowest priority). SetNice changes the nice value of a process. Raising
+// the priority usually requires privileges. On Windows the nice value is
+// mapped to and from the priority class of the process.
+type Niceness interface {
+	Nice() (int, error)
+	SetNice(nice int) error
+}
+
+// Windows priority classes reported in SchedulerInfo.
+const (
+	PriorityClassIdle        = "idle"
+	PriorityClassBelowNormal = "below_normal"
+	PriorityClassNormal      = "normal"
+	PriorityClassAboveNormal = "above_normal"
+	PriorityClassHigh        = "high"
+	PriorityClassRealtime    = "realtime"
+)
+
+// PriorityClassSetter is the interface that wraps the SetPriorityClass
+// method. SetPriorityClass changes the priority class of a process on
+// Windows to one of the PriorityClass constants. The current priority class
+// is reported by Scheduler.
+type PriorityClassSetter interface {
+	SetPriorityClass(class string) error
+}
+
+// Affinity is the interface that wraps the Affinity and SetAffinity methods.
+// Affinity returns the numbers of the logical CPUs that a process is allowed
+// to run on. SetAffinity restricts a process to the given logical CPUs. On
+// Windows only the CPUs of the processor group of the process (at most 64)
+// are reported and can be set.
+type Affinity interface {
+	Affinity() ([]int, error)
+	SetAffinity(cpus []int) error
+}
+
+// Suspender is the interface that wraps the Suspend and Resume methods.
+// Suspend stops all threads of a process until Resume is called. The
+// process can still be inspected while it is suspended.
+type Suspender interface {
+	Suspend() error
+	Resume() error
+}
+
+// MemoryInfo contains memory stats for a process (all values are specified
+// in bytes).
 type MemoryInfo struct {
 	Resident uint64            `json:"resident_bytes"`
 	Virtual  uint64            `json:"virtual_bytes"`
@@ -137,6 +289,7 @@ type MemoryInfo struct {
 type SeccompInfo struct {
 	Mode       string `json:"mode"`
 	NoNewPrivs *bool  `json:"no_new_privs,omitempty"` // Added in kernel 4.10.
+	Filters    *int   `json:"filters,omitempty"`      // Number of seccomp filters. Added in kernel 5.9.
 }
 
 // CapabilityInfo contains capability set info.
@@ -154,6 +307,160 @@ type Capabilities interface {
 	Capabilities() (*CapabilityInfo, error)
 }
 
+// Privileges is the interface that wraps the Privileges method.
+// Privileges returns the privileges of a process in a form that is shared
+// by the platforms. On Linux they are the capabilities of the process and on
+// Windows the privileges of its access token.
+type Privileges interface {
+	Privileges() (*PrivilegeInfo, error)
+}
+
+// PrivilegeInfo contains the privileges of a process.
+type PrivilegeInfo struct {
+	Privileges     []Privilege `json:"privileges"`                // Privileges held by the process.
+	Elevated       bool        `json:"elevated"`                  // Whether the process runs as root (Linux) or with an elevated token (Windows).
+	ElevationType  string      `json:"elevation_type,omitempty"`  // UAC elevation type: default, full, or limited (Windows only).
+	IntegrityLevel string      `json:"integrity_level,omitempty"` // Mandatory integrity level: untrusted, low, medium, medium_plus, high, system, or protected (Windows only).
+}
+
+// Privilege is a privilege held by a process.
+type Privilege struct {
+	Name    string `json:"name"`    // Privilege name (e.g. net_admin on Linux, SeDebugPrivilege on Windows).
+	Enabled bool   `json:"enabled"` // Whether the privilege is in effect. Privileges that are held but disabled can be enabled by the process.
+}
+
+// GUIResources is the interface that wraps the GUIResources method.
+// GUIResources returns the number of GDI and USER objects used by a process
+// on Windows. The counts grow steadily in processes that leak window handles,
+// device contexts, or other GUI objects.
+type GUIResources interface {
+	GUIResources() (*GUIResourceInfo, error)
+}
+
+// GUIResourceInfo contains the GUI objects used by a process.
+type GUIResourceInfo struct {
+	GDIObjects      uint32 `json:"gdi_objects"`       // GDI objects (e.g. bitmaps, fonts, device contexts) in use.
+	GDIObjectsPeak  uint32 `json:"gdi_objects_peak"`  // Highest number of GDI objects in use.
+	USERObjects     uint32 `json:"user_objects"`      // USER objects (e.g. windows, menus, cursors) in use.
+	USERObjectsPeak uint32 `json:"user_objects_peak"` // Highest number of USER objects in use.
+}
+
+// JobObject is the interface that wraps the JobObject method.
+// JobObject reports whether a process is in a Windows job object and the
+// limits and accounting of the job. Job objects are used to group, limit,
+// and sandbox processes, including those of Windows containers.
+type JobObject interface {
+	JobObject() (*JobObjectInfo, error)
+}
+
+// JobObjectInfo contains information about the job object of a process.
+// Jobs without a name cannot be opened by other processes, so the limits
+// and accounting are only reported for processes that are in the same job
+// as the calling process.
+type JobObjectInfo struct {
+	InJob      bool                 `json:"in_job"`               // Whether the process is in any job.
+	Limits     *JobObjectLimits     `json:"limits,omitempty"`     // Limits of the job.
+	Accounting *JobObjectAccounting `json:"accounting,omitempty"` // Accounting of all processes in the job.
+}
+
+// JobObjectLimits contains the limits of a job object. Limits that are not
+// set are nil.
+type JobObjectLimits struct {
+	Flags           []string       `json:"flags,omitempty"`             // Limit flags (e.g. kill_on_job_close, breakaway_ok).
+	ActiveProcesses *uint32        `json:"active_processes,omitempty"`  // Maximum number of active processes.
+	ProcessMemory   *uint64        `json:"process_memory,omitempty"`    // Maximum committed memory of each process in bytes.
+	JobMemory       *uint64        `json:"job_memory,omitempty"`        // Maximum committed memory of all processes in bytes.
+	ProcessUserTime *time.Duration `json:"process_user_time,omitempty"` // Maximum user-mode CPU time of each process.
+	JobUserTime     *time.Duration `json:"job_user_time,omitempty"`     // Maximum user-mode CPU time of all processes.
+	CPURate         *float64       `json:"cpu_rate,omitempty"`          // Maximum CPU usage in percent of all CPUs.
+}
+
+// JobObjectAccounting contains the resource usage of all processes that
+// have been in a job object.
+type JobObjectAccounting struct {
+	UserTime            time.Duration `json:"user_time"`            // User-mode CPU time.
+	KernelTime          time.Duration `json:"kernel_time"`          // Kernel-mode CPU time.
+	PageFaults          uint32        `json:"page_faults"`          // Number of page faults.
+	TotalProcesses      uint32        `json:"total_processes"`      // Number of processes that have been in the job.
+	ActiveProcesses     uint32        `json:"active_processes"`     // Number of processes currently in the job.
+	TerminatedProcesses uint32        `json:"terminated_processes"` // Number of processes terminated because of a limit violation.
+	ReadBytes           uint64        `json:"read_bytes"`           // Bytes read by all processes.
+	WriteBytes          uint64        `json:"write_bytes"`          // Bytes written by all processes.
+	PeakProcessMemory   uint64        `json:"peak_process_memory"`  // Highest committed memory of a process in bytes.
+	PeakJobMemory       uint64        `json:"peak_job_memory"`      // Highest committed memory of all processes in bytes.
+}
+
+// Namespaces is the interface that wraps the Namespaces method.
+// Namespaces returns the inode numbers of the Linux namespaces of a process
+// keyed by namespace type (e.g. pid, net, mnt, uts, ipc, user, cgroup).
+// Processes that share a namespace have the same inode number for it.
+type Namespaces interface {
+	Namespaces() (map[string]uint64, error)
+}
+
+// HashAlgorithm identifies a hash algorithm of ExecutableHash.
+type HashAlgorithm string
+
+// Hash algorithms supported by ExecutableHash.
+const (
+	HashMD5    HashAlgorithm = "md5"
+	HashSHA1   HashAlgorithm = "sha1"
+	HashSHA256 HashAlgorithm = "sha256"
+	HashSHA512 HashAlgorithm = "sha512"
+)
+
+// ExecutableHasher is the interface that wraps the ExecutableHash method.
+// ExecutableHash hashes the executable file of a process with the given
+// algorithms, or with SHA-256 if none are given. The whole file is read, so
+// hashing is only done on request.
+type ExecutableHasher interface {
+	ExecutableHash(algorithms ...HashAlgorithm) (*ExecutableHashInfo, error)
+}
+
+// ExecutableHashInfo contains the hashes of the executable of a process.
+type ExecutableHashInfo struct {
+	Path   string                   `json:"path"`   // Path of the executable.
+	Size   int64                    `json:"size"`   // Size of the executable in bytes.
+	Hashes map[HashAlgorithm]string `json:"hashes"` // Hex encoded hashes.
+	// Deleted reports whether the executable has been deleted since the
+	// process started. On Linux the hashes are still of the running binary.
+	Deleted bool `json:"deleted,omitempty"`
+	// Replaced reports whether another file has been put at the path of the
+	// executable since the process started (Linux only).
+	Replaced bool `json:"replaced,omitempty"`
+}
+
+// CodeSignature is the interface that wraps the SignatureInfo method.
+// SignatureInfo verifies the code signature of the executable of a process:
+// the Authenticode signature on Windows and the code signature on macOS.
+type CodeSignature interface {
+	SignatureInfo() (*SignatureInfo, error)
+}
+
+// Code signature statuses reported in SignatureInfo.
+const (
+	SignatureStatusValid     = "valid"     // The signature is valid and trusted.
+	SignatureStatusUnsigned  = "unsigned"  // The executable is not signed.
+	SignatureStatusInvalid   = "invalid"   // The executable was modified after signing or the signature is malformed.
+	SignatureStatusUntrusted = "untrusted" // The signing certificate does not chain to a trusted root or is distrusted.
+	SignatureStatusExpired   = "expired"   // The signing certificate expired and the signature has no timestamp.
+	SignatureStatusRevoked   = "revoked"   // The signing certificate was revoked.
+	SignatureStatusUnknown   = "unknown"   // The signature could not be verified for another reason.
+)
+
+// SignatureInfo contains the code signature of an executable.
+type SignatureInfo struct {
+	Path      string `json:"path"`                // Path of the executable.
+	Signed    bool   `json:"signed"`              // Whether the executable has a signature.
+	Status    string `json:"status"`              // One of the SignatureStatus constants.
+	Subject   string `json:"subject,omitempty"`   // Name of the signer (e.g. Microsoft Windows, Developer ID Application: Example Inc (ABCDE12345)).
+	Issuer    string `json:"issuer,omitempty"`    // Name of the issuer of the signing certificate.
+	Catalog   bool   `json:"catalog,omitempty"`   // Whether the executable is signed through a security catalog rather than an embedded signature (Windows only).
+	TeamID    string `json:"team_id,omitempty"`   // Team identifier of the signer (macOS only).
+	AdHoc     bool   `json:"ad_hoc,omitempty"`    // Whether the executable has an ad-hoc signature without a certificate (macOS only).
+	Notarized *bool  `json:"notarized,omitempty"` // Whether the executable is notarized by Apple (macOS only).
+}
+
 // Seccomp is the interface that wraps the Seccomp method.
 // Seccomp returns seccomp info on Linux
 type Seccomp interface {
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// ProcessFilter selects the processes returned by ProcessesMatching. Each
+// non-empty field keeps only the processes that match one of its values and
+// a process must match all the non-empty fields. The zero value matches all
+// processes.
+type ProcessFilter struct {
+	PIDs   []int    // Process IDs.
+	Names  []string // Process names, as reported in ProcessInfo.Name.
+	UIDs   []string // Real user IDs, as reported in UserInfo.UID (the SID on Windows).
+	States []string // Process states (ProcessState constants). Darwin and Linux only.
+}
+
+// Process states used by ProcessFilter.
+const (
+	ProcessStateRunning   = "running"    // Running or runnable.
+	ProcessStateSleeping  = "sleeping"   // Interruptible sleep.
+	ProcessStateDiskSleep = "disk_sleep" // Uninterruptible sleep, usually waiting on I/O (Linux only).
+	ProcessStateStopped   = "stopped"    // Stopped by a signal or traced.
+	ProcessStateZombie    = "zombie"     // Exited but not yet reaped by its parent.
+	ProcessStateIdle      = "idle"       // Idle kernel thread (Linux only).
+)
+
+// MatchPID reports whether pid is accepted by the PIDs of the filter.
+func (f ProcessFilter) MatchPID(pid int) bool {
+	if len(f.PIDs) == 0 {
+		return true
+	}
+	for _, v := range f.PIDs {
+		if v == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchName reports whether name is accepted by the Names of the filter.
+func (f ProcessFilter) MatchName(name string) bool {
+	return matchString(f.Names, name)
+}
+
+// MatchUID reports whether uid is accepted by the UIDs of the filter.
+func (f ProcessFilter) MatchUID(uid string) bool {
+	return matchString(f.UIDs, uid)
+}
+
+// MatchState reports whether state is accepted by the States of the filter.
+func (f ProcessFilter) MatchState(state string) bool {
+	return matchString(f.States, state)
+}
+
+func matchString(values []string, s string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Raw is the interface that wraps the Raw method.
+// Raw returns the raw data parsed from the sources that a provider reads for
+// a host or process (e.g. all fields of /proc/<pid>/status on Linux or the
+// values of the Windows CurrentVersion registry key). It gives access to
+// values that the typed structs do not cover yet. The data is only collected
+// when Raw is called. Its keys and format depend on the provider and are not
+// covered by any compatibility guarantee.
+type Raw interface {
+	Raw() (map[string]interface{}, error)
+}
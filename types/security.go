@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// LSMStatus is the interface that wraps the LSMStatus method.
+// LSMStatus returns the status of the Linux Security Modules of the host.
+type LSMStatus interface {
+	LSMStatus() (*LSMStatusInfo, error)
+}
+
+// LSMStatusInfo contains the status of the Linux Security Modules.
+type LSMStatusInfo struct {
+	Modules  []string        `json:"modules,omitempty"`  // Active security modules in the order they are called (Linux 4.20 and newer).
+	SELinux  *SELinuxStatus  `json:"selinux,omitempty"`  // Nil if SELinux is not built into the kernel.
+	AppArmor *AppArmorStatus `json:"apparmor,omitempty"` // Nil if AppArmor is not built into the kernel.
+}
+
+// SELinux modes.
+const (
+	SELinuxEnforcing  = "enforcing"
+	SELinuxPermissive = "permissive"
+	SELinuxDisabled   = "disabled"
+)
+
+// SELinuxStatus contains the status of SELinux.
+type SELinuxStatus struct {
+	Mode          string `json:"mode"`                     // Current mode (see SELinux constants).
+	ConfigMode    string `json:"config_mode,omitempty"`    // Mode configured in /etc/selinux/config for the next boot.
+	Policy        string `json:"policy,omitempty"`         // Name of the configured policy (e.g. targeted).
+	PolicyVersion int    `json:"policy_version,omitempty"` // Version of the loaded policy format.
+}
+
+// AppArmorStatus contains the status of AppArmor.
+type AppArmorStatus struct {
+	Enabled          bool `json:"enabled"`
+	Profiles         *int `json:"profiles,omitempty"`          // Number of loaded profiles. Reading them requires root.
+	EnforceProfiles  *int `json:"enforce_profiles,omitempty"`  // Number of profiles in enforce mode.
+	ComplainProfiles *int `json:"complain_profiles,omitempty"` // Number of profiles in complain mode.
+}
+
+// WindowsSecurity is the interface that wraps the WindowsSecurity method.
+// WindowsSecurity returns the state of the antivirus and disk encryption
+// protections of a Windows host.
+type WindowsSecurity interface {
+	WindowsSecurity() (*WindowsSecurityInfo, error)
+}
+
+// WindowsSecurityInfo contains the state of the Windows security features.
+type WindowsSecurityInfo struct {
+	AntivirusHealth string            `json:"antivirus_health,omitempty"` // Health of the registered antivirus products (see SecurityHealth constants). Empty if Windows Security Center is not available, as on Windows Server.
+	FirewallHealth  string            `json:"firewall_health,omitempty"`  // Health of the registered firewalls (see SecurityHealth constants).
+	Defender        *DefenderStatus   `json:"defender,omitempty"`         // Nil if Microsoft Defender Antivirus is not installed.
+	BitLocker       []BitLockerVolume `json:"bitlocker,omitempty"`        // Encryption status of the fixed volumes.
+}
+
+// Health states reported by Windows Security Center.
+const (
+	SecurityHealthGood         = "good"
+	SecurityHealthNotMonitored = "not_monitored"
+	SecurityHealthPoor         = "poor"
+	SecurityHealthSnoozed      = "snoozed"
+)
+
+// DefenderStatus contains the state of Microsoft Defender Antivirus.
+type DefenderStatus struct {
+	Running            bool   `json:"running"`                     // Whether the WinDefend service is running.
+	RealTimeProtection bool   `json:"real_time_protection"`        // Whether the service is running and real-time monitoring is not disabled.
+	SignatureVersion   string `json:"signature_version,omitempty"` // Version of the antivirus definitions.
+}
+
+// BitLocker protection statuses.
+const (
+	BitLockerOn                = "on"
+	BitLockerOff               = "off"
+	BitLockerEncrypting        = "encrypting"
+	BitLockerDecrypting        = "decrypting"
+	BitLockerSuspended         = "suspended"
+	BitLockerLocked            = "locked"
+	BitLockerWaitingActivation = "waiting_activation"
+	BitLockerUnknown           = "unknown"
+)
+
+// BitLockerVolume contains the BitLocker status of a volume.
+type BitLockerVolume struct {
+	Volume string `json:"volume"` // Drive letter (e.g. C:).
+	Status string `json:"status"` // Protection status (see BitLocker constants).
+}
+
+// Firewall is the interface that wraps the Firewall method.
+// Firewall returns the state of the host firewalls.
+type Firewall interface {
+	Firewall() (*FirewallInfo, error)
+}
+
+// Names of the host firewalls.
+const (
+	FirewallWindows     = "windows_firewall"     // Windows Defender Firewall.
+	FirewallUFW         = "ufw"                  // Uncomplicated Firewall.
+	FirewallFirewalld   = "firewalld"            // firewalld daemon.
+	FirewallNftables    = "nftables"             // nftables service that loads /etc/nftables.conf.
+	FirewallApplication = "application_firewall" // macOS Application Firewall.
+)
+
+// FirewallInfo contains the state of the host firewalls.
+type FirewallInfo struct {
+	Enabled   bool             `json:"enabled"`   // Whether any of the firewalls is enabled.
+	Firewalls []FirewallStatus `json:"firewalls"` // Firewalls that are installed.
+}
+
+// FirewallStatus contains the state of a host firewall.
+type FirewallStatus struct {
+	Name    string `json:"name"`              // Name of the firewall (see Firewall constants).
+	Profile string `json:"profile,omitempty"` // Network profile the state applies to (Windows only: domain, private, or public).
+	Enabled bool   `json:"enabled"`
+}
+
+// AuditStatus is the interface that wraps the AuditStatus method.
+// AuditStatus returns the status of the kernel audit subsystem.
+type AuditStatus interface {
+	AuditStatus() (*AuditStatusInfo, error)
+}
+
+// Actions taken by the kernel when audit records are lost.
+const (
+	AuditFailureSilent = "silent" // Records are dropped silently.
+	AuditFailurePrintk = "printk" // The loss is logged to the kernel log.
+	AuditFailurePanic  = "panic"  // The kernel panics.
+)
+
+// AuditStatusInfo contains the status of the kernel audit subsystem.
+type AuditStatusInfo struct {
+	Enabled      bool   `json:"enabled"`
+	Immutable    bool   `json:"immutable"`     // Whether the configuration is locked until the next boot.
+	FailureMode  string `json:"failure_mode"`  // Action on lost records (see AuditFailure constants).
+	PID          int    `json:"pid"`           // ID of the process that receives the records (e.g. auditd). 0 if none is registered.
+	RateLimit    uint32 `json:"rate_limit"`    // Maximum number of records per second. 0 if unlimited.
+	BacklogLimit uint32 `json:"backlog_limit"` // Maximum number of records queued for the audit daemon.
+	Backlog      uint32 `json:"backlog"`       // Number of records queued for the audit daemon.
+	Lost         uint32 `json:"lost"`          // Number of records lost since boot.
+}
@@ -0,0 +1,38 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// Sessions is the interface that wraps the Sessions and Users methods.
+// Sessions returns the interactive login sessions of the host and Users
+// returns the distinct names of the users that are logged in.
+type Sessions interface {
+	Sessions() ([]SessionInfo, error)
+	Users() ([]string, error)
+}
+
+// SessionInfo contains information about a login session.
+type SessionInfo struct {
+	ID         string    `json:"id,omitempty"`          // Session identifier.
+	User       string    `json:"user"`                  // Name of the logged in user.
+	Terminal   string    `json:"terminal,omitempty"`    // Terminal or window station (e.g. pts/0, Console).
+	RemoteHost string    `json:"remote_host,omitempty"` // Host the session originates from, if remote.
+	LoginTime  time.Time `json:"login_time"`            // Time of login.
+	PID        int       `json:"pid,omitempty"`         // Process ID of the login process.
+}
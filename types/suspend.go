@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// SuspendTimer is the interface that wraps the SuspendInfo method.
+// SuspendInfo returns how much of the time since boot the host spent
+// suspended (sleeping or hibernated) and how much it was active.
+type SuspendTimer interface {
+	SuspendInfo() (*SuspendInfo, error)
+}
+
+// SuspendInfo contains the time spent suspended since boot. Active plus
+// Suspended equals the uptime of the host.
+type SuspendInfo struct {
+	Active       time.Duration `json:"active"`                  // Time spent running since boot.
+	Suspended    time.Duration `json:"suspended"`               // Time spent suspended since boot.
+	SuspendCount *uint64       `json:"suspend_count,omitempty"` // Number of successful suspends since boot.
+}
+
+// UptimeInfo contains the time since the host booted.
+type UptimeInfo struct {
+	Wall   time.Duration  `json:"wall"`             // Wall-clock time since boot, including the time spent suspended.
+	Active *time.Duration `json:"active,omitempty"` // Time since boot excluding the time spent suspended. Nil if the platform does not account for suspends.
+}
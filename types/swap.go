@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// Swap is the interface that wraps the SwapInfo method.
+// SwapInfo returns the usage of each swap device and the paging counters of
+// the host.
+type Swap interface {
+	SwapInfo() (*SwapInfo, error)
+}
+
+// SwapInfo contains the swap devices of the host and the number of pages
+// moved between memory and swap since boot. Paging rates, like the Windows
+// Pages/sec counter, are the difference between two samples of the counters
+// divided by the time between them.
+type SwapInfo struct {
+	Devices  []SwapDevice `json:"devices"`
+	PagesIn  uint64       `json:"pages_in"`  // Pages read from swap since boot.
+	PagesOut uint64       `json:"pages_out"` // Pages written to swap since boot.
+	PageSize uint64       `json:"page_size"` // Size of a page in bytes.
+}
+
+// SwapDevice contains the usage of a swap partition, swap file, or page file.
+type SwapDevice struct {
+	Name     string `json:"name"`               // Path of the device or file (e.g. /dev/sda2, C:\pagefile.sys).
+	Type     string `json:"type,omitempty"`     // Type of the device (e.g. partition, file).
+	Total    uint64 `json:"total_bytes"`        // Size of the device.
+	Used     uint64 `json:"used_bytes"`         // Space in use.
+	Priority *int   `json:"priority,omitempty"` // Priority of the device. Higher priority devices are used first.
+}
@@ -0,0 +1,37 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// TimeSync is the interface that wraps the TimeSync method.
+// TimeSync returns the clock synchronization status of the host.
+type TimeSync interface {
+	TimeSync() (*TimeSyncInfo, error)
+}
+
+// TimeSyncInfo contains the clock synchronization status of the host.
+// Optional fields are nil when the platform does not expose them.
+type TimeSyncInfo struct {
+	Synchronized   bool           `json:"synchronized"`              // Is the clock synchronized to a time source.
+	Server         string         `json:"server,omitempty"`          // Time source that was last used.
+	Offset         *time.Duration `json:"offset,omitempty"`          // Estimated offset of the clock from the time source.
+	MaxError       *time.Duration `json:"max_error,omitempty"`       // Maximum error of the clock.
+	EstimatedError *time.Duration `json:"estimated_error,omitempty"` // Estimated error of the clock.
+	LastSync       *time.Time     `json:"last_sync,omitempty"`       // Time of the last successful synchronization.
+}
@@ -0,0 +1,32 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// TPM is the interface that wraps the TPM method.
+// TPM returns information about the Trusted Platform Module of the host.
+type TPM interface {
+	TPM() (*TPMInfo, error)
+}
+
+// TPMInfo contains information about the Trusted Platform Module.
+type TPMInfo struct {
+	Present          bool   `json:"present"`
+	Version          string `json:"version,omitempty"`           // Specification version (1.2 or 2.0).
+	ManufacturerID   string `json:"manufacturer_id,omitempty"`   // TCG vendor ID (e.g. IFX).
+	ManufacturerName string `json:"manufacturer_name,omitempty"` // Vendor name (e.g. Infineon).
+}
@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ByteUnits selects the unit system used to format sizes in bytes.
+type ByteUnits int
+
+const (
+	// IEC formats sizes with binary prefixes, which are powers of 1024
+	// (e.g. 1.5 GiB). It is the default.
+	IEC ByteUnits = iota
+	// SI formats sizes with decimal prefixes, which are powers of 1000
+	// (e.g. 1.6 GB).
+	SI
+)
+
+// FormatBytes formats a size in bytes with one decimal using the given unit
+// system (e.g. 1.5 GiB or 1.6 GB). Sizes below one kilobyte are formatted
+// as an integer number of bytes.
+func FormatBytes(n uint64, units ByteUnits) string {
+	unit, suffix := uint64(1024), "iB"
+	if units == SI {
+		unit, suffix = 1000, "B"
+	}
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := unit, 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	prefix := "KMGTPE"[exp : exp+1]
+	if units == SI && exp == 0 {
+		prefix = "k"
+	}
+	return fmt.Sprintf("%.1f %s%s", float64(n)/float64(div), prefix, suffix)
+}
+
+// FormatDuration formats a duration with its two most significant units
+// (e.g. 3d4h, 5h12m, 2m30s). Durations under a minute are rounded to
+// hundredths of a second (e.g. 1.25s).
+func FormatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == math.MinInt64:
+		// -d overflows. MaxInt64 has the same two most significant units.
+		return "-" + FormatDuration(math.MaxInt64)
+	case d < 0:
+		return "-" + FormatDuration(-d)
+	case d >= day:
+		return fmt.Sprintf("%dd%dh", d/day, (d%day)/time.Hour)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", d/time.Hour, (d%time.Hour)/time.Minute)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%ds", d/time.Minute, (d%time.Minute)/time.Second)
+	default:
+		return d.Round(10 * time.Millisecond).String()
+	}
+}
+
+// formatBytes formats n using the first of units or IEC if none is given.
+func formatBytes(n uint64, units []ByteUnits) string {
+	if len(units) > 0 {
+		return FormatBytes(n, units[0])
+	}
+	return FormatBytes(n, IEC)
+}
+
+// TotalHuman returns Total formatted for humans (e.g. 15.5 GiB). The unit
+// system defaults to IEC.
+func (m HostMemoryInfo) TotalHuman(units ...ByteUnits) string {
+	return formatBytes(m.Total, units)
+}
+
+// UsedHuman returns Used formatted for humans. The unit system defaults to
+// IEC.
+func (m HostMemoryInfo) UsedHuman(units ...ByteUnits) string {
+	return formatBytes(m.Used, units)
+}
+
+// AvailableHuman returns Available formatted for humans. The unit system
+// defaults to IEC.
+func (m HostMemoryInfo) AvailableHuman(units ...ByteUnits) string {
+	return formatBytes(m.Available, units)
+}
+
+// FreeHuman returns Free formatted for humans. The unit system defaults to
+// IEC.
+func (m HostMemoryInfo) FreeHuman(units ...ByteUnits) string {
+	return formatBytes(m.Free, units)
+}
+
+// ResidentHuman returns Resident formatted for humans. The unit system
+// defaults to IEC.
+func (m MemoryInfo) ResidentHuman(units ...ByteUnits) string {
+	return formatBytes(m.Resident, units)
+}
+
+// VirtualHuman returns Virtual formatted for humans. The unit system
+// defaults to IEC.
+func (m MemoryInfo) VirtualHuman(units ...ByteUnits) string {
+	return formatBytes(m.Virtual, units)
+}
@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n   uint64
+		iec string
+		si  string
+	}{
+		{0, "0 B", "0 B"},
+		{999, "999 B", "999 B"},
+		{1000, "1000 B", "1.0 kB"},
+		{1024, "1.0 KiB", "1.0 kB"},
+		{3 << 19, "1.5 MiB", "1.6 MB"},
+		{16 << 30, "16.0 GiB", "17.2 GB"},
+		{1<<64 - 1, "16.0 EiB", "18.4 EB"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.iec, FormatBytes(tc.n, IEC), "IEC %d", tc.n)
+		assert.Equal(t, tc.si, FormatBytes(tc.n, SI), "SI %d", tc.n)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "1.25s", FormatDuration(1250*time.Millisecond))
+	assert.Equal(t, "2m30s", FormatDuration(150*time.Second))
+	assert.Equal(t, "5h12m", FormatDuration(5*time.Hour+12*time.Minute+5*time.Second))
+	assert.Equal(t, "3d4h", FormatDuration(76*time.Hour))
+	assert.Equal(t, "-2m0s", FormatDuration(-2*time.Minute))
+	assert.Equal(t, "-106751d23h", FormatDuration(math.MinInt64))
+}
+
+func TestHumanMethods(t *testing.T) {
+	m := HostMemoryInfo{Total: 8 << 30, Used: 3 << 29, Available: 5 << 29, Free: 1 << 20}
+	assert.Equal(t, "8.0 GiB", m.TotalHuman())
+	assert.Equal(t, "8.6 GB", m.TotalHuman(SI))
+	assert.Equal(t, "1.5 GiB", m.UsedHuman())
+	assert.Equal(t, "2.5 GiB", m.AvailableHuman())
+	assert.Equal(t, "1.0 MiB", m.FreeHuman())
+
+	p := MemoryInfo{Resident: 2048, Virtual: 2000}
+	assert.Equal(t, "2.0 KiB", p.ResidentHuman())
+	assert.Equal(t, "2.0 kB", p.VirtualHuman(SI))
+}
+
+func TestCPUTimesTotalBusy(t *testing.T) {
+	c := CPUTimes{
+		User:   3 * time.Second,
+		System: 2 * time.Second,
+		Idle:   10 * time.Second,
+		IOWait: time.Second,
+		IRQ:    time.Second,
+		Steal:  time.Second,
+	}
+	assert.Equal(t, 18*time.Second, c.Total())
+	assert.Equal(t, 7*time.Second, c.TotalBusy())
+}
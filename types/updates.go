@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import "time"
+
+// InstalledUpdates is the interface that wraps the InstalledUpdates method.
+// InstalledUpdates returns the operating system updates (e.g. Windows
+// hotfixes) that are installed on the host.
+type InstalledUpdates interface {
+	InstalledUpdates() ([]UpdateInfo, error)
+}
+
+// UpdateInfo contains information about an installed operating system
+// update.
+type UpdateInfo struct {
+	ID          string    `json:"id"`                     // Update identifier (e.g. KB5031356).
+	InstallTime time.Time `json:"install_time,omitempty"` // Zero if unknown.
+}
+
+// RebootRequired is the interface that wraps the RebootRequired method.
+// RebootRequired reports whether the host must be restarted to finish the
+// installation of updates.
+type RebootRequired interface {
+	RebootRequired() (*RebootRequiredInfo, error)
+}
+
+// RebootRequiredInfo contains whether a reboot is pending and why.
+type RebootRequiredInfo struct {
+	Required bool     `json:"required"`
+	Reasons  []string `json:"reasons,omitempty"` // Updated packages (Linux) or pending operations (Windows) that need the reboot.
+}
+
+// LastUpdate is the interface that wraps the LastUpdate method.
+// LastUpdate returns when the operating system last installed updates.
+type LastUpdate interface {
+	LastUpdate() (*LastUpdateInfo, error)
+}
+
+// LastUpdateInfo contains the time of the last installation of updates.
+type LastUpdateInfo struct {
+	Time   time.Time `json:"time"`   // Time of the last installation (UTC).
+	Source string    `json:"source"` // History the time was read from (e.g. dpkg, rpm, apk, cbs, softwareupdate).
+}
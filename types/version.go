@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a version number split into its numeric components. It is
+// used to compare OS and kernel versions, which often do not follow semantic
+// versioning (e.g. 5.15.0-101-generic or 10.0.22631.3296).
+type Version struct {
+	Major    int    `json:"major"`
+	Minor    int    `json:"minor"`
+	Patch    int    `json:"patch"`
+	Revision int    `json:"revision,omitempty"` // Fourth component (e.g. Windows UBR).
+	Suffix   string `json:"suffix,omitempty"`   // Trailing non-numeric part (e.g. -101-generic).
+}
+
+// ParseVersion parses up to four dot separated numeric components from the
+// beginning of s. Missing components are zero and any remaining text is
+// stored as the Suffix. An error is returned if s does not start with a
+// number.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	components := []*int{&v.Major, &v.Minor, &v.Patch, &v.Revision}
+
+	rest := strings.TrimSpace(s)
+	rest = strings.TrimPrefix(strings.TrimPrefix(rest, "v"), "V")
+	for i, c := range components {
+		if i > 0 {
+			if len(rest) < 2 || rest[0] != '.' || !isDigit(rest[1]) {
+				break
+			}
+			rest = rest[1:]
+		}
+
+		end := 0
+		for end < len(rest) && isDigit(rest[end]) {
+			end++
+		}
+		if end == 0 {
+			return Version{}, fmt.Errorf("invalid version %q: no leading number", s)
+		}
+
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		*c = n
+		rest = rest[end:]
+	}
+	v.Suffix = rest
+	return v, nil
+}
+
+// Compare compares the numeric components of two versions. The result is
+// -1 if v < other, 0 if v == other, and +1 if v > other. The Suffix is not
+// taken into account because its format is vendor specific.
+func (v Version) Compare(other Version) int {
+	a := [...]int{v.Major, v.Minor, v.Patch, v.Revision}
+	b := [...]int{other.Major, other.Minor, other.Patch, other.Revision}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if v is greater than or equal to the version given
+// by the major, minor, and patch numbers.
+func (v Version) AtLeast(major, minor, patch int) bool {
+	return v.Compare(Version{Major: major, Minor: minor, Patch: patch}) >= 0
+}
+
+// String returns the numeric components of the version followed by the
+// suffix. The revision is omitted when it is zero.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Revision != 0 {
+		s += "." + strconv.Itoa(v.Revision)
+	}
+	return s + v.Suffix
+}
+
+// CompareVersions parses and compares two version strings. See
+// Version.Compare for the meaning of the result.
+func CompareVersions(a, b string) (int, error) {
+	va, err := ParseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
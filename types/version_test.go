@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Version
+	}{
+		{"5.15.0-101-generic", Version{Major: 5, Minor: 15, Suffix: "-101-generic"}},
+		{"10.0.22631.3296", Version{Major: 10, Minor: 0, Patch: 22631, Revision: 3296}},
+		{"10.0.22621.3296 (WinBuild.160101.0800)", Version{Major: 10, Patch: 22621, Revision: 3296, Suffix: " (WinBuild.160101.0800)"}},
+		{"6.8.9-arch1-1", Version{Major: 6, Minor: 8, Patch: 9, Suffix: "-arch1-1"}},
+		{"4.18.0-513.el8.x86_64", Version{Major: 4, Minor: 18, Suffix: "-513.el8.x86_64"}},
+		{"21.6.0", Version{Major: 21, Minor: 6}},
+		{"7", Version{Major: 7}},
+		{"v1.2", Version{Major: 1, Minor: 2}},
+		{"3.10.", Version{Major: 3, Minor: 10, Suffix: "."}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseVersion(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+
+	for _, input := range []string{"", "generic", "-1.2"} {
+		_, err := ParseVersion(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"5.15.0-101-generic", "5.15.0-91-generic", 0},
+		{"5.15.0", "5.4.0", 1},
+		{"5.4", "5.15", -1},
+		{"10.0.22631.3296", "10.0.22631.3155", 1},
+		{"10.0.19045", "10.0.22000", -1},
+		{"4.18.0-513.el8.x86_64", "4.18", 0},
+	}
+
+	for _, tc := range testCases {
+		c, err := CompareVersions(tc.a, tc.b)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, c, "%v <=> %v", tc.a, tc.b)
+	}
+
+	v, err := ParseVersion("5.15.0-101-generic")
+	require.NoError(t, err)
+	assert.True(t, v.AtLeast(5, 15, 0))
+	assert.True(t, v.AtLeast(5, 8, 0))
+	assert.False(t, v.AtLeast(6, 1, 0))
+	assert.Equal(t, "5.15.0-101-generic", v.String())
+}
+
+func TestOSInfoParsedVersion(t *testing.T) {
+	os := OSInfo{Type: "windows", Major: 10, Minor: 0, Build: "22631.3296"}
+	assert.Equal(t, Version{Major: 10, Patch: 22631, Revision: 3296}, os.ParsedVersion())
+
+	os = OSInfo{Type: "linux", Major: 22, Minor: 4, Patch: 3}
+	assert.Equal(t, Version{Major: 22, Minor: 4, Patch: 3}, os.ParsedVersion())
+}
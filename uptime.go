@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// Uptime returns the wall-clock uptime of h and its active uptime, which
+// excludes the time spent in sleep or hibernation. The wall-clock uptime of a
+// laptop that is suspended every night is much larger than the time it was
+// running. The active uptime is measured by the types.SuspendTimer of the
+// host (CLOCK_MONOTONIC on Linux and QueryUnbiasedInterruptTime on Windows)
+// and is nil if the host does not implement it. When it cannot be measured
+// the wall-clock uptime is returned with the error.
+func Uptime(h types.Host) (*types.UptimeInfo, error) {
+	info := &types.UptimeInfo{Wall: h.Info().Uptime()}
+
+	t, ok := h.(types.SuspendTimer)
+	if !ok {
+		return info, nil
+	}
+	s, err := t.SuspendInfo()
+	if err != nil {
+		if errors.Is(err, types.ErrNotImplemented) {
+			return info, nil
+		}
+		return info, err
+	}
+
+	// The clocks of the suspend timer are read at the same time, which is
+	// more accurate than the boot time that is rounded to seconds.
+	info.Wall = s.Active + s.Suspended
+	info.Active = &s.Active
+	return info, nil
+}
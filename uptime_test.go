@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/providers/fake"
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestUptime(t *testing.T) {
+	bootTime := time.Now().Add(-10 * time.Hour)
+
+	h := &fake.Host{
+		HostInfo: types.HostInfo{BootTime: bootTime},
+		Suspend:  &types.SuspendInfo{Active: 2 * time.Hour, Suspended: 8 * time.Hour},
+	}
+	u, err := Uptime(h)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Hour, u.Wall)
+	if assert.NotNil(t, u.Active) {
+		assert.Equal(t, 2*time.Hour, *u.Active)
+	}
+
+	// Without a suspend timer only the wall-clock uptime is known.
+	h.Suspend = nil
+	u, err = Uptime(h)
+	require.NoError(t, err)
+	assert.InDelta(t, 10*time.Hour, u.Wall, float64(time.Minute))
+	assert.Nil(t, u.Active)
+
+	h.Errors = map[string]error{"SuspendInfo": errors.New("clock failed")}
+	u, err = Uptime(h)
+	assert.Error(t, err)
+	assert.NotZero(t, u.Wall)
+	assert.Nil(t, u.Active)
+}
+
+func TestUptimeSelf(t *testing.T) {
+	h, err := Host(WithoutFQDN())
+	if errors.Is(err, types.ErrNotImplemented) {
+		t.Skip("host provider not implemented")
+	}
+	require.NotNil(t, h)
+
+	u, err := Uptime(h)
+	require.NoError(t, err)
+	assert.Greater(t, u.Wall, time.Duration(0))
+	if u.Active != nil {
+		assert.LessOrEqual(t, *u.Active, u.Wall)
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+// userNameTTL is how long resolved user and group names are cached.
+const userNameTTL = 5 * time.Minute
+
+var (
+	userNames  = newNameCache(lookupUserName)
+	groupNames = newNameCache(lookupGroupName)
+)
+
+// ResolveUser returns u with the Username and Group fields set to the names
+// of its UID and GID. On Windows the IDs are SIDs and the user name is the
+// account name in the DOMAIN\user form. IDs that are unknown to the system
+// (e.g. the UID of a user that was deleted or that only exists in a
+// container) leave the names empty without an error. The names are cached
+// for five minutes, including unknown IDs, so resolving the users of many
+// processes does not read the user database for each process.
+func ResolveUser(u types.UserInfo) (types.UserInfo, error) {
+	var errs []error
+	if u.UID != "" {
+		name, err := userNames.get(u.UID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve user %v: %w", u.UID, err))
+		}
+		u.Username = name
+	}
+	if u.GID != "" {
+		name, err := groupNames.get(u.GID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve group %v: %w", u.GID, err))
+		}
+		u.Group = name
+	}
+	if len(errs) > 0 {
+		return u, errs[0]
+	}
+	return u, nil
+}
+
+func lookupUserName(uid string) (string, error) {
+	u, err := user.LookupId(uid)
+	if err != nil {
+		var unknown user.UnknownUserIdError
+		if errors.As(err, &unknown) {
+			return "", nil
+		}
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func lookupGroupName(gid string) (string, error) {
+	g, err := user.LookupGroupId(gid)
+	if err != nil {
+		var unknown user.UnknownGroupIdError
+		if errors.As(err, &unknown) {
+			return "", nil
+		}
+		return "", err
+	}
+	return g.Name, nil
+}
+
+// nameCache caches the names of IDs for userNameTTL. Lookup errors are not
+// cached.
+type nameCache struct {
+	mu      sync.Mutex
+	entries map[string]nameEntry
+	lookup  func(id string) (string, error)
+	now     func() time.Time
+}
+
+type nameEntry struct {
+	name    string
+	expires time.Time
+}
+
+func newNameCache(lookup func(string) (string, error)) *nameCache {
+	return &nameCache{
+		entries: map[string]nameEntry{},
+		lookup:  lookup,
+		now:     time.Now,
+	}
+}
+
+func (c *nameCache) get(id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if e, ok := c.entries[id]; ok && now.Before(e.expires) {
+		return e.name, nil
+	}
+
+	name, err := c.lookup(id)
+	if err != nil {
+		return "", err
+	}
+
+	// Drop the expired entries so that the cache does not grow with the IDs
+	// of processes that are gone.
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[id] = nameEntry{name: name, expires: now.Add(userNameTTL)}
+	return name, nil
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sysinfo
+
+import (
+	"errors"
+	osUser "os/user"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-sysinfo/types"
+)
+
+func TestResolveUser(t *testing.T) {
+	current, err := osUser.Current()
+	require.NoError(t, err)
+
+	u, err := ResolveUser(types.UserInfo{UID: current.Uid, GID: current.Gid})
+	require.NoError(t, err)
+	assert.Equal(t, current.Uid, u.UID)
+	assert.Equal(t, current.Username, u.Username)
+
+	group, err := osUser.LookupGroupId(current.Gid)
+	if err == nil {
+		assert.Equal(t, group.Name, u.Group)
+	}
+
+	if runtime.GOOS != "windows" {
+		// IDs without a user or group are not an error.
+		u, err = ResolveUser(types.UserInfo{UID: "4000000001", GID: "4000000001"})
+		require.NoError(t, err)
+		assert.Empty(t, u.Username)
+		assert.Empty(t, u.Group)
+	}
+}
+
+func TestNameCache(t *testing.T) {
+	var lookups int
+	var fail bool
+	c := newNameCache(func(id string) (string, error) {
+		if fail {
+			return "", errors.New("lookup failed")
+		}
+		lookups++
+		return "name-" + id, nil
+	})
+	now := time.Unix(1700000000, 0)
+	c.now = func() time.Time { return now }
+
+	name, err := c.get("1")
+	require.NoError(t, err)
+	assert.Equal(t, "name-1", name)
+
+	name, _ = c.get("1")
+	assert.Equal(t, "name-1", name)
+	assert.Equal(t, 1, lookups, "the name is cached")
+
+	now = now.Add(userNameTTL)
+	c.get("2")
+	assert.Equal(t, 2, lookups)
+	assert.NotContains(t, c.entries, "1", "expired entries are dropped")
+
+	fail = true
+	_, err = c.get("3")
+	assert.Error(t, err)
+	assert.NotContains(t, c.entries, "3", "errors are not cached")
+}